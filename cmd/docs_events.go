@@ -36,6 +36,7 @@ func NewDocEventsCommand() *cobra.Command {
 				events.DeletedMetadata{},
 				events.SavedMetadata{},
 				events.RevertedTransaction{},
+				events.PartiallyRevertedTransaction{},
 				events.InsertedSchema{},
 			} {
 				schema := jsonschema.Reflect(o)
@@ -10,16 +10,22 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
+	"github.com/formancehq/go-libs/v5/pkg/fx/messagingfx"
 	"github.com/formancehq/go-libs/v5/pkg/fx/storagefx"
+	"github.com/formancehq/go-libs/v5/pkg/messaging/publish"
 	"github.com/formancehq/go-libs/v5/pkg/observe/metrics"
 	"github.com/formancehq/go-libs/v5/pkg/observe/traces"
 	"github.com/formancehq/go-libs/v5/pkg/service"
 	"github.com/formancehq/go-libs/v5/pkg/storage/bun/connect"
 
+	"github.com/formancehq/ledger/internal/bus"
+	ledgercontroller "github.com/formancehq/ledger/internal/controller/ledger"
+	systemcontroller "github.com/formancehq/ledger/internal/controller/system"
 	"github.com/formancehq/ledger/internal/replication"
 	"github.com/formancehq/ledger/internal/replication/drivers"
 	"github.com/formancehq/ledger/internal/replication/drivers/alldrivers"
 	"github.com/formancehq/ledger/internal/storage"
+	systemstore "github.com/formancehq/ledger/internal/storage/system"
 	"github.com/formancehq/ledger/internal/worker"
 )
 
@@ -35,6 +41,19 @@ const (
 	WorkerBucketCleanupRetentionPeriodFlag = "worker-bucket-cleanup-retention-period"
 	WorkerBucketCleanupScheduleFlag        = "worker-bucket-cleanup-schedule"
 
+	WorkerHoldsExpiryBatchSizeFlag = "worker-holds-expiry-batch-size"
+	WorkerHoldsExpiryScheduleFlag  = "worker-holds-expiry-schedule"
+
+	WorkerRevertJobsBatchSizeFlag = "worker-revert-jobs-batch-size"
+	WorkerRevertJobsScheduleFlag  = "worker-revert-jobs-schedule"
+
+	WorkerScheduledTransactionsBatchSizeFlag = "worker-scheduled-transactions-batch-size"
+	WorkerScheduledTransactionsScheduleFlag  = "worker-scheduled-transactions-schedule"
+
+	WorkerRecurrencesBatchSizeFlag         = "worker-recurrences-batch-size"
+	WorkerRecurrencesAccountsBatchSizeFlag = "worker-recurrences-accounts-batch-size"
+	WorkerRecurrencesScheduleFlag          = "worker-recurrences-schedule"
+
 	WorkerGRPCAddressFlag = "worker-grpc-address"
 )
 
@@ -53,6 +72,19 @@ type WorkerConfiguration struct {
 
 	BucketCleanupRetentionPeriod time.Duration `mapstructure:"worker-bucket-cleanup-retention-period"`
 	BucketCleanupCRONSpec        cron.Schedule `mapstructure:"worker-bucket-cleanup-schedule"`
+
+	HoldsExpiryBatchSize int           `mapstructure:"worker-holds-expiry-batch-size"`
+	HoldsExpiryCRONSpec  cron.Schedule `mapstructure:"worker-holds-expiry-schedule"`
+
+	RevertJobsBatchSize int           `mapstructure:"worker-revert-jobs-batch-size"`
+	RevertJobsCRONSpec  cron.Schedule `mapstructure:"worker-revert-jobs-schedule"`
+
+	ScheduledTransactionsBatchSize int           `mapstructure:"worker-scheduled-transactions-batch-size"`
+	ScheduledTransactionsCRONSpec  cron.Schedule `mapstructure:"worker-scheduled-transactions-schedule"`
+
+	RecurrencesBatchSize         int           `mapstructure:"worker-recurrences-batch-size"`
+	RecurrencesAccountsBatchSize int           `mapstructure:"worker-recurrences-accounts-batch-size"`
+	RecurrencesCRONSpec          cron.Schedule `mapstructure:"worker-recurrences-schedule"`
 }
 
 func (cfg WorkerConfiguration) Validate() error {
@@ -62,6 +94,33 @@ func (cfg WorkerConfiguration) Validate() error {
 	if cfg.BucketCleanupCRONSpec == nil {
 		return fmt.Errorf("bucket cleanup schedule must be set")
 	}
+	if cfg.HoldsExpiryBatchSize <= 0 {
+		return fmt.Errorf("holds expiry batch size must be greater than zero")
+	}
+	if cfg.HoldsExpiryCRONSpec == nil {
+		return fmt.Errorf("holds expiry schedule must be set")
+	}
+	if cfg.RevertJobsBatchSize <= 0 {
+		return fmt.Errorf("revert jobs batch size must be greater than zero")
+	}
+	if cfg.RevertJobsCRONSpec == nil {
+		return fmt.Errorf("revert jobs schedule must be set")
+	}
+	if cfg.ScheduledTransactionsBatchSize <= 0 {
+		return fmt.Errorf("scheduled transactions batch size must be greater than zero")
+	}
+	if cfg.ScheduledTransactionsCRONSpec == nil {
+		return fmt.Errorf("scheduled transactions schedule must be set")
+	}
+	if cfg.RecurrencesBatchSize <= 0 {
+		return fmt.Errorf("recurrences batch size must be greater than zero")
+	}
+	if cfg.RecurrencesAccountsBatchSize <= 0 {
+		return fmt.Errorf("recurrences accounts batch size must be greater than zero")
+	}
+	if cfg.RecurrencesCRONSpec == nil {
+		return fmt.Errorf("recurrences schedule must be set")
+	}
 
 	return nil
 }
@@ -70,10 +129,13 @@ type WorkerCommandConfiguration struct {
 	WorkerConfiguration `mapstructure:",squash"`
 	commonConfig        `mapstructure:",squash"`
 	WorkerGRPCConfig    `mapstructure:",squash"`
+
+	NumscriptCacheMaxCount uint `mapstructure:"numscript-cache-max-count"`
 }
 
 // addWorkerFlags adds command-line flags to cmd to configure worker runtime behavior.
-// The flags control async block hashing, pipeline pull/push/sync behavior and pagination, and bucket cleanup retention and schedule.
+// The flags control async block hashing, pipeline pull/push/sync behavior and pagination, bucket cleanup retention and schedule,
+// the voiding of expired holds, the processing of revert jobs, the execution of scheduled transactions and the processing of recurrences.
 func addWorkerFlags(cmd *cobra.Command) {
 	cmd.Flags().Int(WorkerAsyncBlockHasherMaxBlockSizeFlag, 1000, "Max block size")
 	cmd.Flags().String(WorkerAsyncBlockHasherScheduleFlag, "0 * * * * *", "Schedule")
@@ -83,6 +145,15 @@ func addWorkerFlags(cmd *cobra.Command) {
 	cmd.Flags().Uint64(WorkerPipelinesLogsPageSize, 100, "Pipelines logs page size")
 	cmd.Flags().Duration(WorkerBucketCleanupRetentionPeriodFlag, 30*24*time.Hour, "Retention period for deleted buckets before hard delete")
 	cmd.Flags().String(WorkerBucketCleanupScheduleFlag, "0 0 * * * *", "Schedule for bucket cleanup (cron format)")
+	cmd.Flags().Int(WorkerHoldsExpiryBatchSizeFlag, 100, "Max number of expired holds voided per ledger on each run")
+	cmd.Flags().String(WorkerHoldsExpiryScheduleFlag, "0 * * * * *", "Schedule for voiding expired holds (cron format)")
+	cmd.Flags().Int(WorkerRevertJobsBatchSizeFlag, 100, "Max number of transactions reverted per job on each run")
+	cmd.Flags().String(WorkerRevertJobsScheduleFlag, "*/10 * * * * *", "Schedule for processing revert jobs (cron format)")
+	cmd.Flags().Int(WorkerScheduledTransactionsBatchSizeFlag, 100, "Max number of scheduled transactions executed per ledger on each run")
+	cmd.Flags().String(WorkerScheduledTransactionsScheduleFlag, "*/10 * * * * *", "Schedule for executing the due scheduled transactions (cron format)")
+	cmd.Flags().Int(WorkerRecurrencesBatchSizeFlag, 100, "Max number of due recurrences processed per ledger on each run")
+	cmd.Flags().Int(WorkerRecurrencesAccountsBatchSizeFlag, 100, "Max number of accounts of an occurrence of a recurrence processed in a single sql transaction")
+	cmd.Flags().String(WorkerRecurrencesScheduleFlag, "*/10 * * * * *", "Schedule for processing the due recurrences (cron format)")
 }
 
 // NewWorkerCommand constructs the "worker" Cobra command which initializes and runs the worker service using loaded configuration and composed FX modules.
@@ -110,11 +181,29 @@ func NewWorkerCommand() *cobra.Command {
 			return service.New(cmd.OutOrStdout(),
 				fx.NopLogger,
 				otlpModule(cmd, cfg.commonConfig),
+				messagingfx.PublishModuleFromFlags(cmd, service.IsDebug(cmd)),
 				storagefx.BunConnectModule(*connectionOptions, service.IsDebug(cmd)),
 				storage.NewFXModule(storage.ModuleConfig{}),
 				drivers.NewFXModule(),
 				fx.Invoke(alldrivers.Register),
+				// The runners write through the ledger controllers of the system controller,
+				// so the writes of the worker publish events like the writes of the api
+				systemcontroller.NewFXModule(systemcontroller.ModuleConfiguration{
+					NumscriptInterpreter:      cfg.NumscriptInterpreter,
+					NumscriptInterpreterFlags: cfg.NumscriptInterpreterFlags,
+					NSCacheConfiguration: ledgercontroller.CacheConfiguration{
+						MaxCount: cfg.NumscriptCacheMaxCount,
+					},
+					DatabaseRetryConfiguration: systemcontroller.DatabaseRetryConfiguration{
+						MaxRetry: 10,
+						Delay:    time.Millisecond * 100,
+					},
+					EnableFeatures:        cfg.ExperimentalFeaturesEnabled,
+					SchemaEnforcementMode: cfg.commonConfig.SchemaEnforcementMode,
+				}),
+				bus.NewFxModule(),
 				newWorkerModule(cfg.WorkerConfiguration),
+				replication.NewFXEmbeddedClientModule(),
 				worker.NewGRPCServerFXModule(worker.GRPCServerModuleConfig{
 					Address: cfg.Address,
 					ServerOptions: []grpc.ServerOption{
@@ -126,18 +215,26 @@ func NewWorkerCommand() *cobra.Command {
 	}
 
 	cmd.Flags().String(WorkerGRPCAddressFlag, ":8081", "GRPC address")
+	cmd.Flags().Uint(NumscriptCacheMaxCountFlag, 1024, "Numscript cache max count")
+	cmd.Flags().Bool(NumscriptInterpreterFlag, false, "Enable experimental numscript rewrite")
+	cmd.Flags().StringSlice(NumscriptInterpreterFlagsToPass, nil, "Feature flags to pass to the experimental numscript interpreter")
+	cmd.Flags().String(SchemaEnforcementMode, "audit", "Schema enforcement mode. Values: `audit`, `strict`, `off`")
 
 	addWorkerFlags(cmd)
 	service.AddFlags(cmd.Flags())
 	connect.AddFlags(cmd.Flags())
 	metrics.AddFlags(cmd.Flags())
 	traces.AddFlags(cmd.Flags())
+	publish.AddFlags(ServiceName, cmd.Flags(), func(cd *publish.ConfigDefault) {
+		cd.PublisherCircuitBreakerSchema = systemstore.SchemaSystem
+	})
 
 	return cmd
 }
 
 // newWorkerModule creates an fx.Option that configures the worker module using the provided WorkerConfiguration.
-// It maps the configuration into AsyncBlockRunnerConfig, ReplicationConfig, and BucketCleanupRunnerConfig for the worker.
+// It maps the configuration into AsyncBlockRunnerConfig, ReplicationConfig, BucketCleanupRunnerConfig, HoldsExpiryRunnerConfig,
+// RevertJobsRunnerConfig, ScheduledTransactionsRunnerConfig and RecurrencesRunnerConfig for the worker.
 func newWorkerModule(configuration WorkerConfiguration) fx.Option {
 	return worker.NewFXModule(worker.ModuleConfig{
 		AsyncBlockRunnerConfig: storage.AsyncBlockRunnerConfig{
@@ -154,5 +251,22 @@ func newWorkerModule(configuration WorkerConfiguration) fx.Option {
 			RetentionPeriod: configuration.BucketCleanupRetentionPeriod,
 			Schedule:        configuration.BucketCleanupCRONSpec,
 		},
+		HoldsExpiryRunnerConfig: storage.HoldsExpiryRunnerConfig{
+			BatchSize: configuration.HoldsExpiryBatchSize,
+			Schedule:  configuration.HoldsExpiryCRONSpec,
+		},
+		RevertJobsRunnerConfig: storage.RevertJobsRunnerConfig{
+			BatchSize: configuration.RevertJobsBatchSize,
+			Schedule:  configuration.RevertJobsCRONSpec,
+		},
+		ScheduledTransactionsRunnerConfig: storage.ScheduledTransactionsRunnerConfig{
+			BatchSize: configuration.ScheduledTransactionsBatchSize,
+			Schedule:  configuration.ScheduledTransactionsCRONSpec,
+		},
+		RecurrencesRunnerConfig: storage.RecurrencesRunnerConfig{
+			BatchSize:         configuration.RecurrencesBatchSize,
+			AccountsBatchSize: configuration.RecurrencesAccountsBatchSize,
+			Schedule:          configuration.RecurrencesCRONSpec,
+		},
 	})
 }
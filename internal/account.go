@@ -23,6 +23,8 @@ type Account struct {
 	UpdatedAt        time.Time         `json:"updatedAt" bun:"updated_at,type:timestamp without time zone,nullzero"`
 	Volumes          VolumesByAssets   `json:"volumes,omitempty" bun:"volumes,scanonly"`
 	EffectiveVolumes VolumesByAssets   `json:"effectiveVolumes,omitempty" bun:"effective_volumes,scanonly"`
+	// ChartLabels are the values of the variable segments of the address, indexed by label
+	ChartLabels map[string]string `json:"chart,omitempty" bun:"-"`
 }
 
 func (a Account) GetAddress() string {
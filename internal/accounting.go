@@ -0,0 +1,207 @@
+package ledger
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// AccountingClass classifies the segments of a chart of accounts for the financial statements
+type AccountingClass string
+
+const (
+	AccountingClassAsset     AccountingClass = "asset"
+	AccountingClassLiability AccountingClass = "liability"
+	AccountingClassEquity    AccountingClass = "equity"
+	AccountingClassIncome    AccountingClass = "income"
+	AccountingClassExpense   AccountingClass = "expense"
+)
+
+func (c AccountingClass) Validate() error {
+	switch c {
+	case AccountingClassAsset, AccountingClassLiability, AccountingClassEquity, AccountingClassIncome, AccountingClassExpense:
+		return nil
+	default:
+		return fmt.Errorf("invalid class `%s`, expected one of `asset`, `liability`, `equity`, `income`, `expense`", c)
+	}
+}
+
+// Or returns the class, or the fallback if the class is empty
+func (c AccountingClass) Or(fallback AccountingClass) AccountingClass {
+	if c == "" {
+		return fallback
+	}
+	return c
+}
+
+// IsDebitNormal indicates if the balance of the class increases with debits (inputs)
+func (c AccountingClass) IsDebitNormal() bool {
+	return c == AccountingClassAsset || c == AccountingClassExpense
+}
+
+// NormalBalances returns the balances signed according to the normal balance of the class,
+// credit normal classes being positive when their outputs exceed their inputs
+func (c AccountingClass) NormalBalances(balances BalancesByAssets) BalancesByAssets {
+	ret := BalancesByAssets{}
+	for asset, balance := range balances {
+		if c.IsDebitNormal() {
+			ret[asset] = new(big.Int).Set(balance)
+		} else {
+			ret[asset] = new(big.Int).Neg(balance)
+		}
+	}
+	return ret
+}
+
+// TrialBalanceTotals are the debits (inputs) and credits (outputs) of accounts for an asset
+type TrialBalanceTotals struct {
+	Debit  *big.Int `json:"debit"`
+	Credit *big.Int `json:"credit"`
+}
+
+// TrialBalanceTotalsByAssets indexes the totals of a trial balance by asset
+type TrialBalanceTotalsByAssets map[string]TrialBalanceTotals
+
+// NewTrialBalanceTotals converts volumes to debit and credit totals
+func NewTrialBalanceTotals(volumes VolumesByAssets) TrialBalanceTotalsByAssets {
+	ret := TrialBalanceTotalsByAssets{}
+	for asset, v := range volumes {
+		ret[asset] = TrialBalanceTotals{
+			Debit:  new(big.Int).Set(v.Input),
+			Credit: new(big.Int).Set(v.Output),
+		}
+	}
+	return ret
+}
+
+// Add adds the totals of other to the totals
+func (t TrialBalanceTotalsByAssets) Add(other TrialBalanceTotalsByAssets) {
+	for asset, totals := range other {
+		current, ok := t[asset]
+		if !ok {
+			current = TrialBalanceTotals{
+				Debit:  new(big.Int),
+				Credit: new(big.Int),
+			}
+		}
+		current.Debit.Add(current.Debit, totals.Debit)
+		current.Credit.Add(current.Credit, totals.Credit)
+		t[asset] = current
+	}
+}
+
+// TrialBalanceNode is a segment of the chart of accounts with the debit and credit totals of its subtree
+type TrialBalanceNode struct {
+	Segment  string                     `json:"segment"`
+	Path     string                     `json:"path"`
+	Account  bool                       `json:"account"`
+	Class    AccountingClass            `json:"class,omitempty"`
+	Totals   TrialBalanceTotalsByAssets `json:"totals"`
+	Children []TrialBalanceNode         `json:"children,omitempty"`
+}
+
+// NewTrialBalanceNodes converts the nodes of a chart tree, without totals
+func NewTrialBalanceNodes(nodes []ChartTreeNode) []TrialBalanceNode {
+	if len(nodes) == 0 {
+		return nil
+	}
+	ret := make([]TrialBalanceNode, 0, len(nodes))
+	for _, node := range nodes {
+		ret = append(ret, TrialBalanceNode{
+			Segment:  node.Segment,
+			Path:     node.Path,
+			Account:  node.Account,
+			Class:    node.Class,
+			Totals:   TrialBalanceTotalsByAssets{},
+			Children: NewTrialBalanceNodes(node.Children),
+		})
+	}
+	return ret
+}
+
+// TrialBalance lists the debit and credit totals of the segments of a chart of accounts.
+// Totals sums the root segments, its debits and credits are equal when all the accounts belong to the chart.
+type TrialBalance struct {
+	Nodes  []TrialBalanceNode         `json:"nodes"`
+	Totals TrialBalanceTotalsByAssets `json:"totals"`
+}
+
+// FinancialStatementLine is the amounts of the subtree of a classified segment, signed by its normal balance
+type FinancialStatementLine struct {
+	Path    string           `json:"path"`
+	Amounts BalancesByAssets `json:"amounts"`
+}
+
+// NewFinancialStatementLine computes the amounts of a line from the balances at the end of the period
+// and, if the period has a start, the balances before the start
+func NewFinancialStatementLine(class AccountingClass, path string, closing, opening BalancesByAssets) FinancialStatementLine {
+	return FinancialStatementLine{
+		Path:    path,
+		Amounts: class.NormalBalances(closing.add(opening.neg())),
+	}
+}
+
+// FinancialStatementSection groups the lines of a class
+type FinancialStatementSection struct {
+	Class AccountingClass          `json:"class"`
+	Lines []FinancialStatementLine `json:"lines"`
+	Total BalancesByAssets         `json:"total"`
+}
+
+// NewFinancialStatementSection sums the lines of a class
+func NewFinancialStatementSection(class AccountingClass, lines []FinancialStatementLine) FinancialStatementSection {
+	total := BalancesByAssets{}
+	for _, line := range lines {
+		total = total.add(line.Amounts)
+	}
+	return FinancialStatementSection{
+		Class: class,
+		Lines: lines,
+		Total: total,
+	}
+}
+
+// BalanceSheet is the position of the asset, liability and equity classes at a point in time.
+// NetIncome is the income minus the expenses not yet closed to equity, so that assets equal
+// liabilities plus equity plus net income.
+type BalanceSheet struct {
+	Assets      FinancialStatementSection `json:"assets"`
+	Liabilities FinancialStatementSection `json:"liabilities"`
+	Equity      FinancialStatementSection `json:"equity"`
+	NetIncome   BalancesByAssets          `json:"netIncome"`
+}
+
+// IncomeStatement is the performance of the income and expense classes over a period
+type IncomeStatement struct {
+	Income    FinancialStatementSection `json:"income"`
+	Expenses  FinancialStatementSection `json:"expenses"`
+	NetIncome BalancesByAssets          `json:"netIncome"`
+}
+
+// NetIncome returns the income minus the expenses
+func NetIncome(income, expenses FinancialStatementSection) BalancesByAssets {
+	return income.Total.add(expenses.Total.neg())
+}
+
+// add returns the sum of the balances, without modifying them
+func (b BalancesByAssets) add(other BalancesByAssets) BalancesByAssets {
+	ret := BalancesByAssets{}
+	for asset, balance := range b {
+		ret[asset] = new(big.Int).Set(balance)
+	}
+	for asset, balance := range other {
+		if current, ok := ret[asset]; ok {
+			current.Add(current, balance)
+		} else {
+			ret[asset] = new(big.Int).Set(balance)
+		}
+	}
+	return ret
+}
+
+func (b BalancesByAssets) neg() BalancesByAssets {
+	ret := BalancesByAssets{}
+	for asset, balance := range b {
+		ret[asset] = new(big.Int).Neg(balance)
+	}
+	return ret
+}
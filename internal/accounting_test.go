@@ -0,0 +1,47 @@
+package ledger
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrialBalanceTotals(t *testing.T) {
+	t.Parallel()
+
+	totals := TrialBalanceTotalsByAssets{}
+	totals.Add(NewTrialBalanceTotals(VolumesByAssets{
+		"USD": NewVolumesInt64(100, 30),
+	}))
+	totals.Add(NewTrialBalanceTotals(VolumesByAssets{
+		"USD": NewVolumesInt64(0, 70),
+		"EUR": NewVolumesInt64(10, 0),
+	}))
+
+	require.Equal(t, TrialBalanceTotalsByAssets{
+		"USD": {Debit: big.NewInt(100), Credit: big.NewInt(100)},
+		"EUR": {Debit: big.NewInt(10), Credit: big.NewInt(0)},
+	}, totals)
+}
+
+func TestFinancialStatementSections(t *testing.T) {
+	t.Parallel()
+
+	income := NewFinancialStatementSection(AccountingClassIncome, []FinancialStatementLine{
+		NewFinancialStatementLine(AccountingClassIncome, "fees", BalancesByAssets{"USD": big.NewInt(-150)}, BalancesByAssets{"USD": big.NewInt(-50)}),
+		NewFinancialStatementLine(AccountingClassIncome, "interests", BalancesByAssets{"USD": big.NewInt(-20)}, nil),
+	})
+	require.Equal(t, BalancesByAssets{"USD": big.NewInt(100)}, income.Lines[0].Amounts)
+	require.Equal(t, BalancesByAssets{"USD": big.NewInt(120)}, income.Total)
+
+	expenses := NewFinancialStatementSection(AccountingClassExpense, []FinancialStatementLine{
+		NewFinancialStatementLine(AccountingClassExpense, "providers", BalancesByAssets{"USD": big.NewInt(50), "EUR": big.NewInt(5)}, nil),
+	})
+	require.Equal(t, BalancesByAssets{"USD": big.NewInt(50), "EUR": big.NewInt(5)}, expenses.Total)
+
+	require.Equal(t, BalancesByAssets{
+		"USD": big.NewInt(70),
+		"EUR": big.NewInt(-5),
+	}, NetIncome(income, expenses))
+}
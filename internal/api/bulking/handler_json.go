@@ -160,6 +160,10 @@ func mapBulkElementError(err error) string {
 		return common.ErrValidation
 	case errors.Is(err, ledgercontroller.ErrSchemaNotSpecified{}):
 		return common.ErrSchemaNotSpecified
+	case errors.Is(err, ledgercontroller.ErrAccountRuleViolation{}):
+		return common.ErrAccountRule
+	case errors.Is(err, ledgercontroller.ErrPeriodClosed{}):
+		return common.ErrPeriodClosed
 	case errors.Is(err, ledgercontroller.ErrNotFound), errors.Is(err, ledgercontroller.ErrSchemaNotFound{}):
 		return api.ErrorCodeNotFound
 	default:
@@ -38,6 +38,7 @@ func TestMapBulkElementError(t *testing.T) {
 		{"invalid idempotency input", ledgercontroller.ErrInvalidIdempotencyInput{}, common.ErrValidation},
 		{"schema validation", ledgercontroller.ErrSchemaValidationError{}, common.ErrValidation},
 		{"schema not specified", ledgercontroller.ErrSchemaNotSpecified{}, common.ErrSchemaNotSpecified},
+		{"account rule violation", ledgercontroller.ErrAccountRuleViolation{}, common.ErrAccountRule},
 		{"not found", ledgercontroller.ErrNotFound, api.ErrorCodeNotFound},
 		{"schema not found", ledgercontroller.ErrSchemaNotFound{}, api.ErrorCodeNotFound},
 		{"unknown", errors.New("boom"), api.ErrorInternal},
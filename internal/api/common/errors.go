@@ -24,8 +24,16 @@ const (
 	ErrMetadataOverride    = "METADATA_OVERRIDE"
 	ErrBulkSizeExceeded    = "BULK_SIZE_EXCEEDED"
 	ErrLedgerAlreadyExists = "LEDGER_ALREADY_EXISTS"
+	ErrLedgerNotFound      = "LEDGER_NOT_FOUND"
 	ErrSchemaAlreadyExists = "SCHEMA_ALREADY_EXISTS"
 	ErrSchemaNotSpecified  = "SCHEMA_NOT_SPECIFIED"
+	ErrAccountRule         = "ACCOUNT_RULE_VIOLATION"
+	ErrPeriodClosed        = "PERIOD_CLOSED"
+	ErrHoldNotPending      = "HOLD_NOT_PENDING"
+	ErrHoldExpired         = "HOLD_EXPIRED"
+	ErrScheduleNotPending  = "SCHEDULED_TRANSACTION_NOT_PENDING"
+	ErrRecurrenceCompleted = "RECURRENCE_COMPLETED"
+	ErrPreconditionFailed  = "PRECONDITION_FAILED"
 
 	ErrInterpreterParse   = "INTERPRETER_PARSE"
 	ErrInterpreterRuntime = "INTERPRETER_RUNTIME"
@@ -43,6 +51,10 @@ func HandleCommonErrors(w http.ResponseWriter, r *http.Request, err error) {
 		api.BadRequest(w, ErrSchemaNotSpecified, err)
 	case errors.Is(err, ledgercontroller.ErrSchemaNotFound{}):
 		api.NotFound(w, err)
+	case errors.Is(err, ledgercontroller.ErrAccountRuleViolation{}):
+		api.BadRequest(w, ErrAccountRule, err)
+	case errors.Is(err, ledgercontroller.ErrPeriodClosed{}):
+		api.WriteErrorResponse(w, http.StatusConflict, ErrPeriodClosed, err)
 	default:
 		InternalServerError(w, r, err)
 	}
@@ -62,6 +74,10 @@ func HandleCommonWriteErrors(w http.ResponseWriter, r *http.Request, err error)
 		api.BadRequest(w, ErrSchemaNotSpecified, err)
 	case errors.Is(err, ledgercontroller.ErrSchemaNotFound{}):
 		api.NotFound(w, err)
+	case errors.Is(err, ledgercontroller.ErrPreconditionFailed{}):
+		api.WriteErrorResponse(w, http.StatusPreconditionFailed, ErrPreconditionFailed, err)
+	case errors.Is(err, ledger.ErrMissingFeature{}):
+		api.BadRequest(w, ErrValidation, err)
 	default:
 		HandleCommonErrors(w, r, err)
 	}
@@ -42,7 +42,7 @@ func LedgerMiddleware(
 			if err != nil {
 				switch {
 				case postgres.IsNotFoundError(err):
-					api.WriteErrorResponse(w, http.StatusNotFound, "LEDGER_NOT_FOUND", err)
+					api.WriteErrorResponse(w, http.StatusNotFound, ErrLedgerNotFound, err)
 				default:
 					InternalServerError(w, r, err)
 				}
@@ -62,6 +62,55 @@ func (mr *LedgerControllerMockRecorder) BeginTX(ctx, options any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTX", reflect.TypeOf((*LedgerController)(nil).BeginTX), ctx, options)
 }
 
+// CancelScheduledTransaction mocks base method.
+func (m *LedgerController) CancelScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelScheduledTransaction", ctx, id)
+	ret0, _ := ret[0].(*ledger.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelScheduledTransaction indicates an expected call of CancelScheduledTransaction.
+func (mr *LedgerControllerMockRecorder) CancelScheduledTransaction(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelScheduledTransaction", reflect.TypeOf((*LedgerController)(nil).CancelScheduledTransaction), ctx, id)
+}
+
+// CaptureHold mocks base method.
+func (m *LedgerController) CaptureHold(ctx context.Context, parameters ledger0.Parameters[ledger0.CaptureHold]) (*ledger.Log, *ledger.CapturedHold, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureHold", ctx, parameters)
+	ret0, _ := ret[0].(*ledger.Log)
+	ret1, _ := ret[1].(*ledger.CapturedHold)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// CaptureHold indicates an expected call of CaptureHold.
+func (mr *LedgerControllerMockRecorder) CaptureHold(ctx, parameters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureHold", reflect.TypeOf((*LedgerController)(nil).CaptureHold), ctx, parameters)
+}
+
+// ClosePeriod mocks base method.
+func (m *LedgerController) ClosePeriod(ctx context.Context, parameters ledger0.Parameters[ledger0.ClosePeriod]) (*ledger.Log, *ledger.ClosedPeriod, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePeriod", ctx, parameters)
+	ret0, _ := ret[0].(*ledger.Log)
+	ret1, _ := ret[1].(*ledger.ClosedPeriod)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// ClosePeriod indicates an expected call of ClosePeriod.
+func (mr *LedgerControllerMockRecorder) ClosePeriod(ctx, parameters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePeriod", reflect.TypeOf((*LedgerController)(nil).ClosePeriod), ctx, parameters)
+}
+
 // Commit mocks base method.
 func (m *LedgerController) Commit(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -106,6 +155,53 @@ func (mr *LedgerControllerMockRecorder) CountTransactions(ctx, query any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTransactions", reflect.TypeOf((*LedgerController)(nil).CountTransactions), ctx, query)
 }
 
+// CreateHold mocks base method.
+func (m *LedgerController) CreateHold(ctx context.Context, parameters ledger0.Parameters[ledger0.CreateHold]) (*ledger.Log, *ledger.CreatedHold, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, parameters)
+	ret0, _ := ret[0].(*ledger.Log)
+	ret1, _ := ret[1].(*ledger.CreatedHold)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *LedgerControllerMockRecorder) CreateHold(ctx, parameters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*LedgerController)(nil).CreateHold), ctx, parameters)
+}
+
+// CreateRecurrence mocks base method.
+func (m *LedgerController) CreateRecurrence(ctx context.Context, input ledger0.CreateRecurrence) (*ledger.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecurrence", ctx, input)
+	ret0, _ := ret[0].(*ledger.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecurrence indicates an expected call of CreateRecurrence.
+func (mr *LedgerControllerMockRecorder) CreateRecurrence(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecurrence", reflect.TypeOf((*LedgerController)(nil).CreateRecurrence), ctx, input)
+}
+
+// CreateRevertJob mocks base method.
+func (m *LedgerController) CreateRevertJob(ctx context.Context, input ledger0.CreateRevertJob) (*ledger.RevertJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRevertJob", ctx, input)
+	ret0, _ := ret[0].(*ledger.RevertJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRevertJob indicates an expected call of CreateRevertJob.
+func (mr *LedgerControllerMockRecorder) CreateRevertJob(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRevertJob", reflect.TypeOf((*LedgerController)(nil).CreateRevertJob), ctx, input)
+}
+
 // CreateTransaction mocks base method.
 func (m *LedgerController) CreateTransaction(ctx context.Context, parameters ledger0.Parameters[ledger0.CreateTransaction]) (*ledger.Log, *ledger.CreatedTransaction, bool, error) {
 	m.ctrl.T.Helper()
@@ -139,6 +235,20 @@ func (mr *LedgerControllerMockRecorder) DeleteAccountMetadata(ctx, parameters an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccountMetadata", reflect.TypeOf((*LedgerController)(nil).DeleteAccountMetadata), ctx, parameters)
 }
 
+// DeleteRecurrence mocks base method.
+func (m *LedgerController) DeleteRecurrence(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecurrence", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecurrence indicates an expected call of DeleteRecurrence.
+func (mr *LedgerControllerMockRecorder) DeleteRecurrence(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecurrence", reflect.TypeOf((*LedgerController)(nil).DeleteRecurrence), ctx, id)
+}
+
 // DeleteTransactionMetadata mocks base method.
 func (m *LedgerController) DeleteTransactionMetadata(ctx context.Context, parameters ledger0.Parameters[ledger0.DeleteTransactionMetadata]) (*ledger.Log, bool, error) {
 	m.ctrl.T.Helper()
@@ -155,6 +265,38 @@ func (mr *LedgerControllerMockRecorder) DeleteTransactionMetadata(ctx, parameter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransactionMetadata", reflect.TypeOf((*LedgerController)(nil).DeleteTransactionMetadata), ctx, parameters)
 }
 
+// DiffSchema mocks base method.
+func (m *LedgerController) DiffSchema(ctx context.Context, version string, data ledger.SchemaData, query common.PaginatedQuery[any]) (*ledger.SchemaDiff, *paginate.Cursor[ledger.Account], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffSchema", ctx, version, data, query)
+	ret0, _ := ret[0].(*ledger.SchemaDiff)
+	ret1, _ := ret[1].(*paginate.Cursor[ledger.Account])
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DiffSchema indicates an expected call of DiffSchema.
+func (mr *LedgerControllerMockRecorder) DiffSchema(ctx, version, data, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffSchema", reflect.TypeOf((*LedgerController)(nil).DiffSchema), ctx, version, data, query)
+}
+
+// ExecuteScheduledTransaction mocks base method.
+func (m *LedgerController) ExecuteScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, *ledger.CreatedTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteScheduledTransaction", ctx, id)
+	ret0, _ := ret[0].(*ledger.ScheduledTransaction)
+	ret1, _ := ret[1].(*ledger.CreatedTransaction)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ExecuteScheduledTransaction indicates an expected call of ExecuteScheduledTransaction.
+func (mr *LedgerControllerMockRecorder) ExecuteScheduledTransaction(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteScheduledTransaction", reflect.TypeOf((*LedgerController)(nil).ExecuteScheduledTransaction), ctx, id)
+}
+
 // Export mocks base method.
 func (m *LedgerController) Export(ctx context.Context, w ledger0.ExportWriter) error {
 	m.ctrl.T.Helper()
@@ -184,6 +326,21 @@ func (mr *LedgerControllerMockRecorder) GetAccount(ctx, query any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*LedgerController)(nil).GetAccount), ctx, query)
 }
 
+// GetAccountStatement mocks base method.
+func (m *LedgerController) GetAccountStatement(ctx context.Context, address string, query common.PaginatedQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.AccountStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountStatement", ctx, address, query)
+	ret0, _ := ret[0].(*ledger.AccountStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountStatement indicates an expected call of GetAccountStatement.
+func (mr *LedgerControllerMockRecorder) GetAccountStatement(ctx, address, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountStatement", reflect.TypeOf((*LedgerController)(nil).GetAccountStatement), ctx, address, query)
+}
+
 // GetAggregatedBalances mocks base method.
 func (m *LedgerController) GetAggregatedBalances(ctx context.Context, q common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (ledger.BalancesByAssets, error) {
 	m.ctrl.T.Helper()
@@ -199,6 +356,96 @@ func (mr *LedgerControllerMockRecorder) GetAggregatedBalances(ctx, q any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregatedBalances", reflect.TypeOf((*LedgerController)(nil).GetAggregatedBalances), ctx, q)
 }
 
+// GetBalanceHistory mocks base method.
+func (m *LedgerController) GetBalanceHistory(ctx context.Context, query common.ResourceQuery[ledger.GetBalanceHistoryOptions]) ([]ledger.BalanceHistoryPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceHistory", ctx, query)
+	ret0, _ := ret[0].([]ledger.BalanceHistoryPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceHistory indicates an expected call of GetBalanceHistory.
+func (mr *LedgerControllerMockRecorder) GetBalanceHistory(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceHistory", reflect.TypeOf((*LedgerController)(nil).GetBalanceHistory), ctx, query)
+}
+
+// GetBalanceSheet mocks base method.
+func (m *LedgerController) GetBalanceSheet(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.BalanceSheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceSheet", ctx, version, query)
+	ret0, _ := ret[0].(*ledger.BalanceSheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceSheet indicates an expected call of GetBalanceSheet.
+func (mr *LedgerControllerMockRecorder) GetBalanceSheet(ctx, version, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceSheet", reflect.TypeOf((*LedgerController)(nil).GetBalanceSheet), ctx, version, query)
+}
+
+// GetChartTree mocks base method.
+func (m *LedgerController) GetChartTree(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) ([]ledger.ChartTreeNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChartTree", ctx, version, query)
+	ret0, _ := ret[0].([]ledger.ChartTreeNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChartTree indicates an expected call of GetChartTree.
+func (mr *LedgerControllerMockRecorder) GetChartTree(ctx, version, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChartTree", reflect.TypeOf((*LedgerController)(nil).GetChartTree), ctx, version, query)
+}
+
+// GetClosedPeriod mocks base method.
+func (m *LedgerController) GetClosedPeriod(ctx context.Context) (*ledger.ClosedPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClosedPeriod", ctx)
+	ret0, _ := ret[0].(*ledger.ClosedPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClosedPeriod indicates an expected call of GetClosedPeriod.
+func (mr *LedgerControllerMockRecorder) GetClosedPeriod(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClosedPeriod", reflect.TypeOf((*LedgerController)(nil).GetClosedPeriod), ctx)
+}
+
+// GetHold mocks base method.
+func (m *LedgerController) GetHold(ctx context.Context, id string) (*ledger.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHold", ctx, id)
+	ret0, _ := ret[0].(*ledger.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHold indicates an expected call of GetHold.
+func (mr *LedgerControllerMockRecorder) GetHold(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHold", reflect.TypeOf((*LedgerController)(nil).GetHold), ctx, id)
+}
+
+// GetIncomeStatement mocks base method.
+func (m *LedgerController) GetIncomeStatement(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.IncomeStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncomeStatement", ctx, version, query)
+	ret0, _ := ret[0].(*ledger.IncomeStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncomeStatement indicates an expected call of GetIncomeStatement.
+func (mr *LedgerControllerMockRecorder) GetIncomeStatement(ctx, version, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncomeStatement", reflect.TypeOf((*LedgerController)(nil).GetIncomeStatement), ctx, version, query)
+}
+
 // GetMigrationsInfo mocks base method.
 func (m *LedgerController) GetMigrationsInfo(ctx context.Context) ([]migrations.Info, error) {
 	m.ctrl.T.Helper()
@@ -214,6 +461,51 @@ func (mr *LedgerControllerMockRecorder) GetMigrationsInfo(ctx any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMigrationsInfo", reflect.TypeOf((*LedgerController)(nil).GetMigrationsInfo), ctx)
 }
 
+// GetRecurrence mocks base method.
+func (m *LedgerController) GetRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurrence", ctx, id)
+	ret0, _ := ret[0].(*ledger.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurrence indicates an expected call of GetRecurrence.
+func (mr *LedgerControllerMockRecorder) GetRecurrence(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurrence", reflect.TypeOf((*LedgerController)(nil).GetRecurrence), ctx, id)
+}
+
+// GetRevertJob mocks base method.
+func (m *LedgerController) GetRevertJob(ctx context.Context, id string) (*ledger.RevertJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevertJob", ctx, id)
+	ret0, _ := ret[0].(*ledger.RevertJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevertJob indicates an expected call of GetRevertJob.
+func (mr *LedgerControllerMockRecorder) GetRevertJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevertJob", reflect.TypeOf((*LedgerController)(nil).GetRevertJob), ctx, id)
+}
+
+// GetScheduledTransaction mocks base method.
+func (m *LedgerController) GetScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledTransaction", ctx, id)
+	ret0, _ := ret[0].(*ledger.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledTransaction indicates an expected call of GetScheduledTransaction.
+func (mr *LedgerControllerMockRecorder) GetScheduledTransaction(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledTransaction", reflect.TypeOf((*LedgerController)(nil).GetScheduledTransaction), ctx, id)
+}
+
 // GetSchema mocks base method.
 func (m *LedgerController) GetSchema(ctx context.Context, version string) (*ledger.Schema, error) {
 	m.ctrl.T.Helper()
@@ -259,6 +551,21 @@ func (mr *LedgerControllerMockRecorder) GetTransaction(ctx, query any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransaction", reflect.TypeOf((*LedgerController)(nil).GetTransaction), ctx, query)
 }
 
+// GetTrialBalance mocks base method.
+func (m *LedgerController) GetTrialBalance(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.TrialBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrialBalance", ctx, version, query)
+	ret0, _ := ret[0].(*ledger.TrialBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrialBalance indicates an expected call of GetTrialBalance.
+func (mr *LedgerControllerMockRecorder) GetTrialBalance(ctx, version, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrialBalance", reflect.TypeOf((*LedgerController)(nil).GetTrialBalance), ctx, version, query)
+}
+
 // GetVolumesWithBalances mocks base method.
 func (m *LedgerController) GetVolumesWithBalances(ctx context.Context, q common.PaginatedQuery[ledger.GetVolumesOptions]) (*paginate.Cursor[ledger.VolumesWithBalanceByAssetByAccount], error) {
 	m.ctrl.T.Helper()
@@ -364,6 +671,21 @@ func (mr *LedgerControllerMockRecorder) ListLogs(ctx, query any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogs", reflect.TypeOf((*LedgerController)(nil).ListLogs), ctx, query)
 }
 
+// ListRecurrences mocks base method.
+func (m *LedgerController) ListRecurrences(ctx context.Context, query common.PaginatedQuery[any]) (*paginate.Cursor[ledger.Recurrence], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecurrences", ctx, query)
+	ret0, _ := ret[0].(*paginate.Cursor[ledger.Recurrence])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecurrences indicates an expected call of ListRecurrences.
+func (mr *LedgerControllerMockRecorder) ListRecurrences(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecurrences", reflect.TypeOf((*LedgerController)(nil).ListRecurrences), ctx, query)
+}
+
 // ListSchemas mocks base method.
 func (m *LedgerController) ListSchemas(ctx context.Context, query common.PaginatedQuery[any]) (*paginate.Cursor[ledger.Schema], error) {
 	m.ctrl.T.Helper()
@@ -411,6 +733,70 @@ func (mr *LedgerControllerMockRecorder) LockLedger(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLedger", reflect.TypeOf((*LedgerController)(nil).LockLedger), ctx)
 }
 
+// PartiallyRevertTransaction mocks base method.
+func (m *LedgerController) PartiallyRevertTransaction(ctx context.Context, parameters ledger0.Parameters[ledger0.PartiallyRevertTransaction]) (*ledger.Log, *ledger.PartiallyRevertedTransaction, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PartiallyRevertTransaction", ctx, parameters)
+	ret0, _ := ret[0].(*ledger.Log)
+	ret1, _ := ret[1].(*ledger.PartiallyRevertedTransaction)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// PartiallyRevertTransaction indicates an expected call of PartiallyRevertTransaction.
+func (mr *LedgerControllerMockRecorder) PartiallyRevertTransaction(ctx, parameters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PartiallyRevertTransaction", reflect.TypeOf((*LedgerController)(nil).PartiallyRevertTransaction), ctx, parameters)
+}
+
+// PauseRecurrence mocks base method.
+func (m *LedgerController) PauseRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseRecurrence", ctx, id)
+	ret0, _ := ret[0].(*ledger.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PauseRecurrence indicates an expected call of PauseRecurrence.
+func (mr *LedgerControllerMockRecorder) PauseRecurrence(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseRecurrence", reflect.TypeOf((*LedgerController)(nil).PauseRecurrence), ctx, id)
+}
+
+// ReopenPeriod mocks base method.
+func (m *LedgerController) ReopenPeriod(ctx context.Context, parameters ledger0.Parameters[ledger0.ReopenPeriod]) (*ledger.Log, *ledger.ReopenedPeriod, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenPeriod", ctx, parameters)
+	ret0, _ := ret[0].(*ledger.Log)
+	ret1, _ := ret[1].(*ledger.ReopenedPeriod)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// ReopenPeriod indicates an expected call of ReopenPeriod.
+func (mr *LedgerControllerMockRecorder) ReopenPeriod(ctx, parameters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenPeriod", reflect.TypeOf((*LedgerController)(nil).ReopenPeriod), ctx, parameters)
+}
+
+// ResumeRecurrence mocks base method.
+func (m *LedgerController) ResumeRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeRecurrence", ctx, id)
+	ret0, _ := ret[0].(*ledger.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeRecurrence indicates an expected call of ResumeRecurrence.
+func (mr *LedgerControllerMockRecorder) ResumeRecurrence(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeRecurrence", reflect.TypeOf((*LedgerController)(nil).ResumeRecurrence), ctx, id)
+}
+
 // RevertTransaction mocks base method.
 func (m *LedgerController) RevertTransaction(ctx context.Context, parameters ledger0.Parameters[ledger0.RevertTransaction]) (*ledger.Log, *ledger.RevertedTransaction, bool, error) {
 	m.ctrl.T.Helper()
@@ -458,6 +844,38 @@ func (mr *LedgerControllerMockRecorder) RunQuery(ctx, schemaVersion, queryId, ru
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunQuery", reflect.TypeOf((*LedgerController)(nil).RunQuery), ctx, schemaVersion, queryId, runQuery, defaultPageSize)
 }
 
+// RunRecurrence mocks base method.
+func (m *LedgerController) RunRecurrence(ctx context.Context, id string, batchSize int) (*ledger.Recurrence, []ledger.CreatedTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunRecurrence", ctx, id, batchSize)
+	ret0, _ := ret[0].(*ledger.Recurrence)
+	ret1, _ := ret[1].([]ledger.CreatedTransaction)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RunRecurrence indicates an expected call of RunRecurrence.
+func (mr *LedgerControllerMockRecorder) RunRecurrence(ctx, id, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunRecurrence", reflect.TypeOf((*LedgerController)(nil).RunRecurrence), ctx, id, batchSize)
+}
+
+// RunRevertJob mocks base method.
+func (m *LedgerController) RunRevertJob(ctx context.Context, id string, batchSize int) (*ledger.RevertJob, []ledger.RevertedTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunRevertJob", ctx, id, batchSize)
+	ret0, _ := ret[0].(*ledger.RevertJob)
+	ret1, _ := ret[1].([]ledger.RevertedTransaction)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RunRevertJob indicates an expected call of RunRevertJob.
+func (mr *LedgerControllerMockRecorder) RunRevertJob(ctx, id, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunRevertJob", reflect.TypeOf((*LedgerController)(nil).RunRevertJob), ctx, id, batchSize)
+}
+
 // SaveAccountMetadata mocks base method.
 func (m *LedgerController) SaveAccountMetadata(ctx context.Context, parameters ledger0.Parameters[ledger0.SaveAccountMetadata]) (*ledger.Log, bool, error) {
 	m.ctrl.T.Helper()
@@ -489,3 +907,35 @@ func (mr *LedgerControllerMockRecorder) SaveTransactionMetadata(ctx, parameters
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTransactionMetadata", reflect.TypeOf((*LedgerController)(nil).SaveTransactionMetadata), ctx, parameters)
 }
+
+// ScheduleTransaction mocks base method.
+func (m *LedgerController) ScheduleTransaction(ctx context.Context, input ledger0.ScheduleTransaction) (*ledger.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleTransaction", ctx, input)
+	ret0, _ := ret[0].(*ledger.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleTransaction indicates an expected call of ScheduleTransaction.
+func (mr *LedgerControllerMockRecorder) ScheduleTransaction(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleTransaction", reflect.TypeOf((*LedgerController)(nil).ScheduleTransaction), ctx, input)
+}
+
+// VoidHold mocks base method.
+func (m *LedgerController) VoidHold(ctx context.Context, parameters ledger0.Parameters[ledger0.VoidHold]) (*ledger.Log, *ledger.VoidedHold, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidHold", ctx, parameters)
+	ret0, _ := ret[0].(*ledger.Log)
+	ret1, _ := ret[1].(*ledger.VoidedHold)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// VoidHold indicates an expected call of VoidHold.
+func (mr *LedgerControllerMockRecorder) VoidHold(ctx, parameters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidHold", reflect.TypeOf((*LedgerController)(nil).VoidHold), ctx, parameters)
+}
@@ -543,3 +543,17 @@ func (mr *SystemControllerMockRecorder) UpdateLedgerMetadata(ctx, name, m any) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLedgerMetadata", reflect.TypeOf((*SystemController)(nil).UpdateLedgerMetadata), ctx, name, m)
 }
+
+// UpdateLedgerSchemaSettings mocks base method.
+func (m *SystemController) UpdateLedgerSchemaSettings(ctx context.Context, name string, settings ledger.SchemaSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLedgerSchemaSettings", ctx, name, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLedgerSchemaSettings indicates an expected call of UpdateLedgerSchemaSettings.
+func (mr *SystemControllerMockRecorder) UpdateLedgerSchemaSettings(ctx, name, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLedgerSchemaSettings", reflect.TypeOf((*SystemController)(nil).UpdateLedgerSchemaSettings), ctx, name, settings)
+}
@@ -14,6 +14,7 @@ import (
 	. "github.com/formancehq/go-libs/v5/pkg/types/collections"
 	"github.com/formancehq/go-libs/v5/pkg/types/time"
 
+	"github.com/formancehq/ledger/internal/queries"
 	storagecommon "github.com/formancehq/ledger/internal/storage/common"
 )
 
@@ -59,7 +60,7 @@ func getQueryBuilder(r *http.Request) (query.Builder, error) {
 	}
 
 	if len(q) > 0 {
-		return query.ParseJSON(q)
+		return queries.ParseJSON(q)
 	}
 	return nil, nil
 }
@@ -72,6 +73,31 @@ func getExpand(r *http.Request) []string {
 	)
 }
 
+func getFields(r *http.Request) []string {
+	if !r.URL.Query().Has("fields") {
+		return nil
+	}
+	return Flatten(
+		Map(r.URL.Query()["fields"], func(from string) []string {
+			return strings.Split(from, ",")
+		}),
+	)
+}
+
+// getQueryFields returns the fields of the projection of a paginated query, including one read from a cursor
+func getQueryFields[Options any](q storagecommon.PaginatedQuery[Options]) []string {
+	switch v := q.(type) {
+	case storagecommon.InitialPaginatedQuery[Options]:
+		return v.Options.Fields
+	case storagecommon.OffsetPaginatedQuery[Options]:
+		return v.Options.Fields
+	case storagecommon.ColumnPaginatedQuery[Options]:
+		return v.Options.Fields
+	default:
+		return nil
+	}
+}
+
 func getPaginatedQuery[Options any](
 	r *http.Request,
 	paginationConfig storagecommon.PaginationConfig,
@@ -160,6 +186,7 @@ func getResourceQuery[v any](r *http.Request, modifiers ...func(*v) error) (*sto
 		OOT:     oot,
 		Builder: builder,
 		Expand:  getExpand(r),
+		Fields:  getFields(r),
 		Opts:    options,
 	}, nil
 }
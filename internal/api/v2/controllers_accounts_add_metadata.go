@@ -22,11 +22,20 @@ func addAccountMetadata(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	preconditions, err := getAccountPreconditions(r, address)
+	if err != nil {
+		api.BadRequest(w, common.ErrValidation, err)
+		return
+	}
+
 	common.WithBody(w, r, func(m metadata.Metadata) {
-		_, idempotencyHit, err := l.SaveAccountMetadata(r.Context(), getCommandParameters(r, ledger.SaveAccountMetadata{
+		parameters := getCommandParameters(r, ledger.SaveAccountMetadata{
 			Address:  address,
 			Metadata: m,
-		}))
+		})
+		parameters.Preconditions = preconditions
+
+		_, idempotencyHit, err := l.SaveAccountMetadata(r.Context(), parameters)
 		if err != nil {
 			common.HandleCommonWriteErrors(w, r, err)
 			return
@@ -1,6 +1,7 @@
 package v2
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -12,6 +13,7 @@ import (
 	"github.com/formancehq/go-libs/v5/pkg/authn/jwt"
 	"github.com/formancehq/go-libs/v5/pkg/transport/api"
 	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
 
 	ledger "github.com/formancehq/ledger/internal"
 	"github.com/formancehq/ledger/internal/api/common"
@@ -22,13 +24,16 @@ func TestAccountsAddMetadata(t *testing.T) {
 	t.Parallel()
 
 	type testCase struct {
-		name              string
-		queryParams       url.Values
-		expectStatusCode  int
-		expectedErrorCode string
-		account           string
-		body              any
+		name                string
+		queryParams         url.Values
+		expectStatusCode    int
+		expectedErrorCode   string
+		account             string
+		body                any
+		ifMatch             string
+		expectPreconditions ledgercontroller.Preconditions
 	}
+	version := time.Now()
 
 	testCases := []testCase{
 		{
@@ -45,6 +50,27 @@ func TestAccountsAddMetadata(t *testing.T) {
 			expectStatusCode:  http.StatusBadRequest,
 			expectedErrorCode: common.ErrValidation,
 		},
+		{
+			name:    "with If-Match",
+			account: "world",
+			body: metadata.Metadata{
+				"foo": "bar",
+			},
+			ifMatch: fmt.Sprintf(`"%d"`, version.UnixMicro()),
+			expectPreconditions: ledgercontroller.Preconditions{
+				AccountVersions: map[string]time.Time{"world": version},
+			},
+		},
+		{
+			name:    "with invalid If-Match",
+			account: "world",
+			body: metadata.Metadata{
+				"foo": "bar",
+			},
+			ifMatch:           `"abc"`,
+			expectStatusCode:  http.StatusBadRequest,
+			expectedErrorCode: common.ErrValidation,
+		},
 		{
 			name:              "invalid account address",
 			account:           "%8X%2F",
@@ -68,6 +94,7 @@ func TestAccountsAddMetadata(t *testing.T) {
 							Address:  testCase.account,
 							Metadata: testCase.body.(metadata.Metadata),
 						},
+						Preconditions: testCase.expectPreconditions,
 					}).
 					Return(&ledger.Log{}, false, nil)
 			}
@@ -77,6 +104,9 @@ func TestAccountsAddMetadata(t *testing.T) {
 			req := httptest.NewRequest(http.MethodPost, "/", api.Buffer(t, testCase.body))
 			// httptest.NewRequest check for invalid urls while we want to test invalid urls
 			req.URL.Path = "/xxx/accounts/" + testCase.account + "/metadata"
+			if testCase.ifMatch != "" {
+				req.Header.Set("If-Match", testCase.ifMatch)
+			}
 			rec := httptest.NewRecorder()
 			req.URL.RawQuery = testCase.queryParams.Encode()
 
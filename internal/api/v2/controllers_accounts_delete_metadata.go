@@ -19,14 +19,20 @@ func deleteAccountMetadata(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	preconditions, err := getAccountPreconditions(r, address)
+	if err != nil {
+		api.BadRequest(w, common.ErrValidation, err)
+		return
+	}
+
+	parameters := getCommandParameters(r, ledger.DeleteAccountMetadata{
+		Address: address,
+		Key:     chi.URLParam(r, "key"),
+	})
+	parameters.Preconditions = preconditions
+
 	_, idempotencyHit, err := common.LedgerFromContext(r.Context()).
-		DeleteAccountMetadata(
-			r.Context(),
-			getCommandParameters(r, ledger.DeleteAccountMetadata{
-				Address: address,
-				Key:     chi.URLParam(r, "key"),
-			}),
-		)
+		DeleteAccountMetadata(r.Context(), parameters)
 	if err != nil {
 
 		common.HandleCommonWriteErrors(w, r, err)
@@ -27,8 +27,9 @@ func listAccounts(paginationConfig storagecommon.PaginationConfig) http.HandlerF
 			return
 		}
 
+		fields := getQueryFields[any](query)
 		api.RenderCursor(w, *paginate.MapCursor(cursor, func(account ledger.Account) any {
-			return renderAccount(r, account)
+			return renderProjection(renderAccount(r, account), fields)
 		}))
 	}
 }
@@ -44,5 +44,10 @@ func readAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the version is only meaningful for the current state of the account
+	if pit == nil {
+		writeETag(w, acc.UpdatedAt)
+	}
+
 	api.Ok(w, renderAccount(r, *acc))
 }
@@ -2,6 +2,7 @@ package v2
 
 import (
 	"bytes"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -17,6 +18,7 @@ import (
 
 	ledger "github.com/formancehq/ledger/internal"
 	"github.com/formancehq/ledger/internal/api/common"
+	ledgercontroller "github.com/formancehq/ledger/internal/controller/ledger"
 	storagecommon "github.com/formancehq/ledger/internal/storage/common"
 )
 
@@ -120,3 +122,34 @@ func TestAccountsRead(t *testing.T) {
 		})
 	}
 }
+
+func TestAccountsReadETag(t *testing.T) {
+	t.Parallel()
+
+	updatedAt := time.Now()
+	systemController, ledgerController := newTestingSystemController(t, true)
+	ledgerController.EXPECT().
+		GetAccount(gomock.Any(), storagecommon.ResourceQuery[any]{
+			Builder: query.Match("address", "foo"),
+		}).
+		Return(&ledger.Account{Address: "foo", UpdatedAt: updatedAt}, nil)
+
+	router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+	req := httptest.NewRequest(http.MethodGet, "/xxx/accounts/foo", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, fmt.Sprintf(`"%d"`, updatedAt.UnixMicro()), rec.Header().Get("ETag"))
+
+	// the ETag can be used as is to make a write conditional
+	req = httptest.NewRequest(http.MethodPost, "/xxx/accounts/foo/metadata", nil)
+	req.Header.Set("If-Match", rec.Header().Get("ETag"))
+	preconditions, err := getAccountPreconditions(req, "foo")
+	require.NoError(t, err)
+	require.Equal(t, ledgercontroller.Preconditions{
+		AccountVersions: map[string]time.Time{"foo": updatedAt},
+	}, preconditions)
+}
@@ -0,0 +1,146 @@
+package v2
+
+import (
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/common"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+)
+
+func readAccountStatement(paginationConfig storagecommon.PaginationConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address, err := url.PathUnescape(chi.URLParam(r, "address"))
+		if err != nil {
+			api.BadRequestWithDetails(w, common.ErrValidation, err, err.Error())
+			return
+		}
+
+		startTime, err := getDate(r, "startTime")
+		if err != nil {
+			api.BadRequest(w, common.ErrValidation, err)
+			return
+		}
+		endTime, err := getDate(r, "endTime")
+		if err != nil {
+			api.BadRequest(w, common.ErrValidation, err)
+			return
+		}
+
+		format := r.URL.Query().Get("format")
+		switch format {
+		case "":
+			if r.Header.Get("Accept") == "text/csv" {
+				format = "csv"
+			}
+		case "json", "csv":
+		default:
+			api.BadRequest(w, common.ErrValidation, errors.New("invalid format, expected `json` or `csv`"))
+			return
+		}
+
+		// the moves are ordered by date, the column is only used by the cursor
+		query, err := getPaginatedQuery[ledger.GetAggregatedVolumesOptions](
+			r,
+			paginationConfig,
+			"",
+			paginate.OrderAsc,
+			func(resourceQuery *storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]) {
+				resourceQuery.OOT = startTime
+				resourceQuery.PIT = endTime
+				resourceQuery.Opts.UseInsertionDate = api.QueryParamBool(r, "useInsertionDate")
+			},
+		)
+		if err != nil {
+			api.BadRequest(w, common.ErrValidation, err)
+			return
+		}
+
+		l := common.LedgerFromContext(r.Context())
+		statement, err := l.GetAccountStatement(r.Context(), address, query)
+		if err != nil {
+			common.HandleCommonPaginationErrors(w, r, err)
+			return
+		}
+
+		if format != "csv" {
+			api.Ok(w, renderAccountStatement(r, *statement))
+			return
+		}
+
+		// the export holds every move from the requested page, so the next pages are read before writing it
+		lines := statement.Lines.Data
+		for cursor := statement.Lines; cursor.HasMore; {
+			next, err := storagecommon.UnmarshalCursor[ledger.GetAggregatedVolumesOptions](cursor.Next)
+			if err != nil {
+				common.InternalServerError(w, r, err)
+				return
+			}
+
+			page, err := l.GetAccountStatement(r.Context(), address, next)
+			if err != nil {
+				common.HandleCommonPaginationErrors(w, r, err)
+				return
+			}
+			cursor = page.Lines
+			lines = append(lines, cursor.Data...)
+		}
+
+		useInsertionDate := api.QueryParamBool(r, "useInsertionDate")
+		if cursorQuery, ok := query.(storagecommon.OffsetPaginatedQuery[ledger.GetAggregatedVolumesOptions]); ok {
+			// the dates of a page follow its cursor
+			useInsertionDate = cursorQuery.Options.Opts.UseInsertionDate
+		}
+
+		writeAccountStatementCSV(w, *statement, lines, useInsertionDate)
+	}
+}
+
+// writeAccountStatementCSV writes a row per opening balance, per move, then per closing balance
+func writeAccountStatementCSV(w http.ResponseWriter, statement ledger.AccountStatement, lines []ledger.AccountStatementLine, useInsertionDate bool) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writeBalances := func(kind string, date time.Time, balances ledger.BalancesByAssets) {
+		assets := make([]string, 0, len(balances))
+		for asset := range balances {
+			assets = append(assets, asset)
+		}
+		slices.Sort(assets)
+
+		for _, asset := range assets {
+			_ = writer.Write([]string{kind, date.Format(time.DateFormat), "", asset, "", balances[asset].String()})
+		}
+	}
+
+	_ = writer.Write([]string{"type", "date", "transactionId", "asset", "amount", "balance"})
+	writeBalances("opening", statement.StartTime, statement.OpeningBalances)
+	for _, line := range lines {
+		date := line.EffectiveDate
+		if useInsertionDate {
+			date = line.InsertionDate
+		}
+		_ = writer.Write([]string{
+			"move",
+			date.Format(time.DateFormat),
+			strconv.FormatUint(line.TransactionID, 10),
+			line.Asset,
+			line.Amount.String(),
+			line.Balance.String(),
+		})
+	}
+	writeBalances("closing", statement.EndTime, statement.ClosingBalances)
+	writer.Flush()
+}
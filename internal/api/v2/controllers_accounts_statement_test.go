@@ -0,0 +1,300 @@
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/formancehq/go-libs/v5/pkg/authn/jwt"
+	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+)
+
+func TestReadAccountStatement(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name              string
+		queryParams       url.Values
+		headers           http.Header
+		expectQuery       storagecommon.PaginatedQuery[ledger.GetAggregatedVolumesOptions]
+		expectBackendCall bool
+		returnErr         error
+		expectStatusCode  int
+		expectedErrorCode string
+		expectedCSV       string
+	}
+	end, err := time.ParseTime("2024-01-02T00:00:00Z")
+	require.NoError(t, err)
+	start := end.Add(-24 * time.Hour)
+
+	statement := ledger.AccountStatement{
+		Account:   "users:1",
+		StartTime: start,
+		EndTime:   end,
+		OpeningBalances: ledger.BalancesByAssets{
+			"USD": big.NewInt(100),
+		},
+		Lines: &paginate.Cursor[ledger.AccountStatementLine]{
+			PageSize: paginate.QueryDefaultPageSize,
+			Data: []ledger.AccountStatementLine{{
+				TransactionID: 1,
+				InsertionDate: start,
+				EffectiveDate: start,
+				Asset:         "USD",
+				Amount:        big.NewInt(-30),
+				Balance:       big.NewInt(70),
+			}},
+		},
+		ClosingBalances: ledger.BalancesByAssets{
+			"USD": big.NewInt(70),
+		},
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "nominal",
+			queryParams: url.Values{
+				"startTime": []string{start.Format(time.DateFormat)},
+				"endTime":   []string{end.Format(time.DateFormat)},
+			},
+			expectQuery: storagecommon.InitialPaginatedQuery[ledger.GetAggregatedVolumesOptions]{
+				PageSize: paginate.QueryDefaultPageSize,
+				Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
+				Options: storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+					OOT:    &start,
+					PIT:    &end,
+					Expand: make([]string, 0),
+				},
+			},
+			expectBackendCall: true,
+			expectStatusCode:  http.StatusOK,
+		},
+		{
+			name: "with page size",
+			queryParams: url.Values{
+				"startTime": []string{start.Format(time.DateFormat)},
+				"endTime":   []string{end.Format(time.DateFormat)},
+				"pageSize":  []string{"10"},
+			},
+			expectQuery: storagecommon.InitialPaginatedQuery[ledger.GetAggregatedVolumesOptions]{
+				PageSize: 10,
+				Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
+				Options: storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+					OOT:    &start,
+					PIT:    &end,
+					Expand: make([]string, 0),
+				},
+			},
+			expectBackendCall: true,
+			expectStatusCode:  http.StatusOK,
+		},
+		{
+			name: "csv using insertion date",
+			queryParams: url.Values{
+				"startTime":        []string{start.Format(time.DateFormat)},
+				"endTime":          []string{end.Format(time.DateFormat)},
+				"useInsertionDate": []string{"true"},
+			},
+			headers: http.Header{
+				"Accept": []string{"text/csv"},
+			},
+			expectQuery: storagecommon.InitialPaginatedQuery[ledger.GetAggregatedVolumesOptions]{
+				PageSize: paginate.QueryDefaultPageSize,
+				Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
+				Options: storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+					OOT:    &start,
+					PIT:    &end,
+					Expand: make([]string, 0),
+					Opts: ledger.GetAggregatedVolumesOptions{
+						UseInsertionDate: true,
+					},
+				},
+			},
+			expectBackendCall: true,
+			expectStatusCode:  http.StatusOK,
+			expectedCSV: `type,date,transactionId,asset,amount,balance
+opening,2024-01-01T00:00:00Z,,USD,,100
+move,2024-01-01T00:00:00Z,1,USD,-30,70
+closing,2024-01-02T00:00:00Z,,USD,,70
+`,
+		},
+		{
+			name: "invalid format",
+			queryParams: url.Values{
+				"format": []string{"xml"},
+			},
+			expectStatusCode:  http.StatusBadRequest,
+			expectedErrorCode: "VALIDATION",
+		},
+		{
+			name: "invalid start time",
+			queryParams: url.Values{
+				"startTime": []string{"yesterday"},
+			},
+			expectStatusCode:  http.StatusBadRequest,
+			expectedErrorCode: "VALIDATION",
+		},
+		{
+			name: "invalid query",
+			expectQuery: storagecommon.InitialPaginatedQuery[ledger.GetAggregatedVolumesOptions]{
+				PageSize: paginate.QueryDefaultPageSize,
+				Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
+				Options: storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+					Expand: make([]string, 0),
+				},
+			},
+			expectBackendCall: true,
+			returnErr:         storagecommon.ErrInvalidQuery{},
+			expectStatusCode:  http.StatusBadRequest,
+			expectedErrorCode: "VALIDATION",
+		},
+		{
+			name: "backend error",
+			expectQuery: storagecommon.InitialPaginatedQuery[ledger.GetAggregatedVolumesOptions]{
+				PageSize: paginate.QueryDefaultPageSize,
+				Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
+				Options: storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+					Expand: make([]string, 0),
+				},
+			},
+			expectBackendCall: true,
+			returnErr:         errors.New("database error"),
+			expectStatusCode:  http.StatusInternalServerError,
+			expectedErrorCode: "INTERNAL",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			if tc.expectBackendCall {
+				ledgerController.EXPECT().
+					GetAccountStatement(gomock.Any(), "users:1", tc.expectQuery).
+					Return(&statement, tc.returnErr)
+			}
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodGet, "/default/accounts/users:1/statement", nil)
+			req.URL.RawQuery = tc.queryParams.Encode()
+			for key, values := range tc.headers {
+				req.Header[key] = values
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectStatusCode, rec.Code)
+			switch {
+			case tc.expectedErrorCode != "":
+				var errorResponse api.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Equal(t, tc.expectedErrorCode, errorResponse.ErrorCode)
+			case tc.expectedCSV != "":
+				require.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+				require.Equal(t, tc.expectedCSV, rec.Body.String())
+			default:
+				var response struct {
+					Data ledger.AccountStatement `json:"data"`
+				}
+				api.Decode(t, rec.Body, &response)
+				require.Equal(t, statement, response.Data)
+			}
+		})
+	}
+}
+
+func TestReadAccountStatementCSVPages(t *testing.T) {
+	t.Parallel()
+
+	end, err := time.ParseTime("2024-01-02T00:00:00Z")
+	require.NoError(t, err)
+	start := end.Add(-24 * time.Hour)
+
+	initialQuery := storagecommon.InitialPaginatedQuery[ledger.GetAggregatedVolumesOptions]{
+		PageSize: 1,
+		Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
+		Options: storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+			OOT:    &start,
+			PIT:    &end,
+			Expand: make([]string, 0),
+		},
+	}
+	nextCursor := paginate.EncodeCursor(storagecommon.OffsetPaginatedQuery[ledger.GetAggregatedVolumesOptions]{
+		InitialPaginatedQuery: initialQuery,
+		Offset:                1,
+	})
+	// the dates of the cursor are compared once decoded
+	nextQuery, err := storagecommon.UnmarshalCursor[ledger.GetAggregatedVolumesOptions](nextCursor)
+	require.NoError(t, err)
+	newStatement := func(line ledger.AccountStatementLine, next string) *ledger.AccountStatement {
+		return &ledger.AccountStatement{
+			Account:         "users:1",
+			StartTime:       start,
+			EndTime:         end,
+			OpeningBalances: ledger.BalancesByAssets{"USD": big.NewInt(100)},
+			Lines: &paginate.Cursor[ledger.AccountStatementLine]{
+				PageSize: 1,
+				HasMore:  next != "",
+				Next:     next,
+				Data:     []ledger.AccountStatementLine{line},
+			},
+			ClosingBalances: ledger.BalancesByAssets{"USD": big.NewInt(120)},
+		}
+	}
+
+	systemController, ledgerController := newTestingSystemController(t, true)
+	// the export follows the cursors to write every move of the period
+	ledgerController.EXPECT().
+		GetAccountStatement(gomock.Any(), "users:1", initialQuery).
+		Return(newStatement(ledger.AccountStatementLine{
+			TransactionID: 1,
+			EffectiveDate: start,
+			Asset:         "USD",
+			Amount:        big.NewInt(50),
+			Balance:       big.NewInt(150),
+		}, nextCursor), nil)
+	ledgerController.EXPECT().
+		GetAccountStatement(gomock.Any(), "users:1", nextQuery).
+		Return(newStatement(ledger.AccountStatementLine{
+			TransactionID: 2,
+			EffectiveDate: start,
+			Asset:         "USD",
+			Amount:        big.NewInt(-30),
+			Balance:       big.NewInt(120),
+		}, ""), nil)
+
+	router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+	req := httptest.NewRequest(http.MethodGet, "/default/accounts/users:1/statement", nil)
+	req.URL.RawQuery = url.Values{
+		"startTime": []string{start.Format(time.DateFormat)},
+		"endTime":   []string{end.Format(time.DateFormat)},
+		"pageSize":  []string{"1"},
+		"format":    []string{"csv"},
+	}.Encode()
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, `type,date,transactionId,asset,amount,balance
+opening,2024-01-01T00:00:00Z,,USD,,100
+move,2024-01-01T00:00:00Z,1,USD,50,150
+move,2024-01-01T00:00:00Z,2,USD,-30,120
+closing,2024-01-02T00:00:00Z,,USD,,120
+`, rec.Body.String())
+}
@@ -0,0 +1,65 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/formancehq/go-libs/v5/pkg/query"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/common"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+	ledgerstore "github.com/formancehq/ledger/internal/storage/ledger"
+)
+
+func readAccountBalanceHistory(w http.ResponseWriter, r *http.Request) {
+	address, err := url.PathUnescape(chi.URLParam(r, "address"))
+	if err != nil {
+		api.BadRequestWithDetails(w, common.ErrValidation, err, err.Error())
+		return
+	}
+
+	readBalanceHistory(w, r, query.Match("address", address))
+}
+
+func readBalancesAggregatedHistory(w http.ResponseWriter, r *http.Request) {
+	readBalanceHistory(w, r, nil)
+}
+
+// readBalanceHistory renders the balance history of the accounts matching the builder,
+// or the filter of the request if no builder is provided
+func readBalanceHistory(w http.ResponseWriter, r *http.Request, builder query.Builder) {
+	rq, err := getResourceQuery[ledger.GetBalanceHistoryOptions](r, func(options *ledger.GetBalanceHistoryOptions) error {
+		options.UseInsertionDate = api.QueryParamBool(r, "useInsertionDate")
+		options.Interval = ledger.BalanceHistoryInterval(r.URL.Query().Get("interval"))
+		if options.Interval == "" {
+			options.Interval = ledger.BalanceHistoryIntervalDay
+		}
+
+		return options.Interval.Validate()
+	})
+	if err != nil {
+		api.BadRequest(w, common.ErrValidation, err)
+		return
+	}
+	if builder != nil {
+		rq.Builder = builder
+	}
+
+	history, err := common.LedgerFromContext(r.Context()).GetBalanceHistory(r.Context(), *rq)
+	if err != nil {
+		switch {
+		case errors.Is(err, storagecommon.ErrInvalidQuery{}) || errors.Is(err, ledgerstore.ErrMissingFeature{}):
+			api.BadRequest(w, common.ErrValidation, err)
+		default:
+			common.HandleCommonErrors(w, r, err)
+		}
+		return
+	}
+
+	api.Ok(w, renderBalanceHistory(r, history))
+}
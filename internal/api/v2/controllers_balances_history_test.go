@@ -0,0 +1,164 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/formancehq/go-libs/v5/pkg/authn/jwt"
+	"github.com/formancehq/go-libs/v5/pkg/query"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+)
+
+func TestReadBalanceHistory(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name              string
+		path              string
+		queryParams       url.Values
+		body              string
+		expectQuery       storagecommon.ResourceQuery[ledger.GetBalanceHistoryOptions]
+		expectBackendCall bool
+		returnErr         error
+		expectStatusCode  int
+		expectedErrorCode string
+	}
+	start := time.Now().Add(-48 * time.Hour)
+	end := time.Now()
+
+	history := []ledger.BalanceHistoryPoint{{
+		Date: end,
+		Balances: ledger.BalancesByAssets{
+			"USD": big.NewInt(100),
+		},
+	}}
+
+	for _, tc := range []testCase{
+		{
+			name: "account",
+			path: "/default/accounts/users:1/history",
+			queryParams: url.Values{
+				"oot":      []string{start.Format(time.DateFormat)},
+				"pit":      []string{end.Format(time.DateFormat)},
+				"interval": []string{"hour"},
+			},
+			expectQuery: storagecommon.ResourceQuery[ledger.GetBalanceHistoryOptions]{
+				OOT:     &start,
+				PIT:     &end,
+				Builder: query.Match("address", "users:1"),
+				Opts: ledger.GetBalanceHistoryOptions{
+					Interval: ledger.BalanceHistoryIntervalHour,
+				},
+			},
+			expectBackendCall: true,
+			expectStatusCode:  http.StatusOK,
+		},
+		{
+			name: "filter using insertion date",
+			path: "/default/aggregate/balances/history",
+			queryParams: url.Values{
+				"oot":              []string{start.Format(time.DateFormat)},
+				"pit":              []string{end.Format(time.DateFormat)},
+				"useInsertionDate": []string{"true"},
+			},
+			body: `{"$match": {"address": "users:"}}`,
+			expectQuery: storagecommon.ResourceQuery[ledger.GetBalanceHistoryOptions]{
+				OOT:     &start,
+				PIT:     &end,
+				Builder: query.Match("address", "users:"),
+				Opts: ledger.GetBalanceHistoryOptions{
+					GetAggregatedVolumesOptions: ledger.GetAggregatedVolumesOptions{
+						UseInsertionDate: true,
+					},
+					Interval: ledger.BalanceHistoryIntervalDay,
+				},
+			},
+			expectBackendCall: true,
+			expectStatusCode:  http.StatusOK,
+		},
+		{
+			name: "invalid interval",
+			path: "/default/aggregate/balances/history",
+			queryParams: url.Values{
+				"interval": []string{"week"},
+			},
+			expectStatusCode:  http.StatusBadRequest,
+			expectedErrorCode: "VALIDATION",
+		},
+		{
+			name: "invalid query",
+			path: "/default/aggregate/balances/history",
+			expectQuery: storagecommon.ResourceQuery[ledger.GetBalanceHistoryOptions]{
+				Opts: ledger.GetBalanceHistoryOptions{
+					Interval: ledger.BalanceHistoryIntervalDay,
+				},
+			},
+			expectBackendCall: true,
+			returnErr:         storagecommon.ErrInvalidQuery{},
+			expectStatusCode:  http.StatusBadRequest,
+			expectedErrorCode: "VALIDATION",
+		},
+		{
+			name: "backend error",
+			path: "/default/aggregate/balances/history",
+			expectQuery: storagecommon.ResourceQuery[ledger.GetBalanceHistoryOptions]{
+				Opts: ledger.GetBalanceHistoryOptions{
+					Interval: ledger.BalanceHistoryIntervalDay,
+				},
+			},
+			expectBackendCall: true,
+			returnErr:         errors.New("database error"),
+			expectStatusCode:  http.StatusInternalServerError,
+			expectedErrorCode: "INTERNAL",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tc.expectQuery.Expand == nil {
+				tc.expectQuery.Expand = []string{}
+			}
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			if tc.expectBackendCall {
+				ledgerController.EXPECT().
+					GetBalanceHistory(gomock.Any(), tc.expectQuery).
+					Return(history, tc.returnErr)
+			}
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, bytes.NewBufferString(tc.body))
+			req.URL.RawQuery = tc.queryParams.Encode()
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectStatusCode, rec.Code)
+			if tc.expectedErrorCode != "" {
+				var errorResponse api.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Equal(t, tc.expectedErrorCode, errorResponse.ErrorCode)
+			} else {
+				var response struct {
+					Data []ledger.BalanceHistoryPoint `json:"data"`
+				}
+				api.Decode(t, rec.Body, &response)
+				require.Equal(t, history, response.Data)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	"github.com/formancehq/ledger/internal/api/common"
+	ledgercontroller "github.com/formancehq/ledger/internal/controller/ledger"
+)
+
+func readHold(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	hold, err := l.GetHold(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, ledgercontroller.ErrNotFound):
+			api.NotFound(w, err)
+		default:
+			common.HandleCommonErrors(w, r, err)
+		}
+		return
+	}
+
+	api.Ok(w, renderHold(r, *hold))
+}
+
+func createHold(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	type request struct {
+		Source      string            `json:"source"`
+		Destination string            `json:"destination"`
+		Asset       string            `json:"asset"`
+		Amount      *big.Int          `json:"amount"`
+		Metadata    metadata.Metadata `json:"metadata,omitempty"`
+		ExpiresAt   *time.Time        `json:"expiresAt,omitempty"`
+	}
+
+	x := request{}
+	if err := json.NewDecoder(r.Body).Decode(&x); err != nil {
+		api.BadRequest(w, common.ErrValidation, errors.New("expected JSON body with the hold"))
+		return
+	}
+
+	_, ret, idempotencyHit, err := l.CreateHold(r.Context(), getCommandParameters(r, ledgercontroller.CreateHold{
+		Source:      x.Source,
+		Destination: x.Destination,
+		Asset:       x.Asset,
+		Amount:      x.Amount,
+		Metadata:    x.Metadata,
+		ExpiresAt:   x.ExpiresAt,
+	}))
+	if err != nil {
+		writeHoldError(w, r, err)
+		return
+	}
+	if idempotencyHit {
+		w.Header().Set("Idempotency-Hit", "true")
+	}
+
+	api.Created(w, renderHold(r, ret.Hold))
+}
+
+func captureHold(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	type request struct {
+		Amount   *big.Int          `json:"amount,omitempty"`
+		Metadata metadata.Metadata `json:"metadata,omitempty"`
+	}
+
+	x := request{}
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&x); err != nil {
+			api.BadRequest(w, common.ErrValidation, errors.New("expected JSON body with the captured amount"))
+			return
+		}
+	}
+
+	_, ret, idempotencyHit, err := l.CaptureHold(r.Context(), getCommandParameters(r, ledgercontroller.CaptureHold{
+		ID:       chi.URLParam(r, "id"),
+		Amount:   x.Amount,
+		Metadata: x.Metadata,
+	}))
+	if err != nil {
+		writeHoldError(w, r, err)
+		return
+	}
+	if idempotencyHit {
+		w.Header().Set("Idempotency-Hit", "true")
+	}
+
+	api.Created(w, renderTransaction(r, ret.Transaction))
+}
+
+func voidHold(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	_, _, idempotencyHit, err := l.VoidHold(r.Context(), getCommandParameters(r, ledgercontroller.VoidHold{
+		ID: chi.URLParam(r, "id"),
+	}))
+	if err != nil {
+		writeHoldError(w, r, err)
+		return
+	}
+	if idempotencyHit {
+		w.Header().Set("Idempotency-Hit", "true")
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeHoldError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, ledgercontroller.ErrInvalidHold{}):
+		api.BadRequest(w, common.ErrValidation, err)
+	case errors.Is(err, &ledgercontroller.ErrInsufficientFunds{}):
+		api.BadRequest(w, common.ErrInsufficientFund, err)
+	case errors.Is(err, ledgercontroller.ErrHoldNotPending{}):
+		api.BadRequest(w, common.ErrHoldNotPending, err)
+	case errors.Is(err, ledgercontroller.ErrHoldExpired{}):
+		api.BadRequest(w, common.ErrHoldExpired, err)
+	default:
+		common.HandleCommonWriteErrors(w, r, err)
+	}
+}
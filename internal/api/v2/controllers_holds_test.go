@@ -0,0 +1,238 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/formancehq/go-libs/v5/pkg/authn/jwt"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/common"
+	ledgercontroller "github.com/formancehq/ledger/internal/controller/ledger"
+)
+
+func TestCreateHold(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name                 string
+		body                 string
+		expectControllerCall bool
+		returnErr            error
+		expectedStatusCode   int
+		expectedErrorCode    string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:                 "nominal",
+			body:                 `{"source": "bank", "destination": "merchant", "asset": "USD", "amount": 100}`,
+			expectControllerCall: true,
+			expectedStatusCode:   http.StatusCreated,
+		},
+		{
+			name:               "invalid body",
+			body:               `not a json`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  common.ErrValidation,
+		},
+		{
+			name:                 "invalid hold",
+			body:                 `{"source": "bank", "destination": "merchant", "asset": "USD", "amount": 100}`,
+			expectControllerCall: true,
+			returnErr:            ledgercontroller.ErrInvalidHold{},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorCode:    common.ErrValidation,
+		},
+		{
+			name:                 "insufficient funds",
+			body:                 `{"source": "bank", "destination": "merchant", "asset": "USD", "amount": 100}`,
+			expectControllerCall: true,
+			returnErr:            &ledgercontroller.ErrInsufficientFunds{},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorCode:    common.ErrInsufficientFund,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			if tc.expectControllerCall {
+				ledgerController.EXPECT().
+					CreateHold(gomock.Any(), ledgercontroller.Parameters[ledgercontroller.CreateHold]{
+						Input: ledgercontroller.CreateHold{
+							Source:      "bank",
+							Destination: "merchant",
+							Asset:       "USD",
+							Amount:      big.NewInt(100),
+						},
+					}).
+					Return(&ledger.Log{}, &ledger.CreatedHold{
+						Hold: ledger.Hold{
+							ID:          "hold",
+							Source:      "bank",
+							Destination: "merchant",
+							Asset:       "USD",
+							Amount:      big.NewInt(100),
+							Metadata:    metadata.Metadata{},
+							Status:      ledger.HoldStatusPending,
+						},
+					}, false, tc.returnErr)
+			}
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodPost, "/default/holds", bytes.NewBufferString(tc.body))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code)
+			if tc.expectedErrorCode != "" {
+				var errorResponse api.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Equal(t, tc.expectedErrorCode, errorResponse.ErrorCode)
+				return
+			}
+			hold, ok := api.DecodeSingleResponse[ledger.Hold](t, rec.Body)
+			require.True(t, ok)
+			require.Equal(t, "hold", hold.ID)
+		})
+	}
+}
+
+func TestCaptureHold(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		body               string
+		expectedAmount     *big.Int
+		returnErr          error
+		expectedStatusCode int
+		expectedErrorCode  string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:               "full amount",
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "partial amount",
+			body:               `{"amount": 40}`,
+			expectedAmount:     big.NewInt(40),
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "not pending",
+			returnErr:          ledgercontroller.ErrHoldNotPending{},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  common.ErrHoldNotPending,
+		},
+		{
+			name:               "expired",
+			returnErr:          ledgercontroller.ErrHoldExpired{},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  common.ErrHoldExpired,
+		},
+		{
+			name:               "not found",
+			returnErr:          ledgercontroller.ErrNotFound,
+			expectedStatusCode: http.StatusNotFound,
+			expectedErrorCode:  api.ErrorCodeNotFound,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			ledgerController.EXPECT().
+				CaptureHold(gomock.Any(), ledgercontroller.Parameters[ledgercontroller.CaptureHold]{
+					Input: ledgercontroller.CaptureHold{
+						ID:     "hold",
+						Amount: tc.expectedAmount,
+					},
+				}).
+				Return(&ledger.Log{}, &ledger.CapturedHold{
+					Transaction: ledger.NewTransaction().WithPostings(
+						ledger.NewPosting("bank", "merchant", "USD", big.NewInt(40)),
+					),
+				}, false, tc.returnErr)
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodPost, "/default/holds/hold/capture", bytes.NewBufferString(tc.body))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code)
+			if tc.expectedErrorCode != "" {
+				var errorResponse api.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Equal(t, tc.expectedErrorCode, errorResponse.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestVoidHold(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		returnErr          error
+		expectedStatusCode int
+		expectedErrorCode  string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:               "nominal",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:               "not pending",
+			returnErr:          ledgercontroller.ErrHoldNotPending{},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  common.ErrHoldNotPending,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			ledgerController.EXPECT().
+				VoidHold(gomock.Any(), ledgercontroller.Parameters[ledgercontroller.VoidHold]{
+					Input: ledgercontroller.VoidHold{
+						ID: "hold",
+					},
+				}).
+				Return(&ledger.Log{}, &ledger.VoidedHold{}, false, tc.returnErr)
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodPost, "/default/holds/hold/void", nil)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code)
+			if tc.expectedErrorCode != "" {
+				var errorResponse api.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Equal(t, tc.expectedErrorCode, errorResponse.ErrorCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/common"
+	systemcontroller "github.com/formancehq/ledger/internal/controller/system"
+)
+
+func updateLedgerSchemaSettings(systemController systemcontroller.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		common.WithBody(w, r, func(settings ledger.SchemaSettings) {
+			if err := systemController.UpdateLedgerSchemaSettings(r.Context(), chi.URLParam(r, "ledger"), settings); err != nil {
+				switch {
+				case errors.Is(err, systemcontroller.ErrInvalidLedgerConfiguration{}):
+					api.BadRequest(w, common.ErrValidation, err)
+				case errors.Is(err, systemcontroller.ErrLedgerNotFound):
+					api.WriteErrorResponse(w, http.StatusNotFound, common.ErrLedgerNotFound, err)
+				default:
+					common.HandleCommonWriteErrors(w, r, err)
+				}
+				return
+			}
+
+			api.NoContent(w)
+		})
+	}
+}
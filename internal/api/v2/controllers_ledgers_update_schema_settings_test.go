@@ -0,0 +1,86 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/formancehq/go-libs/v5/pkg/authn/jwt"
+	logging "github.com/formancehq/go-libs/v5/pkg/observe/log"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+
+	ledger "github.com/formancehq/ledger/internal"
+	systemcontroller "github.com/formancehq/ledger/internal/controller/system"
+)
+
+func TestLedgersUpdateSchemaSettings(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		returnErr          error
+		expectedStatusCode int
+		expectedErrorCode  string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:               "nominal",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:               "invalid configuration",
+			returnErr:          systemcontroller.ErrInvalidLedgerConfiguration{},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  "VALIDATION",
+		},
+		{
+			name:               "ledger not found",
+			returnErr:          systemcontroller.ErrLedgerNotFound,
+			expectedStatusCode: http.StatusNotFound,
+			expectedErrorCode:  "LEDGER_NOT_FOUND",
+		},
+		{
+			name:               "unexpected error",
+			returnErr:          errors.New("unexpected error"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErrorCode:  api.ErrorInternal,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := logging.TestingContext()
+
+			name := uuid.NewString()
+			settings := ledger.SchemaSettings{
+				SchemaEnforcementMode: ledger.SchemaEnforcementStrict,
+				DefaultSchemaVersion:  "v1",
+			}
+			systemController, _ := newTestingSystemController(t, false)
+			systemController.EXPECT().
+				UpdateLedgerSchemaSettings(gomock.Any(), name, settings).
+				Return(tc.returnErr)
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodPut, "/"+name+"/schema-settings", api.Buffer(t, settings))
+			req = req.WithContext(ctx)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code)
+			if tc.expectedErrorCode != "" {
+				err := api.ErrorResponse{}
+				api.Decode(t, rec.Body, &err)
+				require.Equal(t, tc.expectedErrorCode, err.ErrorCode)
+			}
+		})
+	}
+}
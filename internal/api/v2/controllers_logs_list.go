@@ -27,8 +27,9 @@ func listLogs(paginationConfig storagecommon.PaginationConfig) http.HandlerFunc
 			return
 		}
 
+		fields := getQueryFields[any](rq)
 		api.RenderCursor(w, *paginate.MapCursor(cursor, func(log ledger.Log) any {
-			return renderLog(r, log)
+			return renderProjection(renderLog(r, log), fields)
 		}))
 	}
 }
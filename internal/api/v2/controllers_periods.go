@@ -0,0 +1,98 @@
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	"github.com/formancehq/ledger/internal/api/common"
+	ledgercontroller "github.com/formancehq/ledger/internal/controller/ledger"
+)
+
+func readClosedPeriod(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	period, err := l.GetClosedPeriod(r.Context())
+	if err != nil {
+		common.HandleCommonErrors(w, r, err)
+		return
+	}
+
+	type response struct {
+		ClosedUntil *time.Time `json:"closedUntil"`
+	}
+	ret := response{}
+	if period != nil {
+		ret.ClosedUntil = &period.ClosedUntil
+	}
+
+	api.Ok(w, ret)
+}
+
+func closePeriod(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	type request struct {
+		ClosedUntil time.Time `json:"closedUntil"`
+	}
+
+	x := request{}
+	if err := json.NewDecoder(r.Body).Decode(&x); err != nil {
+		api.BadRequest(w, common.ErrValidation, errors.New("expected JSON body with the close date"))
+		return
+	}
+
+	_, _, idempotencyHit, err := l.ClosePeriod(r.Context(), getCommandParameters(r, ledgercontroller.ClosePeriod{
+		ClosedUntil: x.ClosedUntil,
+	}))
+	if err != nil {
+		writePeriodError(w, r, err)
+		return
+	}
+	if idempotencyHit {
+		w.Header().Set("Idempotency-Hit", "true")
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func reopenPeriod(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	type request struct {
+		ClosedUntil *time.Time `json:"closedUntil,omitempty"`
+	}
+
+	x := request{}
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&x); err != nil {
+			api.BadRequest(w, common.ErrValidation, errors.New("expected JSON body with the new close date"))
+			return
+		}
+	}
+
+	_, _, idempotencyHit, err := l.ReopenPeriod(r.Context(), getCommandParameters(r, ledgercontroller.ReopenPeriod{
+		ClosedUntil: x.ClosedUntil,
+	}))
+	if err != nil {
+		writePeriodError(w, r, err)
+		return
+	}
+	if idempotencyHit {
+		w.Header().Set("Idempotency-Hit", "true")
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writePeriodError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, ledgercontroller.ErrInvalidPeriod{}):
+		api.BadRequest(w, common.ErrValidation, err)
+	default:
+		common.HandleCommonWriteErrors(w, r, err)
+	}
+}
@@ -0,0 +1,195 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/formancehq/go-libs/v5/pkg/authn/jwt"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/common"
+	ledgercontroller "github.com/formancehq/ledger/internal/controller/ledger"
+)
+
+func TestReadClosedPeriod(t *testing.T) {
+	t.Parallel()
+
+	closedUntil := time.Now()
+
+	type testCase struct {
+		name     string
+		period   *ledger.ClosedPeriod
+		expected *time.Time
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "no closed period",
+		},
+		{
+			name:     "closed period",
+			period:   &ledger.ClosedPeriod{ClosedUntil: closedUntil},
+			expected: &closedUntil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			ledgerController.EXPECT().
+				GetClosedPeriod(gomock.Any()).
+				Return(tc.period, nil)
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodGet, "/default/periods", nil)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			ret, ok := api.DecodeSingleResponse[struct {
+				ClosedUntil *time.Time `json:"closedUntil"`
+			}](t, rec.Body)
+			require.True(t, ok)
+			require.Equal(t, tc.expected, ret.ClosedUntil)
+		})
+	}
+}
+
+func TestClosePeriod(t *testing.T) {
+	t.Parallel()
+
+	closedUntil := time.Now()
+
+	type testCase struct {
+		name                 string
+		body                 string
+		expectControllerCall bool
+		returnErr            error
+		expectedStatusCode   int
+		expectedErrorCode    string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:                 "nominal",
+			body:                 `{"closedUntil": "` + closedUntil.Format(time.RFC3339Nano) + `"}`,
+			expectControllerCall: true,
+			expectedStatusCode:   http.StatusNoContent,
+		},
+		{
+			name:               "invalid body",
+			body:               `not a json`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  common.ErrValidation,
+		},
+		{
+			name:                 "invalid period",
+			body:                 `{"closedUntil": "` + closedUntil.Format(time.RFC3339Nano) + `"}`,
+			expectControllerCall: true,
+			returnErr:            ledgercontroller.ErrInvalidPeriod{},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorCode:    common.ErrValidation,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			if tc.expectControllerCall {
+				ledgerController.EXPECT().
+					ClosePeriod(gomock.Any(), ledgercontroller.Parameters[ledgercontroller.ClosePeriod]{
+						Input: ledgercontroller.ClosePeriod{
+							ClosedUntil: closedUntil,
+						},
+					}).
+					Return(nil, nil, false, tc.returnErr)
+			}
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodPost, "/default/periods/close", bytes.NewBufferString(tc.body))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code)
+			if tc.expectedErrorCode != "" {
+				var errorResponse api.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Equal(t, tc.expectedErrorCode, errorResponse.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestReopenPeriod(t *testing.T) {
+	t.Parallel()
+
+	closedUntil := time.Now()
+
+	type testCase struct {
+		name                string
+		body                string
+		expectedClosedUntil *time.Time
+		returnErr           error
+		expectedStatusCode  int
+		expectedErrorCode   string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:               "all the periods",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:                "move the close date back",
+			body:                `{"closedUntil": "` + closedUntil.Format(time.RFC3339Nano) + `"}`,
+			expectedClosedUntil: pointer.For(closedUntil),
+			expectedStatusCode:  http.StatusNoContent,
+		},
+		{
+			name:               "no closed period",
+			returnErr:          ledgercontroller.ErrInvalidPeriod{},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  common.ErrValidation,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			ledgerController.EXPECT().
+				ReopenPeriod(gomock.Any(), ledgercontroller.Parameters[ledgercontroller.ReopenPeriod]{
+					Input: ledgercontroller.ReopenPeriod{
+						ClosedUntil: tc.expectedClosedUntil,
+					},
+				}).
+				Return(nil, nil, false, tc.returnErr)
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodPost, "/default/periods/reopen", bytes.NewBufferString(tc.body))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code)
+			if tc.expectedErrorCode != "" {
+				var errorResponse api.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Equal(t, tc.expectedErrorCode, errorResponse.ErrorCode)
+			}
+		})
+	}
+}
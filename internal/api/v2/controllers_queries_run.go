@@ -46,19 +46,29 @@ func runQuery(paginationConfig storage.PaginationConfig) http.HandlerFunc {
 	}
 }
 
+func renderQueryItem(r *http.Request, item any) any {
+	switch v := item.(type) {
+	case ledger.Transaction:
+		return renderTransaction(r, v)
+	case ledger.Account:
+		return renderAccount(r, v)
+	case ledger.VolumesWithBalanceByAssetByAccount:
+		return renderVolumesWithBalances(r, v)
+	case ledger.Log:
+		return renderLog(r, v)
+	case ledger.BalancesByAssets:
+		return renderBalancesByAssets(r, v)
+	case queries.AggregationGroup:
+		return renderAggregationGroup(r, v)
+	case queries.Projection:
+		return renderProjection(renderQueryItem(r, v.Item), v.Fields)
+	}
+	return item
+}
+
 func getJsonResponse(r *http.Request, w http.ResponseWriter, resource queries.ResourceKind, cursor paginate.Cursor[any]) error {
 	renderedCursor := *paginate.MapCursor(&cursor, func(item any) any {
-		switch v := item.(type) {
-		case ledger.Transaction:
-			return renderTransaction(r, v)
-		case ledger.Account:
-			return renderAccount(r, v)
-		case ledger.VolumesWithBalanceByAssetByAccount:
-			return renderVolumesWithBalances(r, v)
-		case ledger.Log:
-			return renderLog(r, v)
-		}
-		return item
+		return renderQueryItem(r, item)
 	})
 	{
 		v := api.BaseResponse[any]{
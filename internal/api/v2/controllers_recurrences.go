@@ -0,0 +1,135 @@
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	"github.com/formancehq/ledger/internal/api/common"
+	ledgercontroller "github.com/formancehq/ledger/internal/controller/ledger"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+)
+
+func listRecurrences(paginationConfig storagecommon.PaginationConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := common.LedgerFromContext(r.Context())
+
+		query, err := getPaginatedQuery[any](r, paginationConfig, "inserted_at", paginate.OrderAsc)
+		if err != nil {
+			api.BadRequest(w, common.ErrValidation, err)
+			return
+		}
+
+		recurrences, err := l.ListRecurrences(r.Context(), query)
+		if err != nil {
+			common.HandleCommonPaginationErrors(w, r, err)
+			return
+		}
+
+		api.RenderCursor(w, *recurrences)
+	}
+}
+
+func readRecurrence(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	recurrence, err := l.GetRecurrence(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeRecurrenceError(w, r, err)
+		return
+	}
+
+	api.Ok(w, recurrence)
+}
+
+func createRecurrence(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	type request struct {
+		Schedule   string            `json:"schedule"`
+		StartAt    *time.Time        `json:"startAt"`
+		Template   string            `json:"template"`
+		Vars       map[string]string `json:"vars"`
+		Metadata   metadata.Metadata `json:"metadata"`
+		Accounts   string            `json:"accounts"`
+		AccountVar string            `json:"accountVar"`
+	}
+
+	x := request{}
+	if err := json.NewDecoder(r.Body).Decode(&x); err != nil {
+		api.BadRequest(w, common.ErrValidation, errors.New("expected JSON body with the recurrence"))
+		return
+	}
+
+	recurrence, err := l.CreateRecurrence(r.Context(), ledgercontroller.CreateRecurrence{
+		Schedule:      x.Schedule,
+		StartAt:       x.StartAt,
+		Template:      x.Template,
+		Vars:          x.Vars,
+		Metadata:      x.Metadata,
+		Accounts:      x.Accounts,
+		AccountVar:    x.AccountVar,
+		SchemaVersion: r.URL.Query().Get("schemaVersion"),
+	})
+	if err != nil {
+		writeRecurrenceError(w, r, err)
+		return
+	}
+
+	api.Created(w, recurrence)
+}
+
+func pauseRecurrence(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	recurrence, err := l.PauseRecurrence(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeRecurrenceError(w, r, err)
+		return
+	}
+
+	api.Ok(w, recurrence)
+}
+
+func resumeRecurrence(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	recurrence, err := l.ResumeRecurrence(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeRecurrenceError(w, r, err)
+		return
+	}
+
+	api.Ok(w, recurrence)
+}
+
+func deleteRecurrence(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	if err := l.DeleteRecurrence(r.Context(), chi.URLParam(r, "id")); err != nil {
+		writeRecurrenceError(w, r, err)
+		return
+	}
+
+	api.NoContent(w)
+}
+
+func writeRecurrenceError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, ledgercontroller.ErrInvalidRecurrence{}):
+		api.BadRequest(w, common.ErrValidation, err)
+	case errors.Is(err, ledgercontroller.ErrRecurrenceCompleted{}):
+		api.BadRequest(w, common.ErrRecurrenceCompleted, err)
+	case errors.Is(err, ledgercontroller.ErrNotFound):
+		api.NotFound(w, err)
+	default:
+		common.HandleCommonErrors(w, r, err)
+	}
+}
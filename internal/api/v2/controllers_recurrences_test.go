@@ -0,0 +1,238 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/formancehq/go-libs/v5/pkg/authn/jwt"
+	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/common"
+	ledgercontroller "github.com/formancehq/ledger/internal/controller/ledger"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+)
+
+func TestCreateRecurrence(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name                 string
+		body                 string
+		queryParams          string
+		expectControllerCall bool
+		expectedInput        ledgercontroller.CreateRecurrence
+		returnErr            error
+		expectedStatusCode   int
+		expectedErrorCode    string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:                 "for each account",
+			body:                 `{"schedule": "0 0 1 * *", "template": "FEES", "vars": {"amount": "USD 100"}, "accounts": "users:$userID", "accountVar": "user", "metadata": {"kind": "fees"}}`,
+			queryParams:          "?schemaVersion=v1",
+			expectControllerCall: true,
+			expectedInput: ledgercontroller.CreateRecurrence{
+				Schedule:      "0 0 1 * *",
+				Template:      "FEES",
+				Vars:          map[string]string{"amount": "USD 100"},
+				Metadata:      metadata.Metadata{"kind": "fees"},
+				Accounts:      "users:$userID",
+				AccountVar:    "user",
+				SchemaVersion: "v1",
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "invalid body",
+			body:               `not a json`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  common.ErrValidation,
+		},
+		{
+			name:                 "invalid recurrence",
+			body:                 `{"schedule": "monthly", "template": "FEES"}`,
+			expectControllerCall: true,
+			expectedInput: ledgercontroller.CreateRecurrence{
+				Schedule: "monthly",
+				Template: "FEES",
+			},
+			returnErr:          ledgercontroller.ErrInvalidRecurrence{},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  common.ErrValidation,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			if tc.expectControllerCall {
+				ledgerController.EXPECT().
+					CreateRecurrence(gomock.Any(), tc.expectedInput).
+					Return(&ledger.Recurrence{
+						ID:       "recurrence",
+						Schedule: tc.expectedInput.Schedule,
+						Template: tc.expectedInput.Template,
+						Status:   ledger.RecurrenceStatusActive,
+					}, tc.returnErr)
+			}
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodPost, "/default/recurrences"+tc.queryParams, bytes.NewBufferString(tc.body))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code)
+			if tc.expectedErrorCode != "" {
+				var errorResponse api.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Equal(t, tc.expectedErrorCode, errorResponse.ErrorCode)
+				return
+			}
+			recurrence, ok := api.DecodeSingleResponse[ledger.Recurrence](t, rec.Body)
+			require.True(t, ok)
+			require.Equal(t, "recurrence", recurrence.ID)
+		})
+	}
+}
+
+func TestListRecurrences(t *testing.T) {
+	t.Parallel()
+
+	systemController, ledgerController := newTestingSystemController(t, true)
+	ledgerController.EXPECT().
+		ListRecurrences(gomock.Any(), storagecommon.InitialPaginatedQuery[any]{
+			PageSize: 10,
+			Column:   "inserted_at",
+			Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
+			Options: storagecommon.ResourceQuery[any]{
+				Expand: make([]string, 0),
+			},
+		}).
+		Return(&paginate.Cursor[ledger.Recurrence]{
+			Data: []ledger.Recurrence{
+				{ID: "recurrence1", Status: ledger.RecurrenceStatusActive},
+				{ID: "recurrence2", Status: ledger.RecurrenceStatusPaused},
+			},
+		}, nil)
+
+	router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+	req := httptest.NewRequest(http.MethodGet, "/default/recurrences?pageSize=10", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	cursor := api.DecodeCursorResponse[ledger.Recurrence](t, rec.Body)
+	require.Len(t, cursor.Data, 2)
+	require.Equal(t, ledger.RecurrenceStatusPaused, cursor.Data[1].Status)
+}
+
+func TestUpdateRecurrenceStatus(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		action             string
+		returnErr          error
+		expectedStatusCode int
+		expectedErrorCode  string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:               "pause",
+			action:             "pause",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "resume",
+			action:             "resume",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "resume completed",
+			action:             "resume",
+			returnErr:          ledgercontroller.ErrRecurrenceCompleted{},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  common.ErrRecurrenceCompleted,
+		},
+		{
+			name:               "pause not found",
+			action:             "pause",
+			returnErr:          ledgercontroller.ErrNotFound,
+			expectedStatusCode: http.StatusNotFound,
+			expectedErrorCode:  api.ErrorCodeNotFound,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			if tc.action == "pause" {
+				ledgerController.EXPECT().
+					PauseRecurrence(gomock.Any(), "recurrence").
+					Return(&ledger.Recurrence{Status: ledger.RecurrenceStatusPaused}, tc.returnErr)
+			} else {
+				ledgerController.EXPECT().
+					ResumeRecurrence(gomock.Any(), "recurrence").
+					Return(&ledger.Recurrence{Status: ledger.RecurrenceStatusActive}, tc.returnErr)
+			}
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodPost, "/default/recurrences/recurrence/"+tc.action, nil)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code)
+			if tc.expectedErrorCode != "" {
+				var errorResponse api.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Equal(t, tc.expectedErrorCode, errorResponse.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestDeleteRecurrence(t *testing.T) {
+	t.Parallel()
+
+	for _, notFound := range []bool{false, true} {
+		systemController, ledgerController := newTestingSystemController(t, true)
+		var returnErr error
+		if notFound {
+			returnErr = ledgercontroller.ErrNotFound
+		}
+		ledgerController.EXPECT().
+			DeleteRecurrence(gomock.Any(), "recurrence").
+			Return(returnErr)
+
+		router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+		req := httptest.NewRequest(http.MethodDelete, "/default/recurrences/recurrence", nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		if notFound {
+			require.Equal(t, http.StatusNotFound, rec.Code)
+		} else {
+			require.Equal(t, http.StatusNoContent, rec.Code)
+		}
+	}
+}
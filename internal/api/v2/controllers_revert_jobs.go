@@ -0,0 +1,66 @@
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+
+	"github.com/formancehq/ledger/internal/api/common"
+	ledgercontroller "github.com/formancehq/ledger/internal/controller/ledger"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+)
+
+func readRevertJob(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	job, err := l.GetRevertJob(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, ledgercontroller.ErrNotFound):
+			api.NotFound(w, err)
+		default:
+			common.HandleCommonErrors(w, r, err)
+		}
+		return
+	}
+
+	api.Ok(w, job)
+}
+
+func createRevertJob(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	type request struct {
+		Query    json.RawMessage   `json:"query,omitempty"`
+		Metadata metadata.Metadata `json:"metadata,omitempty"`
+	}
+
+	x := request{}
+	if err := json.NewDecoder(r.Body).Decode(&x); err != nil {
+		api.BadRequest(w, common.ErrValidation, errors.New("expected JSON body with the filter of the transactions to revert"))
+		return
+	}
+
+	job, err := l.CreateRevertJob(r.Context(), ledgercontroller.CreateRevertJob{
+		Query:           x.Query,
+		Force:           api.QueryParamBool(r, "force"),
+		AtEffectiveDate: api.QueryParamBool(r, "atEffectiveDate"),
+		Metadata:        x.Metadata,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, storagecommon.ErrInvalidQuery{}):
+			api.BadRequest(w, common.ErrValidation, err)
+		default:
+			common.HandleCommonErrors(w, r, err)
+		}
+		return
+	}
+
+	api.Accepted(w, job)
+}
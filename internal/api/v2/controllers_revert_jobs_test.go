@@ -0,0 +1,166 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/formancehq/go-libs/v5/pkg/authn/jwt"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/common"
+	ledgercontroller "github.com/formancehq/ledger/internal/controller/ledger"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+)
+
+func TestCreateRevertJob(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name                 string
+		body                 string
+		queryParams          string
+		expectControllerCall bool
+		expectedInput        ledgercontroller.CreateRevertJob
+		returnErr            error
+		expectedStatusCode   int
+		expectedErrorCode    string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:                 "nominal",
+			body:                 `{"query": {"$match": {"account": "users:001"}}, "metadata": {"reason": "bug"}}`,
+			expectControllerCall: true,
+			expectedInput: ledgercontroller.CreateRevertJob{
+				Query:    json.RawMessage(`{"$match": {"account": "users:001"}}`),
+				Metadata: metadata.Metadata{"reason": "bug"},
+			},
+			expectedStatusCode: http.StatusAccepted,
+		},
+		{
+			name:                 "with force and at effective date",
+			body:                 `{"query": {"$match": {"account": "users:001"}}}`,
+			queryParams:          "?force=true&atEffectiveDate=true",
+			expectControllerCall: true,
+			expectedInput: ledgercontroller.CreateRevertJob{
+				Query:           json.RawMessage(`{"$match": {"account": "users:001"}}`),
+				Force:           true,
+				AtEffectiveDate: true,
+			},
+			expectedStatusCode: http.StatusAccepted,
+		},
+		{
+			name:               "invalid body",
+			body:               `not a json`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  common.ErrValidation,
+		},
+		{
+			name:                 "invalid query",
+			body:                 `{"query": {"$unknown": {}}}`,
+			expectControllerCall: true,
+			expectedInput: ledgercontroller.CreateRevertJob{
+				Query: json.RawMessage(`{"$unknown": {}}`),
+			},
+			returnErr:          storagecommon.NewErrInvalidQuery("unknown operator"),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  common.ErrValidation,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			if tc.expectControllerCall {
+				ledgerController.EXPECT().
+					CreateRevertJob(gomock.Any(), tc.expectedInput).
+					Return(&ledger.RevertJob{
+						ID:     "job",
+						Status: ledger.RevertJobStatusPending,
+					}, tc.returnErr)
+			}
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodPost, "/default/revert-jobs"+tc.queryParams, bytes.NewBufferString(tc.body))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code)
+			if tc.expectedErrorCode != "" {
+				var errorResponse api.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Equal(t, tc.expectedErrorCode, errorResponse.ErrorCode)
+				return
+			}
+			job, ok := api.DecodeSingleResponse[ledger.RevertJob](t, rec.Body)
+			require.True(t, ok)
+			require.Equal(t, "job", job.ID)
+		})
+	}
+}
+
+func TestReadRevertJob(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		returnErr          error
+		expectedStatusCode int
+		expectedErrorCode  string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:               "nominal",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "not found",
+			returnErr:          ledgercontroller.ErrNotFound,
+			expectedStatusCode: http.StatusNotFound,
+			expectedErrorCode:  api.ErrorCodeNotFound,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			ledgerController.EXPECT().
+				GetRevertJob(gomock.Any(), "job").
+				Return(&ledger.RevertJob{
+					ID:       "job",
+					Status:   ledger.RevertJobStatusRunning,
+					Total:    10,
+					Reverted: 4,
+				}, tc.returnErr)
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodGet, "/default/revert-jobs/job", nil)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code)
+			if tc.expectedErrorCode != "" {
+				var errorResponse api.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Equal(t, tc.expectedErrorCode, errorResponse.ErrorCode)
+				return
+			}
+			job, ok := api.DecodeSingleResponse[ledger.RevertJob](t, rec.Body)
+			require.True(t, ok)
+			require.Equal(t, uint64(4), job.Reverted)
+		})
+	}
+}
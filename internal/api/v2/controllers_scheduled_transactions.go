@@ -0,0 +1,102 @@
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/common"
+	ledgercontroller "github.com/formancehq/ledger/internal/controller/ledger"
+)
+
+func readScheduledTransaction(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	scheduledTransaction, err := l.GetScheduledTransaction(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, ledgercontroller.ErrNotFound):
+			api.NotFound(w, err)
+		default:
+			common.HandleCommonErrors(w, r, err)
+		}
+		return
+	}
+
+	api.Ok(w, renderScheduledTransaction(r, *scheduledTransaction))
+}
+
+func scheduleTransaction(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	type request struct {
+		ScheduledAt     time.Time                    `json:"scheduledAt"`
+		Postings        ledger.Postings              `json:"postings"`
+		Script          ledgercontroller.ScriptV1    `json:"script"`
+		Reference       string                       `json:"reference"`
+		Metadata        metadata.Metadata            `json:"metadata"`
+		AccountMetadata map[string]metadata.Metadata `json:"accountMetadata"`
+		Runtime         ledger.RuntimeType           `json:"runtime,omitempty"`
+		Force           bool                         `json:"force"`
+	}
+
+	x := request{}
+	if err := json.NewDecoder(r.Body).Decode(&x); err != nil {
+		api.BadRequest(w, common.ErrValidation, errors.New("expected JSON body with the scheduled transaction"))
+		return
+	}
+
+	script := x.Script.ToCore()
+	scheduledTransaction, err := l.ScheduleTransaction(r.Context(), ledgercontroller.ScheduleTransaction{
+		ScheduledAt: x.ScheduledAt,
+		Transaction: ledger.ScheduledTransactionData{
+			Postings:        x.Postings,
+			Script:          script.Plain,
+			Template:        script.Template,
+			Vars:            script.Vars,
+			Reference:       x.Reference,
+			Metadata:        x.Metadata,
+			AccountMetadata: x.AccountMetadata,
+			Runtime:         x.Runtime,
+			Force:           x.Force,
+		},
+		SchemaVersion: r.URL.Query().Get("schemaVersion"),
+	})
+	if err != nil {
+		writeScheduledTransactionError(w, r, err)
+		return
+	}
+
+	api.Created(w, renderScheduledTransaction(r, *scheduledTransaction))
+}
+
+func cancelScheduledTransaction(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	if _, err := l.CancelScheduledTransaction(r.Context(), chi.URLParam(r, "id")); err != nil {
+		writeScheduledTransactionError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeScheduledTransactionError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, ledgercontroller.ErrInvalidScheduledTransaction{}):
+		api.BadRequest(w, common.ErrValidation, err)
+	case errors.Is(err, ledgercontroller.ErrScheduledTransactionNotPending{}):
+		api.BadRequest(w, common.ErrScheduleNotPending, err)
+	case errors.Is(err, ledgercontroller.ErrNotFound):
+		api.NotFound(w, err)
+	default:
+		common.HandleCommonErrors(w, r, err)
+	}
+}
@@ -0,0 +1,201 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/formancehq/go-libs/v5/pkg/authn/jwt"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/common"
+	ledgercontroller "github.com/formancehq/ledger/internal/controller/ledger"
+)
+
+func TestScheduleTransaction(t *testing.T) {
+	t.Parallel()
+
+	scheduledAt := time.Now().Add(time.Hour)
+
+	type testCase struct {
+		name                 string
+		body                 string
+		expectControllerCall bool
+		expectedInput        ledgercontroller.ScheduleTransaction
+		returnErr            error
+		expectedStatusCode   int
+		expectedErrorCode    string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:                 "with postings",
+			body:                 `{"scheduledAt": "` + scheduledAt.Format(time.RFC3339Nano) + `", "postings": [{"source": "world", "destination": "bank", "asset": "USD", "amount": 100}]}`,
+			expectControllerCall: true,
+			expectedInput: ledgercontroller.ScheduleTransaction{
+				ScheduledAt: scheduledAt,
+				Transaction: ledger.ScheduledTransactionData{
+					Postings: ledger.Postings{ledger.NewPosting("world", "bank", "USD", big.NewInt(100))},
+					Vars:     map[string]string{},
+				},
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:                 "with script",
+			body:                 `{"scheduledAt": "` + scheduledAt.Format(time.RFC3339Nano) + `", "script": {"plain": "send [USD 100] (source = @world destination = @bank)"}}`,
+			expectControllerCall: true,
+			expectedInput: ledgercontroller.ScheduleTransaction{
+				ScheduledAt: scheduledAt,
+				Transaction: ledger.ScheduledTransactionData{
+					Script: "send [USD 100] (source = @world destination = @bank)",
+					Vars:   map[string]string{},
+				},
+			},
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "invalid body",
+			body:               `not a json`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  common.ErrValidation,
+		},
+		{
+			name:                 "invalid scheduled transaction",
+			body:                 `{"scheduledAt": "` + scheduledAt.Format(time.RFC3339Nano) + `"}`,
+			expectControllerCall: true,
+			expectedInput: ledgercontroller.ScheduleTransaction{
+				ScheduledAt: scheduledAt,
+				Transaction: ledger.ScheduledTransactionData{
+					Vars: map[string]string{},
+				},
+			},
+			returnErr:          ledgercontroller.ErrInvalidScheduledTransaction{},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  common.ErrValidation,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			if tc.expectControllerCall {
+				ledgerController.EXPECT().
+					ScheduleTransaction(gomock.Any(), tc.expectedInput).
+					Return(&ledger.ScheduledTransaction{
+						ID:          "scheduled",
+						ScheduledAt: scheduledAt,
+						Transaction: tc.expectedInput.Transaction,
+						Status:      ledger.ScheduledTransactionStatusPending,
+					}, tc.returnErr)
+			}
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodPost, "/default/scheduled-transactions", bytes.NewBufferString(tc.body))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code)
+			if tc.expectedErrorCode != "" {
+				var errorResponse api.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Equal(t, tc.expectedErrorCode, errorResponse.ErrorCode)
+				return
+			}
+			scheduledTransaction, ok := api.DecodeSingleResponse[ledger.ScheduledTransaction](t, rec.Body)
+			require.True(t, ok)
+			require.Equal(t, "scheduled", scheduledTransaction.ID)
+		})
+	}
+}
+
+func TestReadScheduledTransaction(t *testing.T) {
+	t.Parallel()
+
+	systemController, ledgerController := newTestingSystemController(t, true)
+	ledgerController.EXPECT().
+		GetScheduledTransaction(gomock.Any(), "scheduled").
+		Return(&ledger.ScheduledTransaction{
+			ID: "scheduled",
+			Transaction: ledger.ScheduledTransactionData{
+				Postings: ledger.Postings{ledger.NewPosting("world", "bank", "USD", big.NewInt(100))},
+			},
+			Status: ledger.ScheduledTransactionStatusFailed,
+			Error:  "insufficient funds",
+		}, nil)
+
+	router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+	req := httptest.NewRequest(http.MethodGet, "/default/scheduled-transactions/scheduled", nil)
+	req.Header.Set(HeaderBigIntAsString, "true")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"amount":"100"`)
+	require.Contains(t, rec.Body.String(), `"error":"insufficient funds"`)
+}
+
+func TestCancelScheduledTransaction(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		returnErr          error
+		expectedStatusCode int
+		expectedErrorCode  string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:               "nominal",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:               "not pending",
+			returnErr:          ledgercontroller.ErrScheduledTransactionNotPending{},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  common.ErrScheduleNotPending,
+		},
+		{
+			name:               "not found",
+			returnErr:          ledgercontroller.ErrNotFound,
+			expectedStatusCode: http.StatusNotFound,
+			expectedErrorCode:  api.ErrorCodeNotFound,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			ledgerController.EXPECT().
+				CancelScheduledTransaction(gomock.Any(), "scheduled").
+				Return(&ledger.ScheduledTransaction{}, tc.returnErr)
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodPost, "/default/scheduled-transactions/scheduled/cancel", nil)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code)
+			if tc.expectedErrorCode != "" {
+				var errorResponse api.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Equal(t, tc.expectedErrorCode, errorResponse.ErrorCode)
+			}
+		})
+	}
+}
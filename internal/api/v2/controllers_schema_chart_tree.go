@@ -0,0 +1,43 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/common"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+	ledgerstore "github.com/formancehq/ledger/internal/storage/ledger"
+)
+
+func readChartTree(w http.ResponseWriter, r *http.Request) {
+	rq, err := getResourceQuery[ledger.GetAggregatedVolumesOptions](r, func(options *ledger.GetAggregatedVolumesOptions) error {
+		options.UseInsertionDate = api.QueryParamBool(r, "useInsertionDate")
+
+		return nil
+	})
+	if err != nil {
+		api.BadRequest(w, common.ErrValidation, err)
+		return
+	}
+
+	tree, err := common.LedgerFromContext(r.Context()).GetChartTree(r.Context(), chi.URLParam(r, "version"), *rq)
+	if err != nil {
+		switch {
+		case postgres.IsNotFoundError(err):
+			api.NotFound(w, err)
+		case errors.Is(err, storagecommon.ErrInvalidQuery{}) || errors.Is(err, ledgerstore.ErrMissingFeature{}):
+			api.BadRequest(w, common.ErrValidation, err)
+		default:
+			common.HandleCommonErrors(w, r, err)
+		}
+		return
+	}
+
+	api.Ok(w, renderChartTree(r, tree))
+}
@@ -0,0 +1,120 @@
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/formancehq/go-libs/v5/pkg/authn/jwt"
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+)
+
+func TestReadChartTree(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name              string
+		queryParams       url.Values
+		expectQuery       storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]
+		returnErr         error
+		expectStatusCode  int
+		expectedErrorCode string
+	}
+	now := time.Now()
+
+	tree := []ledger.ChartTreeNode{{
+		Segment:       "world",
+		Path:          "world",
+		Account:       true,
+		AccountsCount: 1,
+		Balances: ledger.BalancesByAssets{
+			"USD": big.NewInt(-100),
+		},
+	}}
+
+	for _, tc := range []testCase{
+		{
+			name:             "nominal",
+			expectStatusCode: http.StatusOK,
+		},
+		{
+			name: "using pit and insertion date",
+			queryParams: url.Values{
+				"pit":              []string{now.Format(time.DateFormat)},
+				"useInsertionDate": []string{"true"},
+			},
+			expectQuery: storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+				PIT:    &now,
+				Expand: []string{},
+				Opts: ledger.GetAggregatedVolumesOptions{
+					UseInsertionDate: true,
+				},
+			},
+			expectStatusCode: http.StatusOK,
+		},
+		{
+			name:              "schema not found",
+			returnErr:         postgres.ErrNotFound,
+			expectStatusCode:  http.StatusNotFound,
+			expectedErrorCode: "NOT_FOUND",
+		},
+		{
+			name:              "invalid query",
+			returnErr:         storagecommon.ErrInvalidQuery{},
+			expectStatusCode:  http.StatusBadRequest,
+			expectedErrorCode: "VALIDATION",
+		},
+		{
+			name:              "backend error",
+			returnErr:         errors.New("database error"),
+			expectStatusCode:  http.StatusInternalServerError,
+			expectedErrorCode: "INTERNAL",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tc.expectQuery.Expand == nil {
+				tc.expectQuery.Expand = []string{}
+			}
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			ledgerController.EXPECT().
+				GetChartTree(gomock.Any(), "v1", tc.expectQuery).
+				Return(tree, tc.returnErr)
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodGet, "/default/schemas/v1/chart/tree", nil)
+			req.URL.RawQuery = tc.queryParams.Encode()
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectStatusCode, rec.Code)
+			if tc.expectedErrorCode != "" {
+				var errorResponse api.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Equal(t, tc.expectedErrorCode, errorResponse.ErrorCode)
+			} else {
+				var response struct {
+					Data []ledger.ChartTreeNode `json:"data"`
+				}
+				api.Decode(t, rec.Body, &response)
+				require.Equal(t, tree, response.Data)
+			}
+		})
+	}
+}
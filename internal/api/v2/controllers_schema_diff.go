@@ -0,0 +1,73 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/common"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+)
+
+type schemaDiff struct {
+	ledger.SchemaDiff
+	IncompatibleAccounts *paginate.Cursor[any] `json:"incompatibleAccounts"`
+}
+
+func renderSchemaDiff(r *http.Request, diff ledger.SchemaDiff, incompatibleAccounts *paginate.Cursor[ledger.Account]) any {
+	return schemaDiff{
+		SchemaDiff: diff,
+		IncompatibleAccounts: paginate.MapCursor(incompatibleAccounts, func(account ledger.Account) any {
+			return renderAccount(r, account)
+		}),
+	}
+}
+
+// writeSchemaDiff compares the schema data with the schema `from` (the latest one if empty),
+// and lists a page of the existing accounts matching its chart of accounts but not the new one
+func writeSchemaDiff(w http.ResponseWriter, r *http.Request, paginationConfig storagecommon.PaginationConfig, from string, data ledger.SchemaData) {
+	l := common.LedgerFromContext(r.Context())
+
+	query, err := getPaginatedQuery[any](r, paginationConfig, "address", paginate.OrderAsc)
+	if err != nil {
+		api.BadRequest(w, common.ErrValidation, err)
+		return
+	}
+
+	diff, incompatibleAccounts, err := l.DiffSchema(r.Context(), from, data, query)
+	if err != nil {
+		switch {
+		case postgres.IsNotFoundError(err):
+			api.NotFound(w, err)
+		default:
+			common.HandleCommonPaginationErrors(w, r, err)
+		}
+		return
+	}
+
+	api.Ok(w, renderSchemaDiff(r, *diff, incompatibleAccounts))
+}
+
+func diffSchemas(paginationConfig storagecommon.PaginationConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := common.LedgerFromContext(r.Context())
+
+		to, err := l.GetSchema(r.Context(), chi.URLParam(r, "to"))
+		if err != nil {
+			switch {
+			case postgres.IsNotFoundError(err):
+				api.NotFound(w, err)
+			default:
+				common.HandleCommonErrors(w, r, err)
+			}
+			return
+		}
+
+		writeSchemaDiff(w, r, paginationConfig, chi.URLParam(r, "from"), to.SchemaData)
+	}
+}
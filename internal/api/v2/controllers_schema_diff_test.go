@@ -0,0 +1,169 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/formancehq/go-libs/v5/pkg/authn/jwt"
+	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
+
+	ledger "github.com/formancehq/ledger/internal"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+)
+
+func TestDiffSchemas(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name              string
+		getSchemaErr      error
+		expectDiffCall    bool
+		diffErr           error
+		expectStatusCode  int
+		expectedErrorCode string
+		expectedPageSize  uint64
+		queryParams       string
+	}
+
+	toSchema := &ledger.Schema{
+		Version: "v2",
+		SchemaData: ledger.SchemaData{
+			Chart: ledger.ChartOfAccounts{
+				"world": {Account: &ledger.ChartAccount{}},
+			},
+		},
+	}
+	diff := &ledger.SchemaDiff{
+		Chart: ledger.SchemaDiffEntries{
+			Added:    []string{"world"},
+			Removed:  []string{"bank"},
+			Modified: []string{},
+		},
+	}
+	incompatibleAccounts := &paginate.Cursor[ledger.Account]{
+		Data: []ledger.Account{{Address: "bank"}},
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:             "nominal",
+			expectDiffCall:   true,
+			expectStatusCode: http.StatusOK,
+			expectedPageSize: paginate.QueryDefaultPageSize,
+		},
+		{
+			name:             "with page size",
+			expectDiffCall:   true,
+			expectStatusCode: http.StatusOK,
+			expectedPageSize: 10,
+			queryParams:      "?pageSize=10",
+		},
+		{
+			name:              "target schema not found",
+			getSchemaErr:      postgres.ErrNotFound,
+			expectStatusCode:  http.StatusNotFound,
+			expectedErrorCode: "NOT_FOUND",
+		},
+		{
+			name:              "source schema not found",
+			expectDiffCall:    true,
+			diffErr:           postgres.ErrNotFound,
+			expectStatusCode:  http.StatusNotFound,
+			expectedErrorCode: "NOT_FOUND",
+			expectedPageSize:  paginate.QueryDefaultPageSize,
+		},
+		{
+			name:              "backend error",
+			expectDiffCall:    true,
+			diffErr:           errors.New("database error"),
+			expectStatusCode:  http.StatusInternalServerError,
+			expectedErrorCode: "INTERNAL",
+			expectedPageSize:  paginate.QueryDefaultPageSize,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			ledgerController.EXPECT().
+				GetSchema(gomock.Any(), "v2").
+				Return(toSchema, tc.getSchemaErr)
+			if tc.expectDiffCall {
+				ledgerController.EXPECT().
+					DiffSchema(gomock.Any(), "v1", toSchema.SchemaData, storagecommon.InitialPaginatedQuery[any]{
+						PageSize: tc.expectedPageSize,
+						Column:   "address",
+						Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
+						Options: storagecommon.ResourceQuery[any]{
+							Expand: []string{},
+						},
+					}).
+					Return(diff, incompatibleAccounts, tc.diffErr)
+			}
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodGet, "/default/schemas/v1/diff/v2"+tc.queryParams, nil)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectStatusCode, rec.Code)
+			if tc.expectedErrorCode != "" {
+				var errorResponse api.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Equal(t, tc.expectedErrorCode, errorResponse.ErrorCode)
+			} else {
+				var response struct {
+					Data struct {
+						ledger.SchemaDiff
+						IncompatibleAccounts paginate.Cursor[ledger.Account] `json:"incompatibleAccounts"`
+					} `json:"data"`
+				}
+				api.Decode(t, rec.Body, &response)
+				require.Equal(t, *diff, response.Data.SchemaDiff)
+				require.Equal(t, incompatibleAccounts.Data, response.Data.IncompatibleAccounts.Data)
+			}
+		})
+	}
+}
+
+func TestInsertSchemaDryRun(t *testing.T) {
+	t.Parallel()
+
+	systemController, ledgerController := newTestingSystemController(t, true)
+
+	data := ledger.SchemaData{
+		Chart: ledger.ChartOfAccounts{
+			"world": {Account: &ledger.ChartAccount{}},
+		},
+	}
+
+	ledgerController.EXPECT().
+		InsertSchema(gomock.Any(), gomock.Any()).
+		Return(nil, nil, false, nil)
+	ledgerController.EXPECT().
+		DiffSchema(gomock.Any(), "", gomock.Any(), gomock.Any()).
+		Return(&ledger.SchemaDiff{}, &paginate.Cursor[ledger.Account]{}, nil)
+
+	router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+	body, err := json.Marshal(data)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/default/schemas/v1?dryRun=true", bytes.NewBuffer(body))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,79 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/api/common"
+	ledgercontroller "github.com/formancehq/ledger/internal/controller/ledger"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+	ledgerstore "github.com/formancehq/ledger/internal/storage/ledger"
+)
+
+func readTrialBalance(w http.ResponseWriter, r *http.Request) {
+	readFinancialStatement(w, r, func(ctx context.Context, l ledgercontroller.Controller, version string, rq storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (any, error) {
+		trialBalance, err := l.GetTrialBalance(ctx, version, rq)
+		if err != nil {
+			return nil, err
+		}
+		return renderTrialBalance(r, *trialBalance), nil
+	})
+}
+
+func readBalanceSheet(w http.ResponseWriter, r *http.Request) {
+	readFinancialStatement(w, r, func(ctx context.Context, l ledgercontroller.Controller, version string, rq storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (any, error) {
+		balanceSheet, err := l.GetBalanceSheet(ctx, version, rq)
+		if err != nil {
+			return nil, err
+		}
+		return renderBalanceSheet(r, *balanceSheet), nil
+	})
+}
+
+func readIncomeStatement(w http.ResponseWriter, r *http.Request) {
+	readFinancialStatement(w, r, func(ctx context.Context, l ledgercontroller.Controller, version string, rq storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (any, error) {
+		incomeStatement, err := l.GetIncomeStatement(ctx, version, rq)
+		if err != nil {
+			return nil, err
+		}
+		return renderIncomeStatement(r, *incomeStatement), nil
+	})
+}
+
+func readFinancialStatement(
+	w http.ResponseWriter,
+	r *http.Request,
+	fn func(ctx context.Context, l ledgercontroller.Controller, version string, rq storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (any, error),
+) {
+	rq, err := getResourceQuery[ledger.GetAggregatedVolumesOptions](r, func(options *ledger.GetAggregatedVolumesOptions) error {
+		options.UseInsertionDate = api.QueryParamBool(r, "useInsertionDate")
+
+		return nil
+	})
+	if err != nil {
+		api.BadRequest(w, common.ErrValidation, err)
+		return
+	}
+
+	ret, err := fn(r.Context(), common.LedgerFromContext(r.Context()), chi.URLParam(r, "version"), *rq)
+	if err != nil {
+		switch {
+		case postgres.IsNotFoundError(err):
+			api.NotFound(w, err)
+		case errors.Is(err, storagecommon.ErrInvalidQuery{}) || errors.Is(err, ledgerstore.ErrMissingFeature{}):
+			api.BadRequest(w, common.ErrValidation, err)
+		default:
+			common.HandleCommonErrors(w, r, err)
+		}
+		return
+	}
+
+	api.Ok(w, ret)
+}
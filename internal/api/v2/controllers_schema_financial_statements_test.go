@@ -0,0 +1,187 @@
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/formancehq/go-libs/v5/pkg/authn/jwt"
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+)
+
+func TestReadFinancialStatements(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name              string
+		path              string
+		queryParams       url.Values
+		expectCall        func(ledgerController *LedgerController, query storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions], err error) any
+		expectQuery       storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]
+		returnErr         error
+		expectStatusCode  int
+		expectedErrorCode string
+	}
+	now := time.Now()
+
+	trialBalance := func(ledgerController *LedgerController, query storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions], err error) any {
+		ret := &ledger.TrialBalance{
+			Nodes: []ledger.TrialBalanceNode{{
+				Segment: "banks",
+				Path:    "banks",
+				Account: true,
+				Class:   ledger.AccountingClassAsset,
+				Totals: ledger.TrialBalanceTotalsByAssets{
+					"USD": {Debit: big.NewInt(100), Credit: big.NewInt(20)},
+				},
+			}},
+			Totals: ledger.TrialBalanceTotalsByAssets{
+				"USD": {Debit: big.NewInt(100), Credit: big.NewInt(20)},
+			},
+		}
+		ledgerController.EXPECT().
+			GetTrialBalance(gomock.Any(), "v1", query).
+			Return(ret, err)
+		return ret
+	}
+	section := func(class ledger.AccountingClass, path string, amount int64) ledger.FinancialStatementSection {
+		return ledger.FinancialStatementSection{
+			Class: class,
+			Lines: []ledger.FinancialStatementLine{{
+				Path:    path,
+				Amounts: ledger.BalancesByAssets{"USD": big.NewInt(amount)},
+			}},
+			Total: ledger.BalancesByAssets{"USD": big.NewInt(amount)},
+		}
+	}
+	balanceSheet := func(ledgerController *LedgerController, query storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions], err error) any {
+		ret := &ledger.BalanceSheet{
+			Assets:      section(ledger.AccountingClassAsset, "banks", 100),
+			Liabilities: section(ledger.AccountingClassLiability, "customers", 90),
+			Equity:      section(ledger.AccountingClassEquity, "capital", 5),
+			NetIncome:   ledger.BalancesByAssets{"USD": big.NewInt(5)},
+		}
+		ledgerController.EXPECT().
+			GetBalanceSheet(gomock.Any(), "v1", query).
+			Return(ret, err)
+		return ret
+	}
+	incomeStatement := func(ledgerController *LedgerController, query storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions], err error) any {
+		ret := &ledger.IncomeStatement{
+			Income:    section(ledger.AccountingClassIncome, "fees", 10),
+			Expenses:  section(ledger.AccountingClassExpense, "providers", 5),
+			NetIncome: ledger.BalancesByAssets{"USD": big.NewInt(5)},
+		}
+		ledgerController.EXPECT().
+			GetIncomeStatement(gomock.Any(), "v1", query).
+			Return(ret, err)
+		return ret
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "trial balance",
+			path: "/default/schemas/v1/chart/trial-balance",
+			queryParams: url.Values{
+				"pit": []string{now.Format(time.DateFormat)},
+			},
+			expectCall: trialBalance,
+			expectQuery: storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+				PIT: &now,
+			},
+			expectStatusCode: http.StatusOK,
+		},
+		{
+			name: "balance sheet using insertion date",
+			path: "/default/schemas/v1/chart/balance-sheet",
+			queryParams: url.Values{
+				"useInsertionDate": []string{"true"},
+			},
+			expectCall: balanceSheet,
+			expectQuery: storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+				Opts: ledger.GetAggregatedVolumesOptions{
+					UseInsertionDate: true,
+				},
+			},
+			expectStatusCode: http.StatusOK,
+		},
+		{
+			name: "income statement",
+			path: "/default/schemas/v1/chart/income-statement",
+			queryParams: url.Values{
+				"oot": []string{now.Format(time.DateFormat)},
+			},
+			expectCall: incomeStatement,
+			expectQuery: storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+				OOT: &now,
+			},
+			expectStatusCode: http.StatusOK,
+		},
+		{
+			name:              "schema not found",
+			path:              "/default/schemas/v1/chart/trial-balance",
+			expectCall:        trialBalance,
+			returnErr:         postgres.ErrNotFound,
+			expectStatusCode:  http.StatusNotFound,
+			expectedErrorCode: "NOT_FOUND",
+		},
+		{
+			name:              "invalid query",
+			path:              "/default/schemas/v1/chart/balance-sheet",
+			expectCall:        balanceSheet,
+			returnErr:         storagecommon.ErrInvalidQuery{},
+			expectStatusCode:  http.StatusBadRequest,
+			expectedErrorCode: "VALIDATION",
+		},
+		{
+			name:              "backend error",
+			path:              "/default/schemas/v1/chart/income-statement",
+			expectCall:        incomeStatement,
+			returnErr:         errors.New("database error"),
+			expectStatusCode:  http.StatusInternalServerError,
+			expectedErrorCode: "INTERNAL",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tc.expectQuery.Expand == nil {
+				tc.expectQuery.Expand = []string{}
+			}
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			expected := tc.expectCall(ledgerController, tc.expectQuery, tc.returnErr)
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			req.URL.RawQuery = tc.queryParams.Encode()
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectStatusCode, rec.Code)
+			if tc.expectedErrorCode != "" {
+				var errorResponse api.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Equal(t, tc.expectedErrorCode, errorResponse.ErrorCode)
+			} else {
+				expectedJSON, err := json.Marshal(map[string]any{"data": expected})
+				require.NoError(t, err)
+				require.JSONEq(t, string(expectedJSON), rec.Body.String())
+			}
+		})
+	}
+}
@@ -12,34 +12,45 @@ import (
 	ledger "github.com/formancehq/ledger/internal"
 	"github.com/formancehq/ledger/internal/api/common"
 	ledgercontroller "github.com/formancehq/ledger/internal/controller/ledger"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
 )
 
-func insertSchema(w http.ResponseWriter, r *http.Request) {
-	data := ledger.SchemaData{}
-	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
-		api.BadRequest(w, common.ErrValidation, err)
-		return
-	}
-
-	l := common.LedgerFromContext(r.Context())
-	_, _, idempotencyHit, err := l.InsertSchema(r.Context(), getCommandParameters(r, ledgercontroller.InsertSchema{
-		Data:    data,
-		Version: chi.URLParam(r, "version"),
-	}))
-	if err != nil {
-		switch {
-		case errors.Is(err, ledgercontroller.ErrSchemaAlreadyExists{}):
-			api.WriteErrorResponse(w, http.StatusConflict, common.ErrSchemaAlreadyExists, err)
-		case errors.Is(err, ledger.ErrInvalidSchema{}):
+func insertSchema(paginationConfig storagecommon.PaginationConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data := ledger.SchemaData{}
+		if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
 			api.BadRequest(w, common.ErrValidation, err)
-		default:
-			common.HandleCommonWriteErrors(w, r, err)
+			return
+		}
+
+		l := common.LedgerFromContext(r.Context())
+		parameters := getCommandParameters(r, ledgercontroller.InsertSchema{
+			Data:    data,
+			Version: chi.URLParam(r, "version"),
+		})
+		_, _, idempotencyHit, err := l.InsertSchema(r.Context(), parameters)
+		if err != nil {
+			switch {
+			case errors.Is(err, ledgercontroller.ErrSchemaAlreadyExists{}):
+				api.WriteErrorResponse(w, http.StatusConflict, common.ErrSchemaAlreadyExists, err)
+			case errors.Is(err, ledger.ErrInvalidSchema{}):
+				api.BadRequest(w, common.ErrValidation, err)
+			default:
+				common.HandleCommonWriteErrors(w, r, err)
+			}
+			return
+		}
+		if idempotencyHit {
+			w.Header().Set("Idempotency-Hit", "true")
 		}
-		return
-	}
-	if idempotencyHit {
-		w.Header().Set("Idempotency-Hit", "true")
-	}
 
-	w.WriteHeader(http.StatusNoContent)
+		if parameters.DryRun {
+			// the body holds the schema, accounts can only be filtered using the query parameter
+			r.Body = http.NoBody
+			writeSchemaDiff(w, r, paginationConfig, "", data)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
 }
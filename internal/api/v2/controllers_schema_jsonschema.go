@@ -0,0 +1,29 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+
+	"github.com/formancehq/ledger/internal/api/common"
+)
+
+func readSchemaJSONSchemas(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	schema, err := l.GetSchema(r.Context(), chi.URLParam(r, "version"))
+	if err != nil {
+		switch {
+		case postgres.IsNotFoundError(err):
+			api.NotFound(w, err)
+		default:
+			common.HandleCommonErrors(w, r, err)
+		}
+		return
+	}
+
+	api.Ok(w, schema.JSONSchemas())
+}
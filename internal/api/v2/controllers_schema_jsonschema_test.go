@@ -0,0 +1,91 @@
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/formancehq/go-libs/v5/pkg/authn/jwt"
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+
+	ledger "github.com/formancehq/ledger/internal"
+)
+
+func TestReadSchemaJSONSchemas(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name              string
+		returnErr         error
+		expectStatusCode  int
+		expectedErrorCode string
+	}
+
+	schema := &ledger.Schema{
+		Version: "v1",
+		SchemaData: ledger.SchemaData{
+			Chart: ledger.ChartOfAccounts{
+				"world": {Account: &ledger.ChartAccount{}},
+			},
+			Transactions: ledger.TransactionTemplates{
+				"DEPOSIT": {Script: "deposit"},
+			},
+		},
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:             "nominal",
+			expectStatusCode: http.StatusOK,
+		},
+		{
+			name:              "schema not found",
+			returnErr:         postgres.ErrNotFound,
+			expectStatusCode:  http.StatusNotFound,
+			expectedErrorCode: "NOT_FOUND",
+		},
+		{
+			name:              "backend error",
+			returnErr:         errors.New("database error"),
+			expectStatusCode:  http.StatusInternalServerError,
+			expectedErrorCode: "INTERNAL",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			ledgerController.EXPECT().
+				GetSchema(gomock.Any(), "v1").
+				Return(schema, tc.returnErr)
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodGet, "/default/schemas/v1/jsonschema", nil)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectStatusCode, rec.Code)
+			if tc.expectedErrorCode != "" {
+				var errorResponse api.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResponse))
+				require.Equal(t, tc.expectedErrorCode, errorResponse.ErrorCode)
+			} else {
+				var response struct {
+					Data map[string]map[string]map[string]any `json:"data"`
+				}
+				api.Decode(t, rec.Body, &response)
+				require.Equal(t, "^world$", response.Data["chart"]["world"]["properties"].(map[string]any)["address"].(map[string]any)["pattern"])
+				require.Contains(t, response.Data["transactions"], "DEPOSIT")
+				require.Empty(t, response.Data["queries"])
+			}
+		})
+	}
+}
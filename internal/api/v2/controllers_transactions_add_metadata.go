@@ -24,10 +24,19 @@ func addTransactionMetadata(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		_, idempotencyHit, err := l.SaveTransactionMetadata(r.Context(), getCommandParameters(r, ledgercontroller.SaveTransactionMetadata{
+		preconditions, err := getTransactionPreconditions(r, txID)
+		if err != nil {
+			api.BadRequest(w, common.ErrValidation, err)
+			return
+		}
+
+		parameters := getCommandParameters(r, ledgercontroller.SaveTransactionMetadata{
 			TransactionID: txID,
 			Metadata:      m,
-		}))
+		})
+		parameters.Preconditions = preconditions
+
+		_, idempotencyHit, err := l.SaveTransactionMetadata(r.Context(), parameters)
 		if err != nil {
 			switch {
 			case errors.Is(err, ledgercontroller.ErrNotFound):
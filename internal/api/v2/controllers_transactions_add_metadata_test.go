@@ -14,6 +14,7 @@ import (
 	"github.com/formancehq/go-libs/v5/pkg/authn/jwt"
 	"github.com/formancehq/go-libs/v5/pkg/transport/api"
 	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
 
 	"github.com/formancehq/ledger/internal/api/common"
 	ledgercontroller "github.com/formancehq/ledger/internal/controller/ledger"
@@ -23,15 +24,18 @@ func TestTransactionsAddMetadata(t *testing.T) {
 	t.Parallel()
 
 	type testCase struct {
-		name              string
-		queryParams       url.Values
-		expectStatusCode  int
-		expectedErrorCode string
-		body              any
-		id                any
-		expectBackendCall bool
-		returnErr         error
+		name                string
+		queryParams         url.Values
+		expectStatusCode    int
+		expectedErrorCode   string
+		body                any
+		id                  any
+		expectBackendCall   bool
+		returnErr           error
+		ifMatch             string
+		expectPreconditions ledgercontroller.Preconditions
 	}
+	version := time.Now()
 
 	testCases := []testCase{
 		{
@@ -63,6 +67,40 @@ func TestTransactionsAddMetadata(t *testing.T) {
 			expectStatusCode:  http.StatusNotFound,
 			expectedErrorCode: api.ErrorCodeNotFound,
 		},
+		{
+			name: "with If-Match",
+			body: metadata.Metadata{
+				"foo": "bar",
+			},
+			ifMatch:           fmt.Sprintf(`"%d"`, version.UnixMicro()),
+			expectBackendCall: true,
+			expectPreconditions: ledgercontroller.Preconditions{
+				TransactionVersions: map[uint64]time.Time{1: version},
+			},
+		},
+		{
+			name: "with If-Match on an updated transaction",
+			body: metadata.Metadata{
+				"foo": "bar",
+			},
+			ifMatch:           fmt.Sprintf(`"%d"`, version.UnixMicro()),
+			expectBackendCall: true,
+			expectPreconditions: ledgercontroller.Preconditions{
+				TransactionVersions: map[uint64]time.Time{1: version},
+			},
+			returnErr:         ledgercontroller.ErrPreconditionFailed{},
+			expectStatusCode:  http.StatusPreconditionFailed,
+			expectedErrorCode: common.ErrPreconditionFailed,
+		},
+		{
+			name: "with invalid If-Match",
+			body: metadata.Metadata{
+				"foo": "bar",
+			},
+			ifMatch:           `W/"abc"`,
+			expectStatusCode:  http.StatusBadRequest,
+			expectedErrorCode: common.ErrValidation,
+		},
 		{
 			name: "unexpected error",
 			body: metadata.Metadata{
@@ -94,6 +132,7 @@ func TestTransactionsAddMetadata(t *testing.T) {
 							TransactionID: 1,
 							Metadata:      testCase.body.(metadata.Metadata),
 						},
+						Preconditions: testCase.expectPreconditions,
 					}).
 					Return(nil, false, testCase.returnErr)
 			}
@@ -101,6 +140,9 @@ func TestTransactionsAddMetadata(t *testing.T) {
 			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
 
 			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/xxx/transactions/%v/metadata", testCase.id), api.Buffer(t, testCase.body))
+			if testCase.ifMatch != "" {
+				req.Header.Set("If-Match", testCase.ifMatch)
+			}
 			rec := httptest.NewRecorder()
 			req.URL.RawQuery = testCase.queryParams.Encode()
 
@@ -14,8 +14,13 @@ import (
 	ledgerstore "github.com/formancehq/ledger/internal/storage/ledger"
 )
 
+type createTransactionRequest struct {
+	bulking.TransactionRequest
+	Preconditions ledgercontroller.Preconditions `json:"preconditions"`
+}
+
 func createTransaction(w http.ResponseWriter, r *http.Request) {
-	common.WithBody(w, r, func(payload bulking.TransactionRequest) {
+	common.WithBody(w, r, func(payload createTransactionRequest) {
 		l := common.LedgerFromContext(r.Context())
 
 		txType := []string{}
@@ -47,7 +52,10 @@ func createTransaction(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		_, res, idempotencyHit, err := l.CreateTransaction(r.Context(), getCommandParameters(r, *createTransaction))
+		parameters := getCommandParameters(r, *createTransaction)
+		parameters.Preconditions = payload.Preconditions
+
+		_, res, idempotencyHit, err := l.CreateTransaction(r.Context(), parameters)
 		if err != nil {
 			switch {
 			case errors.Is(err, &ledgercontroller.ErrInsufficientFunds{}), errors.Is(err, numscript.MissingFundsErr{}):
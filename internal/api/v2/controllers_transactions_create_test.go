@@ -13,6 +13,7 @@ import (
 
 	"github.com/formancehq/go-libs/v5/pkg/authn/jwt"
 	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
 	"github.com/formancehq/go-libs/v5/pkg/types/time"
 
 	ledger "github.com/formancehq/ledger/internal"
@@ -23,15 +24,16 @@ import (
 
 func TestTransactionCreate(t *testing.T) {
 	type testCase struct {
-		name                 string
-		expectedDryRun       bool
-		expectedRunScript    ledgercontroller.RunScript
-		returnError          error
-		payload              any
-		expectedStatusCode   int
-		expectedErrorCode    string
-		queryParams          url.Values
-		expectControllerCall bool
+		name                  string
+		expectedDryRun        bool
+		expectedRunScript     ledgercontroller.RunScript
+		expectedPreconditions ledgercontroller.Preconditions
+		returnError           error
+		payload               any
+		expectedStatusCode    int
+		expectedErrorCode     string
+		queryParams           url.Values
+		expectControllerCall  bool
 	}
 
 	testCases := []testCase{
@@ -241,6 +243,26 @@ func TestTransactionCreate(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedErrorCode:  common.ErrInsufficientFund,
 		},
+		{
+			name:                 "with account rule violation",
+			expectControllerCall: true,
+			payload: bulking.TransactionRequest{
+				Script: ledgercontroller.ScriptV1{
+					Script: ledgercontroller.Script{
+						Plain: `XXX`,
+					},
+				},
+			},
+			expectedRunScript: ledgercontroller.RunScript{
+				Script: ledgercontroller.Script{
+					Plain: `XXX`,
+					Vars:  map[string]string{},
+				},
+			},
+			returnError:        ledgercontroller.ErrAccountRuleViolation{},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErrorCode:  common.ErrAccountRule,
+		},
 		{
 			name: "using JSON postings and negative amount",
 			payload: bulking.TransactionRequest{
@@ -323,6 +345,26 @@ func TestTransactionCreate(t *testing.T) {
 			},
 			returnError: ledgercontroller.ErrNoPostings,
 		},
+		{
+			name:                 "numscript in a closed period",
+			expectControllerCall: true,
+			payload: bulking.TransactionRequest{
+				Script: ledgercontroller.ScriptV1{
+					Script: ledgercontroller.Script{
+						Plain: `vars {}`,
+					},
+				},
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedErrorCode:  common.ErrPeriodClosed,
+			expectedRunScript: ledgercontroller.RunScript{
+				Script: ledgercontroller.Script{
+					Plain: `vars {}`,
+					Vars:  map[string]string{},
+				},
+			},
+			returnError: ledgercontroller.ErrPeriodClosed{},
+		},
 		{
 			name:                 "numscript and metadata override",
 			expectControllerCall: true,
@@ -359,6 +401,65 @@ func TestTransactionCreate(t *testing.T) {
 			},
 			returnError: &ledgercontroller.ErrMetadataOverride{},
 		},
+		{
+			name:                 "with preconditions",
+			expectControllerCall: true,
+			payload: createTransactionRequest{
+				TransactionRequest: bulking.TransactionRequest{
+					Script: ledgercontroller.ScriptV1{
+						Script: ledgercontroller.Script{
+							Plain: `XXX`,
+						},
+					},
+				},
+				Preconditions: ledgercontroller.Preconditions{
+					Balances: map[string]map[string]*big.Int{
+						"bank": {"USD": big.NewInt(100)},
+					},
+					LastLogID: pointer.For(uint64(10)),
+				},
+			},
+			expectedRunScript: ledgercontroller.RunScript{
+				Script: ledgercontroller.Script{
+					Plain: `XXX`,
+					Vars:  map[string]string{},
+				},
+			},
+			expectedPreconditions: ledgercontroller.Preconditions{
+				Balances: map[string]map[string]*big.Int{
+					"bank": {"USD": big.NewInt(100)},
+				},
+				LastLogID: pointer.For(uint64(10)),
+			},
+		},
+		{
+			name:                 "with failed preconditions",
+			expectControllerCall: true,
+			payload: createTransactionRequest{
+				TransactionRequest: bulking.TransactionRequest{
+					Script: ledgercontroller.ScriptV1{
+						Script: ledgercontroller.Script{
+							Plain: `XXX`,
+						},
+					},
+				},
+				Preconditions: ledgercontroller.Preconditions{
+					LastLogID: pointer.For(uint64(10)),
+				},
+			},
+			expectedRunScript: ledgercontroller.RunScript{
+				Script: ledgercontroller.Script{
+					Plain: `XXX`,
+					Vars:  map[string]string{},
+				},
+			},
+			expectedPreconditions: ledgercontroller.Preconditions{
+				LastLogID: pointer.For(uint64(10)),
+			},
+			expectedStatusCode: http.StatusPreconditionFailed,
+			expectedErrorCode:  common.ErrPreconditionFailed,
+			returnError:        ledgercontroller.ErrPreconditionFailed{},
+		},
 		{
 			name:                 "unexpected error",
 			expectControllerCall: true,
@@ -407,6 +508,7 @@ func TestTransactionCreate(t *testing.T) {
 						Input: ledgercontroller.CreateTransaction{
 							RunScript: testCase.expectedRunScript,
 						},
+						Preconditions: tc.expectedPreconditions,
 					})
 
 				if tc.returnError == nil {
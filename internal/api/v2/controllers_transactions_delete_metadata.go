@@ -21,12 +21,21 @@ func deleteTransactionMetadata(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	preconditions, err := getTransactionPreconditions(r, txID)
+	if err != nil {
+		api.BadRequest(w, common.ErrValidation, err)
+		return
+	}
+
 	metadataKey := chi.URLParam(r, "key")
 
-	_, idempotencyHit, err := l.DeleteTransactionMetadata(r.Context(), getCommandParameters(r, ledgercontroller.DeleteTransactionMetadata{
+	parameters := getCommandParameters(r, ledgercontroller.DeleteTransactionMetadata{
 		TransactionID: txID,
 		Key:           metadataKey,
-	}))
+	})
+	parameters.Preconditions = preconditions
+
+	_, idempotencyHit, err := l.DeleteTransactionMetadata(r.Context(), parameters)
 	if err != nil {
 		common.HandleCommonWriteErrors(w, r, err)
 		return
@@ -37,8 +37,9 @@ func listTransactions(paginationConfig storagecommon.PaginationConfig) http.Hand
 			return
 		}
 
+		fields := getQueryFields[any](rq)
 		api.RenderCursor(w, *paginate.MapCursor(cursor, func(tx ledger.Transaction) any {
-			return renderTransaction(r, tx)
+			return renderProjection(renderTransaction(r, tx), fields)
 		}))
 	}
 }
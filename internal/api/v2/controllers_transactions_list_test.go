@@ -3,10 +3,12 @@ package v2
 import (
 	"bytes"
 	"fmt"
+	"maps"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -34,6 +36,7 @@ func TestTransactionsList(t *testing.T) {
 		expectQuery       storagecommon.PaginatedQuery[any]
 		expectStatusCode  int
 		expectedErrorCode string
+		expectedKeys      []string
 	}
 	now := time.Now()
 
@@ -50,6 +53,23 @@ func TestTransactionsList(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "using fields",
+			queryParams: url.Values{
+				"fields": []string{"id,timestamp,revertedAt"},
+			},
+			expectQuery: storagecommon.InitialPaginatedQuery[any]{
+				PageSize: paginate.QueryDefaultPageSize,
+				Column:   "id",
+				Order:    pointer.For(paginate.Order(paginate.OrderDesc)),
+				Options: storagecommon.ResourceQuery[any]{
+					PIT:    &now,
+					Expand: make([]string, 0),
+					Fields: []string{"id", "timestamp", "revertedAt"},
+				},
+			},
+			expectedKeys: []string{"id", "timestamp", "reverted"},
+		},
 		{
 			name: "using metadata",
 			body: `{"$match": {"metadata[roles]": "admin"}}`,
@@ -286,10 +306,15 @@ func TestTransactionsList(t *testing.T) {
 			router.ServeHTTP(rec, req)
 
 			require.Equal(t, testCase.expectStatusCode, rec.Code)
-			if testCase.expectStatusCode < 300 && testCase.expectStatusCode >= 200 {
+			switch {
+			case testCase.expectedKeys != nil:
+				cursor := api.DecodeCursorResponse[map[string]any](t, rec.Body)
+				require.Len(t, cursor.Data, 1)
+				require.ElementsMatch(t, testCase.expectedKeys, slices.Collect(maps.Keys(cursor.Data[0])))
+			case testCase.expectStatusCode < 300 && testCase.expectStatusCode >= 200:
 				cursor := api.DecodeCursorResponse[ledger.Transaction](t, rec.Body)
 				require.Equal(t, expectedCursor, *cursor)
-			} else {
+			default:
 				err := api.ErrorResponse{}
 				api.Decode(t, rec.Body, &err)
 				require.EqualValues(t, testCase.expectedErrorCode, err.ErrorCode)
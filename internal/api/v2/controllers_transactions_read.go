@@ -44,5 +44,10 @@ func readTransaction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the version is only meaningful for the current state of the transaction
+	if pit == nil {
+		writeETag(w, tx.UpdatedAt)
+	}
+
 	api.Ok(w, renderTransaction(r, *tx))
 }
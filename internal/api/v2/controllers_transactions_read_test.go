@@ -1,6 +1,7 @@
 package v2
 
 import (
+	"fmt"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
@@ -48,4 +49,31 @@ func TestTransactionsRead(t *testing.T) {
 	require.Equal(t, http.StatusOK, rec.Code)
 	response, _ := api.DecodeSingleResponse[ledger.Transaction](t, rec.Body)
 	require.Equal(t, tx, response)
+	// no ETag for a past state of the transaction
+	require.Empty(t, rec.Header().Get("ETag"))
+}
+
+func TestTransactionsReadETag(t *testing.T) {
+	t.Parallel()
+
+	tx := ledger.NewTransaction().
+		WithPostings(ledger.NewPosting("world", "bank", "USD", big.NewInt(100))).
+		WithUpdatedAt(time.Now())
+
+	systemController, ledgerController := newTestingSystemController(t, true)
+	ledgerController.EXPECT().
+		GetTransaction(gomock.Any(), storagecommon.ResourceQuery[any]{
+			Builder: query.Match("id", 0),
+		}).
+		Return(&tx, nil)
+
+	router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+	req := httptest.NewRequest(http.MethodGet, "/xxx/transactions/0", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, fmt.Sprintf(`"%d"`, tx.UpdatedAt.UnixMicro()), rec.Header().Get("ETag"))
 }
@@ -3,6 +3,8 @@ package v2
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math/big"
 	"net/http"
 	"strconv"
 
@@ -36,15 +38,21 @@ func revertTransaction(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	_, ret, idempotencyHit, err := l.RevertTransaction(
-		r.Context(),
-		getCommandParameters(r, ledgercontroller.RevertTransaction{
-			Force:           api.QueryParamBool(r, "force"),
-			AtEffectiveDate: api.QueryParamBool(r, "atEffectiveDate"),
-			TransactionID:   txId,
-			Metadata:        x.Metadata,
-		}),
-	)
+	preconditions, err := getTransactionPreconditions(r, txId)
+	if err != nil {
+		api.BadRequest(w, common.ErrValidation, err)
+		return
+	}
+
+	parameters := getCommandParameters(r, ledgercontroller.RevertTransaction{
+		Force:           api.QueryParamBool(r, "force"),
+		AtEffectiveDate: api.QueryParamBool(r, "atEffectiveDate"),
+		TransactionID:   txId,
+		Metadata:        x.Metadata,
+	})
+	parameters.Preconditions = preconditions
+
+	_, ret, idempotencyHit, err := l.RevertTransaction(r.Context(), parameters)
 	if err != nil {
 		switch {
 		case errors.Is(err, &ledgercontroller.ErrInsufficientFunds{}):
@@ -67,3 +75,89 @@ func revertTransaction(w http.ResponseWriter, r *http.Request) {
 
 	api.Created(w, renderTransaction(r, ret.RevertTransaction))
 }
+
+func partiallyRevertTransaction(w http.ResponseWriter, r *http.Request) {
+	l := common.LedgerFromContext(r.Context())
+
+	txId, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		api.BadRequest(w, common.ErrValidation, err)
+		return
+	}
+
+	type postingRequest struct {
+		Index  int      `json:"index"`
+		Amount *big.Int `json:"amount"`
+	}
+	type request struct {
+		Postings []postingRequest  `json:"postings,omitempty"`
+		Ratio    string            `json:"ratio,omitempty"`
+		Metadata metadata.Metadata `json:"metadata,omitempty"`
+	}
+
+	x := request{}
+	if err := json.NewDecoder(r.Body).Decode(&x); err != nil {
+		api.BadRequest(w, common.ErrValidation, errors.New("expected JSON body with postings or ratio"))
+		return
+	}
+
+	input := ledgercontroller.PartiallyRevertTransaction{
+		Force:           api.QueryParamBool(r, "force"),
+		AtEffectiveDate: api.QueryParamBool(r, "atEffectiveDate"),
+		TransactionID:   txId,
+		Metadata:        x.Metadata,
+	}
+	if x.Postings != nil {
+		input.Amounts = make(map[int]*big.Int, len(x.Postings))
+		for _, posting := range x.Postings {
+			if posting.Amount == nil {
+				api.BadRequest(w, common.ErrValidation, fmt.Errorf("missing amount for posting %d", posting.Index))
+				return
+			}
+			if _, ok := input.Amounts[posting.Index]; ok {
+				api.BadRequest(w, common.ErrValidation, fmt.Errorf("posting %d is specified multiple times", posting.Index))
+				return
+			}
+			input.Amounts[posting.Index] = posting.Amount
+		}
+	}
+	if x.Ratio != "" {
+		ratio, ok := new(big.Rat).SetString(x.Ratio)
+		if !ok {
+			api.BadRequest(w, common.ErrValidation, fmt.Errorf("invalid ratio: %s", x.Ratio))
+			return
+		}
+		input.Ratio = ratio
+	}
+
+	preconditions, err := getTransactionPreconditions(r, txId)
+	if err != nil {
+		api.BadRequest(w, common.ErrValidation, err)
+		return
+	}
+
+	parameters := getCommandParameters(r, input)
+	parameters.Preconditions = preconditions
+
+	_, ret, idempotencyHit, err := l.PartiallyRevertTransaction(r.Context(), parameters)
+	if err != nil {
+		switch {
+		case errors.Is(err, ledgercontroller.ErrInvalidPartialRevert{}):
+			api.BadRequest(w, common.ErrValidation, err)
+		case errors.Is(err, &ledgercontroller.ErrInsufficientFunds{}):
+			api.BadRequest(w, common.ErrInsufficientFund, err)
+		case errors.Is(err, ledgercontroller.ErrAlreadyReverted{}):
+			api.BadRequest(w, common.ErrAlreadyRevert, err)
+		case errors.Is(err, ledgercontroller.ErrNotFound):
+			api.NotFound(w, err)
+		default:
+			common.HandleCommonWriteErrors(w, r, err)
+		}
+		return
+	}
+	if idempotencyHit {
+		w.Header().Set("Idempotency-Hit", "true")
+	}
+
+	api.Created(w, renderTransaction(r, ret.RevertTransaction))
+}
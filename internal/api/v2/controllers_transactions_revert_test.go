@@ -106,3 +106,123 @@ func TestTransactionsRevert(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionsPartialRevert(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name              string
+		body              any
+		expectInput       ledgercontroller.PartiallyRevertTransaction
+		returnErr         error
+		expectBackendCall bool
+		expectStatusCode  int
+		expectErrorCode   string
+	}
+
+	testCases := []testCase{
+		{
+			name: "with amounts",
+			body: map[string]any{
+				"postings": []map[string]any{{"index": 1, "amount": 50}},
+			},
+			expectInput: ledgercontroller.PartiallyRevertTransaction{
+				Amounts: map[int]*big.Int{1: big.NewInt(50)},
+			},
+			expectBackendCall: true,
+		},
+		{
+			name: "with ratio",
+			body: map[string]any{
+				"ratio": "1/4",
+			},
+			expectInput: ledgercontroller.PartiallyRevertTransaction{
+				Ratio: big.NewRat(1, 4),
+			},
+			expectBackendCall: true,
+		},
+		{
+			name: "with invalid ratio",
+			body: map[string]any{
+				"ratio": "abc",
+			},
+			expectStatusCode: http.StatusBadRequest,
+			expectErrorCode:  common.ErrValidation,
+		},
+		{
+			name: "with duplicated posting",
+			body: map[string]any{
+				"postings": []map[string]any{{"index": 0, "amount": 50}, {"index": 0, "amount": 10}},
+			},
+			expectStatusCode: http.StatusBadRequest,
+			expectErrorCode:  common.ErrValidation,
+		},
+		{
+			name: "with amounts exceeding the revertible amounts",
+			body: map[string]any{
+				"postings": []map[string]any{{"index": 0, "amount": 500}},
+			},
+			expectInput: ledgercontroller.PartiallyRevertTransaction{
+				Amounts: map[int]*big.Int{0: big.NewInt(500)},
+			},
+			returnErr:         ledgercontroller.ErrInvalidPartialRevert{},
+			expectBackendCall: true,
+			expectStatusCode:  http.StatusBadRequest,
+			expectErrorCode:   common.ErrValidation,
+		},
+		{
+			name: "with already revert",
+			body: map[string]any{
+				"ratio": "1",
+			},
+			expectInput: ledgercontroller.PartiallyRevertTransaction{
+				Ratio: big.NewRat(1, 1),
+			},
+			returnErr:         ledgercontroller.ErrAlreadyReverted{},
+			expectBackendCall: true,
+			expectStatusCode:  http.StatusBadRequest,
+			expectErrorCode:   common.ErrAlreadyRevert,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			returnTx := ledger.NewTransaction().WithPostings(
+				ledger.NewPosting("bank", "world", "USD", big.NewInt(50)),
+			)
+
+			systemController, ledgerController := newTestingSystemController(t, true)
+			if tc.expectBackendCall {
+				ledgerController.
+					EXPECT().
+					PartiallyRevertTransaction(gomock.Any(), ledgercontroller.Parameters[ledgercontroller.PartiallyRevertTransaction]{
+						Input: tc.expectInput,
+					}).
+					Return(&ledger.Log{}, &ledger.PartiallyRevertedTransaction{
+						RevertTransaction: returnTx,
+					}, false, tc.returnErr)
+			}
+
+			router := NewRouter(systemController, jwt.NewNoAuth(), "develop")
+
+			req := httptest.NewRequest(http.MethodPost, "/xxx/transactions/0/partial-revert", api.Buffer(t, tc.body))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if tc.expectStatusCode == 0 {
+				require.Equal(t, http.StatusCreated, rec.Code)
+				tx, ok := api.DecodeSingleResponse[ledger.Transaction](t, rec.Body)
+				require.True(t, ok)
+				require.Equal(t, returnTx, tx)
+			} else {
+				require.Equal(t, tc.expectStatusCode, rec.Code)
+				err := api.ErrorResponse{}
+				api.Decode(t, rec.Body, &err)
+				require.EqualValues(t, tc.expectErrorCode, err.ErrorCode)
+			}
+		})
+	}
+}
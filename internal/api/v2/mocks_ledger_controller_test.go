@@ -86,6 +86,127 @@ func (c *LedgerControllerBeginTXCall) DoAndReturn(f func(context.Context, *sql.T
 	return c
 }
 
+// CancelScheduledTransaction mocks base method.
+func (m *LedgerController) CancelScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelScheduledTransaction", ctx, id)
+	ret0, _ := ret[0].(*ledger.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelScheduledTransaction indicates an expected call of CancelScheduledTransaction.
+func (mr *LedgerControllerMockRecorder) CancelScheduledTransaction(ctx, id any) *LedgerControllerCancelScheduledTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelScheduledTransaction", reflect.TypeOf((*LedgerController)(nil).CancelScheduledTransaction), ctx, id)
+	return &LedgerControllerCancelScheduledTransactionCall{Call: call}
+}
+
+// LedgerControllerCancelScheduledTransactionCall wrap *gomock.Call
+type LedgerControllerCancelScheduledTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerCancelScheduledTransactionCall) Return(arg0 *ledger.ScheduledTransaction, arg1 error) *LedgerControllerCancelScheduledTransactionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerCancelScheduledTransactionCall) Do(f func(context.Context, string) (*ledger.ScheduledTransaction, error)) *LedgerControllerCancelScheduledTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerCancelScheduledTransactionCall) DoAndReturn(f func(context.Context, string) (*ledger.ScheduledTransaction, error)) *LedgerControllerCancelScheduledTransactionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CaptureHold mocks base method.
+func (m *LedgerController) CaptureHold(ctx context.Context, parameters ledger0.Parameters[ledger0.CaptureHold]) (*ledger.Log, *ledger.CapturedHold, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureHold", ctx, parameters)
+	ret0, _ := ret[0].(*ledger.Log)
+	ret1, _ := ret[1].(*ledger.CapturedHold)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// CaptureHold indicates an expected call of CaptureHold.
+func (mr *LedgerControllerMockRecorder) CaptureHold(ctx, parameters any) *LedgerControllerCaptureHoldCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureHold", reflect.TypeOf((*LedgerController)(nil).CaptureHold), ctx, parameters)
+	return &LedgerControllerCaptureHoldCall{Call: call}
+}
+
+// LedgerControllerCaptureHoldCall wrap *gomock.Call
+type LedgerControllerCaptureHoldCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerCaptureHoldCall) Return(arg0 *ledger.Log, arg1 *ledger.CapturedHold, arg2 bool, arg3 error) *LedgerControllerCaptureHoldCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2, arg3)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerCaptureHoldCall) Do(f func(context.Context, ledger0.Parameters[ledger0.CaptureHold]) (*ledger.Log, *ledger.CapturedHold, bool, error)) *LedgerControllerCaptureHoldCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerCaptureHoldCall) DoAndReturn(f func(context.Context, ledger0.Parameters[ledger0.CaptureHold]) (*ledger.Log, *ledger.CapturedHold, bool, error)) *LedgerControllerCaptureHoldCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ClosePeriod mocks base method.
+func (m *LedgerController) ClosePeriod(ctx context.Context, parameters ledger0.Parameters[ledger0.ClosePeriod]) (*ledger.Log, *ledger.ClosedPeriod, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePeriod", ctx, parameters)
+	ret0, _ := ret[0].(*ledger.Log)
+	ret1, _ := ret[1].(*ledger.ClosedPeriod)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// ClosePeriod indicates an expected call of ClosePeriod.
+func (mr *LedgerControllerMockRecorder) ClosePeriod(ctx, parameters any) *LedgerControllerClosePeriodCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePeriod", reflect.TypeOf((*LedgerController)(nil).ClosePeriod), ctx, parameters)
+	return &LedgerControllerClosePeriodCall{Call: call}
+}
+
+// LedgerControllerClosePeriodCall wrap *gomock.Call
+type LedgerControllerClosePeriodCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerClosePeriodCall) Return(arg0 *ledger.Log, arg1 *ledger.ClosedPeriod, arg2 bool, arg3 error) *LedgerControllerClosePeriodCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2, arg3)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerClosePeriodCall) Do(f func(context.Context, ledger0.Parameters[ledger0.ClosePeriod]) (*ledger.Log, *ledger.ClosedPeriod, bool, error)) *LedgerControllerClosePeriodCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerClosePeriodCall) DoAndReturn(f func(context.Context, ledger0.Parameters[ledger0.ClosePeriod]) (*ledger.Log, *ledger.ClosedPeriod, bool, error)) *LedgerControllerClosePeriodCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Commit mocks base method.
 func (m *LedgerController) Commit(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -202,6 +323,125 @@ func (c *LedgerControllerCountTransactionsCall) DoAndReturn(f func(context.Conte
 	return c
 }
 
+// CreateHold mocks base method.
+func (m *LedgerController) CreateHold(ctx context.Context, parameters ledger0.Parameters[ledger0.CreateHold]) (*ledger.Log, *ledger.CreatedHold, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, parameters)
+	ret0, _ := ret[0].(*ledger.Log)
+	ret1, _ := ret[1].(*ledger.CreatedHold)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *LedgerControllerMockRecorder) CreateHold(ctx, parameters any) *LedgerControllerCreateHoldCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*LedgerController)(nil).CreateHold), ctx, parameters)
+	return &LedgerControllerCreateHoldCall{Call: call}
+}
+
+// LedgerControllerCreateHoldCall wrap *gomock.Call
+type LedgerControllerCreateHoldCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerCreateHoldCall) Return(arg0 *ledger.Log, arg1 *ledger.CreatedHold, arg2 bool, arg3 error) *LedgerControllerCreateHoldCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2, arg3)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerCreateHoldCall) Do(f func(context.Context, ledger0.Parameters[ledger0.CreateHold]) (*ledger.Log, *ledger.CreatedHold, bool, error)) *LedgerControllerCreateHoldCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerCreateHoldCall) DoAndReturn(f func(context.Context, ledger0.Parameters[ledger0.CreateHold]) (*ledger.Log, *ledger.CreatedHold, bool, error)) *LedgerControllerCreateHoldCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateRecurrence mocks base method.
+func (m *LedgerController) CreateRecurrence(ctx context.Context, input ledger0.CreateRecurrence) (*ledger.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecurrence", ctx, input)
+	ret0, _ := ret[0].(*ledger.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecurrence indicates an expected call of CreateRecurrence.
+func (mr *LedgerControllerMockRecorder) CreateRecurrence(ctx, input any) *LedgerControllerCreateRecurrenceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecurrence", reflect.TypeOf((*LedgerController)(nil).CreateRecurrence), ctx, input)
+	return &LedgerControllerCreateRecurrenceCall{Call: call}
+}
+
+// LedgerControllerCreateRecurrenceCall wrap *gomock.Call
+type LedgerControllerCreateRecurrenceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerCreateRecurrenceCall) Return(arg0 *ledger.Recurrence, arg1 error) *LedgerControllerCreateRecurrenceCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerCreateRecurrenceCall) Do(f func(context.Context, ledger0.CreateRecurrence) (*ledger.Recurrence, error)) *LedgerControllerCreateRecurrenceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerCreateRecurrenceCall) DoAndReturn(f func(context.Context, ledger0.CreateRecurrence) (*ledger.Recurrence, error)) *LedgerControllerCreateRecurrenceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateRevertJob mocks base method.
+func (m *LedgerController) CreateRevertJob(ctx context.Context, input ledger0.CreateRevertJob) (*ledger.RevertJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRevertJob", ctx, input)
+	ret0, _ := ret[0].(*ledger.RevertJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRevertJob indicates an expected call of CreateRevertJob.
+func (mr *LedgerControllerMockRecorder) CreateRevertJob(ctx, input any) *LedgerControllerCreateRevertJobCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRevertJob", reflect.TypeOf((*LedgerController)(nil).CreateRevertJob), ctx, input)
+	return &LedgerControllerCreateRevertJobCall{Call: call}
+}
+
+// LedgerControllerCreateRevertJobCall wrap *gomock.Call
+type LedgerControllerCreateRevertJobCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerCreateRevertJobCall) Return(arg0 *ledger.RevertJob, arg1 error) *LedgerControllerCreateRevertJobCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerCreateRevertJobCall) Do(f func(context.Context, ledger0.CreateRevertJob) (*ledger.RevertJob, error)) *LedgerControllerCreateRevertJobCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerCreateRevertJobCall) DoAndReturn(f func(context.Context, ledger0.CreateRevertJob) (*ledger.RevertJob, error)) *LedgerControllerCreateRevertJobCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // CreateTransaction mocks base method.
 func (m *LedgerController) CreateTransaction(ctx context.Context, parameters ledger0.Parameters[ledger0.CreateTransaction]) (*ledger.Log, *ledger.CreatedTransaction, bool, error) {
 	m.ctrl.T.Helper()
@@ -283,6 +523,44 @@ func (c *LedgerControllerDeleteAccountMetadataCall) DoAndReturn(f func(context.C
 	return c
 }
 
+// DeleteRecurrence mocks base method.
+func (m *LedgerController) DeleteRecurrence(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecurrence", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecurrence indicates an expected call of DeleteRecurrence.
+func (mr *LedgerControllerMockRecorder) DeleteRecurrence(ctx, id any) *LedgerControllerDeleteRecurrenceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecurrence", reflect.TypeOf((*LedgerController)(nil).DeleteRecurrence), ctx, id)
+	return &LedgerControllerDeleteRecurrenceCall{Call: call}
+}
+
+// LedgerControllerDeleteRecurrenceCall wrap *gomock.Call
+type LedgerControllerDeleteRecurrenceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerDeleteRecurrenceCall) Return(arg0 error) *LedgerControllerDeleteRecurrenceCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerDeleteRecurrenceCall) Do(f func(context.Context, string) error) *LedgerControllerDeleteRecurrenceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerDeleteRecurrenceCall) DoAndReturn(f func(context.Context, string) error) *LedgerControllerDeleteRecurrenceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // DeleteTransactionMetadata mocks base method.
 func (m *LedgerController) DeleteTransactionMetadata(ctx context.Context, parameters ledger0.Parameters[ledger0.DeleteTransactionMetadata]) (*ledger.Log, bool, error) {
 	m.ctrl.T.Helper()
@@ -323,6 +601,86 @@ func (c *LedgerControllerDeleteTransactionMetadataCall) DoAndReturn(f func(conte
 	return c
 }
 
+// DiffSchema mocks base method.
+func (m *LedgerController) DiffSchema(ctx context.Context, version string, data ledger.SchemaData, query common.PaginatedQuery[any]) (*ledger.SchemaDiff, *paginate.Cursor[ledger.Account], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffSchema", ctx, version, data, query)
+	ret0, _ := ret[0].(*ledger.SchemaDiff)
+	ret1, _ := ret[1].(*paginate.Cursor[ledger.Account])
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DiffSchema indicates an expected call of DiffSchema.
+func (mr *LedgerControllerMockRecorder) DiffSchema(ctx, version, data, query any) *LedgerControllerDiffSchemaCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffSchema", reflect.TypeOf((*LedgerController)(nil).DiffSchema), ctx, version, data, query)
+	return &LedgerControllerDiffSchemaCall{Call: call}
+}
+
+// LedgerControllerDiffSchemaCall wrap *gomock.Call
+type LedgerControllerDiffSchemaCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerDiffSchemaCall) Return(arg0 *ledger.SchemaDiff, arg1 *paginate.Cursor[ledger.Account], arg2 error) *LedgerControllerDiffSchemaCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerDiffSchemaCall) Do(f func(context.Context, string, ledger.SchemaData, common.PaginatedQuery[any]) (*ledger.SchemaDiff, *paginate.Cursor[ledger.Account], error)) *LedgerControllerDiffSchemaCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerDiffSchemaCall) DoAndReturn(f func(context.Context, string, ledger.SchemaData, common.PaginatedQuery[any]) (*ledger.SchemaDiff, *paginate.Cursor[ledger.Account], error)) *LedgerControllerDiffSchemaCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ExecuteScheduledTransaction mocks base method.
+func (m *LedgerController) ExecuteScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, *ledger.CreatedTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteScheduledTransaction", ctx, id)
+	ret0, _ := ret[0].(*ledger.ScheduledTransaction)
+	ret1, _ := ret[1].(*ledger.CreatedTransaction)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ExecuteScheduledTransaction indicates an expected call of ExecuteScheduledTransaction.
+func (mr *LedgerControllerMockRecorder) ExecuteScheduledTransaction(ctx, id any) *LedgerControllerExecuteScheduledTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteScheduledTransaction", reflect.TypeOf((*LedgerController)(nil).ExecuteScheduledTransaction), ctx, id)
+	return &LedgerControllerExecuteScheduledTransactionCall{Call: call}
+}
+
+// LedgerControllerExecuteScheduledTransactionCall wrap *gomock.Call
+type LedgerControllerExecuteScheduledTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerExecuteScheduledTransactionCall) Return(arg0 *ledger.ScheduledTransaction, arg1 *ledger.CreatedTransaction, arg2 error) *LedgerControllerExecuteScheduledTransactionCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerExecuteScheduledTransactionCall) Do(f func(context.Context, string) (*ledger.ScheduledTransaction, *ledger.CreatedTransaction, error)) *LedgerControllerExecuteScheduledTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerExecuteScheduledTransactionCall) DoAndReturn(f func(context.Context, string) (*ledger.ScheduledTransaction, *ledger.CreatedTransaction, error)) *LedgerControllerExecuteScheduledTransactionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Export mocks base method.
 func (m *LedgerController) Export(ctx context.Context, w ledger0.ExportWriter) error {
 	m.ctrl.T.Helper()
@@ -400,47 +758,320 @@ func (c *LedgerControllerGetAccountCall) DoAndReturn(f func(context.Context, com
 	return c
 }
 
-// GetAggregatedBalances mocks base method.
-func (m *LedgerController) GetAggregatedBalances(ctx context.Context, q common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (ledger.BalancesByAssets, error) {
+// GetAccountStatement mocks base method.
+func (m *LedgerController) GetAccountStatement(ctx context.Context, address string, query common.PaginatedQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.AccountStatement, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAggregatedBalances", ctx, q)
-	ret0, _ := ret[0].(ledger.BalancesByAssets)
+	ret := m.ctrl.Call(m, "GetAccountStatement", ctx, address, query)
+	ret0, _ := ret[0].(*ledger.AccountStatement)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAggregatedBalances indicates an expected call of GetAggregatedBalances.
-func (mr *LedgerControllerMockRecorder) GetAggregatedBalances(ctx, q any) *LedgerControllerGetAggregatedBalancesCall {
+// GetAccountStatement indicates an expected call of GetAccountStatement.
+func (mr *LedgerControllerMockRecorder) GetAccountStatement(ctx, address, query any) *LedgerControllerGetAccountStatementCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregatedBalances", reflect.TypeOf((*LedgerController)(nil).GetAggregatedBalances), ctx, q)
-	return &LedgerControllerGetAggregatedBalancesCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountStatement", reflect.TypeOf((*LedgerController)(nil).GetAccountStatement), ctx, address, query)
+	return &LedgerControllerGetAccountStatementCall{Call: call}
 }
 
-// LedgerControllerGetAggregatedBalancesCall wrap *gomock.Call
-type LedgerControllerGetAggregatedBalancesCall struct {
+// LedgerControllerGetAccountStatementCall wrap *gomock.Call
+type LedgerControllerGetAccountStatementCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *LedgerControllerGetAggregatedBalancesCall) Return(arg0 ledger.BalancesByAssets, arg1 error) *LedgerControllerGetAggregatedBalancesCall {
+func (c *LedgerControllerGetAccountStatementCall) Return(arg0 *ledger.AccountStatement, arg1 error) *LedgerControllerGetAccountStatementCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *LedgerControllerGetAggregatedBalancesCall) Do(f func(context.Context, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (ledger.BalancesByAssets, error)) *LedgerControllerGetAggregatedBalancesCall {
+func (c *LedgerControllerGetAccountStatementCall) Do(f func(context.Context, string, common.PaginatedQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.AccountStatement, error)) *LedgerControllerGetAccountStatementCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *LedgerControllerGetAggregatedBalancesCall) DoAndReturn(f func(context.Context, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (ledger.BalancesByAssets, error)) *LedgerControllerGetAggregatedBalancesCall {
+func (c *LedgerControllerGetAccountStatementCall) DoAndReturn(f func(context.Context, string, common.PaginatedQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.AccountStatement, error)) *LedgerControllerGetAccountStatementCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// GetMigrationsInfo mocks base method.
-func (m *LedgerController) GetMigrationsInfo(ctx context.Context) ([]migrations.Info, error) {
+// GetAggregatedBalances mocks base method.
+func (m *LedgerController) GetAggregatedBalances(ctx context.Context, q common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (ledger.BalancesByAssets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAggregatedBalances", ctx, q)
+	ret0, _ := ret[0].(ledger.BalancesByAssets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAggregatedBalances indicates an expected call of GetAggregatedBalances.
+func (mr *LedgerControllerMockRecorder) GetAggregatedBalances(ctx, q any) *LedgerControllerGetAggregatedBalancesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregatedBalances", reflect.TypeOf((*LedgerController)(nil).GetAggregatedBalances), ctx, q)
+	return &LedgerControllerGetAggregatedBalancesCall{Call: call}
+}
+
+// LedgerControllerGetAggregatedBalancesCall wrap *gomock.Call
+type LedgerControllerGetAggregatedBalancesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerGetAggregatedBalancesCall) Return(arg0 ledger.BalancesByAssets, arg1 error) *LedgerControllerGetAggregatedBalancesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerGetAggregatedBalancesCall) Do(f func(context.Context, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (ledger.BalancesByAssets, error)) *LedgerControllerGetAggregatedBalancesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerGetAggregatedBalancesCall) DoAndReturn(f func(context.Context, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (ledger.BalancesByAssets, error)) *LedgerControllerGetAggregatedBalancesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetBalanceHistory mocks base method.
+func (m *LedgerController) GetBalanceHistory(ctx context.Context, query common.ResourceQuery[ledger.GetBalanceHistoryOptions]) ([]ledger.BalanceHistoryPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceHistory", ctx, query)
+	ret0, _ := ret[0].([]ledger.BalanceHistoryPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceHistory indicates an expected call of GetBalanceHistory.
+func (mr *LedgerControllerMockRecorder) GetBalanceHistory(ctx, query any) *LedgerControllerGetBalanceHistoryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceHistory", reflect.TypeOf((*LedgerController)(nil).GetBalanceHistory), ctx, query)
+	return &LedgerControllerGetBalanceHistoryCall{Call: call}
+}
+
+// LedgerControllerGetBalanceHistoryCall wrap *gomock.Call
+type LedgerControllerGetBalanceHistoryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerGetBalanceHistoryCall) Return(arg0 []ledger.BalanceHistoryPoint, arg1 error) *LedgerControllerGetBalanceHistoryCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerGetBalanceHistoryCall) Do(f func(context.Context, common.ResourceQuery[ledger.GetBalanceHistoryOptions]) ([]ledger.BalanceHistoryPoint, error)) *LedgerControllerGetBalanceHistoryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerGetBalanceHistoryCall) DoAndReturn(f func(context.Context, common.ResourceQuery[ledger.GetBalanceHistoryOptions]) ([]ledger.BalanceHistoryPoint, error)) *LedgerControllerGetBalanceHistoryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetBalanceSheet mocks base method.
+func (m *LedgerController) GetBalanceSheet(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.BalanceSheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceSheet", ctx, version, query)
+	ret0, _ := ret[0].(*ledger.BalanceSheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceSheet indicates an expected call of GetBalanceSheet.
+func (mr *LedgerControllerMockRecorder) GetBalanceSheet(ctx, version, query any) *LedgerControllerGetBalanceSheetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceSheet", reflect.TypeOf((*LedgerController)(nil).GetBalanceSheet), ctx, version, query)
+	return &LedgerControllerGetBalanceSheetCall{Call: call}
+}
+
+// LedgerControllerGetBalanceSheetCall wrap *gomock.Call
+type LedgerControllerGetBalanceSheetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerGetBalanceSheetCall) Return(arg0 *ledger.BalanceSheet, arg1 error) *LedgerControllerGetBalanceSheetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerGetBalanceSheetCall) Do(f func(context.Context, string, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.BalanceSheet, error)) *LedgerControllerGetBalanceSheetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerGetBalanceSheetCall) DoAndReturn(f func(context.Context, string, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.BalanceSheet, error)) *LedgerControllerGetBalanceSheetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetChartTree mocks base method.
+func (m *LedgerController) GetChartTree(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) ([]ledger.ChartTreeNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChartTree", ctx, version, query)
+	ret0, _ := ret[0].([]ledger.ChartTreeNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChartTree indicates an expected call of GetChartTree.
+func (mr *LedgerControllerMockRecorder) GetChartTree(ctx, version, query any) *LedgerControllerGetChartTreeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChartTree", reflect.TypeOf((*LedgerController)(nil).GetChartTree), ctx, version, query)
+	return &LedgerControllerGetChartTreeCall{Call: call}
+}
+
+// LedgerControllerGetChartTreeCall wrap *gomock.Call
+type LedgerControllerGetChartTreeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerGetChartTreeCall) Return(arg0 []ledger.ChartTreeNode, arg1 error) *LedgerControllerGetChartTreeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerGetChartTreeCall) Do(f func(context.Context, string, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) ([]ledger.ChartTreeNode, error)) *LedgerControllerGetChartTreeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerGetChartTreeCall) DoAndReturn(f func(context.Context, string, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) ([]ledger.ChartTreeNode, error)) *LedgerControllerGetChartTreeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetClosedPeriod mocks base method.
+func (m *LedgerController) GetClosedPeriod(ctx context.Context) (*ledger.ClosedPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClosedPeriod", ctx)
+	ret0, _ := ret[0].(*ledger.ClosedPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClosedPeriod indicates an expected call of GetClosedPeriod.
+func (mr *LedgerControllerMockRecorder) GetClosedPeriod(ctx any) *LedgerControllerGetClosedPeriodCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClosedPeriod", reflect.TypeOf((*LedgerController)(nil).GetClosedPeriod), ctx)
+	return &LedgerControllerGetClosedPeriodCall{Call: call}
+}
+
+// LedgerControllerGetClosedPeriodCall wrap *gomock.Call
+type LedgerControllerGetClosedPeriodCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerGetClosedPeriodCall) Return(arg0 *ledger.ClosedPeriod, arg1 error) *LedgerControllerGetClosedPeriodCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerGetClosedPeriodCall) Do(f func(context.Context) (*ledger.ClosedPeriod, error)) *LedgerControllerGetClosedPeriodCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerGetClosedPeriodCall) DoAndReturn(f func(context.Context) (*ledger.ClosedPeriod, error)) *LedgerControllerGetClosedPeriodCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetHold mocks base method.
+func (m *LedgerController) GetHold(ctx context.Context, id string) (*ledger.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHold", ctx, id)
+	ret0, _ := ret[0].(*ledger.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHold indicates an expected call of GetHold.
+func (mr *LedgerControllerMockRecorder) GetHold(ctx, id any) *LedgerControllerGetHoldCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHold", reflect.TypeOf((*LedgerController)(nil).GetHold), ctx, id)
+	return &LedgerControllerGetHoldCall{Call: call}
+}
+
+// LedgerControllerGetHoldCall wrap *gomock.Call
+type LedgerControllerGetHoldCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerGetHoldCall) Return(arg0 *ledger.Hold, arg1 error) *LedgerControllerGetHoldCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerGetHoldCall) Do(f func(context.Context, string) (*ledger.Hold, error)) *LedgerControllerGetHoldCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerGetHoldCall) DoAndReturn(f func(context.Context, string) (*ledger.Hold, error)) *LedgerControllerGetHoldCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetIncomeStatement mocks base method.
+func (m *LedgerController) GetIncomeStatement(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.IncomeStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncomeStatement", ctx, version, query)
+	ret0, _ := ret[0].(*ledger.IncomeStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncomeStatement indicates an expected call of GetIncomeStatement.
+func (mr *LedgerControllerMockRecorder) GetIncomeStatement(ctx, version, query any) *LedgerControllerGetIncomeStatementCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncomeStatement", reflect.TypeOf((*LedgerController)(nil).GetIncomeStatement), ctx, version, query)
+	return &LedgerControllerGetIncomeStatementCall{Call: call}
+}
+
+// LedgerControllerGetIncomeStatementCall wrap *gomock.Call
+type LedgerControllerGetIncomeStatementCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerGetIncomeStatementCall) Return(arg0 *ledger.IncomeStatement, arg1 error) *LedgerControllerGetIncomeStatementCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerGetIncomeStatementCall) Do(f func(context.Context, string, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.IncomeStatement, error)) *LedgerControllerGetIncomeStatementCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerGetIncomeStatementCall) DoAndReturn(f func(context.Context, string, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.IncomeStatement, error)) *LedgerControllerGetIncomeStatementCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetMigrationsInfo mocks base method.
+func (m *LedgerController) GetMigrationsInfo(ctx context.Context) ([]migrations.Info, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetMigrationsInfo", ctx)
 	ret0, _ := ret[0].([]migrations.Info)
@@ -478,6 +1109,123 @@ func (c *LedgerControllerGetMigrationsInfoCall) DoAndReturn(f func(context.Conte
 	return c
 }
 
+// GetRecurrence mocks base method.
+func (m *LedgerController) GetRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurrence", ctx, id)
+	ret0, _ := ret[0].(*ledger.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurrence indicates an expected call of GetRecurrence.
+func (mr *LedgerControllerMockRecorder) GetRecurrence(ctx, id any) *LedgerControllerGetRecurrenceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurrence", reflect.TypeOf((*LedgerController)(nil).GetRecurrence), ctx, id)
+	return &LedgerControllerGetRecurrenceCall{Call: call}
+}
+
+// LedgerControllerGetRecurrenceCall wrap *gomock.Call
+type LedgerControllerGetRecurrenceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerGetRecurrenceCall) Return(arg0 *ledger.Recurrence, arg1 error) *LedgerControllerGetRecurrenceCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerGetRecurrenceCall) Do(f func(context.Context, string) (*ledger.Recurrence, error)) *LedgerControllerGetRecurrenceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerGetRecurrenceCall) DoAndReturn(f func(context.Context, string) (*ledger.Recurrence, error)) *LedgerControllerGetRecurrenceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetRevertJob mocks base method.
+func (m *LedgerController) GetRevertJob(ctx context.Context, id string) (*ledger.RevertJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevertJob", ctx, id)
+	ret0, _ := ret[0].(*ledger.RevertJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevertJob indicates an expected call of GetRevertJob.
+func (mr *LedgerControllerMockRecorder) GetRevertJob(ctx, id any) *LedgerControllerGetRevertJobCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevertJob", reflect.TypeOf((*LedgerController)(nil).GetRevertJob), ctx, id)
+	return &LedgerControllerGetRevertJobCall{Call: call}
+}
+
+// LedgerControllerGetRevertJobCall wrap *gomock.Call
+type LedgerControllerGetRevertJobCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerGetRevertJobCall) Return(arg0 *ledger.RevertJob, arg1 error) *LedgerControllerGetRevertJobCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerGetRevertJobCall) Do(f func(context.Context, string) (*ledger.RevertJob, error)) *LedgerControllerGetRevertJobCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerGetRevertJobCall) DoAndReturn(f func(context.Context, string) (*ledger.RevertJob, error)) *LedgerControllerGetRevertJobCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetScheduledTransaction mocks base method.
+func (m *LedgerController) GetScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledTransaction", ctx, id)
+	ret0, _ := ret[0].(*ledger.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledTransaction indicates an expected call of GetScheduledTransaction.
+func (mr *LedgerControllerMockRecorder) GetScheduledTransaction(ctx, id any) *LedgerControllerGetScheduledTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledTransaction", reflect.TypeOf((*LedgerController)(nil).GetScheduledTransaction), ctx, id)
+	return &LedgerControllerGetScheduledTransactionCall{Call: call}
+}
+
+// LedgerControllerGetScheduledTransactionCall wrap *gomock.Call
+type LedgerControllerGetScheduledTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerGetScheduledTransactionCall) Return(arg0 *ledger.ScheduledTransaction, arg1 error) *LedgerControllerGetScheduledTransactionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerGetScheduledTransactionCall) Do(f func(context.Context, string) (*ledger.ScheduledTransaction, error)) *LedgerControllerGetScheduledTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerGetScheduledTransactionCall) DoAndReturn(f func(context.Context, string) (*ledger.ScheduledTransaction, error)) *LedgerControllerGetScheduledTransactionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // GetSchema mocks base method.
 func (m *LedgerController) GetSchema(ctx context.Context, version string) (*ledger.Schema, error) {
 	m.ctrl.T.Helper()
@@ -551,46 +1299,85 @@ func (c *LedgerControllerGetStatsCall) Do(f func(context.Context) (ledger0.Stats
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *LedgerControllerGetStatsCall) DoAndReturn(f func(context.Context) (ledger0.Stats, error)) *LedgerControllerGetStatsCall {
+func (c *LedgerControllerGetStatsCall) DoAndReturn(f func(context.Context) (ledger0.Stats, error)) *LedgerControllerGetStatsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetTransaction mocks base method.
+func (m *LedgerController) GetTransaction(ctx context.Context, query common.ResourceQuery[any]) (*ledger.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransaction", ctx, query)
+	ret0, _ := ret[0].(*ledger.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransaction indicates an expected call of GetTransaction.
+func (mr *LedgerControllerMockRecorder) GetTransaction(ctx, query any) *LedgerControllerGetTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransaction", reflect.TypeOf((*LedgerController)(nil).GetTransaction), ctx, query)
+	return &LedgerControllerGetTransactionCall{Call: call}
+}
+
+// LedgerControllerGetTransactionCall wrap *gomock.Call
+type LedgerControllerGetTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerGetTransactionCall) Return(arg0 *ledger.Transaction, arg1 error) *LedgerControllerGetTransactionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerGetTransactionCall) Do(f func(context.Context, common.ResourceQuery[any]) (*ledger.Transaction, error)) *LedgerControllerGetTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerGetTransactionCall) DoAndReturn(f func(context.Context, common.ResourceQuery[any]) (*ledger.Transaction, error)) *LedgerControllerGetTransactionCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// GetTransaction mocks base method.
-func (m *LedgerController) GetTransaction(ctx context.Context, query common.ResourceQuery[any]) (*ledger.Transaction, error) {
+// GetTrialBalance mocks base method.
+func (m *LedgerController) GetTrialBalance(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.TrialBalance, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransaction", ctx, query)
-	ret0, _ := ret[0].(*ledger.Transaction)
+	ret := m.ctrl.Call(m, "GetTrialBalance", ctx, version, query)
+	ret0, _ := ret[0].(*ledger.TrialBalance)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTransaction indicates an expected call of GetTransaction.
-func (mr *LedgerControllerMockRecorder) GetTransaction(ctx, query any) *LedgerControllerGetTransactionCall {
+// GetTrialBalance indicates an expected call of GetTrialBalance.
+func (mr *LedgerControllerMockRecorder) GetTrialBalance(ctx, version, query any) *LedgerControllerGetTrialBalanceCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransaction", reflect.TypeOf((*LedgerController)(nil).GetTransaction), ctx, query)
-	return &LedgerControllerGetTransactionCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrialBalance", reflect.TypeOf((*LedgerController)(nil).GetTrialBalance), ctx, version, query)
+	return &LedgerControllerGetTrialBalanceCall{Call: call}
 }
 
-// LedgerControllerGetTransactionCall wrap *gomock.Call
-type LedgerControllerGetTransactionCall struct {
+// LedgerControllerGetTrialBalanceCall wrap *gomock.Call
+type LedgerControllerGetTrialBalanceCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *LedgerControllerGetTransactionCall) Return(arg0 *ledger.Transaction, arg1 error) *LedgerControllerGetTransactionCall {
+func (c *LedgerControllerGetTrialBalanceCall) Return(arg0 *ledger.TrialBalance, arg1 error) *LedgerControllerGetTrialBalanceCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *LedgerControllerGetTransactionCall) Do(f func(context.Context, common.ResourceQuery[any]) (*ledger.Transaction, error)) *LedgerControllerGetTransactionCall {
+func (c *LedgerControllerGetTrialBalanceCall) Do(f func(context.Context, string, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.TrialBalance, error)) *LedgerControllerGetTrialBalanceCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *LedgerControllerGetTransactionCall) DoAndReturn(f func(context.Context, common.ResourceQuery[any]) (*ledger.Transaction, error)) *LedgerControllerGetTransactionCall {
+func (c *LedgerControllerGetTrialBalanceCall) DoAndReturn(f func(context.Context, string, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.TrialBalance, error)) *LedgerControllerGetTrialBalanceCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -868,6 +1655,45 @@ func (c *LedgerControllerListLogsCall) DoAndReturn(f func(context.Context, commo
 	return c
 }
 
+// ListRecurrences mocks base method.
+func (m *LedgerController) ListRecurrences(ctx context.Context, query common.PaginatedQuery[any]) (*paginate.Cursor[ledger.Recurrence], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecurrences", ctx, query)
+	ret0, _ := ret[0].(*paginate.Cursor[ledger.Recurrence])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecurrences indicates an expected call of ListRecurrences.
+func (mr *LedgerControllerMockRecorder) ListRecurrences(ctx, query any) *LedgerControllerListRecurrencesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecurrences", reflect.TypeOf((*LedgerController)(nil).ListRecurrences), ctx, query)
+	return &LedgerControllerListRecurrencesCall{Call: call}
+}
+
+// LedgerControllerListRecurrencesCall wrap *gomock.Call
+type LedgerControllerListRecurrencesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerListRecurrencesCall) Return(arg0 *paginate.Cursor[ledger.Recurrence], arg1 error) *LedgerControllerListRecurrencesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerListRecurrencesCall) Do(f func(context.Context, common.PaginatedQuery[any]) (*paginate.Cursor[ledger.Recurrence], error)) *LedgerControllerListRecurrencesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerListRecurrencesCall) DoAndReturn(f func(context.Context, common.PaginatedQuery[any]) (*paginate.Cursor[ledger.Recurrence], error)) *LedgerControllerListRecurrencesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // ListSchemas mocks base method.
 func (m *LedgerController) ListSchemas(ctx context.Context, query common.PaginatedQuery[any]) (*paginate.Cursor[ledger.Schema], error) {
 	m.ctrl.T.Helper()
@@ -987,6 +1813,166 @@ func (c *LedgerControllerLockLedgerCall) DoAndReturn(f func(context.Context) (le
 	return c
 }
 
+// PartiallyRevertTransaction mocks base method.
+func (m *LedgerController) PartiallyRevertTransaction(ctx context.Context, parameters ledger0.Parameters[ledger0.PartiallyRevertTransaction]) (*ledger.Log, *ledger.PartiallyRevertedTransaction, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PartiallyRevertTransaction", ctx, parameters)
+	ret0, _ := ret[0].(*ledger.Log)
+	ret1, _ := ret[1].(*ledger.PartiallyRevertedTransaction)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// PartiallyRevertTransaction indicates an expected call of PartiallyRevertTransaction.
+func (mr *LedgerControllerMockRecorder) PartiallyRevertTransaction(ctx, parameters any) *LedgerControllerPartiallyRevertTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PartiallyRevertTransaction", reflect.TypeOf((*LedgerController)(nil).PartiallyRevertTransaction), ctx, parameters)
+	return &LedgerControllerPartiallyRevertTransactionCall{Call: call}
+}
+
+// LedgerControllerPartiallyRevertTransactionCall wrap *gomock.Call
+type LedgerControllerPartiallyRevertTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerPartiallyRevertTransactionCall) Return(arg0 *ledger.Log, arg1 *ledger.PartiallyRevertedTransaction, arg2 bool, arg3 error) *LedgerControllerPartiallyRevertTransactionCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2, arg3)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerPartiallyRevertTransactionCall) Do(f func(context.Context, ledger0.Parameters[ledger0.PartiallyRevertTransaction]) (*ledger.Log, *ledger.PartiallyRevertedTransaction, bool, error)) *LedgerControllerPartiallyRevertTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerPartiallyRevertTransactionCall) DoAndReturn(f func(context.Context, ledger0.Parameters[ledger0.PartiallyRevertTransaction]) (*ledger.Log, *ledger.PartiallyRevertedTransaction, bool, error)) *LedgerControllerPartiallyRevertTransactionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PauseRecurrence mocks base method.
+func (m *LedgerController) PauseRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseRecurrence", ctx, id)
+	ret0, _ := ret[0].(*ledger.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PauseRecurrence indicates an expected call of PauseRecurrence.
+func (mr *LedgerControllerMockRecorder) PauseRecurrence(ctx, id any) *LedgerControllerPauseRecurrenceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseRecurrence", reflect.TypeOf((*LedgerController)(nil).PauseRecurrence), ctx, id)
+	return &LedgerControllerPauseRecurrenceCall{Call: call}
+}
+
+// LedgerControllerPauseRecurrenceCall wrap *gomock.Call
+type LedgerControllerPauseRecurrenceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerPauseRecurrenceCall) Return(arg0 *ledger.Recurrence, arg1 error) *LedgerControllerPauseRecurrenceCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerPauseRecurrenceCall) Do(f func(context.Context, string) (*ledger.Recurrence, error)) *LedgerControllerPauseRecurrenceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerPauseRecurrenceCall) DoAndReturn(f func(context.Context, string) (*ledger.Recurrence, error)) *LedgerControllerPauseRecurrenceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ReopenPeriod mocks base method.
+func (m *LedgerController) ReopenPeriod(ctx context.Context, parameters ledger0.Parameters[ledger0.ReopenPeriod]) (*ledger.Log, *ledger.ReopenedPeriod, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenPeriod", ctx, parameters)
+	ret0, _ := ret[0].(*ledger.Log)
+	ret1, _ := ret[1].(*ledger.ReopenedPeriod)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// ReopenPeriod indicates an expected call of ReopenPeriod.
+func (mr *LedgerControllerMockRecorder) ReopenPeriod(ctx, parameters any) *LedgerControllerReopenPeriodCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenPeriod", reflect.TypeOf((*LedgerController)(nil).ReopenPeriod), ctx, parameters)
+	return &LedgerControllerReopenPeriodCall{Call: call}
+}
+
+// LedgerControllerReopenPeriodCall wrap *gomock.Call
+type LedgerControllerReopenPeriodCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerReopenPeriodCall) Return(arg0 *ledger.Log, arg1 *ledger.ReopenedPeriod, arg2 bool, arg3 error) *LedgerControllerReopenPeriodCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2, arg3)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerReopenPeriodCall) Do(f func(context.Context, ledger0.Parameters[ledger0.ReopenPeriod]) (*ledger.Log, *ledger.ReopenedPeriod, bool, error)) *LedgerControllerReopenPeriodCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerReopenPeriodCall) DoAndReturn(f func(context.Context, ledger0.Parameters[ledger0.ReopenPeriod]) (*ledger.Log, *ledger.ReopenedPeriod, bool, error)) *LedgerControllerReopenPeriodCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ResumeRecurrence mocks base method.
+func (m *LedgerController) ResumeRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeRecurrence", ctx, id)
+	ret0, _ := ret[0].(*ledger.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeRecurrence indicates an expected call of ResumeRecurrence.
+func (mr *LedgerControllerMockRecorder) ResumeRecurrence(ctx, id any) *LedgerControllerResumeRecurrenceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeRecurrence", reflect.TypeOf((*LedgerController)(nil).ResumeRecurrence), ctx, id)
+	return &LedgerControllerResumeRecurrenceCall{Call: call}
+}
+
+// LedgerControllerResumeRecurrenceCall wrap *gomock.Call
+type LedgerControllerResumeRecurrenceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerResumeRecurrenceCall) Return(arg0 *ledger.Recurrence, arg1 error) *LedgerControllerResumeRecurrenceCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerResumeRecurrenceCall) Do(f func(context.Context, string) (*ledger.Recurrence, error)) *LedgerControllerResumeRecurrenceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerResumeRecurrenceCall) DoAndReturn(f func(context.Context, string) (*ledger.Recurrence, error)) *LedgerControllerResumeRecurrenceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // RevertTransaction mocks base method.
 func (m *LedgerController) RevertTransaction(ctx context.Context, parameters ledger0.Parameters[ledger0.RevertTransaction]) (*ledger.Log, *ledger.RevertedTransaction, bool, error) {
 	m.ctrl.T.Helper()
@@ -1106,6 +2092,86 @@ func (c *LedgerControllerRunQueryCall) DoAndReturn(f func(context.Context, strin
 	return c
 }
 
+// RunRecurrence mocks base method.
+func (m *LedgerController) RunRecurrence(ctx context.Context, id string, batchSize int) (*ledger.Recurrence, []ledger.CreatedTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunRecurrence", ctx, id, batchSize)
+	ret0, _ := ret[0].(*ledger.Recurrence)
+	ret1, _ := ret[1].([]ledger.CreatedTransaction)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RunRecurrence indicates an expected call of RunRecurrence.
+func (mr *LedgerControllerMockRecorder) RunRecurrence(ctx, id, batchSize any) *LedgerControllerRunRecurrenceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunRecurrence", reflect.TypeOf((*LedgerController)(nil).RunRecurrence), ctx, id, batchSize)
+	return &LedgerControllerRunRecurrenceCall{Call: call}
+}
+
+// LedgerControllerRunRecurrenceCall wrap *gomock.Call
+type LedgerControllerRunRecurrenceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerRunRecurrenceCall) Return(arg0 *ledger.Recurrence, arg1 []ledger.CreatedTransaction, arg2 error) *LedgerControllerRunRecurrenceCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerRunRecurrenceCall) Do(f func(context.Context, string, int) (*ledger.Recurrence, []ledger.CreatedTransaction, error)) *LedgerControllerRunRecurrenceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerRunRecurrenceCall) DoAndReturn(f func(context.Context, string, int) (*ledger.Recurrence, []ledger.CreatedTransaction, error)) *LedgerControllerRunRecurrenceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RunRevertJob mocks base method.
+func (m *LedgerController) RunRevertJob(ctx context.Context, id string, batchSize int) (*ledger.RevertJob, []ledger.RevertedTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunRevertJob", ctx, id, batchSize)
+	ret0, _ := ret[0].(*ledger.RevertJob)
+	ret1, _ := ret[1].([]ledger.RevertedTransaction)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RunRevertJob indicates an expected call of RunRevertJob.
+func (mr *LedgerControllerMockRecorder) RunRevertJob(ctx, id, batchSize any) *LedgerControllerRunRevertJobCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunRevertJob", reflect.TypeOf((*LedgerController)(nil).RunRevertJob), ctx, id, batchSize)
+	return &LedgerControllerRunRevertJobCall{Call: call}
+}
+
+// LedgerControllerRunRevertJobCall wrap *gomock.Call
+type LedgerControllerRunRevertJobCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerRunRevertJobCall) Return(arg0 *ledger.RevertJob, arg1 []ledger.RevertedTransaction, arg2 error) *LedgerControllerRunRevertJobCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerRunRevertJobCall) Do(f func(context.Context, string, int) (*ledger.RevertJob, []ledger.RevertedTransaction, error)) *LedgerControllerRunRevertJobCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerRunRevertJobCall) DoAndReturn(f func(context.Context, string, int) (*ledger.RevertJob, []ledger.RevertedTransaction, error)) *LedgerControllerRunRevertJobCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // SaveAccountMetadata mocks base method.
 func (m *LedgerController) SaveAccountMetadata(ctx context.Context, parameters ledger0.Parameters[ledger0.SaveAccountMetadata]) (*ledger.Log, bool, error) {
 	m.ctrl.T.Helper()
@@ -1185,3 +2251,83 @@ func (c *LedgerControllerSaveTransactionMetadataCall) DoAndReturn(f func(context
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// ScheduleTransaction mocks base method.
+func (m *LedgerController) ScheduleTransaction(ctx context.Context, input ledger0.ScheduleTransaction) (*ledger.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleTransaction", ctx, input)
+	ret0, _ := ret[0].(*ledger.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleTransaction indicates an expected call of ScheduleTransaction.
+func (mr *LedgerControllerMockRecorder) ScheduleTransaction(ctx, input any) *LedgerControllerScheduleTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleTransaction", reflect.TypeOf((*LedgerController)(nil).ScheduleTransaction), ctx, input)
+	return &LedgerControllerScheduleTransactionCall{Call: call}
+}
+
+// LedgerControllerScheduleTransactionCall wrap *gomock.Call
+type LedgerControllerScheduleTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerScheduleTransactionCall) Return(arg0 *ledger.ScheduledTransaction, arg1 error) *LedgerControllerScheduleTransactionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerScheduleTransactionCall) Do(f func(context.Context, ledger0.ScheduleTransaction) (*ledger.ScheduledTransaction, error)) *LedgerControllerScheduleTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerScheduleTransactionCall) DoAndReturn(f func(context.Context, ledger0.ScheduleTransaction) (*ledger.ScheduledTransaction, error)) *LedgerControllerScheduleTransactionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// VoidHold mocks base method.
+func (m *LedgerController) VoidHold(ctx context.Context, parameters ledger0.Parameters[ledger0.VoidHold]) (*ledger.Log, *ledger.VoidedHold, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidHold", ctx, parameters)
+	ret0, _ := ret[0].(*ledger.Log)
+	ret1, _ := ret[1].(*ledger.VoidedHold)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// VoidHold indicates an expected call of VoidHold.
+func (mr *LedgerControllerMockRecorder) VoidHold(ctx, parameters any) *LedgerControllerVoidHoldCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidHold", reflect.TypeOf((*LedgerController)(nil).VoidHold), ctx, parameters)
+	return &LedgerControllerVoidHoldCall{Call: call}
+}
+
+// LedgerControllerVoidHoldCall wrap *gomock.Call
+type LedgerControllerVoidHoldCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *LedgerControllerVoidHoldCall) Return(arg0 *ledger.Log, arg1 *ledger.VoidedHold, arg2 bool, arg3 error) *LedgerControllerVoidHoldCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2, arg3)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *LedgerControllerVoidHoldCall) Do(f func(context.Context, ledger0.Parameters[ledger0.VoidHold]) (*ledger.Log, *ledger.VoidedHold, bool, error)) *LedgerControllerVoidHoldCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *LedgerControllerVoidHoldCall) DoAndReturn(f func(context.Context, ledger0.Parameters[ledger0.VoidHold]) (*ledger.Log, *ledger.VoidedHold, bool, error)) *LedgerControllerVoidHoldCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -1335,3 +1335,41 @@ func (c *SystemControllerUpdateLedgerMetadataCall) DoAndReturn(f func(context.Co
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// UpdateLedgerSchemaSettings mocks base method.
+func (m *SystemController) UpdateLedgerSchemaSettings(ctx context.Context, name string, settings ledger.SchemaSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLedgerSchemaSettings", ctx, name, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLedgerSchemaSettings indicates an expected call of UpdateLedgerSchemaSettings.
+func (mr *SystemControllerMockRecorder) UpdateLedgerSchemaSettings(ctx, name, settings any) *SystemControllerUpdateLedgerSchemaSettingsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLedgerSchemaSettings", reflect.TypeOf((*SystemController)(nil).UpdateLedgerSchemaSettings), ctx, name, settings)
+	return &SystemControllerUpdateLedgerSchemaSettingsCall{Call: call}
+}
+
+// SystemControllerUpdateLedgerSchemaSettingsCall wrap *gomock.Call
+type SystemControllerUpdateLedgerSchemaSettingsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *SystemControllerUpdateLedgerSchemaSettingsCall) Return(arg0 error) *SystemControllerUpdateLedgerSchemaSettingsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *SystemControllerUpdateLedgerSchemaSettingsCall) Do(f func(context.Context, string, ledger.SchemaSettings) error) *SystemControllerUpdateLedgerSchemaSettingsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *SystemControllerUpdateLedgerSchemaSettingsCall) DoAndReturn(f func(context.Context, string, ledger.SchemaSettings) error) *SystemControllerUpdateLedgerSchemaSettingsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
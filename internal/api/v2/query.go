@@ -1,9 +1,14 @@
 package v2
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	stdtime "time"
 
 	"github.com/formancehq/go-libs/v5/pkg/transport/api"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
 
 	"github.com/formancehq/ledger/internal/controller/ledger"
 )
@@ -16,3 +21,53 @@ func getCommandParameters[INPUT any](r *http.Request, input INPUT) ledger.Parame
 		Input:          input,
 	}
 }
+
+// The ETag of accounts and transactions is their version, the date of their last update.
+// It can be sent back in a If-Match header to make a write fail if the entity was updated in between.
+
+func writeETag(w http.ResponseWriter, updatedAt time.Time) {
+	if updatedAt.IsZero() {
+		return
+	}
+	w.Header().Set("ETag", fmt.Sprintf(`"%d"`, updatedAt.UnixMicro()))
+}
+
+// getIfMatch returns the version of the If-Match header, or nil if the header is missing or matches any version
+func getIfMatch(r *http.Request) (*time.Time, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	value, ok := strings.CutPrefix(header, `"`)
+	if ok {
+		value, ok = strings.CutSuffix(value, `"`)
+	}
+	micros, err := strconv.ParseInt(value, 10, 64)
+	if !ok || err != nil {
+		return nil, fmt.Errorf("invalid If-Match header %s, expected a single ETag returned by the api", header)
+	}
+
+	ret := time.New(stdtime.UnixMicro(micros))
+	return &ret, nil
+}
+
+func getAccountPreconditions(r *http.Request, address string) (ledger.Preconditions, error) {
+	version, err := getIfMatch(r)
+	if err != nil || version == nil {
+		return ledger.Preconditions{}, err
+	}
+	return ledger.Preconditions{
+		AccountVersions: map[string]time.Time{address: *version},
+	}, nil
+}
+
+func getTransactionPreconditions(r *http.Request, id uint64) (ledger.Preconditions, error) {
+	version, err := getIfMatch(r)
+	if err != nil || version == nil {
+		return ledger.Preconditions{}, err
+	}
+	return ledger.Preconditions{
+		TransactionVersions: map[uint64]time.Time{id: *version},
+	}, nil
+}
@@ -73,6 +73,7 @@ func NewRouter(
 			router.Get("/", readLedger(systemController))
 			router.Put("/metadata", updateLedgerMetadata(systemController))
 			router.Delete("/metadata/{key}", deleteLedgerMetadata(systemController))
+			router.Put("/schema-settings", updateLedgerSchemaSettings(systemController))
 
 			router.With(common.LedgerMiddleware(systemController, func(r *http.Request) string {
 				return chi.URLParam(r, "ledger")
@@ -83,8 +84,14 @@ func NewRouter(
 				))
 				router.Get("/_info", getLedgerInfo)
 				router.Get("/stats", readStats)
-				router.Post("/schemas/{version}", insertSchema)
+				router.Post("/schemas/{version}", insertSchema(routerOptions.paginationConfig))
 				router.Get("/schemas/{version}", readSchema)
+				router.Get("/schemas/{version}/jsonschema", readSchemaJSONSchemas)
+				router.Get("/schemas/{version}/chart/tree", readChartTree)
+				router.Get("/schemas/{version}/chart/trial-balance", readTrialBalance)
+				router.Get("/schemas/{version}/chart/balance-sheet", readBalanceSheet)
+				router.Get("/schemas/{version}/chart/income-statement", readIncomeStatement)
+				router.Get("/schemas/{from}/diff/{to}", diffSchemas(routerOptions.paginationConfig))
 				router.Get("/schemas", listSchemas(routerOptions.paginationConfig))
 
 				if routerOptions.exporters {
@@ -111,6 +118,8 @@ func NewRouter(
 					router.Get("/", listAccounts(routerOptions.paginationConfig))
 					router.Head("/", countAccounts)
 					router.Get("/{address}", readAccount)
+					router.Get("/{address}/history", readAccountBalanceHistory)
+					router.Get("/{address}/statement", readAccountStatement(routerOptions.paginationConfig))
 					router.Post("/{address}/metadata", addAccountMetadata)
 					router.Delete("/{address}/metadata/{key}", deleteAccountMetadata)
 				})
@@ -121,14 +130,49 @@ func NewRouter(
 					router.Post("/", createTransaction)
 					router.Get("/{id}", readTransaction)
 					router.Post("/{id}/revert", revertTransaction)
+					router.Post("/{id}/partial-revert", partiallyRevertTransaction)
 					router.Post("/{id}/metadata", addTransactionMetadata)
 					router.Delete("/{id}/metadata/{key}", deleteTransactionMetadata)
 				})
 
 				router.Get("/aggregate/balances", readBalancesAggregated)
+				router.Get("/aggregate/balances/history", readBalancesAggregatedHistory)
 
 				router.Get("/volumes", readVolumes(routerOptions.paginationConfig))
 
+				router.Route("/holds", func(router chi.Router) {
+					router.Post("/", createHold)
+					router.Get("/{id}", readHold)
+					router.Post("/{id}/capture", captureHold)
+					router.Post("/{id}/void", voidHold)
+				})
+
+				router.Route("/revert-jobs", func(router chi.Router) {
+					router.Post("/", createRevertJob)
+					router.Get("/{id}", readRevertJob)
+				})
+
+				router.Route("/scheduled-transactions", func(router chi.Router) {
+					router.Post("/", scheduleTransaction)
+					router.Get("/{id}", readScheduledTransaction)
+					router.Post("/{id}/cancel", cancelScheduledTransaction)
+				})
+
+				router.Route("/recurrences", func(router chi.Router) {
+					router.Get("/", listRecurrences(routerOptions.paginationConfig))
+					router.Post("/", createRecurrence)
+					router.Get("/{id}", readRecurrence)
+					router.Delete("/{id}", deleteRecurrence)
+					router.Post("/{id}/pause", pauseRecurrence)
+					router.Post("/{id}/resume", resumeRecurrence)
+				})
+
+				router.Route("/periods", func(router chi.Router) {
+					router.Get("/", readClosedPeriod)
+					router.Post("/close", closePeriod)
+					router.Post("/reopen", reopenPeriod)
+				})
+
 				router.Post("/queries/{id}/run", runQuery(routerOptions.paginationConfig))
 			})
 		})
@@ -6,9 +6,12 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
 	. "github.com/formancehq/go-libs/v5/pkg/types/collections"
+	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
 
 	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/queries"
 )
 
 const HeaderBigIntAsString = "Formance-Bigint-As-String"
@@ -70,6 +73,8 @@ func (tx transaction) MarshalJSON() ([]byte, error) {
 		Reverted                   bool              `json:"reverted"`
 		PreCommitVolumes           postCommitVolumes `json:"preCommitVolumes,omitempty"`
 		PreCommitEffectiveVolumes  postCommitVolumes `json:"preCommitEffectiveVolumes,omitempty"`
+		RevertedAmounts            []string          `json:"revertedAmounts,omitempty"`
+		RevertibleAmounts          []string          `json:"revertibleAmounts,omitempty"`
 	}{
 		Aux: Aux(tx),
 		Postings: Map(tx.Postings, func(p ledger.Posting) posting {
@@ -84,6 +89,22 @@ func (tx transaction) MarshalJSON() ([]byte, error) {
 		PreCommitEffectiveVolumes: postCommitVolumes(
 			tx.PostCommitEffectiveVolumes.SubtractPostings(tx.Postings),
 		),
+		RevertedAmounts: bigIntsAsStrings(tx.RevertedAmounts),
+		RevertibleAmounts: func() []string {
+			if tx.RevertedAmounts == nil {
+				return nil
+			}
+			return bigIntsAsStrings(ledger.Transaction(tx).RevertibleAmounts())
+		}(),
+	})
+}
+
+func bigIntsAsStrings(values []*big.Int) []string {
+	if values == nil {
+		return nil
+	}
+	return Map(values, func(v *big.Int) string {
+		return v.String()
 	})
 }
 
@@ -101,17 +122,26 @@ func (v volumesWithBalanceByAssetByAccount) MarshalJSON() ([]byte, error) {
 	type Aux volumesWithBalanceByAssetByAccount
 	return json.Marshal(struct {
 		Aux
-		Input   string `json:"input"`
-		Output  string `json:"output"`
-		Balance string `json:"balance"`
+		Input   string  `json:"input"`
+		Output  string  `json:"output"`
+		Balance string  `json:"balance"`
+		Pending *string `json:"pending,omitempty"`
 	}{
 		Aux:     Aux(v),
 		Input:   v.Input.String(),
 		Output:  v.Output.String(),
 		Balance: v.Balance.String(),
+		Pending: pendingAsString(v.Pending),
 	})
 }
 
+func pendingAsString(pending *big.Int) *string {
+	if pending == nil {
+		return nil
+	}
+	return pointer.For(pending.String())
+}
+
 func renderVolumesWithBalances(r *http.Request, volumes ledger.VolumesWithBalanceByAssetByAccount) any {
 	if !needBigIntAsString(r) {
 		return volumes
@@ -163,6 +193,203 @@ func renderBalancesByAssets(r *http.Request, v ledger.BalancesByAssets) any {
 	return balancesByAssets(v)
 }
 
+type chartTreeNode ledger.ChartTreeNode
+
+func (n chartTreeNode) MarshalJSON() ([]byte, error) {
+	type Aux ledger.ChartTreeNode
+	return json.Marshal(struct {
+		Aux
+		Balances balancesByAssets `json:"balances"`
+		Children []chartTreeNode  `json:"children,omitempty"`
+	}{
+		Aux:      Aux(n),
+		Balances: balancesByAssets(n.Balances),
+		Children: Map(n.Children, func(child ledger.ChartTreeNode) chartTreeNode {
+			return chartTreeNode(child)
+		}),
+	})
+}
+
+func renderChartTree(r *http.Request, nodes []ledger.ChartTreeNode) any {
+	if !needBigIntAsString(r) {
+		return nodes
+	}
+
+	return Map(nodes, func(node ledger.ChartTreeNode) chartTreeNode {
+		return chartTreeNode(node)
+	})
+}
+
+type balanceHistoryPoint ledger.BalanceHistoryPoint
+
+func (p balanceHistoryPoint) MarshalJSON() ([]byte, error) {
+	type Aux ledger.BalanceHistoryPoint
+	return json.Marshal(struct {
+		Aux
+		Balances balancesByAssets `json:"balances"`
+	}{
+		Aux:      Aux(p),
+		Balances: balancesByAssets(p.Balances),
+	})
+}
+
+func renderBalanceHistory(r *http.Request, points []ledger.BalanceHistoryPoint) any {
+	if !needBigIntAsString(r) {
+		return points
+	}
+
+	return Map(points, func(point ledger.BalanceHistoryPoint) balanceHistoryPoint {
+		return balanceHistoryPoint(point)
+	})
+}
+
+type accountStatementLine ledger.AccountStatementLine
+
+func (l accountStatementLine) MarshalJSON() ([]byte, error) {
+	type Aux ledger.AccountStatementLine
+	return json.Marshal(struct {
+		Aux
+		Amount  string `json:"amount"`
+		Balance string `json:"balance"`
+	}{
+		Aux:     Aux(l),
+		Amount:  l.Amount.String(),
+		Balance: l.Balance.String(),
+	})
+}
+
+type accountStatement ledger.AccountStatement
+
+func (s accountStatement) MarshalJSON() ([]byte, error) {
+	type Aux ledger.AccountStatement
+	return json.Marshal(struct {
+		Aux
+		OpeningBalances balancesByAssets                       `json:"openingBalances"`
+		Lines           *paginate.Cursor[accountStatementLine] `json:"lines"`
+		ClosingBalances balancesByAssets                       `json:"closingBalances"`
+	}{
+		Aux:             Aux(s),
+		OpeningBalances: balancesByAssets(s.OpeningBalances),
+		Lines: paginate.MapCursor(s.Lines, func(line ledger.AccountStatementLine) accountStatementLine {
+			return accountStatementLine(line)
+		}),
+		ClosingBalances: balancesByAssets(s.ClosingBalances),
+	})
+}
+
+func renderAccountStatement(r *http.Request, statement ledger.AccountStatement) any {
+	if !needBigIntAsString(r) {
+		return statement
+	}
+
+	return accountStatement(statement)
+}
+
+type trialBalanceTotalsByAssets ledger.TrialBalanceTotalsByAssets
+
+func (t trialBalanceTotalsByAssets) MarshalJSON() ([]byte, error) {
+	type totals struct {
+		Debit  string `json:"debit"`
+		Credit string `json:"credit"`
+	}
+	return json.Marshal(ConvertMap(t, func(v ledger.TrialBalanceTotals) totals {
+		return totals{
+			Debit:  v.Debit.String(),
+			Credit: v.Credit.String(),
+		}
+	}))
+}
+
+type trialBalanceNode ledger.TrialBalanceNode
+
+func (n trialBalanceNode) MarshalJSON() ([]byte, error) {
+	type Aux ledger.TrialBalanceNode
+	return json.Marshal(struct {
+		Aux
+		Totals   trialBalanceTotalsByAssets `json:"totals"`
+		Children []trialBalanceNode         `json:"children,omitempty"`
+	}{
+		Aux:    Aux(n),
+		Totals: trialBalanceTotalsByAssets(n.Totals),
+		Children: Map(n.Children, func(child ledger.TrialBalanceNode) trialBalanceNode {
+			return trialBalanceNode(child)
+		}),
+	})
+}
+
+func renderTrialBalance(r *http.Request, trialBalance ledger.TrialBalance) any {
+	if !needBigIntAsString(r) {
+		return trialBalance
+	}
+
+	return struct {
+		Nodes  []trialBalanceNode         `json:"nodes"`
+		Totals trialBalanceTotalsByAssets `json:"totals"`
+	}{
+		Nodes: Map(trialBalance.Nodes, func(node ledger.TrialBalanceNode) trialBalanceNode {
+			return trialBalanceNode(node)
+		}),
+		Totals: trialBalanceTotalsByAssets(trialBalance.Totals),
+	}
+}
+
+type financialStatementSection ledger.FinancialStatementSection
+
+func (s financialStatementSection) MarshalJSON() ([]byte, error) {
+	type line struct {
+		Path    string           `json:"path"`
+		Amounts balancesByAssets `json:"amounts"`
+	}
+	return json.Marshal(struct {
+		Class ledger.AccountingClass `json:"class"`
+		Lines []line                 `json:"lines"`
+		Total balancesByAssets       `json:"total"`
+	}{
+		Class: s.Class,
+		Lines: Map(s.Lines, func(l ledger.FinancialStatementLine) line {
+			return line{
+				Path:    l.Path,
+				Amounts: balancesByAssets(l.Amounts),
+			}
+		}),
+		Total: balancesByAssets(s.Total),
+	})
+}
+
+func renderBalanceSheet(r *http.Request, balanceSheet ledger.BalanceSheet) any {
+	if !needBigIntAsString(r) {
+		return balanceSheet
+	}
+
+	return struct {
+		Assets      financialStatementSection `json:"assets"`
+		Liabilities financialStatementSection `json:"liabilities"`
+		Equity      financialStatementSection `json:"equity"`
+		NetIncome   balancesByAssets          `json:"netIncome"`
+	}{
+		Assets:      financialStatementSection(balanceSheet.Assets),
+		Liabilities: financialStatementSection(balanceSheet.Liabilities),
+		Equity:      financialStatementSection(balanceSheet.Equity),
+		NetIncome:   balancesByAssets(balanceSheet.NetIncome),
+	}
+}
+
+func renderIncomeStatement(r *http.Request, incomeStatement ledger.IncomeStatement) any {
+	if !needBigIntAsString(r) {
+		return incomeStatement
+	}
+
+	return struct {
+		Income    financialStatementSection `json:"income"`
+		Expenses  financialStatementSection `json:"expenses"`
+		NetIncome balancesByAssets          `json:"netIncome"`
+	}{
+		Income:    financialStatementSection(incomeStatement.Income),
+		Expenses:  financialStatementSection(incomeStatement.Expenses),
+		NetIncome: balancesByAssets(incomeStatement.NetIncome),
+	}
+}
+
 type createdTransaction ledger.CreatedTransaction
 
 func (tx createdTransaction) MarshalJSON() ([]byte, error) {
@@ -191,6 +418,57 @@ func (tx revertedTransaction) MarshalJSON() ([]byte, error) {
 	})
 }
 
+type hold ledger.Hold
+
+func (h hold) MarshalJSON() ([]byte, error) {
+	type Aux ledger.Hold
+	return json.Marshal(struct {
+		Aux
+		Amount string `json:"amount"`
+	}{
+		Aux:    Aux(h),
+		Amount: h.Amount.String(),
+	})
+}
+
+func renderHold(r *http.Request, h ledger.Hold) any {
+	if !needBigIntAsString(r) {
+		return h
+	}
+
+	return hold(h)
+}
+
+type scheduledTransaction ledger.ScheduledTransaction
+
+func (s scheduledTransaction) MarshalJSON() ([]byte, error) {
+	type AuxData ledger.ScheduledTransactionData
+	type Aux ledger.ScheduledTransaction
+	return json.Marshal(struct {
+		Aux
+		Transaction any `json:"transaction"`
+	}{
+		Aux: Aux(s),
+		Transaction: struct {
+			AuxData
+			Postings postings `json:"postings,omitempty"`
+		}{
+			AuxData: AuxData(s.Transaction),
+			Postings: Map(s.Transaction.Postings, func(p ledger.Posting) posting {
+				return posting(p)
+			}),
+		},
+	})
+}
+
+func renderScheduledTransaction(r *http.Request, s ledger.ScheduledTransaction) any {
+	if !needBigIntAsString(r) {
+		return s
+	}
+
+	return scheduledTransaction(s)
+}
+
 type log ledger.Log
 
 func (l log) MarshalJSON() ([]byte, error) {
@@ -206,6 +484,41 @@ func (l log) MarshalJSON() ([]byte, error) {
 				return createdTransaction(l.Data.(ledger.CreatedTransaction))
 			case ledger.RevertedTransactionLogType:
 				return revertedTransaction(l.Data.(ledger.RevertedTransaction))
+			case ledger.PartiallyRevertedTransactionLogType:
+				return revertedTransaction(l.Data.(ledger.PartiallyRevertedTransaction))
+			case ledger.CreatedHoldLogType:
+				return struct {
+					Hold hold `json:"hold"`
+				}{
+					Hold: hold(l.Data.(ledger.CreatedHold).Hold),
+				}
+			case ledger.CapturedHoldLogType:
+				payload := l.Data.(ledger.CapturedHold)
+				return struct {
+					Hold        hold        `json:"hold"`
+					Transaction transaction `json:"transaction"`
+				}{
+					Hold:        hold(payload.Hold),
+					Transaction: transaction(payload.Transaction),
+				}
+			case ledger.VoidedHoldLogType:
+				return struct {
+					Hold hold `json:"hold"`
+				}{
+					Hold: hold(l.Data.(ledger.VoidedHold).Hold),
+				}
+			case ledger.CreatedScheduledTransactionLogType:
+				return struct {
+					ScheduledTransaction scheduledTransaction `json:"scheduledTransaction"`
+				}{
+					ScheduledTransaction: scheduledTransaction(l.Data.(ledger.CreatedScheduledTransaction).ScheduledTransaction),
+				}
+			case ledger.UpdatedScheduledTransactionLogType:
+				return struct {
+					ScheduledTransaction scheduledTransaction `json:"scheduledTransaction"`
+				}{
+					ScheduledTransaction: scheduledTransaction(l.Data.(ledger.UpdatedScheduledTransaction).ScheduledTransaction),
+				}
 			default:
 				return l.Data
 			}
@@ -221,7 +534,40 @@ func renderLog(r *http.Request, v ledger.Log) any {
 	return log(v)
 }
 
+// renderProjection keeps only the projected fields of a rendered item
+func renderProjection(item any, fields []string) any {
+	if len(fields) == 0 {
+		return item
+	}
+	return queries.Projection{Item: item, Fields: fields}
+}
+
 func needBigIntAsString(r *http.Request) bool {
 	v := strings.ToLower(r.Header.Get(HeaderBigIntAsString))
 	return v == "true" || v == "yes" || v == "y" || v == "1"
 }
+
+type aggregationGroup queries.AggregationGroup
+
+func (g aggregationGroup) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Keys     map[string]*string `json:"keys"`
+		Measures map[string]*string `json:"measures"`
+	}{
+		Keys: g.Keys,
+		Measures: ConvertMap(g.Measures, func(v *big.Int) *string {
+			if v == nil {
+				return nil
+			}
+			return pointer.For(v.String())
+		}),
+	})
+}
+
+func renderAggregationGroup(r *http.Request, v queries.AggregationGroup) any {
+	if !needBigIntAsString(r) {
+		return v
+	}
+
+	return aggregationGroup(v)
+}
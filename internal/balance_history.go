@@ -0,0 +1,76 @@
+package ledger
+
+import (
+	"fmt"
+	stdtime "time"
+
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+)
+
+// MaxBalanceHistoryPoints limits the number of intervals of a balance history
+const MaxBalanceHistoryPoints = 1000
+
+type BalanceHistoryInterval string
+
+const (
+	BalanceHistoryIntervalHour  BalanceHistoryInterval = "hour"
+	BalanceHistoryIntervalDay   BalanceHistoryInterval = "day"
+	BalanceHistoryIntervalMonth BalanceHistoryInterval = "month"
+)
+
+func (i BalanceHistoryInterval) Validate() error {
+	switch i {
+	case BalanceHistoryIntervalHour, BalanceHistoryIntervalDay, BalanceHistoryIntervalMonth:
+		return nil
+	default:
+		return fmt.Errorf("invalid interval `%s`, expected one of `hour`, `day`, `month`", i)
+	}
+}
+
+// end returns the end of the interval containing the date, intervals are aligned on UTC
+func (i BalanceHistoryInterval) end(date stdtime.Time) stdtime.Time {
+	date = date.UTC()
+	switch i {
+	case BalanceHistoryIntervalHour:
+		return date.Truncate(stdtime.Hour).Add(stdtime.Hour)
+	case BalanceHistoryIntervalDay:
+		return stdtime.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, stdtime.UTC)
+	case BalanceHistoryIntervalMonth:
+		return stdtime.Date(date.Year(), date.Month()+1, 1, 0, 0, 0, 0, stdtime.UTC)
+	default:
+		panic(fmt.Sprintf("unexpected interval: %s", i))
+	}
+}
+
+// Dates returns the ends of the intervals between two dates, the last interval ending at the end date.
+// The ends are inclusive, like the point in time of a query, so an interval ends just before the start of the next one.
+func (i BalanceHistoryInterval) Dates(start, end time.Time) ([]time.Time, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+	if !start.Before(end) {
+		return nil, fmt.Errorf("the start date must be before the end date")
+	}
+
+	ret := make([]time.Time, 0)
+	for date := i.end(start.Time); ; date = i.end(date) {
+		if len(ret) == MaxBalanceHistoryPoints {
+			return nil, fmt.Errorf("too many intervals, the maximum is %d", MaxBalanceHistoryPoints)
+		}
+		if !date.Before(end.Time) {
+			return append(ret, end), nil
+		}
+		ret = append(ret, time.New(date).Add(-time.DatePrecision))
+	}
+}
+
+// AggregatedVolumesHistory is the aggregated volumes at each date of a balance history, in the order of the dates
+type AggregatedVolumesHistory struct {
+	Aggregated []VolumesByAssets `bun:"aggregated,type:jsonb"`
+}
+
+// BalanceHistoryPoint is the balances at the end of an interval of a balance history
+type BalanceHistoryPoint struct {
+	Date     time.Time        `json:"date"`
+	Balances BalancesByAssets `json:"balances"`
+}
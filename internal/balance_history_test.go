@@ -0,0 +1,95 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+)
+
+func TestBalanceHistoryDates(t *testing.T) {
+	t.Parallel()
+
+	parse := func(v string) time.Time {
+		ret, err := time.ParseTime(v)
+		require.NoError(t, err)
+		return ret
+	}
+
+	type testCase struct {
+		name          string
+		interval      BalanceHistoryInterval
+		start         string
+		end           string
+		expectedDates []string
+		expectedError string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:     "days",
+			interval: BalanceHistoryIntervalDay,
+			start:    "2024-01-01T00:00:00Z",
+			end:      "2024-01-03T12:00:00Z",
+			expectedDates: []string{
+				"2024-01-01T23:59:59.999999Z",
+				"2024-01-02T23:59:59.999999Z",
+				"2024-01-03T12:00:00Z",
+			},
+		},
+		{
+			name:     "hours within an interval",
+			interval: BalanceHistoryIntervalHour,
+			start:    "2024-01-01T10:15:00Z",
+			end:      "2024-01-01T10:45:00Z",
+			expectedDates: []string{
+				"2024-01-01T10:45:00Z",
+			},
+		},
+		{
+			name:     "months",
+			interval: BalanceHistoryIntervalMonth,
+			start:    "2024-01-31T00:00:00Z",
+			end:      "2024-04-01T00:00:00Z",
+			expectedDates: []string{
+				"2024-01-31T23:59:59.999999Z",
+				"2024-02-29T23:59:59.999999Z",
+				"2024-04-01T00:00:00Z",
+			},
+		},
+		{
+			name:          "invalid interval",
+			interval:      "week",
+			start:         "2024-01-01T00:00:00Z",
+			end:           "2024-02-01T00:00:00Z",
+			expectedError: "invalid interval `week`",
+		},
+		{
+			name:          "end before start",
+			interval:      BalanceHistoryIntervalDay,
+			start:         "2024-02-01T00:00:00Z",
+			end:           "2024-01-01T00:00:00Z",
+			expectedError: "the start date must be before the end date",
+		},
+		{
+			name:          "too many intervals",
+			interval:      BalanceHistoryIntervalHour,
+			start:         "2024-01-01T00:00:00Z",
+			end:           "2025-01-01T00:00:00Z",
+			expectedError: "too many intervals",
+		},
+	} {
+		dates, err := tc.interval.Dates(parse(tc.start), parse(tc.end))
+		if tc.expectedError != "" {
+			require.ErrorContains(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		expected := make([]time.Time, 0, len(tc.expectedDates))
+		for _, date := range tc.expectedDates {
+			expected = append(expected, parse(date))
+		}
+		require.Equal(t, expected, dates, tc.name)
+	}
+}
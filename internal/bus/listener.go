@@ -62,6 +62,15 @@ func (lis *LedgerListener) RevertedTransaction(ctx context.Context, l string, re
 		}))
 }
 
+func (lis *LedgerListener) PartiallyRevertedTransaction(ctx context.Context, l string, reverted, revert ledger.Transaction) {
+	lis.publish(ctx, events.EventTypePartiallyRevertedTransaction,
+		events.NewEventPartiallyRevertedTransaction(events.PartiallyRevertedTransaction{
+			Ledger:              l,
+			RevertedTransaction: reverted,
+			RevertTransaction:   revert,
+		}))
+}
+
 func (lis *LedgerListener) DeletedMetadata(ctx context.Context, l string, targetType string, targetID any, key string) {
 	lis.publish(ctx, events.EventTypeDeletedMetadata,
 		events.NewEventDeletedMetadata(events.DeletedMetadata{
@@ -3,27 +3,213 @@ package ledger
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
+	"path"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	"github.com/formancehq/ledger/internal/queries"
 )
 
-type ChartAccountRules struct{}
+// ChartOverdraftRule defines how far below zero the balance of an account can go for an asset.
+// An empty rule forbids any negative balance, Limit allows the balance to go down to -Limit
+// and Unbounded allows any negative balance.
+// Bounded rules replace the overdrafts allowed by the scripts, while scripts still have to allow
+// the overdraft of an account with an unbounded rule (`allowing unbounded overdraft`).
+type ChartOverdraftRule struct {
+	Unbounded bool     `json:"unbounded,omitempty"`
+	Limit     *big.Int `json:"limit,omitempty"`
+}
+
+// Floor returns the lowest balance allowed by the rule, or nil if the overdraft is unbounded
+func (r ChartOverdraftRule) Floor() *big.Int {
+	if r.Unbounded {
+		return nil
+	}
+	if r.Limit == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Neg(r.Limit)
+}
+
+// ChartAccountRules are the policies applied to the balances of an account after each write.
+// Overdraft rules are indexed by asset, the OVERDRAFT_ANY_ASSET key applies to assets without a dedicated rule.
+type ChartAccountRules struct {
+	Overdraft map[string]ChartOverdraftRule `json:"overdraft,omitempty"`
+}
+
+const OVERDRAFT_ANY_ASSET = "*"
+
+func (r ChartAccountRules) IsZero() bool {
+	return len(r.Overdraft) == 0
+}
+
+// OverdraftRule returns the overdraft rule applying to the asset, if any
+func (r ChartAccountRules) OverdraftRule(asset string) *ChartOverdraftRule {
+	if rule, ok := r.Overdraft[asset]; ok {
+		return &rule
+	}
+	if rule, ok := r.Overdraft[OVERDRAFT_ANY_ASSET]; ok {
+		return &rule
+	}
+	return nil
+}
+
+func (r ChartAccountRules) Validate() error {
+	for asset, rule := range r.Overdraft {
+		if rule.Unbounded && rule.Limit != nil {
+			return fmt.Errorf("overdraft rule for asset `%s` cannot be both unbounded and limited", asset)
+		}
+		if rule.Limit != nil && rule.Limit.Sign() < 0 {
+			return fmt.Errorf("overdraft limit for asset `%s` must be positive", asset)
+		}
+	}
+	return nil
+}
+
+// ValidateBalance checks the balance of the account for the asset against the overdraft rules
+func (r ChartAccountRules) ValidateBalance(account, asset string, balance *big.Int) error {
+	rule := r.OverdraftRule(asset)
+	if rule == nil {
+		return nil
+	}
+	floor := rule.Floor()
+	if floor != nil && balance.Cmp(floor) < 0 {
+		return ErrAccountRuleViolation{
+			account: account,
+			asset:   asset,
+			balance: balance,
+			floor:   floor,
+		}
+	}
+	return nil
+}
 
 type ChartAccountMetadata struct {
 	Default *string `json:"default,omitempty"`
+	// Type is one of the query field types (`string`, `int`, `boolean`, `date`) the value must be parsable as
+	Type     string   `json:"type,omitempty"`
+	Required bool     `json:"required,omitempty"`
+	Enum     []string `json:"enum,omitempty"`
+	Pattern  *string  `json:"pattern,omitempty"`
+	// Immutable metadata cannot be modified nor deleted once set
+	Immutable bool `json:"immutable,omitempty"`
+}
+
+func (m ChartAccountMetadata) Validate() error {
+	if m.Type != "" {
+		fieldType, err := queries.FieldTypeFromString(m.Type)
+		if err != nil {
+			return err
+		}
+		// a metadata holds a single value, lists are only allowed for the variables of the query templates
+		if _, ok := fieldType.(queries.TypeList); ok {
+			return fmt.Errorf("invalid type `%s`, expected one of `boolean`, `date`, `int`, `string`", m.Type)
+		}
+	}
+	if m.Pattern != nil {
+		if _, err := regexp.Compile(*m.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern regex: %v", err)
+		}
+	}
+	if m.Default != nil {
+		if err := m.ValidateValue(*m.Default); err != nil {
+			return fmt.Errorf("invalid default: %v", err)
+		}
+	}
+	return nil
+}
+
+// ValidateValue checks a metadata value against the type, enum and pattern constraints
+func (m ChartAccountMetadata) ValidateValue(value string) error {
+	if m.Type != "" {
+		fieldType, err := queries.FieldTypeFromString(m.Type)
+		if err != nil {
+			return err
+		}
+		switch fieldType.(type) {
+		case queries.TypeNumeric:
+			if _, ok := new(big.Int).SetString(value, 10); !ok {
+				return fmt.Errorf("value `%s` is not an integer", value)
+			}
+		case queries.TypeBoolean:
+			if value != "true" && value != "false" {
+				return fmt.Errorf("value `%s` is not a boolean", value)
+			}
+		case queries.TypeDate:
+			if _, err := time.ParseTime(value); err != nil {
+				return fmt.Errorf("value `%s` is not a date: %v", value, err)
+			}
+		}
+	}
+	if len(m.Enum) > 0 && !slices.Contains(m.Enum, value) {
+		return fmt.Errorf("value `%s` is not one of %s", value, strings.Join(m.Enum, ", "))
+	}
+	if m.Pattern != nil {
+		matches, err := regexp.MatchString(*m.Pattern, value)
+		if err != nil {
+			return fmt.Errorf("invalid pattern regex: %v", err)
+		}
+		if !matches {
+			return fmt.Errorf("value `%s` does not match the pattern `%s`", value, *m.Pattern)
+		}
+	}
+	return nil
 }
 
 type ChartAccount struct {
 	Metadata map[string]ChartAccountMetadata
 	Rules    ChartAccountRules
+	// Assets restricts the assets the account can hold, entries can be glob patterns (ex: `USD/*`).
+	// An empty list allows any asset.
+	Assets []string
+}
+
+// AllowsAsset checks if the asset is part of the allowed assets of the account
+func (c ChartAccount) AllowsAsset(asset string) bool {
+	if len(c.Assets) == 0 {
+		return true
+	}
+	for _, pattern := range c.Assets {
+		if matches, _ := path.Match(pattern, asset); matches {
+			return true
+		}
+	}
+	return false
 }
 
 type ChartSegment struct {
 	VariableSegment *ChartVariableSegment
 	FixedSegments   map[string]ChartSegment
 	Account         *ChartAccount
+	// Class is the accounting class of the subtree of the segment, if any
+	Class AccountingClass
+}
+
+// validateClasses checks the segments of a classified subtree are not tagged with another class
+func (s ChartSegment) validateClasses(parent AccountingClass) error {
+	class := parent
+	if s.Class != "" {
+		if parent != "" && s.Class != parent {
+			return fmt.Errorf("cannot have class `%s` in a subtree of class `%s`", s.Class, parent)
+		}
+		class = s.Class
+	}
+	for _, name := range sortedKeys(s.FixedSegments) {
+		if err := s.FixedSegments[name].validateClasses(class); err != nil {
+			return fmt.Errorf("invalid segment `%v`: %v", name, err)
+		}
+	}
+	if s.VariableSegment != nil {
+		if err := s.VariableSegment.validateClasses(class); err != nil {
+			return fmt.Errorf("invalid segment `$%v`: %v", s.VariableSegment.Label, err)
+		}
+	}
+	return nil
 }
 
 type ChartVariableSegment struct {
@@ -38,6 +224,8 @@ const PATTERN_KEY = PROPERTY_PREFIX + "pattern"
 const SELF_KEY = PROPERTY_PREFIX + "self"
 const RULES_KEY = PROPERTY_PREFIX + "rules"
 const METADATA_KEY = PROPERTY_PREFIX + "metadata"
+const ASSETS_KEY = PROPERTY_PREFIX + "assets"
+const CLASS_KEY = PROPERTY_PREFIX + "class"
 
 type ChartOfAccounts map[string]ChartSegment
 
@@ -81,6 +269,9 @@ func (s *ChartOfAccounts) UnmarshalJSON(data []byte) error {
 		if err != nil {
 			return fmt.Errorf("invalid segment `%v`: %v", key, err)
 		}
+		if err := seg.validateClasses(""); err != nil {
+			return fmt.Errorf("invalid segment `%v`: %v", key, err)
+		}
 		out[key] = seg
 	}
 	*s = out
@@ -97,6 +288,7 @@ func (s *ChartSegment) UnmarshalJSON(data []byte) error {
 		account         ChartAccount
 		fixedSegments   map[string]ChartSegment
 		variableSegment *ChartVariableSegment
+		class           AccountingClass
 	)
 	for key, value := range segment {
 		isSubsegment := !strings.HasPrefix(key, PROPERTY_PREFIX)
@@ -161,11 +353,37 @@ func (s *ChartSegment) UnmarshalJSON(data []byte) error {
 			if err != nil {
 				return fmt.Errorf("invalid default metadata: %v", err)
 			}
+			for key, schema := range account.Metadata {
+				if err := schema.Validate(); err != nil {
+					return fmt.Errorf("invalid metadata `%s`: %v", key, err)
+				}
+			}
 		} else if key == RULES_KEY {
 			err := json.Unmarshal(value, &account.Rules)
 			if err != nil {
 				return fmt.Errorf("invalid account rules: %v", err)
 			}
+			if err := account.Rules.Validate(); err != nil {
+				return fmt.Errorf("invalid account rules: %v", err)
+			}
+		} else if key == CLASS_KEY {
+			err := json.Unmarshal(value, &class)
+			if err != nil {
+				return fmt.Errorf("invalid class: %v", err)
+			}
+			if err := class.Validate(); err != nil {
+				return err
+			}
+		} else if key == ASSETS_KEY {
+			err := json.Unmarshal(value, &account.Assets)
+			if err != nil {
+				return fmt.Errorf("invalid allowed assets: %v", err)
+			}
+			for _, pattern := range account.Assets {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return fmt.Errorf("invalid allowed asset pattern `%s`: %v", pattern, err)
+				}
+			}
 		}
 	}
 	isAccount = isAccount || isLeaf
@@ -174,6 +392,7 @@ func (s *ChartSegment) UnmarshalJSON(data []byte) error {
 	}
 	s.FixedSegments = fixedSegments
 	s.VariableSegment = variableSegment
+	s.Class = class
 
 	if _, ok := segment[METADATA_KEY]; ok && !isAccount {
 		return fmt.Errorf("cannot have %v on a non-account segment", METADATA_KEY)
@@ -181,6 +400,9 @@ func (s *ChartSegment) UnmarshalJSON(data []byte) error {
 	if _, ok := segment[RULES_KEY]; ok && !isAccount {
 		return fmt.Errorf("cannot have %v on a non-account segment", RULES_KEY)
 	}
+	if _, ok := segment[ASSETS_KEY]; ok && !isAccount {
+		return fmt.Errorf("cannot have %v on a non-account segment", ASSETS_KEY)
+	}
 
 	return nil
 }
@@ -214,14 +436,19 @@ func (s ChartSegment) marshalJsonObject() (map[string]any, error) {
 		}
 		out[key] = json.RawMessage(serialized)
 	}
+	if s.Class != "" {
+		out[CLASS_KEY] = s.Class
+	}
 	if s.Account != nil {
 		if s.Account.Metadata != nil {
 			out[METADATA_KEY] = s.Account.Metadata
 		}
-		// Never emitted for now
-		if s.Account.Rules != (ChartAccountRules{}) {
+		if !s.Account.Rules.IsZero() {
 			out[RULES_KEY] = s.Account.Rules
 		}
+		if s.Account.Assets != nil {
+			out[ASSETS_KEY] = s.Account.Assets
+		}
 		if len(s.FixedSegments) > 0 || s.VariableSegment != nil {
 			out[SELF_KEY] = map[string]any{}
 		}
@@ -303,18 +530,186 @@ func (c *ChartOfAccounts) FindAccountSchema(account string) (*ChartAccount, erro
 	return schema, nil
 }
 
+// FindSegment resolves a path of the chart (ex: `users:$userID:main`) to its segment.
+// Variable segments are referenced by their label prefixed with `$`.
+func (c *ChartOfAccounts) FindSegment(chartPath string) (*ChartSegment, error) {
+	var (
+		fixedSegments   = map[string]ChartSegment(*c)
+		variableSegment *ChartVariableSegment
+		segment         *ChartSegment
+	)
+	parts := strings.Split(chartPath, ":")
+	for i, part := range parts {
+		if label, ok := strings.CutPrefix(part, "$"); ok {
+			if variableSegment == nil || variableSegment.Label != label {
+				return nil, fmt.Errorf("no variable segment `%s` under `%s`", part, strings.Join(parts[:i], ":"))
+			}
+			segment = &variableSegment.ChartSegment
+		} else {
+			fixedSegment, ok := fixedSegments[part]
+			if !ok {
+				return nil, fmt.Errorf("no segment `%s` under `%s`", part, strings.Join(parts[:i], ":"))
+			}
+			segment = &fixedSegment
+		}
+		fixedSegments = segment.FixedSegments
+		variableSegment = segment.VariableSegment
+	}
+	return segment, nil
+}
+
+// MatchPath checks if an account address belongs to a path of the chart (ex: `users:$userID:main`)
+func (c *ChartOfAccounts) MatchPath(chartPath, account string) bool {
+	pathParts := strings.Split(chartPath, ":")
+	accountParts := strings.Split(account, ":")
+	if len(pathParts) != len(accountParts) {
+		return false
+	}
+	for i, part := range pathParts {
+		if !strings.HasPrefix(part, "$") && part != accountParts[i] {
+			return false
+		}
+	}
+	// variable segments patterns are checked by resolving the account
+	_, err := c.FindAccountSchema(account)
+	return err == nil
+}
+
 func (c *ChartOfAccounts) ValidatePosting(posting Posting) error {
-	_, err := c.FindAccountSchema(posting.Source)
-	if err != nil {
-		return err
+	for _, account := range []string{posting.Source, posting.Destination} {
+		accountSchema, err := c.FindAccountSchema(account)
+		if err != nil {
+			return err
+		}
+		if !accountSchema.AllowsAsset(posting.Asset) {
+			return ErrAssetNotAllowed{
+				account: account,
+				asset:   posting.Asset,
+				allowed: accountSchema.Assets,
+			}
+		}
 	}
-	_, err = c.FindAccountSchema(posting.Destination)
-	if err != nil {
-		return err
+	return nil
+}
+
+// ValidateBalances checks the post commit volumes of a write against the rules of the chart of accounts.
+// Accounts not defined in the chart are ignored, their addresses are validated on postings.
+func (c *ChartOfAccounts) ValidateBalances(volumes PostCommitVolumes) error {
+	for _, account := range sortedKeys(volumes) {
+		accountSchema, err := c.FindAccountSchema(account)
+		if err != nil {
+			continue
+		}
+		for _, asset := range sortedKeys(volumes[account]) {
+			if err := accountSchema.Rules.ValidateBalance(account, asset, volumes[account][asset].Balance()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateMetadata checks the values of metadata written on the account
+func (c *ChartAccount) ValidateMetadata(address string, m metadata.Metadata) error {
+	for _, key := range sortedKeys(m) {
+		schema, ok := c.Metadata[key]
+		if !ok {
+			continue
+		}
+		if err := schema.ValidateValue(m[key]); err != nil {
+			return ErrInvalidAccountMetadata{
+				account: address,
+				key:     key,
+				err:     err,
+			}
+		}
+	}
+	return nil
+}
+
+// NeedsExistingMetadata indicates if the current metadata of the account are required to validate a write,
+// either to check immutable metadata or required metadata without default value
+func (c *ChartAccount) NeedsExistingMetadata(m metadata.Metadata) bool {
+	for key, schema := range c.Metadata {
+		_, provided := m[key]
+		if schema.Immutable && provided {
+			return true
+		}
+		if schema.Required && schema.Default == nil && !provided {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateMetadataUpdate checks metadata written on an existing account does not modify immutable metadata
+func (c *ChartAccount) ValidateMetadataUpdate(address string, existing, m metadata.Metadata) error {
+	for _, key := range sortedKeys(m) {
+		schema, ok := c.Metadata[key]
+		if !ok || !schema.Immutable {
+			continue
+		}
+		if previous, ok := existing[key]; ok && previous != m[key] {
+			return ErrInvalidAccountMetadata{
+				account: address,
+				key:     key,
+				err:     fmt.Errorf("metadata is immutable"),
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateNewAccountMetadata checks the metadata of an account being created contains all required metadata
+func (c *ChartAccount) ValidateNewAccountMetadata(address string, m metadata.Metadata) error {
+	for _, key := range sortedKeys(c.Metadata) {
+		schema := c.Metadata[key]
+		if !schema.Required || schema.Default != nil {
+			continue
+		}
+		if _, ok := m[key]; !ok {
+			return ErrInvalidAccountMetadata{
+				account: address,
+				key:     key,
+				err:     fmt.Errorf("metadata is required"),
+			}
+		}
 	}
 	return nil
 }
 
+// ValidateMetadataDeletion checks a metadata can be deleted from the account
+func (c *ChartAccount) ValidateMetadataDeletion(address, key string) error {
+	schema, ok := c.Metadata[key]
+	if !ok {
+		return nil
+	}
+	if schema.Required {
+		return ErrInvalidAccountMetadata{
+			account: address,
+			key:     key,
+			err:     fmt.Errorf("metadata is required"),
+		}
+	}
+	if schema.Immutable {
+		return ErrInvalidAccountMetadata{
+			account: address,
+			key:     key,
+			err:     fmt.Errorf("metadata is immutable"),
+		}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
 func (c *ChartAccount) DefaultMetadata() metadata.Metadata {
 	defaultMetadata := metadata.Metadata{}
 	for key, value := range c.Metadata {
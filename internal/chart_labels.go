@@ -0,0 +1,241 @@
+package ledger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/formancehq/go-libs/v5/pkg/query"
+
+	"github.com/formancehq/ledger/internal/queries"
+)
+
+// CHART_LABEL_PREFIX prefixes the filters on the labels of the variable segments of the chart (ex: `chart.userID`)
+const CHART_LABEL_PREFIX = "chart."
+
+// ExtractLabels returns the values of the variable segments of an address, indexed by label.
+// It returns nil if the address does not match the chart of accounts.
+func (c *ChartOfAccounts) ExtractLabels(address string) map[string]string {
+	var (
+		fixedSegments   = map[string]ChartSegment(*c)
+		variableSegment *ChartVariableSegment
+		segment         ChartSegment
+		labels          = map[string]string{}
+	)
+	for _, part := range strings.Split(address, ":") {
+		if fixedSegment, ok := fixedSegments[part]; ok {
+			segment = fixedSegment
+		} else if variableSegment != nil {
+			if variableSegment.Pattern != nil {
+				if matches, err := regexp.MatchString(*variableSegment.Pattern, part); err != nil || !matches {
+					return nil
+				}
+			}
+			labels[variableSegment.Label] = part
+			segment = variableSegment.ChartSegment
+		} else {
+			return nil
+		}
+		fixedSegments = segment.FixedSegments
+		variableSegment = segment.VariableSegment
+	}
+	if segment.Account == nil {
+		return nil
+	}
+	return labels
+}
+
+// LabelFilter builds a filter on the `address` property matching the accounts
+// whose variable segment labelled `label` has the given value.
+// The value must be a valid segment, matching the pattern of the variable segment.
+func (c ChartOfAccounts) LabelFilter(label, value string) (query.Builder, error) {
+	if value == "" {
+		return nil, fmt.Errorf("empty value for chart label `%s`", label)
+	}
+	if strings.Contains(value, ":") {
+		return nil, fmt.Errorf("value `%s` of chart label `%s` must be a single segment", value, label)
+	}
+
+	labelFound := false
+	clauses := make([]query.Builder, 0)
+	for _, path := range c.accountPaths() {
+		level := -1
+		for i, part := range path.parts {
+			if part == "$"+label {
+				level = i
+				break
+			}
+		}
+		if level == -1 {
+			continue
+		}
+		labelFound = true
+		if pattern, ok := path.patterns[level]; ok {
+			if matches, err := regexp.MatchString(pattern, value); err != nil || !matches {
+				continue
+			}
+		}
+
+		clause := []query.Builder{query.Match("address", path.partialAddress(map[int]string{
+			level: value,
+		}))}
+		for _, siblingLevel := range sortedLevels(path.siblings) {
+			for _, sibling := range path.siblings[siblingLevel] {
+				// on the level of the label, only a fixed segment equal to the value can shadow the variable segment
+				if siblingLevel == level && sibling != value {
+					continue
+				}
+				clause = append(clause, query.Not(query.Match("address", path.partialAddress(map[int]string{
+					level:        value,
+					siblingLevel: sibling,
+				}))))
+			}
+		}
+		if len(clause) == 1 {
+			clauses = append(clauses, clause[0])
+		} else {
+			clauses = append(clauses, query.And(clause...))
+		}
+	}
+	if !labelFound {
+		return nil, fmt.Errorf("unknown chart label `%s`", label)
+	}
+	if len(clauses) == 0 {
+		return nil, fmt.Errorf("value `%s` does not match the pattern of chart label `%s`", value, label)
+	}
+	return query.Or(clauses...), nil
+}
+
+// HasLabelFilters checks if a filter targets labels of the chart of accounts
+func HasLabelFilters(builder query.Builder) bool {
+	if builder == nil {
+		return false
+	}
+	found := false
+	_ = builder.Walk(func(_ string, key string, _ *any) error {
+		if strings.HasPrefix(key, CHART_LABEL_PREFIX) {
+			found = true
+		}
+		return nil
+	})
+	return found
+}
+
+// ResolveLabelFilters replaces the filters on labels of the chart of accounts (ex: `chart.userID`)
+// by filters on the `address` property.
+// Only the `$match` and `$in` operators are supported on labels.
+func (c ChartOfAccounts) ResolveLabelFilters(builder query.Builder) (query.Builder, error) {
+	if !HasLabelFilters(builder) {
+		return builder, nil
+	}
+
+	data, err := json.Marshal(builder)
+	if err != nil {
+		return nil, err
+	}
+	var filter map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&filter); err != nil {
+		return nil, err
+	}
+
+	resolved, err := c.resolveLabelFilters(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = json.Marshal(resolved)
+	if err != nil {
+		return nil, err
+	}
+	return queries.ParseJSON(string(data))
+}
+
+func (c ChartOfAccounts) resolveLabelFilters(filter map[string]any) (any, error) {
+	for operator, value := range filter {
+		switch operator {
+		case "$and", "$or":
+			items, ok := value.([]any)
+			if !ok {
+				return nil, fmt.Errorf("expected array for operator %s, got %T", operator, value)
+			}
+			for i, item := range items {
+				itemFilter, ok := item.(map[string]any)
+				if !ok {
+					return nil, fmt.Errorf("expected object in operator %s, got %T", operator, item)
+				}
+				resolved, err := c.resolveLabelFilters(itemFilter)
+				if err != nil {
+					return nil, err
+				}
+				items[i] = resolved
+			}
+		case "$not":
+			notFilter, ok := value.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("expected object for operator %s, got %T", operator, value)
+			}
+			resolved, err := c.resolveLabelFilters(notFilter)
+			if err != nil {
+				return nil, err
+			}
+			filter[operator] = resolved
+		default:
+			keyValue, ok := value.(map[string]any)
+			if !ok {
+				continue
+			}
+			for key, value := range keyValue {
+				label, ok := strings.CutPrefix(key, CHART_LABEL_PREFIX)
+				if !ok {
+					continue
+				}
+				builder, err := c.resolveLabelFilter(operator, label, value)
+				if err != nil {
+					return nil, err
+				}
+				return builder, nil
+			}
+		}
+	}
+	return filter, nil
+}
+
+func (c ChartOfAccounts) resolveLabelFilter(operator, label string, value any) (query.Builder, error) {
+	var values []any
+	switch operator {
+	case "$match":
+		values = []any{value}
+	case "$in":
+		var ok bool
+		values, ok = value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected array value for operator %s on chart label `%s`, got %T", operator, label, value)
+		}
+		if len(values) == 0 {
+			return nil, fmt.Errorf("expected at least one value for operator %s on chart label `%s`", operator, label)
+		}
+	default:
+		return nil, fmt.Errorf("operator %s is not allowed on chart label `%s`", operator, label)
+	}
+
+	clauses := make([]query.Builder, 0, len(values))
+	for _, value := range values {
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string value for chart label `%s`, got %T", label, value)
+		}
+		clause, err := c.LabelFilter(label, str)
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, clause)
+	}
+	if len(clauses) == 1 {
+		return clauses[0], nil
+	}
+	return query.Or(clauses...), nil
+}
@@ -0,0 +1,158 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/formancehq/go-libs/v5/pkg/query"
+	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
+)
+
+func TestChartLabels(t *testing.T) {
+	t.Parallel()
+
+	chart := ChartOfAccounts{
+		"world": {
+			Account: &ChartAccount{},
+		},
+		"users": {
+			FixedSegments: map[string]ChartSegment{
+				"system": {
+					Account: &ChartAccount{},
+				},
+			},
+			VariableSegment: &ChartVariableSegment{
+				Label:   "userID",
+				Pattern: pointer.For("^[0-9]+$"),
+				ChartSegment: ChartSegment{
+					FixedSegments: map[string]ChartSegment{
+						"main": {
+							Account: &ChartAccount{},
+						},
+					},
+					VariableSegment: &ChartVariableSegment{
+						Label: "walletID",
+						ChartSegment: ChartSegment{
+							Account: &ChartAccount{},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("extract", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, map[string]string{}, chart.ExtractLabels("world"))
+		require.Equal(t, map[string]string{}, chart.ExtractLabels("users:system"))
+		require.Equal(t, map[string]string{"userID": "123"}, chart.ExtractLabels("users:123:main"))
+		require.Equal(t, map[string]string{"userID": "123", "walletID": "abc"}, chart.ExtractLabels("users:123:abc"))
+		require.Nil(t, chart.ExtractLabels("users:abc:main"))
+		require.Nil(t, chart.ExtractLabels("users:123"))
+		require.Nil(t, chart.ExtractLabels("unknown"))
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		t.Parallel()
+
+		type testCase struct {
+			name          string
+			filter        query.Builder
+			expected      query.Builder
+			expectedError string
+		}
+		for _, tc := range []testCase{
+			{
+				name:     "no label",
+				filter:   query.Match("address", "world"),
+				expected: query.Match("address", "world"),
+			},
+			{
+				name:   "match",
+				filter: query.Match("chart.userID", "123"),
+				expected: query.Or(
+					query.Match("address", "users:123:main"),
+					query.And(
+						query.Match("address", "users:123:"),
+						query.Not(query.Match("address", "users:123:main")),
+					),
+				),
+			},
+			{
+				name:   "match on a shadowed value",
+				filter: query.Match("chart.walletID", "main"),
+				expected: query.Or(
+					query.And(
+						query.Match("address", "users::main"),
+						query.Not(query.Match("address", "users:system:main")),
+						query.Not(query.Match("address", "users::main")),
+					),
+				),
+			},
+			{
+				name: "nested",
+				filter: query.And(
+					query.Match("metadata[foo]", "bar"),
+					query.Not(query.In("chart.walletID", []any{"abc", "def"})),
+				),
+				expected: query.And(
+					query.Match("metadata[foo]", "bar"),
+					query.Not(query.Or(
+						query.Or(query.And(
+							query.Match("address", "users::abc"),
+							query.Not(query.Match("address", "users:system:abc")),
+						)),
+						query.Or(query.And(
+							query.Match("address", "users::def"),
+							query.Not(query.Match("address", "users:system:def")),
+						)),
+					)),
+				),
+			},
+			{
+				name:          "unknown label",
+				filter:        query.Match("chart.unknown", "123"),
+				expectedError: "unknown chart label `unknown`",
+			},
+			{
+				name:          "empty value",
+				filter:        query.Match("chart.walletID", ""),
+				expectedError: "empty value for chart label `walletID`",
+			},
+			{
+				name:          "value with several segments",
+				filter:        query.Match("chart.walletID", "abc:main"),
+				expectedError: "value `abc:main` of chart label `walletID` must be a single segment",
+			},
+			{
+				name:          "value not matching the pattern",
+				filter:        query.In("chart.userID", []any{"123", "abc"}),
+				expectedError: "value `abc` does not match the pattern of chart label `userID`",
+			},
+			{
+				name:          "unsupported operator",
+				filter:        query.Lt("chart.userID", "123"),
+				expectedError: "operator $lt is not allowed on chart label `userID`",
+			},
+			{
+				name:          "non string value",
+				filter:        query.Match("chart.userID", 123),
+				expectedError: "expected string value for chart label `userID`, got json.Number",
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				builder, err := chart.ResolveLabelFilters(tc.filter)
+				if tc.expectedError != "" {
+					require.EqualError(t, err, tc.expectedError)
+					return
+				}
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, builder)
+			})
+		}
+	})
+}
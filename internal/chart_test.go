@@ -2,10 +2,12 @@ package ledger
 
 import (
 	"encoding/json"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
 	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
 )
 
@@ -90,6 +92,220 @@ func TestChartValidation(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "account rules",
+			source: `{
+    "users": {
+        "$userID": {
+            ".rules": {
+                "overdraft": {
+                    "USD/2": {
+                        "limit": 1000
+                    },
+                    "EUR/2": {},
+                    "*": {
+                        "unbounded": true
+                    }
+                }
+            }
+        }
+    }
+}`,
+			expectedChart: ChartOfAccounts{
+				"users": {
+					VariableSegment: &ChartVariableSegment{
+						Label: "userID",
+						ChartSegment: ChartSegment{
+							Account: &ChartAccount{
+								Rules: ChartAccountRules{
+									Overdraft: map[string]ChartOverdraftRule{
+										"USD/2": {Limit: big.NewInt(1000)},
+										"EUR/2": {},
+										"*":     {Unbounded: true},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "metadata constraints",
+			source: `{
+    "users": {
+        "$userID": {
+            ".metadata": {
+                "kyc_level": {
+                    "type": "int",
+                    "required": true,
+                    "default": "0"
+                },
+                "status": {
+                    "enum": ["active", "blocked"]
+                },
+                "external_id": {
+                    "pattern": "^ext_[0-9]+$",
+                    "immutable": true
+                }
+            }
+        }
+    }
+}`,
+			expectedChart: ChartOfAccounts{
+				"users": {
+					VariableSegment: &ChartVariableSegment{
+						Label: "userID",
+						ChartSegment: ChartSegment{
+							Account: &ChartAccount{
+								Metadata: map[string]ChartAccountMetadata{
+									"kyc_level": {
+										Type:     "int",
+										Required: true,
+										Default:  pointer.For("0"),
+									},
+									"status": {
+										Enum: []string{"active", "blocked"},
+									},
+									"external_id": {
+										Pattern:   pointer.For("^ext_[0-9]+$"),
+										Immutable: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:          "invalid metadata type",
+			source:        `{ "users": { ".metadata": { "foo": { "type": "float" } } } }`,
+			expectedError: "invalid metadata `foo`: invalid type `float`",
+		},
+		{
+			name:          "list metadata type",
+			source:        `{ "users": { ".metadata": { "foo": { "type": "[]string" } } } }`,
+			expectedError: "invalid metadata `foo`: invalid type `[]string`",
+		},
+		{
+			name:          "invalid metadata pattern",
+			source:        `{ "users": { ".metadata": { "foo": { "pattern": "[[" } } } }`,
+			expectedError: "invalid metadata `foo`: invalid pattern regex",
+		},
+		{
+			name:          "default not matching metadata type",
+			source:        `{ "users": { ".metadata": { "foo": { "type": "boolean", "default": "yes" } } } }`,
+			expectedError: "invalid metadata `foo`: invalid default: value `yes` is not a boolean",
+		},
+		{
+			name: "allowed assets",
+			source: `{
+    "users": {
+        "$userID": {
+            ".assets": ["USD/*", "EUR/2"]
+        }
+    }
+}`,
+			expectedChart: ChartOfAccounts{
+				"users": {
+					VariableSegment: &ChartVariableSegment{
+						Label: "userID",
+						ChartSegment: ChartSegment{
+							Account: &ChartAccount{
+								Assets: []string{"USD/*", "EUR/2"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:          "invalid allowed asset pattern",
+			source:        `{ "users": { ".assets": ["USD/["] } }`,
+			expectedError: "invalid allowed asset pattern `USD/[`",
+		},
+		{
+			name: "assets on non-account segment",
+			source: `{
+				"banks": {
+					".assets": ["USD"],
+					"main": {}
+				}
+			}`,
+			expectedError: "cannot have .assets on a non-account segment",
+		},
+		{
+			name: "accounting classes",
+			source: `{
+    "banks": {
+        ".class": "asset",
+        "$bankID": {
+            ".class": "asset"
+        }
+    },
+    "fees": {
+        ".class": "income"
+    }
+}`,
+			expectedChart: ChartOfAccounts{
+				"banks": {
+					Class: AccountingClassAsset,
+					VariableSegment: &ChartVariableSegment{
+						Label: "bankID",
+						ChartSegment: ChartSegment{
+							Class:   AccountingClassAsset,
+							Account: &ChartAccount{},
+						},
+					},
+				},
+				"fees": {
+					Class:   AccountingClassIncome,
+					Account: &ChartAccount{},
+				},
+			},
+		},
+		{
+			name:          "invalid accounting class",
+			source:        `{ "banks": { ".class": "revenue" } }`,
+			expectedError: "invalid class `revenue`",
+		},
+		{
+			name: "accounting class in a subtree of another class",
+			source: `{
+				"banks": {
+					".class": "asset",
+					"loans": { ".class": "liability" }
+				}
+			}`,
+			expectedError: "invalid segment `banks`: invalid segment `loans`: cannot have class `liability` in a subtree of class `asset`",
+		},
+		{
+			name: "unbounded and limited overdraft",
+			source: `{
+				"users": {
+					".rules": {
+						"overdraft": {
+							"USD/2": { "unbounded": true, "limit": 100 }
+						}
+					}
+				}
+			}`,
+			expectedError: "cannot be both unbounded and limited",
+		},
+		{
+			name: "negative overdraft limit",
+			source: `{
+				"users": {
+					".rules": {
+						"overdraft": {
+							"USD/2": { "limit": -100 }
+						}
+					}
+				}
+			}`,
+			expectedError: "overdraft limit for asset `USD/2` must be positive",
+		},
 		{
 			name: "invalid fixed segment",
 			source: `{
@@ -293,6 +509,7 @@ func testChart() ChartOfAccounts {
 						"main": {
 							Account: &ChartAccount{
 								Metadata: map[string]ChartAccountMetadata{},
+								Assets:   []string{"USD/*", "EUR/2"},
 							},
 						},
 					},
@@ -359,6 +576,7 @@ func TestAccountValidation(t *testing.T) {
 			address: "users:001:main",
 			expectedAccount: &ChartAccount{
 				Metadata: map[string]ChartAccountMetadata{},
+				Assets:   []string{"USD/*", "EUR/2"},
 			},
 		},
 		{
@@ -415,9 +633,9 @@ func TestPostingValidation(t *testing.T) {
 	chart := testChart()
 
 	type testCase struct {
-		name        string
-		posting     Posting
-		expectError bool
+		name          string
+		posting       Posting
+		expectedError error
 	}
 
 	for _, tc := range []testCase{
@@ -426,6 +644,7 @@ func TestPostingValidation(t *testing.T) {
 			posting: Posting{
 				Source:      "bank:012",
 				Destination: "users:012:main",
+				Asset:       "USD/2",
 			},
 		},
 		{
@@ -434,7 +653,7 @@ func TestPostingValidation(t *testing.T) {
 				Source:      "bank:invalid",
 				Destination: "users:001:main",
 			},
-			expectError: true,
+			expectedError: ErrInvalidAccount{},
 		},
 		{
 			name: "invalid destination",
@@ -442,15 +661,231 @@ func TestPostingValidation(t *testing.T) {
 				Source:      "bank:012",
 				Destination: "users:invalid:main",
 			},
-			expectError: true,
+			expectedError: ErrInvalidAccount{},
+		},
+		{
+			name: "allowed asset pattern",
+			posting: Posting{
+				Source:      "bank:012",
+				Destination: "users:012:main",
+				Asset:       "USD/4",
+			},
+		},
+		{
+			name: "allowed asset",
+			posting: Posting{
+				Source:      "users:012:main",
+				Destination: "bank:012",
+				Asset:       "EUR/2",
+			},
+		},
+		{
+			name: "disallowed asset on destination",
+			posting: Posting{
+				Source:      "bank:012",
+				Destination: "users:012:main",
+				Asset:       "EUR/3",
+			},
+			expectedError: ErrAssetNotAllowed{},
+		},
+		{
+			name: "disallowed asset on source",
+			posting: Posting{
+				Source:      "users:012:main",
+				Destination: "bank:012",
+				Asset:       "BTC",
+			},
+			expectedError: ErrAssetNotAllowed{},
 		},
 	} {
-		if tc.expectError {
+		if tc.expectedError != nil {
 			err := chart.ValidatePosting(tc.posting)
-			require.ErrorIs(t, err, ErrInvalidAccount{}, tc.name)
+			require.ErrorIs(t, err, tc.expectedError, tc.name)
 		} else {
 			err := chart.ValidatePosting(tc.posting)
 			require.NoError(t, err, tc.name)
 		}
 	}
 }
+
+func TestBalancesValidation(t *testing.T) {
+	t.Parallel()
+
+	chart := ChartOfAccounts{
+		"world": {
+			Account: &ChartAccount{},
+		},
+		"users": {
+			VariableSegment: &ChartVariableSegment{
+				Label: "userID",
+				ChartSegment: ChartSegment{
+					Account: &ChartAccount{
+						Rules: ChartAccountRules{
+							Overdraft: map[string]ChartOverdraftRule{
+								"USD/2": {Limit: big.NewInt(100)},
+								"EUR/2": {},
+								"*":     {Unbounded: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	type testCase struct {
+		name          string
+		volumes       PostCommitVolumes
+		expectedError string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "account without rules",
+			volumes: PostCommitVolumes{
+				"world": {"USD/2": NewVolumesInt64(0, 1000)},
+			},
+		},
+		{
+			name: "limited overdraft within the limit",
+			volumes: PostCommitVolumes{
+				"users:001": {"USD/2": NewVolumesInt64(0, 100)},
+			},
+		},
+		{
+			name: "limited overdraft exceeded",
+			volumes: PostCommitVolumes{
+				"users:001": {"USD/2": NewVolumesInt64(0, 101)},
+			},
+			expectedError: "account `users:001` cannot have a balance below -100 for asset `USD/2` (balance would be -101)",
+		},
+		{
+			name: "no overdraft",
+			volumes: PostCommitVolumes{
+				"users:001": {"EUR/2": NewVolumesInt64(10, 11)},
+			},
+			expectedError: "account `users:001` cannot have a negative balance for asset `EUR/2` (balance would be -1)",
+		},
+		{
+			name: "unbounded overdraft on other assets",
+			volumes: PostCommitVolumes{
+				"users:001": {"BTC": NewVolumesInt64(0, 1000000)},
+			},
+		},
+		{
+			name: "account not in the chart",
+			volumes: PostCommitVolumes{
+				"banks:001": {"EUR/2": NewVolumesInt64(0, 1000)},
+			},
+		},
+	} {
+		err := chart.ValidateBalances(tc.volumes)
+		if tc.expectedError == "" {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.EqualError(t, err, tc.expectedError, tc.name)
+			require.ErrorIs(t, err, ErrAccountRuleViolation{}, tc.name)
+		}
+	}
+}
+
+func TestAccountMetadataValidation(t *testing.T) {
+	t.Parallel()
+
+	account := ChartAccount{
+		Metadata: map[string]ChartAccountMetadata{
+			"kyc_level": {
+				Type:     "int",
+				Required: true,
+				Default:  pointer.For("0"),
+			},
+			"opened_at": {
+				Type: "date",
+			},
+			"status": {
+				Enum: []string{"active", "blocked"},
+			},
+			"external_id": {
+				Pattern:   pointer.For("^ext_[0-9]+$"),
+				Immutable: true,
+				Required:  true,
+			},
+		},
+	}
+
+	type testCase struct {
+		name          string
+		existing      metadata.Metadata
+		metadata      metadata.Metadata
+		expectedError string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "valid values",
+			metadata: metadata.Metadata{
+				"kyc_level":   "2",
+				"opened_at":   "2024-01-01T00:00:00Z",
+				"status":      "active",
+				"external_id": "ext_1",
+				"free":        "anything",
+			},
+		},
+		{
+			name:          "invalid int",
+			metadata:      metadata.Metadata{"kyc_level": "high"},
+			expectedError: "invalid metadata `kyc_level` on account `users:001`: value `high` is not an integer",
+		},
+		{
+			name:          "invalid date",
+			metadata:      metadata.Metadata{"opened_at": "yesterday"},
+			expectedError: "invalid metadata `opened_at` on account `users:001`: value `yesterday` is not a date",
+		},
+		{
+			name:          "value not in enum",
+			metadata:      metadata.Metadata{"status": "closed"},
+			expectedError: "invalid metadata `status` on account `users:001`: value `closed` is not one of active, blocked",
+		},
+		{
+			name:          "value not matching pattern",
+			metadata:      metadata.Metadata{"external_id": "1"},
+			expectedError: "invalid metadata `external_id` on account `users:001`: value `1` does not match the pattern `^ext_[0-9]+$`",
+		},
+		{
+			name:          "immutable metadata modified",
+			existing:      metadata.Metadata{"external_id": "ext_1"},
+			metadata:      metadata.Metadata{"external_id": "ext_2"},
+			expectedError: "invalid metadata `external_id` on account `users:001`: metadata is immutable",
+		},
+		{
+			name:     "immutable metadata set to the same value",
+			existing: metadata.Metadata{"external_id": "ext_1"},
+			metadata: metadata.Metadata{"external_id": "ext_1"},
+		},
+		{
+			name:          "missing required metadata on new account",
+			metadata:      metadata.Metadata{},
+			expectedError: "invalid metadata `external_id` on account `users:001`: metadata is required",
+		},
+	} {
+		err := account.ValidateMetadata("users:001", tc.metadata)
+		if err == nil {
+			if tc.existing != nil {
+				err = account.ValidateMetadataUpdate("users:001", tc.existing, tc.metadata)
+			} else {
+				err = account.ValidateNewAccountMetadata("users:001", tc.metadata)
+			}
+		}
+		if tc.expectedError == "" {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.ErrorContains(t, err, tc.expectedError, tc.name)
+			require.ErrorIs(t, err, ErrInvalidAccountMetadata{}, tc.name)
+		}
+	}
+
+	require.True(t, account.NeedsExistingMetadata(metadata.Metadata{}))
+	require.False(t, (&ChartAccount{Metadata: map[string]ChartAccountMetadata{"status": {}}}).NeedsExistingMetadata(metadata.Metadata{"status": "active"}))
+	require.ErrorContains(t, account.ValidateMetadataDeletion("users:001", "external_id"), "metadata is required")
+	require.NoError(t, account.ValidateMetadataDeletion("users:001", "status"))
+}
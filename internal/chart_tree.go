@@ -0,0 +1,247 @@
+package ledger
+
+import (
+	"slices"
+	"strings"
+)
+
+// ChartTreeNode is a segment of the chart of accounts with the accounts of its subtree rolled up
+type ChartTreeNode struct {
+	// Segment is the name of the segment, prefixed with `$` for variable segments
+	Segment string `json:"segment"`
+	// Path is the path of the segment in the chart (ex: `users:$userID:main`)
+	Path    string  `json:"path"`
+	Pattern *string `json:"pattern,omitempty"`
+	// Account indicates if the segment is an account of the chart
+	Account bool `json:"account"`
+	// Class is the accounting class of the segment, inherited from its ancestors
+	Class AccountingClass `json:"class,omitempty"`
+	// AccountsCount is the number of existing accounts in the subtree of the segment
+	AccountsCount int `json:"accountsCount"`
+	// Balances are the balances of the accounts of the subtree of the segment, aggregated by asset
+	Balances BalancesByAssets `json:"balances"`
+	Children []ChartTreeNode  `json:"children,omitempty"`
+}
+
+// Tree returns the segments of the chart of accounts as a tree, without counts nor balances
+func (c ChartOfAccounts) Tree() []ChartTreeNode {
+	return chartTree(nil, "", c, nil)
+}
+
+// RollUpChartTree sets the counts and the balances of the nodes of a tree from the ones of the accounts of the chart,
+// the counts being indexed by path of account in the chart
+func RollUpChartTree(nodes []ChartTreeNode, counts map[string]int, volumes ChartAccountsVolumes) {
+	for i := range nodes {
+		nodes[i].AccountsCount = 0
+		for path, count := range counts {
+			if IsInChartPath(nodes[i].Path, path) {
+				nodes[i].AccountsCount += count
+			}
+		}
+		nodes[i].Balances = volumes.Subtree(nodes[i].Path).Balances()
+		RollUpChartTree(nodes[i].Children, counts, volumes)
+	}
+}
+
+func chartTree(path []string, class AccountingClass, fixedSegments map[string]ChartSegment, variableSegment *ChartVariableSegment) []ChartTreeNode {
+	var ret []ChartTreeNode
+	for _, name := range sortedKeys(fixedSegments) {
+		segment := fixedSegments[name]
+		segmentPath := append(slices.Clone(path), name)
+		segmentClass := segment.Class.Or(class)
+		ret = append(ret, ChartTreeNode{
+			Segment:  name,
+			Path:     strings.Join(segmentPath, ":"),
+			Account:  segment.Account != nil,
+			Class:    segmentClass,
+			Balances: BalancesByAssets{},
+			Children: chartTree(segmentPath, segmentClass, segment.FixedSegments, segment.VariableSegment),
+		})
+	}
+	if variableSegment != nil {
+		name := "$" + variableSegment.Label
+		segmentPath := append(slices.Clone(path), name)
+		segmentClass := variableSegment.Class.Or(class)
+		ret = append(ret, ChartTreeNode{
+			Segment:  name,
+			Path:     strings.Join(segmentPath, ":"),
+			Pattern:  variableSegment.Pattern,
+			Account:  variableSegment.Account != nil,
+			Class:    segmentClass,
+			Balances: BalancesByAssets{},
+			Children: chartTree(segmentPath, segmentClass, variableSegment.FixedSegments, variableSegment.VariableSegment),
+		})
+	}
+	return ret
+}
+
+// ClassifiedPaths returns the paths of the highest segments tagged with the class.
+// Segments of a class cannot be nested under another class, so the subtrees of the paths cover all the accounts of the class.
+func (c ChartOfAccounts) ClassifiedPaths(class AccountingClass) []string {
+	ret := make([]string, 0)
+	var walk func(nodes []ChartTreeNode)
+	walk = func(nodes []ChartTreeNode) {
+		for _, node := range nodes {
+			if node.Class == class {
+				ret = append(ret, node.Path)
+				continue
+			}
+			walk(node.Children)
+		}
+	}
+	walk(c.Tree())
+	return ret
+}
+
+// chartAccountPath is the path of an account of the chart, with the fixed segments
+// sharing the level of each variable segment of the path
+type chartAccountPath struct {
+	parts    []string
+	siblings map[int][]string
+	patterns map[int]string
+}
+
+// partialAddress returns the path as a partial address, variable segments being left empty
+func (p chartAccountPath) partialAddress(replacements map[int]string) string {
+	parts := make([]string, len(p.parts))
+	for i, part := range p.parts {
+		replacement, ok := replacements[i]
+		switch {
+		case ok:
+			parts[i] = replacement
+		case strings.HasPrefix(part, "$"):
+			parts[i] = ""
+		default:
+			parts[i] = part
+		}
+	}
+	return strings.Join(parts, ":")
+}
+
+func (c ChartOfAccounts) accountPaths() []chartAccountPath {
+	ret := make([]chartAccountPath, 0)
+	var walk func(path chartAccountPath, fixedSegments map[string]ChartSegment, variableSegment *ChartVariableSegment)
+	walk = func(path chartAccountPath, fixedSegments map[string]ChartSegment, variableSegment *ChartVariableSegment) {
+		for _, name := range sortedKeys(fixedSegments) {
+			segment := fixedSegments[name]
+			segmentPath := chartAccountPath{
+				parts:    append(slices.Clone(path.parts), name),
+				siblings: path.siblings,
+				patterns: path.patterns,
+			}
+			if segment.Account != nil {
+				ret = append(ret, segmentPath)
+			}
+			walk(segmentPath, segment.FixedSegments, segment.VariableSegment)
+		}
+		if variableSegment != nil {
+			segmentPath := chartAccountPath{
+				parts:    append(slices.Clone(path.parts), "$"+variableSegment.Label),
+				siblings: make(map[int][]string, len(path.siblings)+1),
+				patterns: make(map[int]string, len(path.patterns)+1),
+			}
+			for level, siblings := range path.siblings {
+				segmentPath.siblings[level] = siblings
+			}
+			if len(fixedSegments) > 0 {
+				segmentPath.siblings[len(path.parts)] = sortedKeys(fixedSegments)
+			}
+			for level, pattern := range path.patterns {
+				segmentPath.patterns[level] = pattern
+			}
+			if variableSegment.Pattern != nil {
+				segmentPath.patterns[len(path.parts)] = *variableSegment.Pattern
+			}
+			if variableSegment.Account != nil {
+				ret = append(ret, segmentPath)
+			}
+			walk(segmentPath, variableSegment.FixedSegments, variableSegment.VariableSegment)
+		}
+	}
+	walk(chartAccountPath{}, c, nil)
+	return ret
+}
+
+// ChartAddressSegment is a segment of the addresses of an account of the chart
+type ChartAddressSegment struct {
+	// Name is the name of a fixed segment, empty for a variable segment
+	Name string
+	// Pattern is the pattern of a variable segment, if any
+	Pattern *string
+	// Excluded are the fixed segments defined at the level of a variable segment, they take precedence over it
+	Excluded []string
+}
+
+// ChartAccountAddresses are the segments of the addresses of an account of the chart
+type ChartAccountAddresses struct {
+	// Path is the path of the account in the chart (ex: `users:$userID:main`)
+	Path     string
+	Segments []ChartAddressSegment
+}
+
+// AccountAddresses returns the segments of the addresses of the accounts of the subtree of a path of the chart,
+// or of all the accounts of the chart if the path is empty.
+// An address belongs to an account if its segments match the ones of the account, as checked by FindAccountSchema.
+func (c ChartOfAccounts) AccountAddresses(chartPath string) []ChartAccountAddresses {
+	ret := make([]ChartAccountAddresses, 0)
+	for _, path := range c.accountPaths() {
+		if !IsInChartPath(chartPath, strings.Join(path.parts, ":")) {
+			continue
+		}
+		segments := make([]ChartAddressSegment, len(path.parts))
+		for i, part := range path.parts {
+			if !strings.HasPrefix(part, "$") {
+				segments[i] = ChartAddressSegment{Name: part}
+				continue
+			}
+			segments[i] = ChartAddressSegment{Excluded: path.siblings[i]}
+			if pattern, ok := path.patterns[i]; ok {
+				segments[i].Pattern = &pattern
+			}
+		}
+		ret = append(ret, ChartAccountAddresses{
+			Path:     strings.Join(path.parts, ":"),
+			Segments: segments,
+		})
+	}
+	return ret
+}
+
+// IsInChartPath checks if a path of the chart is in the subtree of another one, an empty path being the root of the chart
+func IsInChartPath(parent, path string) bool {
+	return parent == "" || path == parent || strings.HasPrefix(path, parent+":")
+}
+
+// ChartAccountsVolumes are the volumes of the accounts of the chart, aggregated by path of account in the chart
+type ChartAccountsVolumes struct {
+	Volumes map[string]VolumesByAssets `bun:"volumes,type:jsonb"`
+}
+
+// Subtree sums the volumes of the accounts of the subtree of a path of the chart
+func (v ChartAccountsVolumes) Subtree(chartPath string) VolumesByAssets {
+	ret := VolumesByAssets{}
+	for path, volumes := range v.Volumes {
+		if !IsInChartPath(chartPath, path) {
+			continue
+		}
+		for asset, assetVolumes := range volumes {
+			total, ok := ret[asset]
+			if !ok {
+				total = NewEmptyVolumes()
+			}
+			total.Input.Add(total.Input, assetVolumes.Input)
+			total.Output.Add(total.Output, assetVolumes.Output)
+			ret[asset] = total
+		}
+	}
+	return ret
+}
+
+func sortedLevels(m map[int][]string) []int {
+	levels := make([]int, 0, len(m))
+	for level := range m {
+		levels = append(levels, level)
+	}
+	slices.Sort(levels)
+	return levels
+}
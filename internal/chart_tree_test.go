@@ -0,0 +1,228 @@
+package ledger
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
+)
+
+func TestChartTree(t *testing.T) {
+	t.Parallel()
+
+	chart := ChartOfAccounts{
+		"world": {
+			Account: &ChartAccount{},
+		},
+		"banks": {
+			FixedSegments: map[string]ChartSegment{
+				"central": {
+					Account: &ChartAccount{},
+				},
+			},
+			VariableSegment: &ChartVariableSegment{
+				Label:   "bankID",
+				Pattern: pointer.For("^[0-9]{3}$"),
+				ChartSegment: ChartSegment{
+					FixedSegments: map[string]ChartSegment{
+						"main": {
+							Account: &ChartAccount{},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	require.Equal(t, []ChartTreeNode{
+		{
+			Segment:  "banks",
+			Path:     "banks",
+			Balances: BalancesByAssets{},
+			Children: []ChartTreeNode{
+				{
+					Segment:  "central",
+					Path:     "banks:central",
+					Account:  true,
+					Balances: BalancesByAssets{},
+				},
+				{
+					Segment:  "$bankID",
+					Path:     "banks:$bankID",
+					Pattern:  pointer.For("^[0-9]{3}$"),
+					Balances: BalancesByAssets{},
+					Children: []ChartTreeNode{{
+						Segment:  "main",
+						Path:     "banks:$bankID:main",
+						Account:  true,
+						Balances: BalancesByAssets{},
+					}},
+				},
+			},
+		},
+		{
+			Segment:  "world",
+			Path:     "world",
+			Account:  true,
+			Balances: BalancesByAssets{},
+		},
+	}, chart.Tree())
+
+	mainSegments := []ChartAddressSegment{
+		{Name: "banks"},
+		{Pattern: pointer.For("^[0-9]{3}$"), Excluded: []string{"central"}},
+		{Name: "main"},
+	}
+	type testCase struct {
+		path     string
+		expected []ChartAccountAddresses
+	}
+	for _, tc := range []testCase{
+		{
+			path:     "world",
+			expected: []ChartAccountAddresses{{Path: "world", Segments: []ChartAddressSegment{{Name: "world"}}}},
+		},
+		{
+			path: "banks",
+			expected: []ChartAccountAddresses{
+				{Path: "banks:central", Segments: []ChartAddressSegment{{Name: "banks"}, {Name: "central"}}},
+				{Path: "banks:$bankID:main", Segments: mainSegments},
+			},
+		},
+		{
+			path:     "banks:$bankID:main",
+			expected: []ChartAccountAddresses{{Path: "banks:$bankID:main", Segments: mainSegments}},
+		},
+		{
+			// a path is not the prefix of the paths sharing its first characters
+			path:     "bank",
+			expected: []ChartAccountAddresses{},
+		},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, chart.AccountAddresses(tc.path))
+		})
+	}
+}
+
+func TestRollUpChartTree(t *testing.T) {
+	t.Parallel()
+
+	chart := ChartOfAccounts{
+		"banks": {
+			FixedSegments: map[string]ChartSegment{
+				"central": {
+					Account: &ChartAccount{},
+				},
+			},
+			VariableSegment: &ChartVariableSegment{
+				Label: "bankID",
+				ChartSegment: ChartSegment{
+					Account: &ChartAccount{},
+				},
+			},
+		},
+		"world": {
+			Account: &ChartAccount{},
+		},
+	}
+
+	tree := chart.Tree()
+	RollUpChartTree(tree, map[string]int{
+		"banks:central": 1,
+		"banks:$bankID": 2,
+		"world":         1,
+	}, ChartAccountsVolumes{Volumes: map[string]VolumesByAssets{
+		"banks:central": {"USD": NewVolumesInt64(100, 0)},
+		"banks:$bankID": {"USD": NewVolumesInt64(50, 20), "EUR": NewVolumesInt64(10, 0)},
+		"world":         {"USD": NewVolumesInt64(0, 150), "EUR": NewVolumesInt64(0, 10)},
+	}})
+
+	require.Equal(t, 3, tree[0].AccountsCount)
+	require.Equal(t, BalancesByAssets{"USD": big.NewInt(130), "EUR": big.NewInt(10)}, tree[0].Balances)
+	require.Equal(t, 1, tree[0].Children[0].AccountsCount)
+	require.Equal(t, BalancesByAssets{"USD": big.NewInt(100)}, tree[0].Children[0].Balances)
+	require.Equal(t, 2, tree[0].Children[1].AccountsCount)
+	require.Equal(t, BalancesByAssets{"USD": big.NewInt(30), "EUR": big.NewInt(10)}, tree[0].Children[1].Balances)
+	require.Equal(t, 1, tree[1].AccountsCount)
+	require.Equal(t, BalancesByAssets{"USD": big.NewInt(-150), "EUR": big.NewInt(-10)}, tree[1].Balances)
+}
+
+func TestChartTreeClasses(t *testing.T) {
+	t.Parallel()
+
+	chart := ChartOfAccounts{
+		"banks": {
+			Class: AccountingClassAsset,
+			VariableSegment: &ChartVariableSegment{
+				Label: "bankID",
+				ChartSegment: ChartSegment{
+					Account: &ChartAccount{},
+				},
+			},
+		},
+		"merchants": {
+			VariableSegment: &ChartVariableSegment{
+				Label: "merchantID",
+				ChartSegment: ChartSegment{
+					FixedSegments: map[string]ChartSegment{
+						"fees": {
+							Class:   AccountingClassIncome,
+							Account: &ChartAccount{},
+						},
+						"payable": {
+							Class:   AccountingClassLiability,
+							Account: &ChartAccount{},
+						},
+					},
+				},
+			},
+		},
+		"world": {
+			Account: &ChartAccount{},
+		},
+	}
+
+	tree := chart.Tree()
+	require.Equal(t, AccountingClassAsset, tree[0].Class)
+	require.Equal(t, AccountingClassAsset, tree[0].Children[0].Class)
+	require.Empty(t, tree[1].Class)
+	require.Empty(t, tree[2].Class)
+
+	require.Equal(t, []string{"banks"}, chart.ClassifiedPaths(AccountingClassAsset))
+	require.Equal(t, []string{"merchants:$merchantID:fees"}, chart.ClassifiedPaths(AccountingClassIncome))
+	require.Equal(t, []string{"merchants:$merchantID:payable"}, chart.ClassifiedPaths(AccountingClassLiability))
+	require.Empty(t, chart.ClassifiedPaths(AccountingClassExpense))
+}
+
+func TestChartAccountAddresses(t *testing.T) {
+	t.Parallel()
+
+	chart := ChartOfAccounts{
+		"users": {
+			FixedSegments: map[string]ChartSegment{
+				"main": {Account: &ChartAccount{}},
+			},
+			VariableSegment: &ChartVariableSegment{
+				Label:   "userID",
+				Pattern: pointer.For("^[0-9]+$"),
+				ChartSegment: ChartSegment{
+					Account: &ChartAccount{},
+				},
+			},
+		},
+		"world": {
+			Account: &ChartAccount{},
+		},
+	}
+
+	require.Equal(t, []ChartAccountAddresses{
+		{Path: "users:main", Segments: []ChartAddressSegment{{Name: "users"}, {Name: "main"}}},
+		{Path: "users:$userID", Segments: []ChartAddressSegment{{Name: "users"}, {Pattern: pointer.For("^[0-9]+$"), Excluded: []string{"main"}}}},
+		{Path: "world", Segments: []ChartAddressSegment{{Name: "world"}}},
+	}, chart.AccountAddresses(""))
+}
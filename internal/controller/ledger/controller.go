@@ -3,12 +3,15 @@ package ledger
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"math/big"
 
 	"github.com/uptrace/bun"
 
 	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
 	"github.com/formancehq/go-libs/v5/pkg/storage/migrations"
 	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
 
 	ledger "github.com/formancehq/ledger/internal"
 	"github.com/formancehq/ledger/internal/machine/vm"
@@ -49,30 +52,121 @@ type Controller interface {
 	//  * ErrTransactionReferenceConflict
 	//  * ErrIdempotencyKeyConflict
 	//  * ErrInsufficientFunds
+	//  * ErrPeriodClosed : indicate the timestamp is before the close date of the ledger
+	//  * ErrPreconditionFailed : indicate the preconditions of the parameters are not met
 	CreateTransaction(ctx context.Context, parameters Parameters[CreateTransaction]) (*ledger.Log, *ledger.CreatedTransaction, bool, error)
 	// RevertTransaction allow to revert a transaction.
 	// It can return following errors:
 	//  * ErrInsufficientFunds
 	//  * ErrAlreadyReverted
 	//  * ErrNotFound
+	//  * ErrPeriodClosed : indicate the revert is at the effective date of a transaction before the close date of the ledger
+	//  * ErrPreconditionFailed : indicate the preconditions of the parameters are not met
 	// Parameter force indicate we want to force revert the transaction even if the accounts does not have funds
 	// Parameter atEffectiveDate indicate we want to set the timestamp of the newly created transaction on the timestamp of the reverted transaction
 	RevertTransaction(ctx context.Context, parameters Parameters[RevertTransaction]) (*ledger.Log, *ledger.RevertedTransaction, bool, error)
+	// PartiallyRevertTransaction allow to revert a part of the amounts of the postings of a transaction.
+	// The amounts reverted by partial reverts are tracked on the transaction, and cannot exceed the amounts of the postings.
+	// Once all the amounts are reverted, the transaction is marked as reverted.
+	// It can return following errors:
+	//  * ErrInsufficientFunds
+	//  * ErrAlreadyReverted
+	//  * ErrNotFound
+	//  * ErrInvalidPartialRevert : indicate the amounts or the ratio to revert are invalid
+	//  * ErrPeriodClosed : indicate the revert is at the effective date of a transaction before the close date of the ledger
+	PartiallyRevertTransaction(ctx context.Context, parameters Parameters[PartiallyRevertTransaction]) (*ledger.Log, *ledger.PartiallyRevertedTransaction, bool, error)
 	// SaveTransactionMetadata allow to add metadata to an existing transaction
 	// It can return following errors:
 	//  * ErrNotFound
+	//  * ErrPreconditionFailed : indicate the preconditions of the parameters are not met
 	SaveTransactionMetadata(ctx context.Context, parameters Parameters[SaveTransactionMetadata]) (*ledger.Log, bool, error)
 	// SaveAccountMetadata allow to add metadata to an account
 	// If the account does not exist, it is created
+	// It can return following errors:
+	//  * ErrPreconditionFailed : indicate the preconditions of the parameters are not met
 	SaveAccountMetadata(ctx context.Context, parameters Parameters[SaveAccountMetadata]) (*ledger.Log, bool, error)
 	// DeleteTransactionMetadata allow to remove metadata of a transaction
 	// It can return following errors:
 	//  * ErrNotFound : indicate the transaction was not found OR the metadata does not exist on the transaction
+	//  * ErrPreconditionFailed : indicate the preconditions of the parameters are not met
 	DeleteTransactionMetadata(ctx context.Context, parameters Parameters[DeleteTransactionMetadata]) (*ledger.Log, bool, error)
 	// DeleteAccountMetadata allow to remove metadata of an account
 	// It can return following errors:
 	//  * ErrNotFound : indicate the account was not found OR the metadata does not exist on the account
+	//  * ErrPreconditionFailed : indicate the preconditions of the parameters are not met
 	DeleteAccountMetadata(ctx context.Context, parameters Parameters[DeleteAccountMetadata]) (*ledger.Log, bool, error)
+	// GetClosedPeriod returns the closed period of the ledger, or nil if no period is closed
+	GetClosedPeriod(ctx context.Context) (*ledger.ClosedPeriod, error)
+	// ClosePeriod forbids the creation and the revert of transactions effective before the close date
+	// It can return following errors:
+	//  * ErrInvalidPeriod : indicate the close date is in the future or not after the current close date
+	ClosePeriod(ctx context.Context, parameters Parameters[ClosePeriod]) (*ledger.Log, *ledger.ClosedPeriod, bool, error)
+	// ReopenPeriod moves the close date back, or reopens all the periods if the requested close date is nil
+	// It can return following errors:
+	//  * ErrInvalidPeriod : indicate no period is closed or the requested close date is not before the current one
+	ReopenPeriod(ctx context.Context, parameters Parameters[ReopenPeriod]) (*ledger.Log, *ledger.ReopenedPeriod, bool, error)
+	GetHold(ctx context.Context, id string) (*ledger.Hold, error)
+	// CreateHold reserves funds on the source account, the amount is deducted from the available balance until the hold ends
+	// Can return following errors:
+	//  * ErrInvalidHold : indicate the hold is malformed
+	//  * machine.ErrInsufficientFund : indicate the available balance of the source is lower than the amount, minus the overdraft allowed by the chart of accounts
+	//  * ErrSchemaValidationError : indicate the accounts or the asset are not allowed by the chart of accounts
+	CreateHold(ctx context.Context, parameters Parameters[CreateHold]) (*ledger.Log, *ledger.CreatedHold, bool, error)
+	// CaptureHold ends a hold with a transaction moving the captured amount, or the full amount if not specified
+	// Can return following errors:
+	//  * ErrInvalidHold : indicate the captured amount is not positive or exceeds the hold
+	//  * ErrHoldNotPending : indicate the hold has already been captured or voided
+	//  * ErrHoldExpired : indicate the hold has expired
+	//  * ErrSchemaValidationError : indicate the accounts or the asset are not allowed by the chart of accounts
+	//  * ErrAccountRuleViolation : indicate the balances after the capture violate the rules of the chart of accounts
+	CaptureHold(ctx context.Context, parameters Parameters[CaptureHold]) (*ledger.Log, *ledger.CapturedHold, bool, error)
+	// VoidHold ends a hold without moving funds
+	// Can return following errors:
+	//  * ErrHoldNotPending : indicate the hold has already been captured or voided
+	VoidHold(ctx context.Context, parameters Parameters[VoidHold]) (*ledger.Log, *ledger.VoidedHold, bool, error)
+	GetRevertJob(ctx context.Context, id string) (*ledger.RevertJob, error)
+	// CreateRevertJob creates a job reverting asynchronously the non reverted transactions matching a filter
+	// Each transaction is reverted like with RevertTransaction, the job id is added to the metadata of the revert transaction.
+	// It can return following errors:
+	//  * common.ErrInvalidQuery : indicate the filter is malformed
+	CreateRevertJob(ctx context.Context, input CreateRevertJob) (*ledger.RevertJob, error)
+	// RunRevertJob reverts the next batch of transactions of a job and saves its progress in the same sql transaction
+	// The transactions which cannot be reverted are counted as failed, the job continues with the next ones.
+	// A job already run by another worker is reported as not found.
+	RunRevertJob(ctx context.Context, id string, batchSize int) (*ledger.RevertJob, []ledger.RevertedTransaction, error)
+	GetScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error)
+	// ScheduleTransaction registers a transaction to create at a future date
+	// It can return following errors:
+	//  * ErrInvalidScheduledTransaction : indicate the date is not in the future or the transaction is malformed
+	ScheduleTransaction(ctx context.Context, input ScheduleTransaction) (*ledger.ScheduledTransaction, error)
+	// CancelScheduledTransaction prevents the execution of a scheduled transaction
+	// It can return following errors:
+	//  * ErrScheduledTransactionNotPending : indicate the transaction has already been executed or canceled
+	CancelScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error)
+	// ExecuteScheduledTransaction creates a due scheduled transaction and records the outcome on the entry
+	// The transactions which cannot be created are marked as failed with the reason of the failure.
+	// The created transaction is nil if the scheduled transaction has not been executed.
+	ExecuteScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, *ledger.CreatedTransaction, error)
+	ListRecurrences(ctx context.Context, query common.PaginatedQuery[any]) (*paginate.Cursor[ledger.Recurrence], error)
+	GetRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error)
+	// CreateRecurrence registers a recurrence creating transactions from a template of the schema on a schedule
+	// It can return following errors:
+	//  * ErrInvalidRecurrence : indicate the schedule, the template, the variables or the accounts are invalid
+	//  * ErrSchemaNotFound : indicate the schema version does not exist
+	CreateRecurrence(ctx context.Context, input CreateRecurrence) (*ledger.Recurrence, error)
+	// PauseRecurrence stops the creation of the transactions until the recurrence is resumed
+	// It can return following errors:
+	//  * ErrRecurrenceCompleted : indicate the schedule has no more occurrence
+	PauseRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error)
+	// ResumeRecurrence restarts a paused recurrence, the occurrences missed while paused are skipped
+	// It can return following errors:
+	//  * ErrRecurrenceCompleted : indicate the schedule has no more occurrence
+	ResumeRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error)
+	DeleteRecurrence(ctx context.Context, id string) error
+	// RunRecurrence creates the transactions of the next batch of accounts of the next occurrence of a due recurrence
+	// and plans the following occurrence once all the accounts have been processed.
+	// The transactions which cannot be created are counted as failed, the recurrence continues with the next ones.
+	RunRecurrence(ctx context.Context, id string, batchSize int) (*ledger.Recurrence, []ledger.CreatedTransaction, error)
 	// Import allow to import the logs of an existing ledger
 	// It can return following errors:
 	//  * ErrImport
@@ -86,6 +180,43 @@ type Controller interface {
 	GetSchema(ctx context.Context, version string) (*ledger.Schema, error)
 	// ListSchemas List all schemas for the ledger
 	ListSchemas(ctx context.Context, query common.PaginatedQuery[any]) (*paginate.Cursor[ledger.Schema], error)
+	// DiffSchema compares schema data with an existing schema version, or the latest one if the version is empty.
+	// It also returns the accounts of the paginated query matching the chart of accounts of the compared version
+	// but not the new one, or all the accounts not matching the new one when there is no version to compare with.
+	// It can return following errors:
+	//  * ErrNotFound : indicate the version to compare with was not found
+	DiffSchema(ctx context.Context, version string, data ledger.SchemaData, query common.PaginatedQuery[any]) (*ledger.SchemaDiff, *paginate.Cursor[ledger.Account], error)
+	// GetChartTree returns the chart of accounts of a schema version as a tree,
+	// with the number of accounts and the aggregated balances of the subtree of each segment.
+	// The filter of the query, if any, is applied in addition to the address filter of each segment.
+	// It can return following errors:
+	//  * ErrNotFound : indicate the version was not found
+	GetChartTree(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) ([]ledger.ChartTreeNode, error)
+	// GetTrialBalance returns the debit (input) and credit (output) totals of each segment of the chart of accounts
+	// of a schema version, by asset.
+	// It can return following errors:
+	//  * ErrNotFound : indicate the version was not found
+	GetTrialBalance(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.TrialBalance, error)
+	// GetBalanceSheet returns the asset, liability and equity classes of the chart of accounts of a schema version at the PIT of the query.
+	// It can return following errors:
+	//  * ErrNotFound : indicate the version was not found
+	GetBalanceSheet(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.BalanceSheet, error)
+	// GetIncomeStatement returns the income and expense classes of the chart of accounts of a schema version
+	// between the OOT and the PIT of the query.
+	// It can return following errors:
+	//  * ErrNotFound : indicate the version was not found
+	GetIncomeStatement(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.IncomeStatement, error)
+	// GetBalanceHistory returns the aggregated balances of the accounts matching the query at the end of each interval
+	// between the OOT (start) and the PIT (end) of the query.
+	// It can return following errors:
+	//  * ErrInvalidQuery : indicate the dates or the interval are invalid
+	GetBalanceHistory(ctx context.Context, query common.ResourceQuery[ledger.GetBalanceHistoryOptions]) ([]ledger.BalanceHistoryPoint, error)
+	// GetAccountStatement returns a page of the moves of an account between the OOT (start) and the PIT (end) of the query, both included,
+	// with the running balances, the balances before the start and the balances at the end.
+	// The moves are paginated by offset.
+	// It can return following errors:
+	//  * ErrInvalidQuery : indicate the dates or the cursor are invalid
+	GetAccountStatement(ctx context.Context, address string, query common.PaginatedQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.AccountStatement, error)
 
 	// Run a query template on the ledger
 	RunQuery(ctx context.Context, schemaVersion string, queryId string, runQuery common.RunQuery, defaultPageSize common.PaginationConfig) (*queries.ResourceKind, *paginate.Cursor[any], error)
@@ -108,6 +239,17 @@ type RevertTransaction struct {
 	Metadata        metadata.Metadata
 }
 
+type PartiallyRevertTransaction struct {
+	Force           bool
+	AtEffectiveDate bool
+	TransactionID   uint64
+	// Amounts are the amounts to revert by index of the postings
+	Amounts map[int]*big.Int
+	// Ratio reverts the same part of the remaining amounts of all the postings, exclusive with Amounts
+	Ratio    *big.Rat
+	Metadata metadata.Metadata
+}
+
 type SaveTransactionMetadata struct {
 	TransactionID uint64
 	Metadata      metadata.Metadata
@@ -132,3 +274,60 @@ type InsertSchema struct {
 	Version string
 	Data    ledger.SchemaData
 }
+
+type ClosePeriod struct {
+	ClosedUntil time.Time
+}
+
+type ReopenPeriod struct {
+	// ClosedUntil is the new close date, nil to reopen all the periods
+	ClosedUntil *time.Time
+}
+
+type CreateHold struct {
+	Source      string
+	Destination string
+	Asset       string
+	Amount      *big.Int
+	Metadata    metadata.Metadata
+	// ExpiresAt is the date after which the hold cannot be captured, nil if the hold does not expire
+	ExpiresAt *time.Time
+}
+
+type CaptureHold struct {
+	ID string
+	// Amount is the captured amount, nil to capture the full amount of the hold
+	Amount   *big.Int
+	Metadata metadata.Metadata
+}
+
+type VoidHold struct {
+	ID string
+}
+
+type ScheduleTransaction struct {
+	ScheduledAt   time.Time
+	Transaction   ledger.ScheduledTransactionData
+	SchemaVersion string
+}
+
+type CreateRecurrence struct {
+	Schedule string
+	// StartAt anchors the schedule, it defaults to the creation date
+	StartAt    *time.Time
+	Template   string
+	Vars       map[string]string
+	Metadata   metadata.Metadata
+	Accounts   string
+	AccountVar string
+	// SchemaVersion is the version of the schema holding the template, it defaults to the default schema version of the ledger
+	SchemaVersion string
+}
+
+type CreateRevertJob struct {
+	// Query is the filter of the transactions to revert, using the syntax of ListTransactions
+	Query           json.RawMessage
+	Force           bool
+	AtEffectiveDate bool
+	Metadata        metadata.Metadata
+}
@@ -5,8 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"maps"
+	"math"
 	"math/big"
 	"reflect"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
@@ -31,6 +34,8 @@ import (
 	storagecommon "github.com/formancehq/ledger/internal/storage/common"
 	ledgerstore "github.com/formancehq/ledger/internal/storage/ledger"
 	"github.com/formancehq/ledger/internal/tracing"
+	"github.com/formancehq/ledger/pkg/accounts"
+	"github.com/formancehq/ledger/pkg/assets"
 	"github.com/formancehq/ledger/pkg/features"
 )
 
@@ -49,14 +54,21 @@ type DefaultController struct {
 	deadLockCounter         metric.Int64Counter
 
 	schemaEnforcementMode SchemaEnforcementMode
+	defaultSchemaVersion  string
 
 	createTransactionLp         *logProcessor[CreateTransaction, ledger.CreatedTransaction]
 	revertTransactionLp         *logProcessor[RevertTransaction, ledger.RevertedTransaction]
+	partiallyRevertTxLp         *logProcessor[PartiallyRevertTransaction, ledger.PartiallyRevertedTransaction]
 	saveTransactionMetadataLp   *logProcessor[SaveTransactionMetadata, ledger.SavedMetadata]
 	saveAccountMetadataLp       *logProcessor[SaveAccountMetadata, ledger.SavedMetadata]
 	deleteTransactionMetadataLp *logProcessor[DeleteTransactionMetadata, ledger.DeletedMetadata]
 	deleteAccountMetadataLp     *logProcessor[DeleteAccountMetadata, ledger.DeletedMetadata]
 	insertSchemaLp              *logProcessor[InsertSchema, ledger.InsertedSchema]
+	closePeriodLp               *logProcessor[ClosePeriod, ledger.ClosedPeriod]
+	reopenPeriodLp              *logProcessor[ReopenPeriod, ledger.ReopenedPeriod]
+	createHoldLp                *logProcessor[CreateHold, ledger.CreatedHold]
+	captureHoldLp               *logProcessor[CaptureHold, ledger.CapturedHold]
+	voidHoldLp                  *logProcessor[VoidHold, ledger.VoidedHold]
 }
 
 func (ctrl *DefaultController) InsertSchema(ctx context.Context, parameters Parameters[InsertSchema]) (*ledger.Log, *ledger.InsertedSchema, bool, error) {
@@ -97,6 +109,389 @@ func (ctrl *DefaultController) ListSchemas(ctx context.Context, query storagecom
 	return ctrl.store.FindSchemas(ctx, query)
 }
 
+func (ctrl *DefaultController) DiffSchema(ctx context.Context, version string, data ledger.SchemaData, query storagecommon.PaginatedQuery[any]) (*ledger.SchemaDiff, *paginate.Cursor[ledger.Account], error) {
+	if version == "" {
+		latestVersion, err := ctrl.store.FindLatestSchemaVersion(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if latestVersion != nil {
+			version = *latestVersion
+		}
+	}
+
+	from := ledger.SchemaData{}
+	if version != "" {
+		schema, err := ctrl.store.FindSchema(ctx, version)
+		if err != nil {
+			return nil, nil, err
+		}
+		from = schema.SchemaData
+	}
+	diff := ledger.DiffSchemas(from, data)
+
+	// the accounts are filtered by the database, so the pages are full
+	options := ledgerstore.AccountsOptions{
+		IncompatibleWith: data.Chart,
+	}
+	if version != "" {
+		// only the accounts valid for the compared chart are broken by the new one,
+		// without a schema to compare with, every account not valid for the new chart is listed
+		options.ChartAccounts = from.Chart.AccountAddresses("")
+	}
+	switch v := query.(type) {
+	case storagecommon.InitialPaginatedQuery[any]:
+		v.Options.Opts = options
+		query = v
+	case storagecommon.ColumnPaginatedQuery[any]:
+		v.Options.Opts = options
+		query = v
+	case storagecommon.OffsetPaginatedQuery[any]:
+		v.Options.Opts = options
+		query = v
+	}
+
+	incompatibleAccounts, err := ctrl.store.Accounts().Paginate(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &diff, incompatibleAccounts, nil
+}
+
+func (ctrl *DefaultController) GetChartTree(ctx context.Context, version string, q storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]) ([]ledger.ChartTreeNode, error) {
+	// the nodes are computed from the same snapshot of the ledger, so the children add up to their parent
+	return withSnapshot(ctx, ctrl.store, func(store Store) ([]ledger.ChartTreeNode, error) {
+		cp := *ctrl
+		cp.store = store
+
+		schema, err := store.FindSchema(ctx, version)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts := schema.Chart.AccountAddresses("")
+		counts, err := cp.countChartAccounts(ctx, accounts, q)
+		if err != nil {
+			return nil, err
+		}
+		volumes, err := cp.getChartAccountsVolumes(ctx, accounts, q)
+		if err != nil {
+			return nil, err
+		}
+
+		tree := schema.Chart.Tree()
+		ledger.RollUpChartTree(tree, counts, volumes)
+
+		return tree, nil
+	})
+}
+
+// countChartAccounts counts the accounts existing at the PIT of the query by path of account in the chart
+func (ctrl *DefaultController) countChartAccounts(ctx context.Context, accounts []ledger.ChartAccountAddresses, q storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (map[string]int, error) {
+	cursor, err := ctrl.store.Accounts().Aggregate(ctx, storagecommon.AggregatedQuery[any]{
+		Options: storagecommon.ResourceQuery[any]{
+			PIT:     q.PIT,
+			Builder: q.Builder,
+			Opts: ledgerstore.AccountsOptions{
+				ChartAccounts: accounts,
+			},
+		},
+		Aggregation: queries.Aggregation{
+			GroupBy: []string{ledgerstore.ChartAccountAggregationKey},
+			Measures: map[string]queries.Measure{
+				"count": {Function: queries.MeasureCount},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]int, len(cursor.Data))
+	for _, group := range cursor.Data {
+		path := group.Keys[ledgerstore.ChartAccountAggregationKey]
+		if path == nil || group.Measures["count"] == nil {
+			continue
+		}
+		ret[*path] = int(group.Measures["count"].Int64())
+	}
+
+	return ret, nil
+}
+
+// getChartAccountsVolumes returns the volumes of the accounts at the PIT of the query by path of account in the chart
+func (ctrl *DefaultController) getChartAccountsVolumes(ctx context.Context, accounts []ledger.ChartAccountAddresses, q storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (ledger.ChartAccountsVolumes, error) {
+	volumes, err := ctrl.store.ChartAccountsVolumes(accounts).GetOne(ctx, storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+		PIT:     q.PIT,
+		Builder: q.Builder,
+		Opts:    q.Opts,
+	})
+	if err != nil {
+		return ledger.ChartAccountsVolumes{}, err
+	}
+
+	return *volumes, nil
+}
+
+func (ctrl *DefaultController) GetTrialBalance(ctx context.Context, version string, q storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.TrialBalance, error) {
+	// the nodes are computed from the same snapshot of the ledger, so they add up to the totals
+	return withSnapshot(ctx, ctrl.store, func(store Store) (*ledger.TrialBalance, error) {
+		cp := *ctrl
+		cp.store = store
+
+		schema, err := store.FindSchema(ctx, version)
+		if err != nil {
+			return nil, err
+		}
+
+		volumes, err := cp.getChartAccountsVolumes(ctx, schema.Chart.AccountAddresses(""), q)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes := ledger.NewTrialBalanceNodes(schema.Chart.Tree())
+		rollUpTrialBalance(nodes, volumes)
+
+		totals := ledger.TrialBalanceTotalsByAssets{}
+		for _, node := range nodes {
+			totals.Add(node.Totals)
+		}
+
+		return &ledger.TrialBalance{
+			Nodes:  nodes,
+			Totals: totals,
+		}, nil
+	})
+}
+
+func rollUpTrialBalance(nodes []ledger.TrialBalanceNode, volumes ledger.ChartAccountsVolumes) {
+	for i := range nodes {
+		nodes[i].Totals = ledger.NewTrialBalanceTotals(volumes.Subtree(nodes[i].Path))
+		rollUpTrialBalance(nodes[i].Children, volumes)
+	}
+}
+
+func (ctrl *DefaultController) GetBalanceSheet(ctx context.Context, version string, q storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.BalanceSheet, error) {
+	// the sections are computed from the same snapshot of the ledger, so the balance sheet balances
+	return withSnapshot(ctx, ctrl.store, func(store Store) (*ledger.BalanceSheet, error) {
+		cp := *ctrl
+		cp.store = store
+
+		schema, err := store.FindSchema(ctx, version)
+		if err != nil {
+			return nil, err
+		}
+
+		// the balance sheet is a position, the net income is accumulated since the beginning
+		q.OOT = nil
+
+		closing, opening, err := cp.getFinancialStatementVolumes(ctx, schema.Chart, q)
+		if err != nil {
+			return nil, err
+		}
+
+		sections := make(map[ledger.AccountingClass]ledger.FinancialStatementSection)
+		for _, class := range []ledger.AccountingClass{
+			ledger.AccountingClassAsset,
+			ledger.AccountingClassLiability,
+			ledger.AccountingClassEquity,
+			ledger.AccountingClassIncome,
+			ledger.AccountingClassExpense,
+		} {
+			sections[class] = getFinancialStatementSection(schema.Chart, class, closing, opening)
+		}
+
+		return &ledger.BalanceSheet{
+			Assets:      sections[ledger.AccountingClassAsset],
+			Liabilities: sections[ledger.AccountingClassLiability],
+			Equity:      sections[ledger.AccountingClassEquity],
+			NetIncome:   ledger.NetIncome(sections[ledger.AccountingClassIncome], sections[ledger.AccountingClassExpense]),
+		}, nil
+	})
+}
+
+func (ctrl *DefaultController) GetIncomeStatement(ctx context.Context, version string, q storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.IncomeStatement, error) {
+	// the sections are computed from the same snapshot of the ledger
+	return withSnapshot(ctx, ctrl.store, func(store Store) (*ledger.IncomeStatement, error) {
+		cp := *ctrl
+		cp.store = store
+
+		schema, err := store.FindSchema(ctx, version)
+		if err != nil {
+			return nil, err
+		}
+
+		closing, opening, err := cp.getFinancialStatementVolumes(ctx, schema.Chart, q)
+		if err != nil {
+			return nil, err
+		}
+
+		income := getFinancialStatementSection(schema.Chart, ledger.AccountingClassIncome, closing, opening)
+		expenses := getFinancialStatementSection(schema.Chart, ledger.AccountingClassExpense, closing, opening)
+
+		return &ledger.IncomeStatement{
+			Income:    income,
+			Expenses:  expenses,
+			NetIncome: ledger.NetIncome(income, expenses),
+		}, nil
+	})
+}
+
+// getFinancialStatementVolumes returns the volumes of the accounts of the chart at the PIT of the query,
+// and before the OOT of the query if any
+func (ctrl *DefaultController) getFinancialStatementVolumes(ctx context.Context, chart ledger.ChartOfAccounts, q storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (ledger.ChartAccountsVolumes, ledger.ChartAccountsVolumes, error) {
+	accounts := chart.AccountAddresses("")
+	closing, err := ctrl.getChartAccountsVolumes(ctx, accounts, q)
+	if err != nil {
+		return ledger.ChartAccountsVolumes{}, ledger.ChartAccountsVolumes{}, err
+	}
+
+	var opening ledger.ChartAccountsVolumes
+	if q.OOT != nil {
+		// dates are stored with a microsecond precision, so the balances just before the start
+		// are the balances at the previous microsecond
+		openingDate := q.OOT.Add(-time.DatePrecision)
+		opening, err = ctrl.getChartAccountsVolumes(ctx, accounts, storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+			PIT:     &openingDate,
+			Builder: q.Builder,
+			Opts:    q.Opts,
+		})
+		if err != nil {
+			return ledger.ChartAccountsVolumes{}, ledger.ChartAccountsVolumes{}, err
+		}
+	}
+
+	return closing, opening, nil
+}
+
+// getFinancialStatementSection computes the amounts of the classified segments of the chart from the closing volumes,
+// minus the opening volumes
+func getFinancialStatementSection(chart ledger.ChartOfAccounts, class ledger.AccountingClass, closing, opening ledger.ChartAccountsVolumes) ledger.FinancialStatementSection {
+	lines := make([]ledger.FinancialStatementLine, 0)
+	for _, path := range chart.ClassifiedPaths(class) {
+		lines = append(lines, ledger.NewFinancialStatementLine(class, path, closing.Subtree(path).Balances(), opening.Subtree(path).Balances()))
+	}
+
+	return ledger.NewFinancialStatementSection(class, lines)
+}
+
+func (ctrl *DefaultController) GetBalanceHistory(ctx context.Context, q storagecommon.ResourceQuery[ledger.GetBalanceHistoryOptions]) ([]ledger.BalanceHistoryPoint, error) {
+	if q.OOT == nil || q.PIT == nil {
+		return nil, storagecommon.NewErrInvalidQuery("both start (oot) and end (pit) dates are required")
+	}
+
+	dates, err := q.Opts.Interval.Dates(*q.OOT, *q.PIT)
+	if err != nil {
+		return nil, storagecommon.NewErrInvalidQuery("%s", err)
+	}
+
+	history, err := ctrl.store.AggregatedVolumesHistory(dates).GetOne(ctx, storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+		Builder: q.Builder,
+		Opts:    q.Opts.GetAggregatedVolumesOptions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(history.Aggregated) != len(dates) {
+		return nil, fmt.Errorf("expected %d points in the balance history, got %d", len(dates), len(history.Aggregated))
+	}
+
+	ret := make([]ledger.BalanceHistoryPoint, 0, len(dates))
+	for i, date := range dates {
+		ret = append(ret, ledger.BalanceHistoryPoint{
+			Date:     date,
+			Balances: history.Aggregated[i].Balances(),
+		})
+	}
+
+	return ret, nil
+}
+
+func (ctrl *DefaultController) GetAccountStatement(ctx context.Context, address string, q storagecommon.PaginatedQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.AccountStatement, error) {
+	// the moves are ordered by date, which is not unique, so they are paginated by offset
+	var paginatedQuery storagecommon.OffsetPaginatedQuery[ledger.GetAggregatedVolumesOptions]
+	switch v := q.(type) {
+	case storagecommon.InitialPaginatedQuery[ledger.GetAggregatedVolumesOptions]:
+		paginatedQuery = storagecommon.OffsetPaginatedQuery[ledger.GetAggregatedVolumesOptions]{InitialPaginatedQuery: v}
+	case storagecommon.OffsetPaginatedQuery[ledger.GetAggregatedVolumesOptions]:
+		paginatedQuery = v
+	default:
+		return nil, storagecommon.NewErrInvalidQuery("the moves of a statement are paginated by offset")
+	}
+
+	options := paginatedQuery.Options
+	if options.OOT == nil || options.PIT == nil {
+		return nil, storagecommon.NewErrInvalidQuery("both start and end dates are required")
+	}
+	if options.PIT.Before(*options.OOT) {
+		return nil, storagecommon.NewErrInvalidQuery("the start date must be before the end date")
+	}
+	if paginatedQuery.Offset > math.MaxInt32 {
+		return nil, storagecommon.NewErrInvalidQuery("offset value exceeds maximum allowed value")
+	}
+
+	// the balances and the moves are read from the same snapshot of the ledger,
+	// so a move committed in between can't be counted twice or missed
+	return withSnapshot(ctx, ctrl.store, func(store Store) (*ledger.AccountStatement, error) {
+		getBalances := func(date time.Time) (ledger.BalancesByAssets, error) {
+			balances, err := store.AggregatedBalances().GetOne(ctx, storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+				PIT: &date,
+				// $in matches the address exactly like the moves, where $match accepts partial addresses
+				Builder: query.In("address", []any{address}),
+				Opts:    options.Opts,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return balances.Aggregated.Balances(), nil
+		}
+
+		// dates are stored with a microsecond precision, so the balances just before the start
+		// are the balances at the previous microsecond
+		opening, err := getBalances(options.OOT.Add(-time.DatePrecision))
+		if err != nil {
+			return nil, err
+		}
+		closing, err := getBalances(*options.PIT)
+		if err != nil {
+			return nil, err
+		}
+
+		movesQuery := ledgerstore.AccountMovesQuery{
+			Account:          address,
+			OOT:              *options.OOT,
+			PIT:              *options.PIT,
+			UseInsertionDate: options.Opts.UseInsertionDate,
+			Offset:           int(paginatedQuery.Offset),
+		}
+		if paginatedQuery.PageSize > 0 {
+			// one more move tells if a next page exists
+			movesQuery.Limit = int(paginatedQuery.PageSize) + 1
+		}
+		moves, err := store.ListAccountMoves(ctx, movesQuery)
+		if err != nil {
+			return nil, err
+		}
+
+		cursor, err := storagecommon.BuildOffsetCursor(paginatedQuery, moves)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ledger.AccountStatement{
+			Account:         address,
+			StartTime:       *options.OOT,
+			EndTime:         *options.PIT,
+			OpeningBalances: opening,
+			Lines: paginate.MapCursor(cursor, func(move ledger.Move) ledger.AccountStatementLine {
+				return ledger.NewAccountStatementLine(move, options.Opts.UseInsertionDate)
+			}),
+			ClosingBalances: closing,
+		}, nil
+	})
+}
+
 func (ctrl *DefaultController) Info() ledger.Ledger {
 	return ctrl.ledger
 }
@@ -159,6 +554,12 @@ func NewDefaultController(
 		opt(ret)
 	}
 
+	// settings of the ledger take precedence over the ones of the instance
+	if l.SchemaEnforcementMode != "" {
+		ret.schemaEnforcementMode = l.SchemaEnforcementMode
+	}
+	ret.defaultSchemaVersion = l.DefaultSchemaVersion
+
 	var err error
 	ret.executeMachineHistogram, err = ret.meter.Int64Histogram("controller.numscript_run", metric.WithUnit("ms"))
 	if err != nil {
@@ -169,13 +570,19 @@ func NewDefaultController(
 		panic(err)
 	}
 
-	ret.createTransactionLp = newLogProcessor[CreateTransaction, ledger.CreatedTransaction]("CreateTransaction", ret.deadLockCounter, ret.schemaEnforcementMode)
-	ret.revertTransactionLp = newLogProcessor[RevertTransaction, ledger.RevertedTransaction]("RevertTransaction", ret.deadLockCounter, ret.schemaEnforcementMode)
-	ret.saveTransactionMetadataLp = newLogProcessor[SaveTransactionMetadata, ledger.SavedMetadata]("SaveTransactionMetadata", ret.deadLockCounter, ret.schemaEnforcementMode)
-	ret.saveAccountMetadataLp = newLogProcessor[SaveAccountMetadata, ledger.SavedMetadata]("SaveAccountMetadata", ret.deadLockCounter, ret.schemaEnforcementMode)
-	ret.deleteTransactionMetadataLp = newLogProcessor[DeleteTransactionMetadata, ledger.DeletedMetadata]("DeleteTransactionMetadata", ret.deadLockCounter, ret.schemaEnforcementMode)
-	ret.deleteAccountMetadataLp = newLogProcessor[DeleteAccountMetadata, ledger.DeletedMetadata]("DeleteAccountMetadata", ret.deadLockCounter, ret.schemaEnforcementMode)
-	ret.insertSchemaLp = newLogProcessor[InsertSchema, ledger.InsertedSchema]("InsertSchema", ret.deadLockCounter, ret.schemaEnforcementMode)
+	ret.createTransactionLp = newLogProcessor[CreateTransaction, ledger.CreatedTransaction]("CreateTransaction", ret.deadLockCounter, ret.schemaEnforcementMode, ret.defaultSchemaVersion)
+	ret.revertTransactionLp = newLogProcessor[RevertTransaction, ledger.RevertedTransaction]("RevertTransaction", ret.deadLockCounter, ret.schemaEnforcementMode, ret.defaultSchemaVersion)
+	ret.partiallyRevertTxLp = newLogProcessor[PartiallyRevertTransaction, ledger.PartiallyRevertedTransaction]("PartiallyRevertTransaction", ret.deadLockCounter, ret.schemaEnforcementMode, ret.defaultSchemaVersion)
+	ret.saveTransactionMetadataLp = newLogProcessor[SaveTransactionMetadata, ledger.SavedMetadata]("SaveTransactionMetadata", ret.deadLockCounter, ret.schemaEnforcementMode, ret.defaultSchemaVersion)
+	ret.saveAccountMetadataLp = newLogProcessor[SaveAccountMetadata, ledger.SavedMetadata]("SaveAccountMetadata", ret.deadLockCounter, ret.schemaEnforcementMode, ret.defaultSchemaVersion)
+	ret.deleteTransactionMetadataLp = newLogProcessor[DeleteTransactionMetadata, ledger.DeletedMetadata]("DeleteTransactionMetadata", ret.deadLockCounter, ret.schemaEnforcementMode, ret.defaultSchemaVersion)
+	ret.deleteAccountMetadataLp = newLogProcessor[DeleteAccountMetadata, ledger.DeletedMetadata]("DeleteAccountMetadata", ret.deadLockCounter, ret.schemaEnforcementMode, ret.defaultSchemaVersion)
+	ret.insertSchemaLp = newLogProcessor[InsertSchema, ledger.InsertedSchema]("InsertSchema", ret.deadLockCounter, ret.schemaEnforcementMode, ret.defaultSchemaVersion)
+	ret.closePeriodLp = newLogProcessor[ClosePeriod, ledger.ClosedPeriod]("ClosePeriod", ret.deadLockCounter, ret.schemaEnforcementMode, ret.defaultSchemaVersion)
+	ret.reopenPeriodLp = newLogProcessor[ReopenPeriod, ledger.ReopenedPeriod]("ReopenPeriod", ret.deadLockCounter, ret.schemaEnforcementMode, ret.defaultSchemaVersion)
+	ret.createHoldLp = newLogProcessor[CreateHold, ledger.CreatedHold]("CreateHold", ret.deadLockCounter, ret.schemaEnforcementMode, ret.defaultSchemaVersion)
+	ret.captureHoldLp = newLogProcessor[CaptureHold, ledger.CapturedHold]("CaptureHold", ret.deadLockCounter, ret.schemaEnforcementMode, ret.defaultSchemaVersion)
+	ret.voidHoldLp = newLogProcessor[VoidHold, ledger.VoidedHold]("VoidHold", ret.deadLockCounter, ret.schemaEnforcementMode, ret.defaultSchemaVersion)
 
 	return ret
 }
@@ -200,16 +607,125 @@ func (ctrl *DefaultController) GetTransaction(ctx context.Context, q storagecomm
 	return ctrl.store.Transactions().GetOne(ctx, q)
 }
 
+// findChart returns the chart of accounts of the default schema version of the ledger, or of the latest schema.
+// It returns nil if the ledger has no schema.
+func (ctrl *DefaultController) findChart(ctx context.Context) (*ledger.ChartOfAccounts, error) {
+	version := ctrl.defaultSchemaVersion
+	if version == "" {
+		latestVersion, err := ctrl.store.FindLatestSchemaVersion(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if latestVersion == nil {
+			return nil, nil
+		}
+		version = *latestVersion
+	}
+
+	schema, err := ctrl.store.FindSchema(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.Chart, nil
+}
+
+// resolveChartLabels resolves the filters on chart labels of the query, and returns the chart of accounts
+// if the labels have to be extracted from the addresses.
+func (ctrl *DefaultController) resolveChartLabels(ctx context.Context, chart *ledger.ChartOfAccounts, q *storagecommon.ResourceQuery[any]) (*ledger.ChartOfAccounts, error) {
+	hasLabelFilters := ledger.HasLabelFilters(q.Builder)
+	expandLabels := slices.Contains(queries.ProjectExpand(q.Fields, q.Expand), "chart")
+	if !hasLabelFilters && !expandLabels {
+		return nil, nil
+	}
+
+	if chart == nil {
+		var err error
+		chart, err = ctrl.findChart(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if chart == nil {
+		if hasLabelFilters {
+			return nil, storagecommon.NewErrInvalidQuery("filters on chart labels require a schema")
+		}
+		return nil, nil
+	}
+
+	if hasLabelFilters {
+		builder, err := chart.ResolveLabelFilters(q.Builder)
+		if err != nil {
+			return nil, storagecommon.NewErrInvalidQuery("%s", err)
+		}
+		q.Builder = builder
+	}
+	if !expandLabels {
+		return nil, nil
+	}
+	return chart, nil
+}
+
+func withChartLabels(chart *ledger.ChartOfAccounts, account ledger.Account) ledger.Account {
+	if chart != nil {
+		account.ChartLabels = chart.ExtractLabels(account.Address)
+	}
+	return account
+}
+
 func (ctrl *DefaultController) CountAccounts(ctx context.Context, q storagecommon.ResourceQuery[any]) (int, error) {
+	if _, err := ctrl.resolveChartLabels(ctx, nil, &q); err != nil {
+		return 0, err
+	}
 	return ctrl.store.Accounts().Count(ctx, q)
 }
 
 func (ctrl *DefaultController) ListAccounts(ctx context.Context, q storagecommon.PaginatedQuery[any]) (*paginate.Cursor[ledger.Account], error) {
-	return ctrl.store.Accounts().Paginate(ctx, q)
+	return ctrl.listAccounts(ctx, nil, q)
+}
+
+func (ctrl *DefaultController) listAccounts(ctx context.Context, chart *ledger.ChartOfAccounts, q storagecommon.PaginatedQuery[any]) (*paginate.Cursor[ledger.Account], error) {
+	var err error
+	switch v := q.(type) {
+	case storagecommon.InitialPaginatedQuery[any]:
+		chart, err = ctrl.resolveChartLabels(ctx, chart, &v.Options)
+		q = v
+	case storagecommon.ColumnPaginatedQuery[any]:
+		chart, err = ctrl.resolveChartLabels(ctx, chart, &v.Options)
+		q = v
+	case storagecommon.OffsetPaginatedQuery[any]:
+		chart, err = ctrl.resolveChartLabels(ctx, chart, &v.Options)
+		q = v
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := ctrl.store.Accounts().Paginate(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if chart != nil {
+		for i := range cursor.Data {
+			cursor.Data[i] = withChartLabels(chart, cursor.Data[i])
+		}
+	}
+
+	return cursor, nil
 }
 
 func (ctrl *DefaultController) GetAccount(ctx context.Context, q storagecommon.ResourceQuery[any]) (*ledger.Account, error) {
-	return ctrl.store.Accounts().GetOne(ctx, q)
+	chart, err := ctrl.resolveChartLabels(ctx, nil, &q)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := ctrl.store.Accounts().GetOne(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	*account = withChartLabels(chart, *account)
+
+	return account, nil
 }
 
 func (ctrl *DefaultController) GetAggregatedBalances(ctx context.Context, q storagecommon.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (ledger.BalancesByAssets, error) {
@@ -294,6 +810,64 @@ func (ctrl *DefaultController) importLog(ctx context.Context, store Store, log l
 				if err := store.InsertSchema(ctx, &payload.Schema); err != nil {
 					return nil, fmt.Errorf("failed to insert schema: %w", err)
 				}
+			case ledger.ClosedPeriod:
+				if err := store.SaveClosedPeriod(ctx, payload); err != nil {
+					return nil, fmt.Errorf("failed to close period: %w", err)
+				}
+			case ledger.ReopenedPeriod:
+				if err := ctrl.saveReopenedPeriod(ctx, store, payload); err != nil {
+					return nil, fmt.Errorf("failed to reopen period: %w", err)
+				}
+			case ledger.CreatedHold:
+				if err := store.InsertHold(ctx, &payload.Hold); err != nil {
+					return nil, fmt.Errorf("failed to insert hold: %w", err)
+				}
+			case ledger.CapturedHold:
+				if _, _, err := store.EndHold(ctx, payload.Hold.ID, payload.Hold.Status, *payload.Hold.EndedAt); err != nil {
+					return nil, fmt.Errorf("failed to capture hold: %w", err)
+				}
+				if err := store.CommitTransaction(ctx, &payload.Transaction); err != nil {
+					return nil, fmt.Errorf("failed to commit transaction: %w", err)
+				}
+				if err := ctrl.upsertTransactionAccounts(ctx, store, nil, &payload.Transaction, nil); err != nil {
+					return nil, fmt.Errorf("failed to upsert transaction accounts: %w", err)
+				}
+			case ledger.VoidedHold:
+				if _, _, err := store.EndHold(ctx, payload.Hold.ID, payload.Hold.Status, *payload.Hold.EndedAt); err != nil {
+					return nil, fmt.Errorf("failed to void hold: %w", err)
+				}
+			case ledger.CreatedRevertJob:
+				job := payload.RevertJob
+				job.MaxTransactionID = payload.MaxTransactionID
+				if err := store.InsertRevertJob(ctx, &job); err != nil {
+					return nil, fmt.Errorf("failed to insert revert job: %w", err)
+				}
+			case ledger.UpdatedRevertJob:
+				if err := store.UpdateRevertJob(ctx, &payload.RevertJob); err != nil {
+					return nil, fmt.Errorf("failed to update revert job: %w", err)
+				}
+			case ledger.CreatedScheduledTransaction:
+				if err := store.InsertScheduledTransaction(ctx, &payload.ScheduledTransaction); err != nil {
+					return nil, fmt.Errorf("failed to insert scheduled transaction: %w", err)
+				}
+			case ledger.UpdatedScheduledTransaction:
+				if err := store.UpdateScheduledTransaction(ctx, &payload.ScheduledTransaction); err != nil {
+					return nil, fmt.Errorf("failed to update scheduled transaction: %w", err)
+				}
+			case ledger.CreatedRecurrence:
+				if err := store.InsertRecurrence(ctx, &payload.Recurrence); err != nil {
+					return nil, fmt.Errorf("failed to insert recurrence: %w", err)
+				}
+			case ledger.UpdatedRecurrence:
+				recurrence := payload.Recurrence
+				recurrence.AccountsOffset = payload.AccountsOffset
+				if err := store.UpdateRecurrence(ctx, &recurrence); err != nil {
+					return nil, fmt.Errorf("failed to update recurrence: %w", err)
+				}
+			case ledger.DeletedRecurrence:
+				if err := store.DeleteRecurrence(ctx, payload.ID); err != nil {
+					return nil, fmt.Errorf("failed to delete recurrence: %w", err)
+				}
 			case ledger.CreatedTransaction:
 				logging.FromContext(ctx).Debugf("Importing transaction %d", *payload.Transaction.ID)
 				var schema *ledger.Schema
@@ -324,6 +898,21 @@ func (ctrl *DefaultController) importLog(ctx context.Context, store Store, log l
 				if err := store.CommitTransaction(ctx, &payload.RevertTransaction); err != nil {
 					return nil, fmt.Errorf("failed to commit transaction: %w", err)
 				}
+			case ledger.PartiallyRevertedTransaction:
+				logging.FromContext(ctx).Debugf("Partially reverting transaction %d", *payload.RevertedTransaction.ID)
+				_, err := store.UpdateTransactionRevertedAmounts(
+					ctx,
+					*payload.RevertedTransaction.ID,
+					payload.RevertedTransaction.RevertedAmounts,
+					payload.RevertedTransaction.UpdatedAt,
+					payload.RevertedTransaction.IsReverted(),
+				)
+				if err != nil {
+					return nil, fmt.Errorf("failed to partially revert transaction: %w", err)
+				}
+				if err := store.CommitTransaction(ctx, &payload.RevertTransaction); err != nil {
+					return nil, fmt.Errorf("failed to commit transaction: %w", err)
+				}
 			case ledger.SavedMetadata:
 				switch payload.TargetType {
 				case ledger.MetaTargetTypeTransaction:
@@ -426,20 +1015,26 @@ func (ctrl *DefaultController) createTransaction(ctx context.Context, store Stor
 	logger := logging.FromContext(ctx).WithField("req", uuid.NewString()[:8])
 	ctx = logging.ContextWithLogger(ctx, logger)
 
+	var template *ledger.TransactionTemplate
 	if schema != nil && len(schema.Transactions) > 0 {
 		if parameters.Input.Template == "" {
 			err := newErrSchemaValidationError(parameters.SchemaVersion, fmt.Errorf("transactions on this ledger must use a template"))
-			if ctrl.schemaEnforcementMode == SchemaEnforcementStrict {
+			if err := ctrl.enforceSchema(ctx, err); err != nil {
 				return nil, err
 			}
-			trace.SpanFromContext(ctx).SetAttributes(attribute.String("schema_validation_failed", err.Error()))
-			logging.FromContext(ctx).Errorf("schema validation failed: %s", err)
-		}
-		if template, ok := schema.SchemaData.Transactions[parameters.Input.Template]; ok {
+		} else if t, ok := schema.SchemaData.Transactions[parameters.Input.Template]; ok {
+			template = &t
 			parameters.Input.Plain = template.Script
 			if parameters.Input.Runtime == "" {
 				parameters.Input.Runtime = template.Runtime
 			}
+			vars, err := template.ResolveVars(parameters.Input.Template, schema.Chart, parameters.Input.Vars)
+			if err != nil {
+				if err := ctrl.enforceSchema(ctx, newErrSchemaValidationError(parameters.SchemaVersion, err)); err != nil {
+					return nil, err
+				}
+			}
+			parameters.Input.Vars = vars
 		} else {
 			return nil, newErrSchemaValidationError(parameters.SchemaVersion, fmt.Errorf("failed to find transaction template `%s`", parameters.Input.Template))
 		}
@@ -447,6 +1042,12 @@ func (ctrl *DefaultController) createTransaction(ctx context.Context, store Stor
 		return nil, newErrSchemaValidationError(parameters.SchemaVersion, fmt.Errorf("can only use templates on a schema with transaction definitions"))
 	}
 
+	if !parameters.Input.Timestamp.IsZero() {
+		if err := ctrl.checkClosedPeriod(ctx, store, parameters.Input.Timestamp); err != nil {
+			return nil, err
+		}
+	}
+
 	m, err := ctrl.getParser(parameters.Input.Runtime).Parse(parameters.Input.Plain)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile script: %w", err)
@@ -458,7 +1059,7 @@ func (ctrl *DefaultController) createTransaction(ctx context.Context, store Stor
 		ctrl.tracer,
 		ctrl.executeMachineHistogram,
 		func(ctx context.Context) (*NumscriptExecutionResult, error) {
-			a, err := m.Execute(ctx, store, parameters.Input.Vars)
+			a, err := m.Execute(ctx, store, parameters.Input.Vars, schema)
 			return a, err
 		},
 	)
@@ -480,6 +1081,18 @@ func (ctrl *DefaultController) createTransaction(ctx context.Context, store Stor
 		}
 		finalMetadata[k] = v
 	}
+	if template != nil {
+		for k, v := range template.DefaultMetadata() {
+			if _, ok := finalMetadata[k]; !ok {
+				finalMetadata[k] = v
+			}
+		}
+		if err := template.ValidateMetadata(parameters.Input.Template, finalMetadata); err != nil {
+			if err := ctrl.enforceSchema(ctx, newErrSchemaValidationError(parameters.SchemaVersion, err)); err != nil {
+				return nil, err
+			}
+		}
+	}
 
 	accountMetadata := result.AccountMetadata
 	if accountMetadata == nil {
@@ -506,10 +1119,16 @@ func (ctrl *DefaultController) createTransaction(ctx context.Context, store Stor
 	if err != nil {
 		return nil, err
 	}
+	if err := ctrl.enforceAccountsMetadata(ctx, store, schema, parameters.SchemaVersion, transaction.AccountsWithDefaultMetadata(schema, accountMetadata)...); err != nil {
+		return nil, err
+	}
 	err = ctrl.upsertTransactionAccounts(ctx, store, schema, &transaction, accountMetadata)
 	if err != nil {
 		return nil, err
 	}
+	if err := ctrl.enforceAccountRules(ctx, schema, transaction.PostCommitVolumes); err != nil {
+		return nil, err
+	}
 
 	return &ledger.CreatedTransaction{
 		Transaction:     transaction,
@@ -517,81 +1136,1236 @@ func (ctrl *DefaultController) createTransaction(ctx context.Context, store Stor
 	}, err
 }
 
-func (ctrl *DefaultController) CreateTransaction(ctx context.Context, parameters Parameters[CreateTransaction]) (*ledger.Log, *ledger.CreatedTransaction, bool, error) {
-	return ctrl.createTransactionLp.forgeLog(ctx, ctrl.store, parameters, ctrl.createTransaction)
+// enforceSchema applies the schema enforcement mode to a schema violation:
+// the error is returned in strict mode, only reported in audit mode and ignored when enforcement is off.
+func (ctrl *DefaultController) enforceSchema(ctx context.Context, err error) error {
+	if err == nil || ctrl.schemaEnforcementMode == SchemaEnforcementStrict {
+		return err
+	}
+	if ctrl.schemaEnforcementMode == SchemaEnforcementOff {
+		return nil
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("schema_validation_failed", err.Error()))
+	logging.FromContext(ctx).Errorf("schema validation failed: %s", err)
+	return nil
 }
 
-func (ctrl *DefaultController) revertTransaction(ctx context.Context, store Store, _schema *ledger.Schema, parameters Parameters[RevertTransaction]) (*ledger.RevertedTransaction, error) {
-	var (
-		hasBeenReverted bool
-		err             error
-	)
-	originalTransaction, hasBeenReverted, err := store.RevertTransaction(ctx, parameters.Input.TransactionID, time.Time{})
-	if err != nil {
-		return nil, err
+// enforceAccountRules checks the balances resulting from a write against the rules of the chart of accounts.
+func (ctrl *DefaultController) enforceAccountRules(ctx context.Context, schema *ledger.Schema, volumes ledger.PostCommitVolumes) error {
+	if schema == nil {
+		return nil
 	}
-	if !hasBeenReverted {
+	return ctrl.enforceSchema(ctx, schema.Chart.ValidateBalances(volumes))
+}
+
+// overdraftFloor returns the lowest balance allowed for an account and an asset, nil if the overdraft is unbounded.
+// The overdraft rule of the chart of accounts applies if any, otherwise only the world account can be overdrafted.
+func overdraftFloor(schema *ledger.Schema, account, asset string) *big.Int {
+	if rule := overdraftRule(schema, account, asset); rule != nil {
+		return rule.Floor()
+	}
+	if account == "world" {
+		return nil
+	}
+	return new(big.Int)
+}
+
+// overdraftRule returns the overdraft rule of the chart of accounts applying to an account and an asset, if any
+func overdraftRule(schema *ledger.Schema, account, asset string) *ledger.ChartOverdraftRule {
+	if schema == nil {
+		return nil
+	}
+	accountSchema, _ := schema.Chart.FindAccountSchema(account)
+	if accountSchema == nil {
+		return nil
+	}
+	return accountSchema.Rules.OverdraftRule(asset)
+}
+
+// enforceAccountsMetadata checks the metadata written on accounts against the chart of accounts.
+// Current metadata of the accounts are only fetched when needed to check immutable or required metadata,
+// all of them with a single query.
+func (ctrl *DefaultController) enforceAccountsMetadata(ctx context.Context, store Store, schema *ledger.Schema, schemaVersion string, accounts ...ledger.AccountWithDefaultMetadata) error {
+	if schema == nil {
+		return nil
+	}
+
+	type accountToCheck struct {
+		ledger.AccountWithDefaultMetadata
+		schema *ledger.ChartAccount
+	}
+	toCheck := make([]accountToCheck, 0)
+	addresses := make([]any, 0)
+	for _, account := range accounts {
+		accountSchema, err := schema.Chart.FindAccountSchema(account.Address)
+		if err != nil {
+			continue
+		}
+		if err := accountSchema.ValidateMetadata(account.Address, account.Metadata); err != nil {
+			return ctrl.enforceSchema(ctx, newErrSchemaValidationError(schemaVersion, err))
+		}
+		if !accountSchema.NeedsExistingMetadata(account.Metadata) {
+			continue
+		}
+		toCheck = append(toCheck, accountToCheck{
+			AccountWithDefaultMetadata: account,
+			schema:                     accountSchema,
+		})
+		addresses = append(addresses, account.Address)
+	}
+	if len(toCheck) == 0 {
+		return nil
+	}
+
+	cursor, err := store.Accounts().Paginate(ctx, storagecommon.OffsetPaginatedQuery[any]{
+		InitialPaginatedQuery: storagecommon.InitialPaginatedQuery[any]{
+			Column:   "address",
+			PageSize: uint64(len(addresses)),
+			Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
+			Options: storagecommon.ResourceQuery[any]{
+				Builder: query.In("address", addresses),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get accounts: %w", err)
+	}
+	existingAccounts := make(map[string]ledger.Account, len(cursor.Data))
+	for _, account := range cursor.Data {
+		existingAccounts[account.Address] = account
+	}
+
+	for _, account := range toCheck {
+		var err error
+		if existing, ok := existingAccounts[account.Address]; ok {
+			err = account.schema.ValidateMetadataUpdate(account.Address, existing.Metadata, account.Metadata)
+		} else {
+			err = account.schema.ValidateNewAccountMetadata(account.Address, account.Metadata)
+		}
+		if err != nil {
+			return ctrl.enforceSchema(ctx, newErrSchemaValidationError(schemaVersion, err))
+		}
+	}
+	return nil
+}
+
+func (ctrl *DefaultController) CreateTransaction(ctx context.Context, parameters Parameters[CreateTransaction]) (*ledger.Log, *ledger.CreatedTransaction, bool, error) {
+	return ctrl.createTransactionLp.forgeLog(ctx, ctrl.store, parameters, ctrl.createTransaction)
+}
+
+func (ctrl *DefaultController) revertTransaction(ctx context.Context, store Store, schema *ledger.Schema, parameters Parameters[RevertTransaction]) (*ledger.RevertedTransaction, error) {
+	var (
+		hasBeenReverted bool
+		err             error
+	)
+	originalTransaction, hasBeenReverted, err := store.RevertTransaction(ctx, parameters.Input.TransactionID, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	if !hasBeenReverted {
+		return nil, newErrAlreadyReverted(parameters.Input.TransactionID)
+	}
+
+	bq := originalTransaction.InvolvedDestinations()
+
+	balances, err := store.GetBalances(ctx, bq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balances: %w", err)
+	}
+
+	reversedTx := originalTransaction.Reverse()
+	if parameters.Input.AtEffectiveDate {
+		if err := ctrl.checkClosedPeriod(ctx, store, originalTransaction.Timestamp); err != nil {
+			return nil, err
+		}
+		reversedTx = reversedTx.WithTimestamp(originalTransaction.Timestamp)
+	} else {
+		reversedTx = reversedTx.WithTimestamp(*originalTransaction.RevertedAt)
+	}
+	reversedTx.Metadata = ledger.MarkReverts(parameters.Input.Metadata, *originalTransaction.ID)
+
+	if !parameters.Input.Force {
+		if err := checkRevertBalances(schema, balances, reversedTx); err != nil {
+			return nil, err
+		}
+	}
+
+	err = store.CommitTransaction(ctx, &reversedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert transaction: %w", err)
+	}
+	if !parameters.Input.Force {
+		if err := ctrl.enforceAccountRules(ctx, schema, reversedTx.PostCommitVolumes); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ledger.RevertedTransaction{
+		RevertedTransaction: *originalTransaction,
+		RevertTransaction:   reversedTx,
+	}, nil
+}
+
+func (ctrl *DefaultController) RevertTransaction(ctx context.Context, parameters Parameters[RevertTransaction]) (*ledger.Log, *ledger.RevertedTransaction, bool, error) {
+	return ctrl.revertTransactionLp.forgeLog(ctx, ctrl.store, parameters, ctrl.revertTransaction)
+}
+
+// checkRevertBalances checks the balances after the revert against the overdraft allowed to the accounts,
+// as the machine does when creating a transaction: the overdraft rules of the chart of accounts apply if any,
+// otherwise only the world account can be overdrafted
+func checkRevertBalances(schema *ledger.Schema, balances ledger.Balances, reversedTx ledger.Transaction) error {
+	for _, posting := range reversedTx.Postings {
+		balances[posting.Source][posting.Asset] = balances[posting.Source][posting.Asset].Add(
+			balances[posting.Source][posting.Asset],
+			big.NewInt(0).Neg(posting.Amount),
+		)
+		if _, ok := balances[posting.Destination]; ok {
+			// if destination is also a source in some posting, since balances should only contain posting sources
+			balances[posting.Destination][posting.Asset] = balances[posting.Destination][posting.Asset].Add(
+				balances[posting.Destination][posting.Asset],
+				posting.Amount,
+			)
+		}
+	}
+
+	for account, forAccount := range balances {
+		for asset, finalBalance := range forAccount {
+			if floor := overdraftFloor(schema, account, asset); floor != nil && finalBalance.Cmp(floor) < 0 {
+				// todo(waiting): break dependency on machine package
+				// notes(gfyrag): wait for the new interpreter
+				return machine.NewErrInsufficientFund("insufficient fund for %s/%s", account, asset)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (ctrl *DefaultController) partiallyRevertTransaction(ctx context.Context, store Store, schema *ledger.Schema, parameters Parameters[PartiallyRevertTransaction]) (*ledger.PartiallyRevertedTransaction, error) {
+	if (parameters.Input.Amounts == nil) == (parameters.Input.Ratio == nil) {
+		return nil, newErrInvalidPartialRevert(errors.New("either the amounts or the ratio must be specified"))
+	}
+
+	originalTransaction, err := store.LockTransaction(ctx, parameters.Input.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+	if originalTransaction.IsReverted() {
 		return nil, newErrAlreadyReverted(parameters.Input.TransactionID)
 	}
 
-	bq := originalTransaction.InvolvedDestinations()
+	amounts := parameters.Input.Amounts
+	if parameters.Input.Ratio != nil {
+		amounts, err = originalTransaction.ScaledRevertibleAmounts(parameters.Input.Ratio)
+		if err != nil {
+			return nil, newErrInvalidPartialRevert(err)
+		}
+	}
+
+	reversedTx, revertedAmounts, err := originalTransaction.PartiallyReverse(amounts)
+	if err != nil {
+		return nil, newErrInvalidPartialRevert(err)
+	}
+	if parameters.Input.AtEffectiveDate {
+		if err := ctrl.checkClosedPeriod(ctx, store, originalTransaction.Timestamp); err != nil {
+			return nil, err
+		}
+		reversedTx = reversedTx.WithTimestamp(originalTransaction.Timestamp)
+	}
+	reversedTx.Metadata = ledger.MarkReverts(parameters.Input.Metadata, *originalTransaction.ID)
+
+	balances, err := store.GetBalances(ctx, reversedTx.InvolvedSources())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balances: %w", err)
+	}
+
+	if !parameters.Input.Force {
+		if err := checkRevertBalances(schema, balances, reversedTx); err != nil {
+			return nil, err
+		}
+	}
+
+	err = store.CommitTransaction(ctx, &reversedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert transaction: %w", err)
+	}
+
+	originalTransaction.RevertedAmounts = revertedAmounts
+	originalTransaction, err = store.UpdateTransactionRevertedAmounts(
+		ctx,
+		*originalTransaction.ID,
+		revertedAmounts,
+		reversedTx.InsertedAt,
+		originalTransaction.IsFullyReverted(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update reverted amounts: %w", err)
+	}
+
+	if !parameters.Input.Force {
+		if err := ctrl.enforceAccountRules(ctx, schema, reversedTx.PostCommitVolumes); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ledger.PartiallyRevertedTransaction{
+		RevertedTransaction: *originalTransaction,
+		RevertTransaction:   reversedTx,
+	}, nil
+}
+
+func (ctrl *DefaultController) PartiallyRevertTransaction(ctx context.Context, parameters Parameters[PartiallyRevertTransaction]) (*ledger.Log, *ledger.PartiallyRevertedTransaction, bool, error) {
+	return ctrl.partiallyRevertTxLp.forgeLog(ctx, ctrl.store, parameters, ctrl.partiallyRevertTransaction)
+}
+
+// checkClosedPeriod returns an ErrPeriodClosed if the date is before the close date of the ledger
+func (ctrl *DefaultController) checkClosedPeriod(ctx context.Context, store Store, date time.Time) error {
+	period, err := store.FindClosedPeriod(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find closed period: %w", err)
+	}
+	if period != nil && date.Before(period.ClosedUntil) {
+		return newErrPeriodClosed(date, period.ClosedUntil)
+	}
+	return nil
+}
+
+func (ctrl *DefaultController) GetClosedPeriod(ctx context.Context) (*ledger.ClosedPeriod, error) {
+	return ctrl.store.FindClosedPeriod(ctx)
+}
+
+func (ctrl *DefaultController) closePeriod(ctx context.Context, store Store, _ *ledger.Schema, parameters Parameters[ClosePeriod]) (*ledger.ClosedPeriod, error) {
+	closedUntil := parameters.Input.ClosedUntil
+	if closedUntil.IsZero() {
+		return nil, newErrInvalidPeriod(errors.New("the close date is required"))
+	}
+	if closedUntil.After(time.Now()) {
+		return nil, newErrInvalidPeriod(errors.New("cannot close a period in the future"))
+	}
+
+	current, err := store.LockClosedPeriod(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find closed period: %w", err)
+	}
+	if current != nil && !closedUntil.After(current.ClosedUntil) {
+		return nil, newErrInvalidPeriod(fmt.Errorf("periods are already closed until %s, they must be reopened to move the close date back", current.ClosedUntil))
+	}
+
+	period := ledger.ClosedPeriod{
+		ClosedUntil: closedUntil,
+	}
+	if err := store.SaveClosedPeriod(ctx, period); err != nil {
+		return nil, err
+	}
+
+	return &period, nil
+}
+
+func (ctrl *DefaultController) ClosePeriod(ctx context.Context, parameters Parameters[ClosePeriod]) (*ledger.Log, *ledger.ClosedPeriod, bool, error) {
+	return ctrl.closePeriodLp.forgeLog(ctx, ctrl.store, parameters, ctrl.closePeriod)
+}
+
+func (ctrl *DefaultController) reopenPeriod(ctx context.Context, store Store, _ *ledger.Schema, parameters Parameters[ReopenPeriod]) (*ledger.ReopenedPeriod, error) {
+	current, err := store.LockClosedPeriod(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find closed period: %w", err)
+	}
+	if current == nil {
+		return nil, newErrInvalidPeriod(errors.New("no period is closed"))
+	}
+	if parameters.Input.ClosedUntil != nil && !parameters.Input.ClosedUntil.Before(current.ClosedUntil) {
+		return nil, newErrInvalidPeriod(fmt.Errorf("periods are closed until %s, the new close date must be before", current.ClosedUntil))
+	}
+
+	period := ledger.ReopenedPeriod{
+		ClosedUntil:         parameters.Input.ClosedUntil,
+		PreviousClosedUntil: current.ClosedUntil,
+	}
+	if err := ctrl.saveReopenedPeriod(ctx, store, period); err != nil {
+		return nil, err
+	}
+
+	return &period, nil
+}
+
+func (ctrl *DefaultController) saveReopenedPeriod(ctx context.Context, store Store, period ledger.ReopenedPeriod) error {
+	if period.ClosedUntil == nil {
+		return store.DeleteClosedPeriod(ctx)
+	}
+	return store.SaveClosedPeriod(ctx, ledger.ClosedPeriod{
+		ClosedUntil: *period.ClosedUntil,
+	})
+}
+
+func (ctrl *DefaultController) ReopenPeriod(ctx context.Context, parameters Parameters[ReopenPeriod]) (*ledger.Log, *ledger.ReopenedPeriod, bool, error) {
+	return ctrl.reopenPeriodLp.forgeLog(ctx, ctrl.store, parameters, ctrl.reopenPeriod)
+}
+
+func (ctrl *DefaultController) GetHold(ctx context.Context, id string) (*ledger.Hold, error) {
+	return ctrl.store.FindHold(ctx, id)
+}
+
+func (ctrl *DefaultController) createHold(ctx context.Context, store Store, schema *ledger.Schema, parameters Parameters[CreateHold]) (*ledger.CreatedHold, error) {
+	input := parameters.Input
+	if !accounts.ValidateAddress(input.Source) {
+		return nil, newErrInvalidHold(fmt.Errorf("invalid source address `%s`", input.Source))
+	}
+	if !accounts.ValidateAddress(input.Destination) {
+		return nil, newErrInvalidHold(fmt.Errorf("invalid destination address `%s`", input.Destination))
+	}
+	if !assets.IsValid(input.Asset) {
+		return nil, newErrInvalidHold(fmt.Errorf("invalid asset `%s`", input.Asset))
+	}
+	if input.Amount == nil || input.Amount.Sign() <= 0 {
+		return nil, newErrInvalidHold(errors.New("the amount must be positive"))
+	}
+
+	if floor := overdraftFloor(schema, input.Source, input.Asset); floor != nil {
+		// Locks the volumes of the source, the available balance already deducts the pending holds
+		balances, err := store.GetBalances(ctx, ledgerstore.BalanceQuery{
+			input.Source: {input.Asset},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get balances: %w", err)
+		}
+		if new(big.Int).Sub(balances[input.Source][input.Asset], input.Amount).Cmp(floor) < 0 {
+			return nil, machine.NewErrInsufficientFund("account %s has insufficient funds to hold %s %s", input.Source, input.Amount, input.Asset)
+		}
+	}
+
+	m := input.Metadata
+	if m == nil {
+		m = metadata.Metadata{}
+	}
+	hold := ledger.Hold{
+		ID:          uuid.NewString(),
+		Source:      input.Source,
+		Destination: input.Destination,
+		Asset:       input.Asset,
+		Amount:      new(big.Int).Set(input.Amount),
+		Metadata:    m,
+		Status:      ledger.HoldStatusPending,
+		ExpiresAt:   input.ExpiresAt,
+	}
+	// The hold is inserted at the date of the log
+	if err := store.InsertHold(ctx, &hold); err != nil {
+		return nil, err
+	}
+	if hold.IsExpired(hold.InsertedAt) {
+		return nil, newErrInvalidHold(errors.New("the expiration date must be in the future"))
+	}
+
+	return &ledger.CreatedHold{
+		Hold: hold,
+	}, nil
+}
+
+func (ctrl *DefaultController) CreateHold(ctx context.Context, parameters Parameters[CreateHold]) (*ledger.Log, *ledger.CreatedHold, bool, error) {
+	return ctrl.createHoldLp.forgeLog(ctx, ctrl.store, parameters, ctrl.createHold)
+}
+
+// endHold changes the status of a pending hold at the date of the log, the hold is returned with its new status
+func (ctrl *DefaultController) endHold(ctx context.Context, store Store, id string, status ledger.HoldStatus) (*ledger.Hold, error) {
+	hold, ended, err := store.EndHold(ctx, id, status, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	if !ended {
+		return nil, newErrHoldNotPending(hold.ID, hold.Status)
+	}
+
+	return hold, nil
+}
+
+func (ctrl *DefaultController) captureHold(ctx context.Context, store Store, schema *ledger.Schema, parameters Parameters[CaptureHold]) (*ledger.CapturedHold, error) {
+	hold, err := ctrl.endHold(ctx, store, parameters.Input.ID, ledger.HoldStatusCaptured)
+	if err != nil {
+		return nil, err
+	}
+	if hold.IsExpired(*hold.EndedAt) {
+		return nil, newErrHoldExpired(hold.ID, *hold.ExpiresAt)
+	}
+
+	transaction, err := hold.Capture(parameters.Input.Amount, parameters.Input.Metadata)
+	if err != nil {
+		return nil, newErrInvalidHold(err)
+	}
+	if err := store.CommitTransaction(ctx, &transaction); err != nil {
+		return nil, err
+	}
+	if err := ctrl.upsertTransactionAccounts(ctx, store, schema, &transaction, nil); err != nil {
+		return nil, err
+	}
+	if err := ctrl.enforceAccountRules(ctx, schema, transaction.PostCommitVolumes); err != nil {
+		return nil, err
+	}
+
+	return &ledger.CapturedHold{
+		Hold:        *hold,
+		Transaction: transaction,
+	}, nil
+}
+
+func (ctrl *DefaultController) CaptureHold(ctx context.Context, parameters Parameters[CaptureHold]) (*ledger.Log, *ledger.CapturedHold, bool, error) {
+	return ctrl.captureHoldLp.forgeLog(ctx, ctrl.store, parameters, ctrl.captureHold)
+}
+
+func (ctrl *DefaultController) voidHold(ctx context.Context, store Store, _ *ledger.Schema, parameters Parameters[VoidHold]) (*ledger.VoidedHold, error) {
+	hold, err := ctrl.endHold(ctx, store, parameters.Input.ID, ledger.HoldStatusVoided)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ledger.VoidedHold{
+		Hold: *hold,
+	}, nil
+}
+
+func (ctrl *DefaultController) VoidHold(ctx context.Context, parameters Parameters[VoidHold]) (*ledger.Log, *ledger.VoidedHold, bool, error) {
+	return ctrl.voidHoldLp.forgeLog(ctx, ctrl.store, parameters, ctrl.voidHold)
+}
+
+func (ctrl *DefaultController) GetRevertJob(ctx context.Context, id string) (*ledger.RevertJob, error) {
+	return ctrl.store.FindRevertJob(ctx, id)
+}
+
+// revertJobQuery returns the filter of the transactions remaining to revert by a job
+func revertJobQuery(job ledger.RevertJob) (query.Builder, error) {
+	filters := []query.Builder{
+		query.Match("reverted", false),
+	}
+	// The transactions created after the job, including its own reverts, are not part of the job
+	if job.MaxTransactionID != nil {
+		filters = append(filters, query.Lte("id", *job.MaxTransactionID))
+	}
+	if len(job.Query) > 0 {
+		builder, err := queries.ParseJSON(string(job.Query))
+		if err != nil {
+			return nil, storagecommon.NewErrInvalidQuery("invalid query: %s", err)
+		}
+		if builder != nil {
+			filters = append(filters, builder)
+		}
+	}
+	if job.LastTransactionID != nil {
+		filters = append(filters, query.Gt("id", *job.LastTransactionID))
+	}
+
+	return query.And(filters...), nil
+}
+
+func (ctrl *DefaultController) CreateRevertJob(ctx context.Context, input CreateRevertJob) (*ledger.RevertJob, error) {
+	now := time.Now()
+	job := ledger.RevertJob{
+		ID:              uuid.NewString(),
+		Query:           input.Query,
+		Force:           input.Force,
+		AtEffectiveDate: input.AtEffectiveDate,
+		Metadata:        input.Metadata,
+		Status:          ledger.RevertJobStatusPending,
+		InsertedAt:      now,
+		UpdatedAt:       now,
+	}
+	if job.Metadata == nil {
+		job.Metadata = metadata.Metadata{}
+	}
+
+	if _, err := revertJobQuery(job); err != nil {
+		return nil, err
+	}
+	lastTransactions, err := ctrl.store.Transactions().Paginate(ctx, storagecommon.InitialPaginatedQuery[any]{
+		Column:   "id",
+		Order:    pointer.For(paginate.Order(paginate.OrderDesc)),
+		PageSize: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(lastTransactions.Data) == 0 {
+		// Nothing to revert
+		job.Status = ledger.RevertJobStatusSucceeded
+		job.EndedAt = &now
+	} else {
+		job.MaxTransactionID = lastTransactions.Data[0].ID
+		builder, err := revertJobQuery(job)
+		if err != nil {
+			return nil, err
+		}
+		total, err := ctrl.store.Transactions().Count(ctx, storagecommon.ResourceQuery[any]{
+			Builder: builder,
+		})
+		if err != nil {
+			return nil, err
+		}
+		job.Total = uint64(total)
+	}
+
+	err = withTX(ctx, ctrl.store, func(store Store) error {
+		if err := store.InsertRevertJob(ctx, &job); err != nil {
+			return err
+		}
+		return insertLog(ctx, store, ledger.NewCreatedRevertJob(job))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// isRevertJobFailure indicates if the error prevents the revert of a transaction of a job without stopping the job
+func isRevertJobFailure(err error) bool {
+	return errors.Is(err, &ErrInsufficientFunds{}) ||
+		errors.Is(err, ErrAlreadyReverted{}) ||
+		errors.Is(err, ErrNotFound) ||
+		errors.Is(err, ErrPeriodClosed{}) ||
+		errors.Is(err, ErrAccountRuleViolation{}) ||
+		errors.Is(err, ErrSchemaValidationError{})
+}
+
+func (ctrl *DefaultController) RunRevertJob(ctx context.Context, id string, batchSize int) (*ledger.RevertJob, []ledger.RevertedTransaction, error) {
+	revertedTransactions := make([]ledger.RevertedTransaction, 0)
+	// The job is locked during the batch, so it is run by one worker at a time,
+	// and the reverts are committed with the progress of the job
+	job, err := withLocked(ctx, ctrl.store, func(store Store) (*ledger.RevertJob, error) {
+		return store.LockRevertJob(ctx, id)
+	}, func(store Store, job *ledger.RevertJob) error {
+		if job.IsFinished() {
+			return nil
+		}
+
+		builder, err := revertJobQuery(*job)
+		if err != nil {
+			return err
+		}
+		cursor, err := store.Transactions().Paginate(ctx, storagecommon.InitialPaginatedQuery[any]{
+			Column:   "id",
+			Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
+			PageSize: uint64(batchSize),
+			Options: storagecommon.ResourceQuery[any]{
+				Builder: builder,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		// Each revert is made in a nested sql transaction, a failing revert does not cancel the others
+		cp := *ctrl
+		cp.store = store
+		job.Status = ledger.RevertJobStatusRunning
+		for _, tx := range cursor.Data {
+			_, ret, _, err := cp.RevertTransaction(ctx, Parameters[RevertTransaction]{
+				Input: RevertTransaction{
+					Force:           job.Force,
+					AtEffectiveDate: job.AtEffectiveDate,
+					TransactionID:   *tx.ID,
+					Metadata:        ledger.MarkRevertJob(job.Metadata, job.ID),
+				},
+			})
+			switch {
+			case err == nil:
+				job.Reverted++
+				revertedTransactions = append(revertedTransactions, *ret)
+			case isRevertJobFailure(err):
+				job.Failed++
+				job.LastError = err.Error()
+			default:
+				// The batch is rolled back, the job resumes on the same batch on the next run
+				return fmt.Errorf("reverting transaction %d: %w", *tx.ID, err)
+			}
+			job.LastTransactionID = tx.ID
+		}
+
+		if !cursor.HasMore {
+			job.Status = ledger.RevertJobStatusSucceeded
+			job.EndedAt = pointer.For(time.Now())
+		}
+		job.UpdatedAt = time.Now()
+
+		if err := store.UpdateRevertJob(ctx, job); err != nil {
+			return err
+		}
+		return insertLog(ctx, store, ledger.UpdatedRevertJob{RevertJob: *job})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return job, revertedTransactions, nil
+}
+
+func (ctrl *DefaultController) GetScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	return ctrl.store.FindScheduledTransaction(ctx, id)
+}
+
+func (ctrl *DefaultController) ScheduleTransaction(ctx context.Context, input ScheduleTransaction) (*ledger.ScheduledTransaction, error) {
+	now := time.Now()
+	if !input.ScheduledAt.After(now) {
+		return nil, newErrInvalidScheduledTransaction(errors.New("the scheduled date must be in the future"))
+	}
+	if err := input.Transaction.Validate(); err != nil {
+		return nil, newErrInvalidScheduledTransaction(err)
+	}
+	if input.Transaction.Metadata == nil {
+		input.Transaction.Metadata = metadata.Metadata{}
+	}
+
+	scheduledTransaction := ledger.ScheduledTransaction{
+		ID:            uuid.NewString(),
+		ScheduledAt:   input.ScheduledAt,
+		Transaction:   input.Transaction,
+		SchemaVersion: input.SchemaVersion,
+		Status:        ledger.ScheduledTransactionStatusPending,
+		InsertedAt:    now,
+		UpdatedAt:     now,
+	}
+	err := withTX(ctx, ctrl.store, func(store Store) error {
+		if err := store.InsertScheduledTransaction(ctx, &scheduledTransaction); err != nil {
+			return err
+		}
+		return insertLog(ctx, store, ledger.CreatedScheduledTransaction{ScheduledTransaction: scheduledTransaction})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &scheduledTransaction, nil
+}
+
+// withTX runs fn in a sql transaction, committed if fn succeeds
+func withTX(ctx context.Context, parent Store, fn func(store Store) error) error {
+	store, _, err := parent.BeginTX(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	if err := fn(store); err != nil {
+		if rollbackErr := store.Rollback(ctx); rollbackErr != nil {
+			logging.FromContext(ctx).Errorf("failed to rollback transaction: %v", rollbackErr)
+		}
+		return err
+	}
+
+	if err := store.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// withLocked runs fn in a sql transaction holding the lock of the entity returned by lock
+func withLocked[T any](ctx context.Context, parent Store, lock func(store Store) (*T, error), fn func(store Store, entity *T) error) (*T, error) {
+	var entity *T
+	err := withTX(ctx, parent, func(store Store) error {
+		var err error
+		entity, err = lock(store)
+		if err != nil {
+			return err
+		}
+		return fn(store, entity)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+// insertLog records a change of the entities which are not written by a log processor,
+// like the revert jobs, the scheduled transactions and the recurrences, so they are replicated and imported with the ledger
+func insertLog(ctx context.Context, store Store, payload ledger.LogPayload) error {
+	log := ledger.NewLog(payload)
+	if err := store.InsertLog(ctx, &log); err != nil {
+		return fmt.Errorf("failed to insert log: %w", err)
+	}
+	return nil
+}
+
+// withSnapshot runs fn in a read only sql transaction with the repeatable read isolation,
+// so all the reads of fn see the same state of the ledger
+func withSnapshot[T any](ctx context.Context, parent Store, fn func(store Store) (T, error)) (T, error) {
+	store, _, err := parent.BeginTX(ctx, &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		var zero T
+		return zero, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() {
+		if rollbackErr := store.Rollback(ctx); rollbackErr != nil {
+			logging.FromContext(ctx).Errorf("failed to rollback transaction: %v", rollbackErr)
+		}
+	}()
+
+	return fn(store)
+}
+
+// withLockedScheduledTransaction runs fn holding the lock of the scheduled transaction,
+// so the execution and the cancellation of a scheduled transaction cannot overlap
+func (ctrl *DefaultController) withLockedScheduledTransaction(ctx context.Context, id string, fn func(store Store, scheduledTransaction *ledger.ScheduledTransaction) error) (*ledger.ScheduledTransaction, error) {
+	return withLocked(ctx, ctrl.store, func(store Store) (*ledger.ScheduledTransaction, error) {
+		return store.LockScheduledTransaction(ctx, id)
+	}, fn)
+}
+
+func (ctrl *DefaultController) CancelScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	return ctrl.withLockedScheduledTransaction(ctx, id, func(store Store, scheduledTransaction *ledger.ScheduledTransaction) error {
+		if scheduledTransaction.Status != ledger.ScheduledTransactionStatusPending {
+			return newErrScheduledTransactionNotPending(scheduledTransaction.ID, scheduledTransaction.Status)
+		}
+
+		now := time.Now()
+		scheduledTransaction.Status = ledger.ScheduledTransactionStatusCanceled
+		scheduledTransaction.UpdatedAt = now
+		scheduledTransaction.EndedAt = &now
+
+		if err := store.UpdateScheduledTransaction(ctx, scheduledTransaction); err != nil {
+			return err
+		}
+		return insertLog(ctx, store, ledger.UpdatedScheduledTransaction{ScheduledTransaction: *scheduledTransaction})
+	})
+}
+
+// scheduledTransactionInput returns the creation of the transaction, effective at the scheduled date
+func scheduledTransactionInput(scheduledTransaction ledger.ScheduledTransaction) CreateTransaction {
+	data := scheduledTransaction.Transaction
+
+	runScript := RunScript{
+		Script: Script{
+			Plain:    data.Script,
+			Template: data.Template,
+			Vars:     data.Vars,
+		},
+		Timestamp: scheduledTransaction.ScheduledAt,
+		Metadata:  data.Metadata,
+		Reference: data.Reference,
+	}
+	if len(data.Postings) > 0 {
+		runScript = TxToScriptData(ledger.TransactionData{
+			Postings:  data.Postings,
+			Timestamp: scheduledTransaction.ScheduledAt,
+			Reference: data.Reference,
+			Metadata:  data.Metadata,
+		}, data.Force)
+	}
+
+	return CreateTransaction{
+		RunScript:       runScript,
+		AccountMetadata: data.AccountMetadata,
+		Runtime:         data.Runtime,
+	}
+}
 
-	balances, err := store.GetBalances(ctx, bq)
+// isCreateTransactionFailure indicates if the error prevents the creation of a scheduled or recurring transaction,
+// the other errors are transient and the execution is retried later
+func isCreateTransactionFailure(err error) bool {
+	return errors.Is(err, &ErrInsufficientFunds{}) ||
+		errors.Is(err, &ErrInvalidVars{}) ||
+		errors.Is(err, ErrCompilationFailed{}) ||
+		errors.Is(err, ErrParsing{}) ||
+		errors.Is(err, ErrRuntime{}) ||
+		errors.Is(err, &ErrMetadataOverride{}) ||
+		errors.Is(err, ErrNoPostings) ||
+		errors.Is(err, ledgerstore.ErrTransactionReferenceConflict{}) ||
+		errors.Is(err, ErrPeriodClosed{}) ||
+		errors.Is(err, ErrAccountRuleViolation{}) ||
+		errors.Is(err, ErrSchemaValidationError{}) ||
+		errors.Is(err, ErrSchemaNotSpecified{}) ||
+		errors.Is(err, ErrSchemaNotFound{})
+}
+
+func (ctrl *DefaultController) ExecuteScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, *ledger.CreatedTransaction, error) {
+	var createdTransaction *ledger.CreatedTransaction
+	scheduledTransaction, err := ctrl.withLockedScheduledTransaction(ctx, id, func(store Store, scheduledTransaction *ledger.ScheduledTransaction) error {
+		now := time.Now()
+		// The transaction may have been executed or canceled since it has been listed
+		if !scheduledTransaction.IsDue(now) {
+			return nil
+		}
+
+		// The transaction is created in the sql transaction holding the lock
+		cp := *ctrl
+		cp.store = store
+		_, ret, _, err := cp.CreateTransaction(ctx, Parameters[CreateTransaction]{
+			SchemaVersion:  scheduledTransaction.SchemaVersion,
+			IdempotencyKey: scheduledTransaction.IdempotencyKey(),
+			Input:          scheduledTransactionInput(*scheduledTransaction),
+		})
+		switch {
+		case err == nil:
+			scheduledTransaction.Status = ledger.ScheduledTransactionStatusExecuted
+			scheduledTransaction.TransactionID = ret.Transaction.ID
+			createdTransaction = ret
+		case isCreateTransactionFailure(err):
+			scheduledTransaction.Status = ledger.ScheduledTransactionStatusFailed
+			scheduledTransaction.Error = err.Error()
+		default:
+			return fmt.Errorf("creating scheduled transaction %s: %w", scheduledTransaction.ID, err)
+		}
+		scheduledTransaction.UpdatedAt = now
+		scheduledTransaction.EndedAt = &now
+
+		if err := store.UpdateScheduledTransaction(ctx, scheduledTransaction); err != nil {
+			return err
+		}
+		return insertLog(ctx, store, ledger.UpdatedScheduledTransaction{ScheduledTransaction: *scheduledTransaction})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get balances: %w", err)
+		return nil, nil, err
 	}
 
-	reversedTx := originalTransaction.Reverse()
-	if parameters.Input.AtEffectiveDate {
-		reversedTx = reversedTx.WithTimestamp(originalTransaction.Timestamp)
-	} else {
-		reversedTx = reversedTx.WithTimestamp(*originalTransaction.RevertedAt)
+	return scheduledTransaction, createdTransaction, nil
+}
+
+func (ctrl *DefaultController) ListRecurrences(ctx context.Context, query storagecommon.PaginatedQuery[any]) (*paginate.Cursor[ledger.Recurrence], error) {
+	return ctrl.store.ListRecurrences(ctx, query)
+}
+
+func (ctrl *DefaultController) GetRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	return ctrl.store.FindRecurrence(ctx, id)
+}
+
+// findRecurrenceSchema returns the schema holding the template of a recurrence
+func (ctrl *DefaultController) findRecurrenceSchema(ctx context.Context, store Store, version string) (*ledger.Schema, error) {
+	schema, err := store.FindSchema(ctx, version)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			latestVersion, err := store.FindLatestSchemaVersion(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return nil, newErrSchemaNotFound(version, latestVersion)
+		}
+		return nil, err
 	}
-	reversedTx.Metadata = ledger.MarkReverts(parameters.Input.Metadata, *originalTransaction.ID)
+	return schema, nil
+}
 
-	// Check balances after the revert, all balances must be greater than 0
-	if !parameters.Input.Force {
-		for _, posting := range reversedTx.Postings {
-			balances[posting.Source][posting.Asset] = balances[posting.Source][posting.Asset].Add(
-				balances[posting.Source][posting.Asset],
-				big.NewInt(0).Neg(posting.Amount),
-			)
-			if _, ok := balances[posting.Destination]; ok {
-				// if destination is also a source in some posting, since balances should only contain posting sources
-				balances[posting.Destination][posting.Asset] = balances[posting.Destination][posting.Asset].Add(
-					balances[posting.Destination][posting.Asset],
-					posting.Amount,
-				)
+// validateRecurrence checks the template and the accounts of a recurrence against the schema
+func validateRecurrence(schema ledger.Schema, recurrence ledger.Recurrence) error {
+	template, ok := schema.Transactions[recurrence.Template]
+	if !ok {
+		return fmt.Errorf("template `%s` not found in schema version `%s`", recurrence.Template, schema.Version)
+	}
+
+	if recurrence.Accounts == "" {
+		if recurrence.AccountVar != "" {
+			return errors.New("the account variable can only be used with accounts")
+		}
+		_, err := template.ResolveVars(recurrence.Template, schema.Chart, recurrence.Vars)
+		return err
+	}
+
+	segment, err := schema.Chart.FindSegment(recurrence.Accounts)
+	if err != nil {
+		return fmt.Errorf("invalid accounts: %w", err)
+	}
+	if segment.Account == nil {
+		return fmt.Errorf("invalid accounts: `%s` is not an account", recurrence.Accounts)
+	}
+	if recurrence.AccountVar == "" {
+		return errors.New("the account variable is required with accounts")
+	}
+	if _, ok := recurrence.Vars[recurrence.AccountVar]; ok {
+		return fmt.Errorf("variable `%s` is set by the recurrence for each account", recurrence.AccountVar)
+	}
+	if len(template.Vars) > 0 {
+		decl, ok := template.Vars[recurrence.AccountVar]
+		if !ok || decl.Type != machine.TypeAccount.String() {
+			return fmt.Errorf("variable `%s` is not an account variable of template `%s`", recurrence.AccountVar, recurrence.Template)
+		}
+	}
+
+	return nil
+}
+
+func (ctrl *DefaultController) CreateRecurrence(ctx context.Context, input CreateRecurrence) (*ledger.Recurrence, error) {
+	now := time.Now()
+	recurrence := ledger.Recurrence{
+		ID:            uuid.NewString(),
+		Schedule:      input.Schedule,
+		StartAt:       now,
+		Template:      input.Template,
+		Vars:          input.Vars,
+		Metadata:      input.Metadata,
+		SchemaVersion: input.SchemaVersion,
+		Accounts:      input.Accounts,
+		AccountVar:    input.AccountVar,
+		Status:        ledger.RecurrenceStatusActive,
+		InsertedAt:    now,
+		UpdatedAt:     now,
+	}
+	if input.StartAt != nil {
+		recurrence.StartAt = *input.StartAt
+	}
+	if recurrence.Vars == nil {
+		recurrence.Vars = map[string]string{}
+	}
+	if recurrence.Metadata == nil {
+		recurrence.Metadata = metadata.Metadata{}
+	}
+	if recurrence.SchemaVersion == "" {
+		recurrence.SchemaVersion = ctrl.defaultSchemaVersion
+	}
+	if recurrence.SchemaVersion == "" {
+		return nil, newErrInvalidRecurrence(errors.New("a schema version is required to use a transaction template"))
+	}
+
+	next, err := recurrence.NextOccurrenceAfter(now)
+	if err != nil {
+		return nil, newErrInvalidRecurrence(fmt.Errorf("invalid schedule: %w", err))
+	}
+	if next == nil {
+		return nil, newErrInvalidRecurrence(errors.New("the schedule has no occurrence in the future"))
+	}
+	recurrence.NextOccurrence = next
+
+	schema, err := ctrl.findRecurrenceSchema(ctx, ctrl.store, recurrence.SchemaVersion)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRecurrence(*schema, recurrence); err != nil {
+		return nil, newErrInvalidRecurrence(err)
+	}
+
+	err = withTX(ctx, ctrl.store, func(store Store) error {
+		if err := store.InsertRecurrence(ctx, &recurrence); err != nil {
+			return err
+		}
+		return insertLog(ctx, store, ledger.CreatedRecurrence{Recurrence: recurrence})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &recurrence, nil
+}
+
+// withLockedRecurrence runs fn holding the lock of the recurrence,
+// so an occurrence cannot be processed while the recurrence is paused, resumed or deleted
+func (ctrl *DefaultController) withLockedRecurrence(ctx context.Context, id string, fn func(store Store, recurrence *ledger.Recurrence) error) (*ledger.Recurrence, error) {
+	return withLocked(ctx, ctrl.store, func(store Store) (*ledger.Recurrence, error) {
+		return store.LockRecurrence(ctx, id)
+	}, fn)
+}
+
+// updateRecurrence saves the status or the progress of a recurrence
+func updateRecurrence(ctx context.Context, store Store, recurrence *ledger.Recurrence) error {
+	if err := store.UpdateRecurrence(ctx, recurrence); err != nil {
+		return err
+	}
+	return insertLog(ctx, store, ledger.NewUpdatedRecurrence(*recurrence))
+}
+
+func (ctrl *DefaultController) PauseRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	return ctrl.withLockedRecurrence(ctx, id, func(store Store, recurrence *ledger.Recurrence) error {
+		switch recurrence.Status {
+		case ledger.RecurrenceStatusCompleted:
+			return newErrRecurrenceCompleted(recurrence.ID)
+		case ledger.RecurrenceStatusPaused:
+			return nil
+		}
+
+		recurrence.Status = ledger.RecurrenceStatusPaused
+		recurrence.UpdatedAt = time.Now()
+
+		return updateRecurrence(ctx, store, recurrence)
+	})
+}
+
+func (ctrl *DefaultController) ResumeRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	return ctrl.withLockedRecurrence(ctx, id, func(store Store, recurrence *ledger.Recurrence) error {
+		switch recurrence.Status {
+		case ledger.RecurrenceStatusCompleted:
+			return newErrRecurrenceCompleted(recurrence.ID)
+		case ledger.RecurrenceStatusActive:
+			return nil
+		}
+
+		now := time.Now()
+		// The occurrences missed while the recurrence was paused are skipped
+		next, err := recurrence.NextOccurrenceAfter(now)
+		if err != nil {
+			return err
+		}
+		recurrence.NextOccurrence = next
+		recurrence.Status = ledger.RecurrenceStatusActive
+		if next == nil {
+			recurrence.Status = ledger.RecurrenceStatusCompleted
+		}
+		recurrence.UpdatedAt = now
+
+		return updateRecurrence(ctx, store, recurrence)
+	})
+}
+
+func (ctrl *DefaultController) DeleteRecurrence(ctx context.Context, id string) error {
+	return withTX(ctx, ctrl.store, func(store Store) error {
+		if err := store.DeleteRecurrence(ctx, id); err != nil {
+			return err
+		}
+		return insertLog(ctx, store, ledger.DeletedRecurrence{ID: id})
+	})
+}
+
+// recurrenceAccounts returns the next batch of accounts matching the chart path of the recurrence which exist at the occurrence
+func recurrenceAccounts(ctx context.Context, store Store, schema ledger.Schema, recurrence ledger.Recurrence, occurrence time.Time, batchSize int) ([]string, bool, error) {
+	cursor, err := store.Accounts().Paginate(ctx, storagecommon.OffsetPaginatedQuery[any]{
+		InitialPaginatedQuery: storagecommon.InitialPaginatedQuery[any]{
+			Column:   "address",
+			PageSize: uint64(batchSize),
+			Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
+			Options: storagecommon.ResourceQuery[any]{
+				PIT: &occurrence,
+				Opts: ledgerstore.AccountsOptions{
+					// the accounts of the subtree of the path are not part of the recurrence
+					ChartAccounts: slices.DeleteFunc(schema.Chart.AccountAddresses(recurrence.Accounts), func(account ledger.ChartAccountAddresses) bool {
+						return account.Path != recurrence.Accounts
+					}),
+				},
+			},
+		},
+		Offset: recurrence.AccountsOffset,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	ret := make([]string, 0, len(cursor.Data))
+	for _, account := range cursor.Data {
+		ret = append(ret, account.Address)
+	}
+
+	return ret, cursor.HasMore, nil
+}
+
+func (ctrl *DefaultController) RunRecurrence(ctx context.Context, id string, batchSize int) (*ledger.Recurrence, []ledger.CreatedTransaction, error) {
+	createdTransactions := make([]ledger.CreatedTransaction, 0)
+	recurrence, err := ctrl.withLockedRecurrence(ctx, id, func(store Store, recurrence *ledger.Recurrence) error {
+		now := time.Now()
+		// The recurrence may have been processed or paused since it has been listed
+		if !recurrence.IsDue(now) {
+			return nil
+		}
+		occurrence := *recurrence.NextOccurrence
+
+		// The transactions are created in the sql transaction holding the lock
+		cp := *ctrl
+		cp.store = store
+		createTransaction := func(account string) error {
+			vars := map[string]string{}
+			maps.Copy(vars, recurrence.Vars)
+			if account != "" {
+				vars[recurrence.AccountVar] = account
+			}
+			_, ret, _, err := cp.CreateTransaction(ctx, Parameters[CreateTransaction]{
+				SchemaVersion:  recurrence.SchemaVersion,
+				IdempotencyKey: recurrence.IdempotencyKey(occurrence, account),
+				Input: CreateTransaction{
+					RunScript: RunScript{
+						Script: Script{
+							Template: recurrence.Template,
+							Vars:     vars,
+						},
+						Timestamp: occurrence,
+						Metadata:  maps.Clone(recurrence.Metadata),
+					},
+				},
+			})
+			switch {
+			case err == nil:
+				createdTransactions = append(createdTransactions, *ret)
+			case isCreateTransactionFailure(err):
+				recurrence.Failed++
+				recurrence.LastError = err.Error()
+			default:
+				return fmt.Errorf("creating transaction of recurrence %s: %w", recurrence.ID, err)
 			}
+			return nil
 		}
 
-		for account, forAccount := range balances {
-			for asset, finalBalance := range forAccount {
-				if finalBalance.Cmp(new(big.Int)) < 0 && account != "world" {
-					// todo(waiting): break dependency on machine package
-					// notes(gfyrag): wait for the new interpreter
-					return nil, machine.NewErrInsufficientFund("insufficient fund for %s/%s", account, asset)
+		if recurrence.Accounts == "" {
+			if err := createTransaction(""); err != nil {
+				return err
+			}
+		} else {
+			schema, err := ctrl.findRecurrenceSchema(ctx, store, recurrence.SchemaVersion)
+			if err != nil {
+				return err
+			}
+			accounts, hasMore, err := recurrenceAccounts(ctx, store, *schema, *recurrence, occurrence, batchSize)
+			if err != nil {
+				return err
+			}
+			for _, account := range accounts {
+				if err := createTransaction(account); err != nil {
+					return err
 				}
 			}
+			// The batch is committed with the progress of the occurrence, the next batch is processed by the next call.
+			// The idempotency keys prevent to create twice the transaction of an account if the accounts shift between the batches.
+			if hasMore {
+				recurrence.AccountsOffset += uint64(batchSize)
+				recurrence.UpdatedAt = now
+
+				return updateRecurrence(ctx, store, recurrence)
+			}
 		}
-	}
 
-	err = store.CommitTransaction(ctx, &reversedTx)
+		next, err := recurrence.NextOccurrenceAfter(occurrence)
+		if err != nil {
+			return err
+		}
+		recurrence.LastOccurrence = &occurrence
+		recurrence.NextOccurrence = next
+		recurrence.AccountsOffset = 0
+		if next == nil {
+			recurrence.Status = ledger.RecurrenceStatusCompleted
+		}
+		recurrence.UpdatedAt = now
+
+		return updateRecurrence(ctx, store, recurrence)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to insert transaction: %w", err)
+		return nil, nil, err
 	}
 
-	return &ledger.RevertedTransaction{
-		RevertedTransaction: *originalTransaction,
-		RevertTransaction:   reversedTx,
-	}, nil
+	return recurrence, createdTransactions, nil
 }
 
-func (ctrl *DefaultController) RevertTransaction(ctx context.Context, parameters Parameters[RevertTransaction]) (*ledger.Log, *ledger.RevertedTransaction, bool, error) {
-	return ctrl.revertTransactionLp.forgeLog(ctx, ctrl.store, parameters, ctrl.revertTransaction)
+// findTransactionTemplate returns a transaction and the template it has been created with,
+// only when the schema declares metadata on its templates.
+func (ctrl *DefaultController) findTransactionTemplate(ctx context.Context, store Store, schema *ledger.Schema, id uint64) (*ledger.Transaction, *ledger.TransactionTemplate, error) {
+	if schema == nil || !schema.Transactions.HasMetadata() {
+		return nil, nil, nil
+	}
+	tx, err := store.Transactions().GetOne(ctx, storagecommon.ResourceQuery[any]{
+		Builder: query.Match("id", id),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	template, ok := schema.Transactions[tx.Template]
+	if !ok {
+		return tx, nil, nil
+	}
+	return tx, &template, nil
 }
 
-func (ctrl *DefaultController) saveTransactionMetadata(ctx context.Context, store Store, _schema *ledger.Schema, parameters Parameters[SaveTransactionMetadata]) (*ledger.SavedMetadata, error) {
+func (ctrl *DefaultController) saveTransactionMetadata(ctx context.Context, store Store, schema *ledger.Schema, parameters Parameters[SaveTransactionMetadata]) (*ledger.SavedMetadata, error) {
+	tx, template, err := ctrl.findTransactionTemplate(ctx, store, schema, parameters.Input.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+	if template != nil {
+		if err := template.ValidateMetadataUpdate(tx.Template, tx.Metadata, parameters.Input.Metadata); err != nil {
+			if err := ctrl.enforceSchema(ctx, newErrSchemaValidationError(parameters.SchemaVersion, err)); err != nil {
+				return nil, err
+			}
+		}
+	}
 	if _, _, err := store.UpdateTransactionMetadata(ctx, parameters.Input.TransactionID, parameters.Input.Metadata, time.Time{}); err != nil {
 		return nil, err
 	}
@@ -616,13 +2390,17 @@ func (ctrl *DefaultController) saveAccountMetadata(ctx context.Context, store St
 			defaultMetadata = accountSchema.DefaultMetadata()
 		}
 	}
-	if err := store.UpsertAccounts(ctx, ledger.AccountWithDefaultMetadata{
+	account := ledger.AccountWithDefaultMetadata{
 		Account: &ledger.Account{
 			Address:  parameters.Input.Address,
 			Metadata: parameters.Input.Metadata,
 		},
 		DefaultMetadata: defaultMetadata,
-	}); err != nil {
+	}
+	if err := ctrl.enforceAccountsMetadata(ctx, store, schema, parameters.SchemaVersion, account); err != nil {
+		return nil, err
+	}
+	if err := store.UpsertAccounts(ctx, account); err != nil {
 		return nil, err
 	}
 
@@ -639,7 +2417,18 @@ func (ctrl *DefaultController) SaveAccountMetadata(ctx context.Context, paramete
 	return log, idempotencyHit, err
 }
 
-func (ctrl *DefaultController) deleteTransactionMetadata(ctx context.Context, store Store, _schema *ledger.Schema, parameters Parameters[DeleteTransactionMetadata]) (*ledger.DeletedMetadata, error) {
+func (ctrl *DefaultController) deleteTransactionMetadata(ctx context.Context, store Store, schema *ledger.Schema, parameters Parameters[DeleteTransactionMetadata]) (*ledger.DeletedMetadata, error) {
+	tx, template, err := ctrl.findTransactionTemplate(ctx, store, schema, parameters.Input.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+	if template != nil {
+		if err := template.ValidateMetadataDeletion(tx.Template, parameters.Input.Key); err != nil {
+			if err := ctrl.enforceSchema(ctx, newErrSchemaValidationError(parameters.SchemaVersion, err)); err != nil {
+				return nil, err
+			}
+		}
+	}
 	_, modified, err := store.DeleteTransactionMetadata(ctx, parameters.Input.TransactionID, parameters.Input.Key, time.Time{})
 	if err != nil {
 		return nil, err
@@ -662,6 +2451,16 @@ func (ctrl *DefaultController) DeleteTransactionMetadata(ctx context.Context, pa
 }
 
 func (ctrl *DefaultController) deleteAccountMetadata(ctx context.Context, store Store, schema *ledger.Schema, parameters Parameters[DeleteAccountMetadata]) (*ledger.DeletedMetadata, error) {
+	if schema != nil {
+		if accountSchema, _ := schema.Chart.FindAccountSchema(parameters.Input.Address); accountSchema != nil {
+			err := accountSchema.ValidateMetadataDeletion(parameters.Input.Address, parameters.Input.Key)
+			if err != nil {
+				if err := ctrl.enforceSchema(ctx, newErrSchemaValidationError(parameters.SchemaVersion, err)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
 	err := store.DeleteAccountMetadata(ctx, parameters.Input.Address, parameters.Input.Key)
 	if err != nil {
 		return nil, err
@@ -679,7 +2478,11 @@ func (ctrl *DefaultController) DeleteAccountMetadata(ctx context.Context, parame
 	return log, idempotencyHit, err
 }
 
-func (ctrl *DefaultController) runQueryFromCursor(ctx context.Context, template ledger.QueryTemplate, q storagecommon.RunQuery) (*queries.ResourceKind, *paginate.Cursor[any], error) {
+func (ctrl *DefaultController) runQueryFromCursor(ctx context.Context, schema *ledger.Schema, template ledger.QueryTemplate, q storagecommon.RunQuery) (*queries.ResourceKind, *paginate.Cursor[any], error) {
+	if template.Aggregation != nil {
+		return ctrl.runAggregationFromCursor(ctx, template, q)
+	}
+
 	var result *paginate.Cursor[any]
 	switch template.Resource {
 	case queries.ResourceKindTransaction:
@@ -697,7 +2500,7 @@ func (ctrl *DefaultController) runQueryFromCursor(ctx context.Context, template
 		if err != nil {
 			return nil, nil, newErrQueryValidation(err)
 		}
-		r, err := ctrl.store.Accounts().Paginate(ctx, resourceQuery)
+		r, err := ctrl.listAccounts(ctx, &schema.Chart, resourceQuery)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -722,12 +2525,126 @@ func (ctrl *DefaultController) runQueryFromCursor(ctx context.Context, template
 			return nil, nil, err
 		}
 		result = paginate.MapCursor(r, func(x ledger.VolumesWithBalanceByAssetByAccount) any { return x })
+	case queries.ResourceKindAggregatedBalance:
+		return nil, nil, newErrQueryValidation(fmt.Errorf("resource %s is not paginated", template.Resource))
 	default:
 		return nil, nil, fmt.Errorf("invalid resource type: %v", template.Resource)
 	}
+	return &template.Resource, withProjection(result, template.Fields), nil
+}
+
+// withProjection wraps the items of a query template with a projection, so only its fields are rendered
+func withProjection(cursor *paginate.Cursor[any], fields []string) *paginate.Cursor[any] {
+	if len(fields) == 0 {
+		return cursor
+	}
+	return paginate.MapCursor(cursor, func(item any) any {
+		return queries.Projection{Item: item, Fields: fields}
+	})
+}
+
+func aggregateFromCursor[ResourceType, Opts any](ctx context.Context, resource storagecommon.PaginatedResource[ResourceType, Opts], cursor string) (*paginate.Cursor[any], error) {
+	aggregatedQuery, err := storagecommon.UnmarshalAggregatedCursor[Opts](cursor)
+	if err != nil {
+		return nil, newErrQueryValidation(err)
+	}
+	r, err := resource.Aggregate(ctx, *aggregatedQuery)
+	if err != nil {
+		return nil, err
+	}
+	return paginate.MapCursor(r, func(x queries.AggregationGroup) any { return x }), nil
+}
+
+func (ctrl *DefaultController) runAggregationFromCursor(ctx context.Context, template ledger.QueryTemplate, q storagecommon.RunQuery) (*queries.ResourceKind, *paginate.Cursor[any], error) {
+	var (
+		result *paginate.Cursor[any]
+		err    error
+	)
+	switch template.Resource {
+	case queries.ResourceKindTransaction:
+		result, err = aggregateFromCursor(ctx, ctrl.store.Transactions(), *q.Cursor)
+	case queries.ResourceKindAccount:
+		result, err = aggregateFromCursor(ctx, ctrl.store.Accounts(), *q.Cursor)
+	case queries.ResourceKindLog:
+		result, err = aggregateFromCursor(ctx, ctrl.store.Logs(), *q.Cursor)
+	case queries.ResourceKindVolume:
+		result, err = aggregateFromCursor(ctx, ctrl.store.Volumes(), *q.Cursor)
+	default:
+		return nil, nil, newErrQueryValidation(fmt.Errorf("resource %s does not support aggregations", template.Resource))
+	}
+	if err != nil {
+		return nil, nil, err
+	}
 	return &template.Resource, result, nil
 }
 
+// runAggregation runs a query template with an aggregation, the groups are sorted by keys
+func (ctrl *DefaultController) runAggregation(ctx context.Context, schema *ledger.Schema, template ledger.QueryTemplate, builder query.Builder, q storagecommon.RunQuery, paginationConfig storagecommon.PaginationConfig) (*queries.ResourceKind, *paginate.Cursor[any], error) {
+	var r *paginate.Cursor[queries.AggregationGroup]
+	switch template.Resource {
+	case queries.ResourceKindTransaction:
+		params, err := ledger.QueryTemplateParams[any]{
+			PageSize: uint(paginationConfig.DefaultPageSize),
+		}.Overwrite(template.Params, q.Params)
+		if err != nil {
+			return nil, nil, newErrQueryValidation(err)
+		}
+		r, err = ctrl.store.Transactions().Aggregate(ctx, templateParamsToAggregatedQuery(*params, builder, *template.Aggregation, paginationConfig))
+		if err != nil {
+			return nil, nil, err
+		}
+	case queries.ResourceKindAccount:
+		params, err := ledger.QueryTemplateParams[any]{
+			PageSize: uint(paginationConfig.DefaultPageSize),
+		}.Overwrite(template.Params, q.Params)
+		if err != nil {
+			return nil, nil, newErrQueryValidation(err)
+		}
+		aggregatedQuery := templateParamsToAggregatedQuery(*params, builder, *template.Aggregation, paginationConfig)
+		if _, err := ctrl.resolveChartLabels(ctx, &schema.Chart, &aggregatedQuery.Options); err != nil {
+			return nil, nil, err
+		}
+		r, err = ctrl.store.Accounts().Aggregate(ctx, aggregatedQuery)
+		if err != nil {
+			return nil, nil, err
+		}
+	case queries.ResourceKindLog:
+		params, err := ledger.QueryTemplateParams[any]{
+			PageSize: uint(paginationConfig.DefaultPageSize),
+		}.Overwrite(template.Params, q.Params)
+		if err != nil {
+			return nil, nil, newErrQueryValidation(err)
+		}
+		r, err = ctrl.store.Logs().Aggregate(ctx, templateParamsToAggregatedQuery(*params, builder, *template.Aggregation, paginationConfig))
+		if err != nil {
+			return nil, nil, err
+		}
+	case queries.ResourceKindVolume:
+		params, err := ledger.QueryTemplateParams[ledger.GetVolumesOptions]{
+			PageSize: uint(paginationConfig.DefaultPageSize),
+		}.Overwrite(template.Params, q.Params)
+		if err != nil {
+			return nil, nil, newErrQueryValidation(err)
+		}
+		r, err = ctrl.store.Volumes().Aggregate(ctx, templateParamsToAggregatedQuery(*params, builder, *template.Aggregation, paginationConfig))
+		if err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, newErrQueryValidation(fmt.Errorf("resource %s does not support aggregations", template.Resource))
+	}
+	return &template.Resource, paginate.MapCursor(r, func(x queries.AggregationGroup) any { return x }), nil
+}
+
+func templateParamsToAggregatedQuery[Opts any](params ledger.QueryTemplateParams[Opts], builder query.Builder, aggregation queries.Aggregation, paginationConfig storagecommon.PaginationConfig) storagecommon.AggregatedQuery[Opts] {
+	resourceQuery := templateParamsToQuery(params, builder, paginationConfig)
+	return storagecommon.AggregatedQuery[Opts]{
+		Options:     resourceQuery.Options,
+		Aggregation: aggregation,
+		PageSize:    resourceQuery.PageSize,
+	}
+}
+
 func templateParamsToQuery[Opts any](params ledger.QueryTemplateParams[Opts], builder query.Builder, paginationConfig storagecommon.PaginationConfig) storagecommon.InitialPaginatedQuery[Opts] {
 	if uint64(params.PageSize) > paginationConfig.MaxPageSize {
 		params.PageSize = uint(paginationConfig.MaxPageSize)
@@ -753,13 +2670,16 @@ func (ctrl *DefaultController) RunQuery(ctx context.Context, schemaVersion strin
 	}
 	if template, ok := schema.Queries[id]; ok {
 		if q.Cursor != nil {
-			return ctrl.runQueryFromCursor(ctx, template, q)
+			return ctrl.runQueryFromCursor(ctx, schema, template, q)
 		} else {
 			var result *paginate.Cursor[any]
 			builder, err := queries.ResolveFilterTemplate(template.Resource, template.Body, template.Vars, q.Vars)
 			if err != nil {
 				return nil, nil, newErrQueryValidation(err)
 			}
+			if template.Aggregation != nil {
+				return ctrl.runAggregation(ctx, schema, template, builder, q, paginationConfig)
+			}
 			switch template.Resource {
 			case queries.ResourceKindTransaction:
 				params, err := ledger.QueryTemplateParams[any]{
@@ -771,6 +2691,7 @@ func (ctrl *DefaultController) RunQuery(ctx context.Context, schemaVersion strin
 					return nil, nil, newErrQueryValidation(err)
 				}
 				resourceQuery := templateParamsToQuery(*params, builder, paginationConfig)
+				resourceQuery.Options.Fields = template.Fields
 				r, err := ctrl.store.Transactions().Paginate(ctx, resourceQuery)
 				if err != nil {
 					return nil, nil, err
@@ -786,7 +2707,8 @@ func (ctrl *DefaultController) RunQuery(ctx context.Context, schemaVersion strin
 					return nil, nil, newErrQueryValidation(err)
 				}
 				resourceQuery := templateParamsToQuery(*params, builder, paginationConfig)
-				r, err := ctrl.store.Accounts().Paginate(ctx, resourceQuery)
+				resourceQuery.Options.Fields = template.Fields
+				r, err := ctrl.listAccounts(ctx, &schema.Chart, resourceQuery)
 				if err != nil {
 					return nil, nil, err
 				}
@@ -801,6 +2723,7 @@ func (ctrl *DefaultController) RunQuery(ctx context.Context, schemaVersion strin
 					return nil, nil, newErrQueryValidation(err)
 				}
 				resourceQuery := templateParamsToQuery(*params, builder, paginationConfig)
+				resourceQuery.Options.Fields = template.Fields
 				r, err := ctrl.store.Logs().Paginate(ctx, resourceQuery)
 				if err != nil {
 					return nil, nil, err
@@ -825,10 +2748,29 @@ func (ctrl *DefaultController) RunQuery(ctx context.Context, schemaVersion strin
 					return nil, nil, err
 				}
 				result = paginate.MapCursor(r, func(x ledger.VolumesWithBalanceByAssetByAccount) any { return x })
+			case queries.ResourceKindAggregatedBalance:
+				params, err := ledger.QueryTemplateParams[ledger.GetAggregatedVolumesOptions]{
+					PageSize: 1,
+					Opts: ledger.GetAggregatedVolumesOptions{
+						UseInsertionDate: false,
+					},
+				}.Overwrite(template.Params, q.Params)
+				if err != nil {
+					return nil, nil, newErrQueryValidation(err)
+				}
+				resourceQuery := templateParamsToQuery(*params, builder, paginationConfig)
+				balances, err := ctrl.GetAggregatedBalances(ctx, resourceQuery.Options)
+				if err != nil {
+					return nil, nil, err
+				}
+				result = &paginate.Cursor[any]{
+					PageSize: 1,
+					Data:     []any{balances},
+				}
 			default:
 				return nil, nil, fmt.Errorf("invalid resource type: %v", template.Resource)
 			}
-			return &template.Resource, result, nil
+			return &template.Resource, withProjection(result, template.Fields), nil
 		}
 	} else {
 		return nil, nil, newErrSchemaValidationError(schemaVersion, fmt.Errorf("unknown query template: %s", id))
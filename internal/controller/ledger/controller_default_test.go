@@ -2,7 +2,9 @@ package ledger
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"testing"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/formancehq/go-libs/v5/pkg/query"
 	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
 	"github.com/formancehq/go-libs/v5/pkg/storage/migrations"
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
 	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
 	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
 	"github.com/formancehq/go-libs/v5/pkg/types/time"
@@ -23,6 +26,7 @@ import (
 	"github.com/formancehq/ledger/internal/queries"
 	"github.com/formancehq/ledger/internal/storage/common"
 	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+	ledgerstore "github.com/formancehq/ledger/internal/storage/ledger"
 )
 
 func TestCreateTransactionWithoutSchema(t *testing.T) {
@@ -54,7 +58,7 @@ func TestCreateTransactionWithoutSchema(t *testing.T) {
 
 	posting := ledger.NewPosting("world", "bank", "USD", big.NewInt(100))
 	numscriptRuntime.EXPECT().
-		Execute(gomock.Any(), store, runScript.Vars).
+		Execute(gomock.Any(), store, runScript.Vars, gomock.Any()).
 		Return(&NumscriptExecutionResult{
 			Postings: ledger.Postings{posting},
 		}, nil)
@@ -185,7 +189,7 @@ send [EUR/2 100] (
 		Return(store, &bun.Tx{}, nil)
 
 	numscriptRuntime.EXPECT().
-		Execute(gomock.Any(), store, runScript.Vars).
+		Execute(gomock.Any(), store, runScript.Vars, gomock.Any()).
 		Return(&NumscriptExecutionResult{
 			Postings: ledger.Postings{
 				ledger.NewPosting("world", "bank", "USD", big.NewInt(100)),
@@ -223,6 +227,502 @@ send [EUR/2 100] (
 	require.NoError(t, err)
 }
 
+func TestCreateTransactionWithTemplateVars(t *testing.T) {
+	t.Parallel()
+
+	schema := ledger.Schema{
+		SchemaData: ledger.SchemaData{
+			Chart: ledger.ChartOfAccounts{
+				"world": {
+					Account: &ledger.ChartAccount{},
+				},
+				"users": {
+					VariableSegment: &ledger.ChartVariableSegment{
+						Label:   "userID",
+						Pattern: pointer.For("^[0-9]+$"),
+						ChartSegment: ledger.ChartSegment{
+							Account: &ledger.ChartAccount{},
+						},
+					},
+				},
+			},
+			Transactions: ledger.TransactionTemplates{
+				"DEPOSIT": {
+					Script: "script",
+					Vars: map[string]ledger.TransactionTemplateVar{
+						"dest": {
+							Type:  "account",
+							Chart: "users:$userID",
+						},
+						"amount": {
+							Type:    "monetary",
+							Default: pointer.For("USD/2 100"),
+						},
+					},
+				},
+			},
+		},
+		Version: "v1.0",
+	}
+
+	type testCase struct {
+		name          string
+		mode          SchemaEnforcementMode
+		vars          map[string]string
+		expectedVars  map[string]string
+		expectedError string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:         "with default value",
+			vars:         map[string]string{"dest": "users:1"},
+			expectedVars: map[string]string{"dest": "users:1", "amount": "USD/2 100"},
+		},
+		{
+			name:          "with missing variable",
+			vars:          map[string]string{},
+			expectedError: "invalid variable `dest` for transaction template `DEPOSIT`: variable is required",
+		},
+		{
+			name:          "with account outside of the chart path",
+			vars:          map[string]string{"dest": "users:abc"},
+			expectedError: "invalid variable `dest` for transaction template `DEPOSIT`: account `users:abc` does not match `users:$userID`",
+		},
+		{
+			name:          "with invalid monetary",
+			vars:          map[string]string{"dest": "users:1", "amount": "100"},
+			expectedError: "invalid variable `amount` for transaction template `DEPOSIT`: invalid monetary",
+		},
+		{
+			name:         "with account outside of the chart path in audit mode",
+			mode:         SchemaEnforcementAudit,
+			vars:         map[string]string{"dest": "users:abc"},
+			expectedVars: map[string]string{"dest": "users:abc", "amount": "USD/2 100"},
+		},
+		{
+			name:         "with undeclared variable when the schema is not enforced",
+			mode:         SchemaEnforcementOff,
+			vars:         map[string]string{"dest": "users:1", "source": "world"},
+			expectedVars: map[string]string{"dest": "users:1", "source": "world", "amount": "USD/2 100"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			store := NewMockStore(ctrl)
+			numscriptRuntime := NewMockNumscriptRuntime(ctrl)
+			parser := NewMockNumscriptParser(ctrl)
+			machineParser := NewMockNumscriptParser(ctrl)
+			interpreterParser := NewMockNumscriptParser(ctrl)
+
+			mode := tc.mode
+			if mode == "" {
+				mode = SchemaEnforcementStrict
+			}
+			l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser, WithSchemaEnforcementMode(mode))
+
+			store.EXPECT().
+				BeginTX(gomock.Any(), nil).
+				Return(store, &bun.Tx{}, nil)
+
+			store.EXPECT().
+				FindSchema(gomock.Any(), "v1.0").
+				Return(&schema, nil)
+
+			if tc.expectedError != "" {
+				store.EXPECT().
+					Rollback(gomock.Any()).
+					Return(nil)
+			} else {
+				parser.EXPECT().
+					Parse("script").
+					Return(numscriptRuntime, nil)
+
+				numscriptRuntime.EXPECT().
+					Execute(gomock.Any(), store, tc.expectedVars, gomock.Any()).
+					Return(&NumscriptExecutionResult{
+						Postings: ledger.Postings{
+							ledger.NewPosting("world", "users:1", "USD/2", big.NewInt(100)),
+						},
+					}, nil)
+
+				store.EXPECT().
+					CommitTransaction(gomock.Any(), gomock.Any()).
+					Return(nil)
+				store.EXPECT().UpsertAccounts(gomock.Any(), gomock.Any())
+
+				store.EXPECT().
+					InsertLog(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, log *ledger.Log) any {
+						log.ID = pointer.For(uint64(0))
+						return log
+					})
+				store.EXPECT().
+					Commit(gomock.Any()).
+					Return(nil)
+			}
+
+			_, _, _, err := l.CreateTransaction(context.Background(), Parameters[CreateTransaction]{
+				SchemaVersion: "v1.0",
+				Input: CreateTransaction{
+					RunScript: RunScript{
+						Script: vm.Script{
+							Template: "DEPOSIT",
+							Vars:     tc.vars,
+						},
+					},
+				},
+			})
+			if tc.expectedError != "" {
+				require.ErrorIs(t, err, ErrSchemaValidationError{})
+				require.ErrorContains(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateTransactionWithAccountRules(t *testing.T) {
+	t.Parallel()
+
+	for _, mode := range []SchemaEnforcementMode{SchemaEnforcementStrict, SchemaEnforcementAudit} {
+		t.Run(string(mode), func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			store := NewMockStore(ctrl)
+			numscriptRuntime := NewMockNumscriptRuntime(ctrl)
+			parser := NewMockNumscriptParser(ctrl)
+			machineParser := NewMockNumscriptParser(ctrl)
+			interpreterParser := NewMockNumscriptParser(ctrl)
+
+			l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser, WithSchemaEnforcementMode(mode))
+
+			schema := ledger.Schema{
+				SchemaData: ledger.SchemaData{
+					Chart: ledger.ChartOfAccounts{
+						"world": {
+							Account: &ledger.ChartAccount{},
+						},
+						"bank": {
+							Account: &ledger.ChartAccount{
+								Rules: ledger.ChartAccountRules{
+									Overdraft: map[string]ledger.ChartOverdraftRule{
+										"USD": {Limit: big.NewInt(50)},
+									},
+								},
+							},
+						},
+					},
+				},
+				Version: "v1.0",
+			}
+
+			runScript := RunScript{}
+
+			parser.EXPECT().
+				Parse(runScript.Plain).
+				Return(numscriptRuntime, nil)
+
+			store.EXPECT().
+				BeginTX(gomock.Any(), nil).
+				Return(store, &bun.Tx{}, nil)
+
+			store.EXPECT().
+				FindSchema(gomock.Any(), "v1.0").
+				Return(&schema, nil)
+
+			numscriptRuntime.EXPECT().
+				Execute(gomock.Any(), store, runScript.Vars, gomock.Any()).
+				Return(&NumscriptExecutionResult{
+					Postings: ledger.Postings{
+						ledger.NewPosting("bank", "world", "USD", big.NewInt(100)),
+					},
+				}, nil)
+
+			store.EXPECT().
+				CommitTransaction(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, tx *ledger.Transaction) error {
+					tx.PostCommitVolumes = ledger.PostCommitVolumes{
+						"bank":  {"USD": ledger.NewVolumesInt64(0, 100)},
+						"world": {"USD": ledger.NewVolumesInt64(100, 0)},
+					}
+					return nil
+				})
+			store.EXPECT().UpsertAccounts(gomock.Any(), gomock.Any())
+
+			if mode == SchemaEnforcementStrict {
+				store.EXPECT().
+					Rollback(gomock.Any()).
+					Return(nil)
+			} else {
+				store.EXPECT().
+					InsertLog(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, log *ledger.Log) any {
+						log.ID = pointer.For(uint64(0))
+						return log
+					})
+				store.EXPECT().
+					Commit(gomock.Any()).
+					Return(nil)
+			}
+
+			_, _, _, err := l.CreateTransaction(context.Background(), Parameters[CreateTransaction]{
+				SchemaVersion: "v1.0",
+				Input: CreateTransaction{
+					RunScript: runScript,
+				},
+			})
+			if mode == SchemaEnforcementStrict {
+				require.ErrorIs(t, err, ErrAccountRuleViolation{})
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateTransactionWithChartOverdraft(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		rule          ledger.ChartOverdraftRule
+		script        string
+		expectedError error
+	}
+	for _, tc := range []testCase{
+		{
+			name:   "within the limit of the chart",
+			rule:   ledger.ChartOverdraftRule{Limit: big.NewInt(100)},
+			script: "send [USD 100] (\n\tsource = @bank\n\tdestination = @world\n)",
+		},
+		{
+			name:          "exceeding the limit of the chart",
+			rule:          ledger.ChartOverdraftRule{Limit: big.NewInt(50)},
+			script:        "send [USD 100] (\n\tsource = @bank\n\tdestination = @world\n)",
+			expectedError: &ErrInsufficientFunds{},
+		},
+		{
+			name:          "chart forbidding the overdraft allowed by the script",
+			rule:          ledger.ChartOverdraftRule{},
+			script:        "send [USD 100] (\n\tsource = @bank allowing overdraft up to [USD 100]\n\tdestination = @world\n)",
+			expectedError: &ErrInsufficientFunds{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			store := NewMockStore(ctrl)
+			store.EXPECT().
+				GetBalances(gomock.Any(), gomock.Any()).
+				Return(ledger.Balances{"bank": {"USD": big.NewInt(0)}}, nil)
+
+			runtime, err := NewDefaultNumscriptParser().Parse(tc.script)
+			require.NoError(t, err)
+
+			_, err = runtime.Execute(context.Background(), store, nil, &ledger.Schema{
+				SchemaData: ledger.SchemaData{
+					Chart: ledger.ChartOfAccounts{
+						"bank": {
+							Account: &ledger.ChartAccount{
+								Rules: ledger.ChartAccountRules{
+									Overdraft: map[string]ledger.ChartOverdraftRule{"USD": tc.rule},
+								},
+							},
+						},
+					},
+				},
+			})
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestExecuteWithChartOverdraft(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		rule          ledger.ChartOverdraftRule
+		balance       *big.Int
+		expectedError error
+	}
+	for _, tc := range []testCase{
+		{
+			name:    "unbounded overdraft",
+			rule:    ledger.ChartOverdraftRule{Unbounded: true},
+			balance: big.NewInt(0),
+		},
+		{
+			name:    "unbounded overdraft with funds",
+			rule:    ledger.ChartOverdraftRule{Unbounded: true},
+			balance: big.NewInt(4),
+		},
+		{
+			name:    "within the bounded overdraft",
+			rule:    ledger.ChartOverdraftRule{Limit: big.NewInt(10)},
+			balance: big.NewInt(0),
+		},
+		{
+			name:          "exceeding the bounded overdraft",
+			rule:          ledger.ChartOverdraftRule{Limit: big.NewInt(5)},
+			balance:       big.NewInt(4),
+			expectedError: &ErrInsufficientFunds{},
+		},
+		{
+			name:          "no overdraft",
+			rule:          ledger.ChartOverdraftRule{},
+			balance:       big.NewInt(0),
+			expectedError: &ErrInsufficientFunds{},
+		},
+	} {
+		for _, parser := range []struct {
+			name   string
+			parser NumscriptParser
+		}{
+			{name: "machine", parser: NewDefaultNumscriptParser()},
+			{name: "interpreter", parser: NewInterpreterNumscriptParser(nil)},
+		} {
+			t.Run(tc.name+"/"+parser.name, func(t *testing.T) {
+				t.Parallel()
+				ctrl := gomock.NewController(t)
+
+				store := NewMockStore(ctrl)
+				store.EXPECT().
+					GetBalances(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, query ledgerstore.BalanceQuery) (ledger.Balances, error) {
+						balances := ledger.Balances{}
+						for account, assets := range query {
+							balances[account] = map[string]*big.Int{}
+							for _, asset := range assets {
+								balances[account][asset] = new(big.Int)
+								if account == "a" {
+									balances[account][asset].Set(tc.balance)
+								}
+							}
+						}
+						return balances, nil
+					}).
+					AnyTimes()
+
+				runtime, err := parser.parser.Parse("send [USD 10] (\n\tsource = @a\n\tdestination = @b\n)")
+				require.NoError(t, err)
+
+				result, err := runtime.Execute(context.Background(), store, nil, &ledger.Schema{
+					SchemaData: ledger.SchemaData{
+						Chart: ledger.ChartOfAccounts{
+							"a": {
+								Account: &ledger.ChartAccount{
+									Rules: ledger.ChartAccountRules{
+										Overdraft: map[string]ledger.ChartOverdraftRule{"USD": tc.rule},
+									},
+								},
+							},
+							"b": {
+								Account: &ledger.ChartAccount{},
+							},
+						},
+					},
+				})
+				if tc.expectedError != nil {
+					// the interpreter reports the missing funds as a runtime error
+					if parser.name == "machine" {
+						require.ErrorIs(t, err, tc.expectedError)
+					} else {
+						require.ErrorIs(t, err, ErrRuntime{})
+					}
+					return
+				}
+				require.NoError(t, err)
+				require.Equal(t, ledger.Postings{
+					ledger.NewPosting("a", "b", "USD", big.NewInt(10)),
+				}, result.Postings)
+			})
+		}
+	}
+}
+
+func TestCheckRevertBalances(t *testing.T) {
+	t.Parallel()
+
+	schema := &ledger.Schema{
+		SchemaData: ledger.SchemaData{
+			Chart: ledger.ChartOfAccounts{
+				"strict": {
+					Account: &ledger.ChartAccount{
+						Rules: ledger.ChartAccountRules{
+							Overdraft: map[string]ledger.ChartOverdraftRule{"USD": {}},
+						},
+					},
+				},
+				"limited": {
+					Account: &ledger.ChartAccount{
+						Rules: ledger.ChartAccountRules{
+							Overdraft: map[string]ledger.ChartOverdraftRule{"USD": {Limit: big.NewInt(50)}},
+						},
+					},
+				},
+				"unbounded": {
+					Account: &ledger.ChartAccount{
+						Rules: ledger.ChartAccountRules{
+							Overdraft: map[string]ledger.ChartOverdraftRule{"USD": {Unbounded: true}},
+						},
+					},
+				},
+				"users": {
+					VariableSegment: &ledger.ChartVariableSegment{
+						Label: "userID",
+						ChartSegment: ledger.ChartSegment{
+							Account: &ledger.ChartAccount{},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	type testCase struct {
+		name        string
+		schema      *ledger.Schema
+		account     string
+		amount      int64
+		expectError bool
+	}
+	for _, tc := range []testCase{
+		{name: "overdraft without schema", account: "users:1", amount: 10, expectError: true},
+		{name: "world without schema", account: "world", amount: 10},
+		{name: "overdraft without rule", schema: schema, account: "users:1", amount: 10, expectError: true},
+		{name: "overdraft forbidden by the chart", schema: schema, account: "strict", amount: 10, expectError: true},
+		{name: "overdraft within the limit of the chart", schema: schema, account: "limited", amount: 50},
+		{name: "overdraft exceeding the limit of the chart", schema: schema, account: "limited", amount: 60, expectError: true},
+		{name: "unbounded overdraft", schema: schema, account: "unbounded", amount: 1000},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkRevertBalances(tc.schema, ledger.Balances{
+				tc.account: {"USD": big.NewInt(0)},
+			}, ledger.NewTransaction().WithPostings(
+				ledger.NewPosting(tc.account, "bank", "USD", big.NewInt(tc.amount)),
+			))
+			if tc.expectError {
+				require.ErrorIs(t, err, &ErrInsufficientFunds{})
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestRevertTransaction(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
@@ -283,17 +783,14 @@ func TestRevertTransaction(t *testing.T) {
 	require.NoError(t, err)
 }
 
-func TestSaveTransactionMetadata(t *testing.T) {
+func TestPartiallyRevertTransaction(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 
 	store := NewMockStore(ctrl)
-	ctx := logging.TestingContext()
 	parser := NewMockNumscriptParser(ctrl)
-	machineParser := NewMockNumscriptParser(ctrl)
-	interpreterParser := NewMockNumscriptParser(ctrl)
-
-	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+	ctx := logging.TestingContext()
+	l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser)
 
 	store.EXPECT().
 		BeginTX(gomock.Any(), nil).
@@ -307,43 +804,72 @@ func TestSaveTransactionMetadata(t *testing.T) {
 		Commit(gomock.Any()).
 		Return(nil)
 
-	m := metadata.Metadata{
-		"foo": "bar",
-	}
+	txToRevert := ledger.NewTransaction().
+		WithPostings(
+			ledger.NewPosting("world", "users:001", "USD", big.NewInt(100)),
+			ledger.NewPosting("users:001", "merchants:001", "USD", big.NewInt(40)),
+		).
+		WithID(1)
+	txToRevert.RevertedAmounts = []*big.Int{big.NewInt(0), big.NewInt(10)}
 	store.EXPECT().
-		UpdateTransactionMetadata(gomock.Any(), uint64(1), m, time.Time{}).
-		Return(&ledger.Transaction{}, true, nil)
+		LockTransaction(gomock.Any(), uint64(1)).
+		Return(&txToRevert, nil)
+
+	store.EXPECT().
+		GetBalances(gomock.Any(), ledgerstore.BalanceQuery{
+			"merchants:001": {"USD"},
+		}).
+		Return(ledger.Balances{
+			"merchants:001": {"USD": big.NewInt(40)},
+		}, nil)
+
+	store.EXPECT().
+		CommitTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, tx *ledger.Transaction) error {
+			require.Equal(t, ledger.Postings{
+				ledger.NewPosting("merchants:001", "users:001", "USD", big.NewInt(15)),
+			}, tx.Postings)
+			return nil
+		})
+
+	store.EXPECT().
+		UpdateTransactionRevertedAmounts(gomock.Any(), uint64(1), gomock.Any(), gomock.Any(), false).
+		DoAndReturn(func(_ context.Context, _ uint64, amounts []*big.Int, _ time.Time, _ bool) (*ledger.Transaction, error) {
+			require.Equal(t, "[0 25]", fmt.Sprint(amounts))
+			txToRevert.RevertedAmounts = amounts
+			return &txToRevert, nil
+		})
 
 	store.EXPECT().
 		InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
-			return x.(*ledger.Log).Type == ledger.SetMetadataLogType
+			return x.(*ledger.Log).Type == ledger.PartiallyRevertedTransactionLogType
 		})).
-		DoAndReturn(func(ctx context.Context, log *ledger.Log) error {
-			log.ID = pointer.For(uint64(0))
+		DoAndReturn(func(ctx context.Context, v *ledger.Log) error {
+			v.ID = pointer.For(uint64(0))
 
 			return nil
 		})
 
-	_, _, err := l.SaveTransactionMetadata(ctx, Parameters[SaveTransactionMetadata]{
-		Input: SaveTransactionMetadata{
-			Metadata:      m,
-			TransactionID: 1,
+	_, ret, _, err := l.PartiallyRevertTransaction(ctx, Parameters[PartiallyRevertTransaction]{
+		Input: PartiallyRevertTransaction{
+			TransactionID: uint64(1),
+			Amounts: map[int]*big.Int{
+				1: big.NewInt(15),
+			},
 		},
 	})
 	require.NoError(t, err)
+	require.Equal(t, "[100 15]", fmt.Sprint(ret.RevertedTransaction.RevertibleAmounts()))
 }
 
-func TestDeleteTransactionMetadata(t *testing.T) {
+func TestPartiallyRevertTransactionExceedingAmounts(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 
 	store := NewMockStore(ctrl)
 	parser := NewMockNumscriptParser(ctrl)
-	machineParser := NewMockNumscriptParser(ctrl)
-	interpreterParser := NewMockNumscriptParser(ctrl)
 	ctx := logging.TestingContext()
-
-	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+	l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser)
 
 	store.EXPECT().
 		BeginTX(gomock.Any(), nil).
@@ -354,182 +880,497 @@ func TestDeleteTransactionMetadata(t *testing.T) {
 		Return(nil, nil)
 
 	store.EXPECT().
-		Commit(gomock.Any()).
+		Rollback(gomock.Any()).
 		Return(nil)
 
+	txToRevert := ledger.NewTransaction().
+		WithPostings(ledger.NewPosting("world", "users:001", "USD", big.NewInt(100))).
+		WithID(1)
+	txToRevert.RevertedAmounts = []*big.Int{big.NewInt(90)}
 	store.EXPECT().
-		DeleteTransactionMetadata(gomock.Any(), uint64(1), "foo", time.Time{}).
-		Return(&ledger.Transaction{}, true, nil)
-
-	store.EXPECT().
-		InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
-			return x.(*ledger.Log).Type == ledger.DeleteMetadataLogType
-		})).
-		DoAndReturn(func(ctx context.Context, log *ledger.Log) error {
-			log.ID = pointer.For(uint64(0))
-
-			return nil
-		})
+		LockTransaction(gomock.Any(), uint64(1)).
+		Return(&txToRevert, nil)
 
-	_, _, err := l.DeleteTransactionMetadata(ctx, Parameters[DeleteTransactionMetadata]{
-		Input: DeleteTransactionMetadata{
-			TransactionID: 1,
-			Key:           "foo",
+	_, _, _, err := l.PartiallyRevertTransaction(ctx, Parameters[PartiallyRevertTransaction]{
+		Input: PartiallyRevertTransaction{
+			TransactionID: uint64(1),
+			Amounts: map[int]*big.Int{
+				0: big.NewInt(20),
+			},
 		},
 	})
-	require.NoError(t, err)
+	require.ErrorIs(t, err, ErrInvalidPartialRevert{})
 }
 
-func TestListTransactions(t *testing.T) {
+func TestCreateTransactionInClosedPeriod(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 
 	store := NewMockStore(ctrl)
 	parser := NewMockNumscriptParser(ctrl)
-	machineParser := NewMockNumscriptParser(ctrl)
-	interpreterParser := NewMockNumscriptParser(ctrl)
 	ctx := logging.TestingContext()
+	l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser)
 
-	transactions := NewMockPaginatedResource[ledger.Transaction, any](ctrl)
+	closedUntil := time.Now().Add(-time.Hour)
 
-	cursor := &paginate.Cursor[ledger.Transaction]{}
-	store.EXPECT().Transactions().Return(transactions)
-	transactions.EXPECT().
-		Paginate(gomock.Any(), common.InitialPaginatedQuery[any]{
-			PageSize: paginate.QueryDefaultPageSize,
-			Order:    pointer.For(paginate.Order(paginate.OrderDesc)),
-			Column:   "id",
-		}).
-		Return(cursor, nil)
+	store.EXPECT().
+		BeginTX(gomock.Any(), nil).
+		Return(store, &bun.Tx{}, nil)
 
-	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
-	ret, err := l.ListTransactions(ctx, common.InitialPaginatedQuery[any]{
-		PageSize: paginate.QueryDefaultPageSize,
-		Order:    pointer.For(paginate.Order(paginate.OrderDesc)),
-		Column:   "id",
+	store.EXPECT().
+		FindLatestSchemaVersion(gomock.Any()).
+		Return(nil, nil)
+
+	store.EXPECT().
+		FindClosedPeriod(gomock.Any()).
+		Return(&ledger.ClosedPeriod{ClosedUntil: closedUntil}, nil)
+
+	store.EXPECT().
+		Rollback(gomock.Any()).
+		Return(nil)
+
+	_, _, _, err := l.CreateTransaction(ctx, Parameters[CreateTransaction]{
+		Input: CreateTransaction{
+			RunScript: RunScript{
+				Script: Script{
+					Plain: "send [USD 100] (source = @world destination = @bank)",
+				},
+				Timestamp: closedUntil.Add(-time.Minute),
+			},
+		},
 	})
-	require.NoError(t, err)
-	require.Equal(t, cursor, ret)
+	require.ErrorIs(t, err, ErrPeriodClosed{})
 }
 
-func TestCountAccounts(t *testing.T) {
+func TestRevertTransactionInClosedPeriod(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 
 	store := NewMockStore(ctrl)
 	parser := NewMockNumscriptParser(ctrl)
-	machineParser := NewMockNumscriptParser(ctrl)
-	interpreterParser := NewMockNumscriptParser(ctrl)
 	ctx := logging.TestingContext()
-	accounts := NewMockPaginatedResource[ledger.Account, any](ctrl)
+	l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser)
 
-	store.EXPECT().Accounts().Return(accounts)
-	accounts.EXPECT().Count(gomock.Any(), common.ResourceQuery[any]{}).Return(1, nil)
+	closedUntil := time.Now().Add(-time.Hour)
 
-	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
-	count, err := l.CountAccounts(ctx, common.ResourceQuery[any]{})
-	require.NoError(t, err)
-	require.Equal(t, 1, count)
+	store.EXPECT().
+		BeginTX(gomock.Any(), nil).
+		Return(store, &bun.Tx{}, nil)
+
+	store.EXPECT().
+		FindLatestSchemaVersion(gomock.Any()).
+		Return(nil, nil)
+
+	store.EXPECT().
+		RevertTransaction(gomock.Any(), uint64(1), time.Time{}).
+		Return(&ledger.Transaction{
+			ID: pointer.For(uint64(1)),
+			TransactionData: ledger.TransactionData{
+				Timestamp: closedUntil.Add(-time.Minute),
+			},
+			RevertedAt: pointer.For(time.Now()),
+		}, true, nil)
+
+	store.EXPECT().
+		GetBalances(gomock.Any(), gomock.Any()).
+		Return(map[string]map[string]*big.Int{}, nil)
+
+	store.EXPECT().
+		FindClosedPeriod(gomock.Any()).
+		Return(&ledger.ClosedPeriod{ClosedUntil: closedUntil}, nil)
+
+	store.EXPECT().
+		Rollback(gomock.Any()).
+		Return(nil)
+
+	_, _, _, err := l.RevertTransaction(ctx, Parameters[RevertTransaction]{
+		Input: RevertTransaction{
+			TransactionID:   uint64(1),
+			AtEffectiveDate: true,
+		},
+	})
+	require.ErrorIs(t, err, ErrPeriodClosed{})
 }
 
-func TestGetTransaction(t *testing.T) {
+func TestClosePeriod(t *testing.T) {
 	t.Parallel()
-	ctrl := gomock.NewController(t)
 
-	store := NewMockStore(ctrl)
-	parser := NewMockNumscriptParser(ctrl)
-	machineParser := NewMockNumscriptParser(ctrl)
-	interpreterParser := NewMockNumscriptParser(ctrl)
-	ctx := logging.TestingContext()
-	transactions := NewMockPaginatedResource[ledger.Transaction, any](ctrl)
+	now := time.Now()
 
-	tx := ledger.Transaction{}
-	store.EXPECT().Transactions().Return(transactions)
-	transactions.EXPECT().GetOne(gomock.Any(), common.ResourceQuery[any]{
-		Builder: query.Match("id", 1),
-	}).Return(&tx, nil)
+	type testCase struct {
+		name          string
+		closedUntil   time.Time
+		current       *ledger.ClosedPeriod
+		expectedError error
+	}
 
-	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
-	ret, err := l.GetTransaction(ctx, common.ResourceQuery[any]{
-		Builder: query.Match("id", 1),
-	})
-	require.NoError(t, err)
-	require.Equal(t, tx, *ret)
+	for _, tc := range []testCase{
+		{
+			name:        "nominal",
+			closedUntil: now.Add(-time.Hour),
+		},
+		{
+			name:        "after the current close date",
+			closedUntil: now.Add(-time.Hour),
+			current:     &ledger.ClosedPeriod{ClosedUntil: now.Add(-2 * time.Hour)},
+		},
+		{
+			name:          "before the current close date",
+			closedUntil:   now.Add(-2 * time.Hour),
+			current:       &ledger.ClosedPeriod{ClosedUntil: now.Add(-time.Hour)},
+			expectedError: ErrInvalidPeriod{},
+		},
+		{
+			name:          "in the future",
+			closedUntil:   now.Add(time.Hour),
+			expectedError: ErrInvalidPeriod{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			store := NewMockStore(ctrl)
+			parser := NewMockNumscriptParser(ctrl)
+			ctx := logging.TestingContext()
+			l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser)
+
+			store.EXPECT().
+				BeginTX(gomock.Any(), nil).
+				Return(store, &bun.Tx{}, nil)
+
+			if tc.closedUntil.Before(now) {
+				store.EXPECT().
+					LockClosedPeriod(gomock.Any()).
+					Return(tc.current, nil)
+			}
+
+			if tc.expectedError == nil {
+				store.EXPECT().
+					SaveClosedPeriod(gomock.Any(), ledger.ClosedPeriod{ClosedUntil: tc.closedUntil}).
+					Return(nil)
+
+				store.EXPECT().
+					InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+						return x.(*ledger.Log).Type == ledger.ClosedPeriodLogType
+					})).
+					DoAndReturn(func(ctx context.Context, v *ledger.Log) error {
+						v.ID = pointer.For(uint64(0))
+						return nil
+					})
+
+				store.EXPECT().
+					Commit(gomock.Any()).
+					Return(nil)
+			} else {
+				store.EXPECT().
+					Rollback(gomock.Any()).
+					Return(nil)
+			}
+
+			_, period, _, err := l.ClosePeriod(ctx, Parameters[ClosePeriod]{
+				Input: ClosePeriod{
+					ClosedUntil: tc.closedUntil,
+				},
+			})
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.closedUntil, period.ClosedUntil)
+		})
+	}
 }
 
-func TestGetAccount(t *testing.T) {
+func TestReopenPeriod(t *testing.T) {
 	t.Parallel()
-	ctrl := gomock.NewController(t)
 
-	store := NewMockStore(ctrl)
-	parser := NewMockNumscriptParser(ctrl)
-	machineParser := NewMockNumscriptParser(ctrl)
-	interpreterParser := NewMockNumscriptParser(ctrl)
-	ctx := logging.TestingContext()
-	accounts := NewMockPaginatedResource[ledger.Account, any](ctrl)
+	now := time.Now()
 
-	account := ledger.Account{}
-	store.EXPECT().Accounts().Return(accounts)
-	accounts.EXPECT().GetOne(gomock.Any(), common.ResourceQuery[any]{
-		Builder: query.Match("address", "world"),
-	}).Return(&account, nil)
+	type testCase struct {
+		name          string
+		closedUntil   *time.Time
+		current       *ledger.ClosedPeriod
+		expectedError error
+	}
 
-	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
-	ret, err := l.GetAccount(ctx, common.ResourceQuery[any]{
-		Builder: query.Match("address", "world"),
-	})
-	require.NoError(t, err)
-	require.Equal(t, account, *ret)
+	for _, tc := range []testCase{
+		{
+			name:    "all the periods",
+			current: &ledger.ClosedPeriod{ClosedUntil: now},
+		},
+		{
+			name:        "move the close date back",
+			closedUntil: pointer.For(now.Add(-time.Hour)),
+			current:     &ledger.ClosedPeriod{ClosedUntil: now},
+		},
+		{
+			name:          "move the close date forward",
+			closedUntil:   pointer.For(now.Add(time.Hour)),
+			current:       &ledger.ClosedPeriod{ClosedUntil: now},
+			expectedError: ErrInvalidPeriod{},
+		},
+		{
+			name:          "no closed period",
+			expectedError: ErrInvalidPeriod{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			store := NewMockStore(ctrl)
+			parser := NewMockNumscriptParser(ctrl)
+			ctx := logging.TestingContext()
+			l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser)
+
+			store.EXPECT().
+				BeginTX(gomock.Any(), nil).
+				Return(store, &bun.Tx{}, nil)
+
+			store.EXPECT().
+				LockClosedPeriod(gomock.Any()).
+				Return(tc.current, nil)
+
+			if tc.expectedError == nil {
+				if tc.closedUntil == nil {
+					store.EXPECT().
+						DeleteClosedPeriod(gomock.Any()).
+						Return(nil)
+				} else {
+					store.EXPECT().
+						SaveClosedPeriod(gomock.Any(), ledger.ClosedPeriod{ClosedUntil: *tc.closedUntil}).
+						Return(nil)
+				}
+
+				store.EXPECT().
+					InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+						return x.(*ledger.Log).Type == ledger.ReopenedPeriodLogType
+					})).
+					DoAndReturn(func(ctx context.Context, v *ledger.Log) error {
+						v.ID = pointer.For(uint64(0))
+						return nil
+					})
+
+				store.EXPECT().
+					Commit(gomock.Any()).
+					Return(nil)
+			} else {
+				store.EXPECT().
+					Rollback(gomock.Any()).
+					Return(nil)
+			}
+
+			_, period, _, err := l.ReopenPeriod(ctx, Parameters[ReopenPeriod]{
+				Input: ReopenPeriod{
+					ClosedUntil: tc.closedUntil,
+				},
+			})
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.closedUntil, period.ClosedUntil)
+			require.Equal(t, tc.current.ClosedUntil, period.PreviousClosedUntil)
+		})
+	}
 }
 
-func TestCountTransactions(t *testing.T) {
+func TestSaveTransactionMetadata(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 
 	store := NewMockStore(ctrl)
+	ctx := logging.TestingContext()
 	parser := NewMockNumscriptParser(ctrl)
 	machineParser := NewMockNumscriptParser(ctrl)
 	interpreterParser := NewMockNumscriptParser(ctrl)
-	ctx := logging.TestingContext()
-	transactions := NewMockPaginatedResource[ledger.Transaction, any](ctrl)
-
-	store.EXPECT().Transactions().Return(transactions)
-	transactions.EXPECT().Count(gomock.Any(), common.ResourceQuery[any]{}).Return(1, nil)
 
 	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
-	count, err := l.CountTransactions(ctx, common.ResourceQuery[any]{})
+
+	store.EXPECT().
+		BeginTX(gomock.Any(), nil).
+		Return(store, &bun.Tx{}, nil)
+
+	store.EXPECT().
+		FindLatestSchemaVersion(gomock.Any()).
+		Return(nil, nil)
+
+	store.EXPECT().
+		Commit(gomock.Any()).
+		Return(nil)
+
+	m := metadata.Metadata{
+		"foo": "bar",
+	}
+	store.EXPECT().
+		UpdateTransactionMetadata(gomock.Any(), uint64(1), m, time.Time{}).
+		Return(&ledger.Transaction{}, true, nil)
+
+	store.EXPECT().
+		InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+			return x.(*ledger.Log).Type == ledger.SetMetadataLogType
+		})).
+		DoAndReturn(func(ctx context.Context, log *ledger.Log) error {
+			log.ID = pointer.For(uint64(0))
+
+			return nil
+		})
+
+	_, _, err := l.SaveTransactionMetadata(ctx, Parameters[SaveTransactionMetadata]{
+		Input: SaveTransactionMetadata{
+			Metadata:      m,
+			TransactionID: 1,
+		},
+	})
 	require.NoError(t, err)
-	require.Equal(t, 1, count)
 }
 
-func TestListAccounts(t *testing.T) {
+func TestSaveAccountMetadataWithSchema(t *testing.T) {
 	t.Parallel()
-	ctrl := gomock.NewController(t)
 
-	store := NewMockStore(ctrl)
-	parser := NewMockNumscriptParser(ctrl)
-	machineParser := NewMockNumscriptParser(ctrl)
-	interpreterParser := NewMockNumscriptParser(ctrl)
-	ctx := logging.TestingContext()
-	accounts := NewMockPaginatedResource[ledger.Account, any](ctrl)
+	schema := ledger.Schema{
+		SchemaData: ledger.SchemaData{
+			Chart: ledger.ChartOfAccounts{
+				"users": {
+					VariableSegment: &ledger.ChartVariableSegment{
+						Label: "userID",
+						ChartSegment: ledger.ChartSegment{
+							Account: &ledger.ChartAccount{
+								Metadata: map[string]ledger.ChartAccountMetadata{
+									"external_id": {
+										Immutable: true,
+									},
+									"kyc_level": {
+										Type: "int",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Version: "v1.0",
+	}
 
-	cursor := &paginate.Cursor[ledger.Account]{}
-	store.EXPECT().Accounts().Return(accounts)
-	accounts.EXPECT().Paginate(gomock.Any(), common.InitialPaginatedQuery[any]{
-		PageSize: paginate.QueryDefaultPageSize,
-		Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
-	}).Return(cursor, nil)
+	type testCase struct {
+		name          string
+		metadata      metadata.Metadata
+		existing      metadata.Metadata
+		newAccount    bool
+		expectedError bool
+	}
 
-	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
-	ret, err := l.ListAccounts(ctx, common.InitialPaginatedQuery[any]{
-		PageSize: paginate.QueryDefaultPageSize,
-		Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
-	})
-	require.NoError(t, err)
-	require.Equal(t, cursor, ret)
+	for _, tc := range []testCase{
+		{
+			name:     "nominal",
+			metadata: metadata.Metadata{"kyc_level": "1"},
+		},
+		{
+			name:          "invalid type",
+			metadata:      metadata.Metadata{"kyc_level": "high"},
+			expectedError: true,
+		},
+		{
+			name:     "set immutable metadata",
+			metadata: metadata.Metadata{"external_id": "abc"},
+			existing: metadata.Metadata{},
+		},
+		{
+			name:          "update immutable metadata",
+			metadata:      metadata.Metadata{"external_id": "abc"},
+			existing:      metadata.Metadata{"external_id": "def"},
+			expectedError: true,
+		},
+		{
+			name:       "set immutable metadata on a new account",
+			metadata:   metadata.Metadata{"external_id": "abc"},
+			newAccount: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			store := NewMockStore(ctrl)
+			parser := NewMockNumscriptParser(ctrl)
+			machineParser := NewMockNumscriptParser(ctrl)
+			interpreterParser := NewMockNumscriptParser(ctrl)
+			ctx := logging.TestingContext()
+
+			l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser, WithSchemaEnforcementMode(SchemaEnforcementStrict))
+
+			store.EXPECT().
+				BeginTX(gomock.Any(), nil).
+				Return(store, &bun.Tx{}, nil)
+
+			store.EXPECT().
+				FindSchema(gomock.Any(), "v1.0").
+				Return(&schema, nil)
+
+			if tc.existing != nil || tc.newAccount {
+				existingAccounts := make([]ledger.Account, 0)
+				if !tc.newAccount {
+					existingAccounts = append(existingAccounts, ledger.Account{
+						Address:  "users:001",
+						Metadata: tc.existing,
+					})
+				}
+				accounts := NewMockPaginatedResource[ledger.Account, any](ctrl)
+				store.EXPECT().Accounts().Return(accounts)
+				accounts.EXPECT().Paginate(gomock.Any(), common.OffsetPaginatedQuery[any]{
+					InitialPaginatedQuery: common.InitialPaginatedQuery[any]{
+						Column:   "address",
+						PageSize: 1,
+						Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
+						Options: common.ResourceQuery[any]{
+							Builder: query.In("address", []any{"users:001"}),
+						},
+					},
+				}).Return(&paginate.Cursor[ledger.Account]{
+					Data: existingAccounts,
+				}, nil)
+			}
+
+			if tc.expectedError {
+				store.EXPECT().
+					Rollback(gomock.Any()).
+					Return(nil)
+			} else {
+				store.EXPECT().
+					UpsertAccounts(gomock.Any(), gomock.Any()).
+					Return(nil)
+				store.EXPECT().
+					InsertLog(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, log *ledger.Log) error {
+						log.ID = pointer.For(uint64(0))
+						return nil
+					})
+				store.EXPECT().
+					Commit(gomock.Any()).
+					Return(nil)
+			}
+
+			_, _, err := l.SaveAccountMetadata(ctx, Parameters[SaveAccountMetadata]{
+				SchemaVersion: "v1.0",
+				Input: SaveAccountMetadata{
+					Address:  "users:001",
+					Metadata: tc.metadata,
+				},
+			})
+			if tc.expectedError {
+				require.ErrorIs(t, err, ErrSchemaValidationError{})
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
 }
 
-func TestGetAggregatedBalances(t *testing.T) {
+func TestDeleteTransactionMetadata(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 
@@ -538,19 +1379,45 @@ func TestGetAggregatedBalances(t *testing.T) {
 	machineParser := NewMockNumscriptParser(ctrl)
 	interpreterParser := NewMockNumscriptParser(ctrl)
 	ctx := logging.TestingContext()
-	aggregatedBalances := NewMockResource[ledger.AggregatedVolumes, ledger.GetAggregatedVolumesOptions](ctrl)
-
-	store.EXPECT().AggregatedBalances().Return(aggregatedBalances)
-	aggregatedBalances.EXPECT().GetOne(gomock.Any(), common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{}).
-		Return(&ledger.AggregatedVolumes{}, nil)
 
 	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
-	ret, err := l.GetAggregatedBalances(ctx, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{})
+
+	store.EXPECT().
+		BeginTX(gomock.Any(), nil).
+		Return(store, &bun.Tx{}, nil)
+
+	store.EXPECT().
+		FindLatestSchemaVersion(gomock.Any()).
+		Return(nil, nil)
+
+	store.EXPECT().
+		Commit(gomock.Any()).
+		Return(nil)
+
+	store.EXPECT().
+		DeleteTransactionMetadata(gomock.Any(), uint64(1), "foo", time.Time{}).
+		Return(&ledger.Transaction{}, true, nil)
+
+	store.EXPECT().
+		InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+			return x.(*ledger.Log).Type == ledger.DeleteMetadataLogType
+		})).
+		DoAndReturn(func(ctx context.Context, log *ledger.Log) error {
+			log.ID = pointer.For(uint64(0))
+
+			return nil
+		})
+
+	_, _, err := l.DeleteTransactionMetadata(ctx, Parameters[DeleteTransactionMetadata]{
+		Input: DeleteTransactionMetadata{
+			TransactionID: 1,
+			Key:           "foo",
+		},
+	})
 	require.NoError(t, err)
-	require.Equal(t, ledger.BalancesByAssets{}, ret)
 }
 
-func TestListLogs(t *testing.T) {
+func TestListTransactions(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 
@@ -559,18 +1426,21 @@ func TestListLogs(t *testing.T) {
 	machineParser := NewMockNumscriptParser(ctrl)
 	interpreterParser := NewMockNumscriptParser(ctrl)
 	ctx := logging.TestingContext()
-	logs := NewMockPaginatedResource[ledger.Log, any](ctrl)
 
-	cursor := &paginate.Cursor[ledger.Log]{}
-	store.EXPECT().Logs().Return(logs)
-	logs.EXPECT().Paginate(gomock.Any(), common.InitialPaginatedQuery[any]{
-		PageSize: paginate.QueryDefaultPageSize,
-		Order:    pointer.For(paginate.Order(paginate.OrderDesc)),
-		Column:   "id",
-	}).Return(cursor, nil)
+	transactions := NewMockPaginatedResource[ledger.Transaction, any](ctrl)
+
+	cursor := &paginate.Cursor[ledger.Transaction]{}
+	store.EXPECT().Transactions().Return(transactions)
+	transactions.EXPECT().
+		Paginate(gomock.Any(), common.InitialPaginatedQuery[any]{
+			PageSize: paginate.QueryDefaultPageSize,
+			Order:    pointer.For(paginate.Order(paginate.OrderDesc)),
+			Column:   "id",
+		}).
+		Return(cursor, nil)
 
 	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
-	ret, err := l.ListLogs(ctx, common.InitialPaginatedQuery[any]{
+	ret, err := l.ListTransactions(ctx, common.InitialPaginatedQuery[any]{
 		PageSize: paginate.QueryDefaultPageSize,
 		Order:    pointer.For(paginate.Order(paginate.OrderDesc)),
 		Column:   "id",
@@ -579,7 +1449,7 @@ func TestListLogs(t *testing.T) {
 	require.Equal(t, cursor, ret)
 }
 
-func TestGetVolumesWithBalances(t *testing.T) {
+func TestDiffSchema(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 
@@ -588,25 +1458,68 @@ func TestGetVolumesWithBalances(t *testing.T) {
 	machineParser := NewMockNumscriptParser(ctrl)
 	interpreterParser := NewMockNumscriptParser(ctrl)
 	ctx := logging.TestingContext()
-	volumes := NewMockPaginatedResource[ledger.VolumesWithBalanceByAssetByAccount, ledger.GetVolumesOptions](ctrl)
 
-	balancesByAssets := &paginate.Cursor[ledger.VolumesWithBalanceByAssetByAccount]{}
-	store.EXPECT().Volumes().Return(volumes)
-	volumes.EXPECT().Paginate(gomock.Any(), common.InitialPaginatedQuery[ledger.GetVolumesOptions]{
+	from := ledger.Schema{
+		Version: "v1",
+		SchemaData: ledger.SchemaData{
+			Chart: ledger.ChartOfAccounts{
+				"world": {Account: &ledger.ChartAccount{}},
+				"bank":  {Account: &ledger.ChartAccount{}},
+			},
+		},
+	}
+	to := ledger.SchemaData{
+		Chart: ledger.ChartOfAccounts{
+			"world": {Account: &ledger.ChartAccount{}},
+		},
+	}
+	query := common.InitialPaginatedQuery[any]{
 		PageSize: paginate.QueryDefaultPageSize,
 		Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
-	}).Return(balancesByAssets, nil)
+		Column:   "address",
+	}
+
+	store.EXPECT().
+		FindLatestSchemaVersion(gomock.Any()).
+		Return(pointer.For("v1"), nil)
+	store.EXPECT().
+		FindSchema(gomock.Any(), "v1").
+		Return(&from, nil)
+
+	accounts := NewMockPaginatedResource[ledger.Account, any](ctrl)
+	store.EXPECT().Accounts().Return(accounts)
+	filteredQuery := query
+	// the accounts broken by the new chart are the ones valid for the compared chart only
+	filteredQuery.Options.Opts = ledgerstore.AccountsOptions{
+		ChartAccounts:    from.Chart.AccountAddresses(""),
+		IncompatibleWith: to.Chart,
+	}
+	accounts.EXPECT().
+		Paginate(gomock.Any(), filteredQuery).
+		Return(&paginate.Cursor[ledger.Account]{
+			PageSize: 2,
+			HasMore:  true,
+			Next:     "next",
+			Data: []ledger.Account{
+				{Address: "bank"},
+			},
+		}, nil)
 
 	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
-	ret, err := l.GetVolumesWithBalances(ctx, common.InitialPaginatedQuery[ledger.GetVolumesOptions]{
-		PageSize: paginate.QueryDefaultPageSize,
-		Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
-	})
+	diff, incompatibleAccounts, err := l.DiffSchema(ctx, "", to, query)
 	require.NoError(t, err)
-	require.Equal(t, balancesByAssets, ret)
+	require.Equal(t, []string{"bank"}, diff.Chart.Removed)
+	require.Equal(t, &paginate.Cursor[ledger.Account]{
+		PageSize: 2,
+		HasMore:  true,
+		Next:     "next",
+		Data: []ledger.Account{
+			{Address: "bank"},
+		},
+	}, incompatibleAccounts)
 }
 
-func TestRunQuery(t *testing.T) {
+func TestGetChartTree(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 
@@ -614,100 +1527,282 @@ func TestRunQuery(t *testing.T) {
 	parser := NewMockNumscriptParser(ctrl)
 	machineParser := NewMockNumscriptParser(ctrl)
 	interpreterParser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
 	accounts := NewMockPaginatedResource[ledger.Account, any](ctrl)
+	chartAccountsVolumes := NewMockResource[ledger.ChartAccountsVolumes, ledger.GetAggregatedVolumesOptions](ctrl)
 
-	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
-
-	schemaVersion := "v1.0"
 	schema := ledger.Schema{
+		Version: "v1",
 		SchemaData: ledger.SchemaData{
-			Chart:        ledger.ChartOfAccounts{},
-			Transactions: ledger.TransactionTemplates{},
-			Queries: ledger.QueryTemplates{
-				"FOO": {
-					Description: "Foo template",
-					Resource:    "accounts",
-					Vars: map[string]queries.VarDecl{
-						"aaa": {
-							Type:    queries.NewTypeString(),
-							Default: nil,
+			Chart: ledger.ChartOfAccounts{
+				"users": {
+					VariableSegment: &ledger.ChartVariableSegment{
+						Label: "userID",
+						ChartSegment: ledger.ChartSegment{
+							Account: &ledger.ChartAccount{},
 						},
 					},
-					Body: json.RawMessage(`{
-						"$match": {
-							"address": "${aaa}"
-						}
-					}`),
 				},
+				"world": {Account: &ledger.ChartAccount{}},
 			},
 		},
-		Version: schemaVersion,
 	}
+	pit := time.Now()
+	metadataFilter := query.Match("metadata[category]", "retail")
+	chartAccounts := schema.Chart.AccountAddresses("")
 
 	store.EXPECT().
-		BeginTX(gomock.Any(), nil).
+		BeginTX(gomock.Any(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}).
 		Return(store, &bun.Tx{}, nil)
-
+	store.EXPECT().Rollback(gomock.Any()).Return(nil)
 	store.EXPECT().
-		InsertSchema(gomock.Any(), &schema).
-		Return(nil)
+		FindSchema(gomock.Any(), "v1").
+		Return(&schema, nil)
 
-	store.EXPECT().
-		Commit(gomock.Any()).
-		Return(nil)
-
-	store.EXPECT().
-		InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
-			return x.(*ledger.Log).Type == ledger.InsertedSchemaLogType
-		})).
-		DoAndReturn(func(_ context.Context, log *ledger.Log) any {
-			log.ID = pointer.For(uint64(0))
-			return log
-		})
+	// the accounts and the volumes of the whole tree are read with a single query each
+	store.EXPECT().Accounts().Return(accounts)
+	accounts.EXPECT().
+		Aggregate(gomock.Any(), common.AggregatedQuery[any]{
+			Options: common.ResourceQuery[any]{
+				PIT:     &pit,
+				Builder: metadataFilter,
+				Opts: ledgerstore.AccountsOptions{
+					ChartAccounts: chartAccounts,
+				},
+			},
+			Aggregation: queries.Aggregation{
+				GroupBy: []string{ledgerstore.ChartAccountAggregationKey},
+				Measures: map[string]queries.Measure{
+					"count": {Function: queries.MeasureCount},
+				},
+			},
+		}).
+		Return(&paginate.Cursor[queries.AggregationGroup]{
+			Data: []queries.AggregationGroup{{
+				Keys:     map[string]*string{ledgerstore.ChartAccountAggregationKey: pointer.For("users:$userID")},
+				Measures: map[string]*big.Int{"count": big.NewInt(2)},
+			}},
+		}, nil)
+	store.EXPECT().ChartAccountsVolumes(chartAccounts).Return(chartAccountsVolumes)
+	chartAccountsVolumes.EXPECT().
+		GetOne(gomock.Any(), common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+			PIT:     &pit,
+			Builder: metadataFilter,
+		}).
+		Return(&ledger.ChartAccountsVolumes{Volumes: map[string]ledger.VolumesByAssets{
+			"users:$userID": {"USD": ledger.NewVolumesInt64(100, 0)},
+			"world":         {"USD": ledger.NewVolumesInt64(0, 100)},
+		}}, nil)
 
-	_, _, _, err := l.InsertSchema(context.Background(), Parameters[InsertSchema]{
-		Input: InsertSchema{
-			Version: schema.Version,
-			Data:    schema.SchemaData,
+	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+	tree, err := l.GetChartTree(ctx, "v1", common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+		PIT:     &pit,
+		Builder: metadataFilter,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []ledger.ChartTreeNode{
+		{
+			Segment:       "users",
+			Path:          "users",
+			AccountsCount: 2,
+			Balances:      ledger.BalancesByAssets{"USD": big.NewInt(100)},
+			Children: []ledger.ChartTreeNode{{
+				Segment:       "$userID",
+				Path:          "users:$userID",
+				Account:       true,
+				AccountsCount: 2,
+				Balances:      ledger.BalancesByAssets{"USD": big.NewInt(100)},
+			}},
+		},
+		{
+			Segment:  "world",
+			Path:     "world",
+			Account:  true,
+			Balances: ledger.BalancesByAssets{"USD": big.NewInt(-100)},
 		},
+	}, tree)
+}
+
+func TestCountAccounts(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	machineParser := NewMockNumscriptParser(ctrl)
+	interpreterParser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+	accounts := NewMockPaginatedResource[ledger.Account, any](ctrl)
+
+	store.EXPECT().Accounts().Return(accounts)
+	accounts.EXPECT().Count(gomock.Any(), common.ResourceQuery[any]{}).Return(1, nil)
+
+	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+	count, err := l.CountAccounts(ctx, common.ResourceQuery[any]{})
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+func TestGetTransaction(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	machineParser := NewMockNumscriptParser(ctrl)
+	interpreterParser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+	transactions := NewMockPaginatedResource[ledger.Transaction, any](ctrl)
+
+	tx := ledger.Transaction{}
+	store.EXPECT().Transactions().Return(transactions)
+	transactions.EXPECT().GetOne(gomock.Any(), common.ResourceQuery[any]{
+		Builder: query.Match("id", 1),
+	}).Return(&tx, nil)
+
+	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+	ret, err := l.GetTransaction(ctx, common.ResourceQuery[any]{
+		Builder: query.Match("id", 1),
 	})
 	require.NoError(t, err)
+	require.Equal(t, tx, *ret)
+}
 
-	store.EXPECT().
-		FindSchema(gomock.Any(), "v1.0").
-		Return(&schema, nil)
+func TestGetAccount(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	machineParser := NewMockNumscriptParser(ctrl)
+	interpreterParser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+	accounts := NewMockPaginatedResource[ledger.Account, any](ctrl)
 
+	account := ledger.Account{}
 	store.EXPECT().Accounts().Return(accounts)
+	accounts.EXPECT().GetOne(gomock.Any(), common.ResourceQuery[any]{
+		Builder: query.Match("address", "world"),
+	}).Return(&account, nil)
 
-	expectedQuery, err := query.ParseJSON(`{"$match": {"address": "bbb"}}`)
+	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+	ret, err := l.GetAccount(ctx, common.ResourceQuery[any]{
+		Builder: query.Match("address", "world"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, account, *ret)
+}
+
+func TestCountTransactions(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	machineParser := NewMockNumscriptParser(ctrl)
+	interpreterParser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+	transactions := NewMockPaginatedResource[ledger.Transaction, any](ctrl)
+
+	store.EXPECT().Transactions().Return(transactions)
+	transactions.EXPECT().Count(gomock.Any(), common.ResourceQuery[any]{}).Return(1, nil)
+
+	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+	count, err := l.CountTransactions(ctx, common.ResourceQuery[any]{})
 	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+func TestListAccounts(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	machineParser := NewMockNumscriptParser(ctrl)
+	interpreterParser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+	accounts := NewMockPaginatedResource[ledger.Account, any](ctrl)
+
 	cursor := &paginate.Cursor[ledger.Account]{}
+	store.EXPECT().Accounts().Return(accounts)
+	accounts.EXPECT().Paginate(gomock.Any(), common.InitialPaginatedQuery[any]{
+		PageSize: paginate.QueryDefaultPageSize,
+		Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
+	}).Return(cursor, nil)
+
+	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+	ret, err := l.ListAccounts(ctx, common.InitialPaginatedQuery[any]{
+		PageSize: paginate.QueryDefaultPageSize,
+		Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, cursor, ret)
+}
+
+func TestListAccountsWithChartLabels(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	machineParser := NewMockNumscriptParser(ctrl)
+	interpreterParser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+	accounts := NewMockPaginatedResource[ledger.Account, any](ctrl)
+
+	schema := ledger.Schema{
+		Version: "v1",
+		SchemaData: ledger.SchemaData{
+			Chart: ledger.ChartOfAccounts{
+				"users": {
+					VariableSegment: &ledger.ChartVariableSegment{
+						Label: "userID",
+						ChartSegment: ledger.ChartSegment{
+							Account: &ledger.ChartAccount{},
+						},
+					},
+				},
+				"world": {Account: &ledger.ChartAccount{}},
+			},
+		},
+	}
+
+	store.EXPECT().
+		FindLatestSchemaVersion(gomock.Any()).
+		Return(pointer.For("v1"), nil)
+	store.EXPECT().
+		FindSchema(gomock.Any(), "v1").
+		Return(&schema, nil)
+	store.EXPECT().Accounts().Return(accounts)
 	accounts.EXPECT().Paginate(gomock.Any(), common.InitialPaginatedQuery[any]{
 		PageSize: paginate.QueryDefaultPageSize,
-		Column:   "address",
 		Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
 		Options: common.ResourceQuery[any]{
-			Builder: expectedQuery,
+			Builder: query.Or(query.Match("address", "users:1")),
+			Expand:  []string{"chart"},
 		},
-	}).Return(cursor, nil)
+	}).Return(&paginate.Cursor[ledger.Account]{
+		Data: []ledger.Account{{Address: "users:1"}},
+	}, nil)
 
-	resource, ret, err := l.RunQuery(context.Background(), schema.Version, "FOO", common.RunQuery{
-		Vars: map[string]any{
-			"aaa": "bbb",
+	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+	ret, err := l.ListAccounts(ctx, common.InitialPaginatedQuery[any]{
+		PageSize: paginate.QueryDefaultPageSize,
+		Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
+		Options: common.ResourceQuery[any]{
+			Builder: query.Match("chart.userID", "1"),
+			Expand:  []string{"chart"},
 		},
-	}, storagecommon.PaginationConfig{
-		MaxPageSize:     paginate.MaxPageSize,
-		DefaultPageSize: paginate.QueryDefaultPageSize,
 	})
 	require.NoError(t, err)
-	require.Equal(t, queries.ResourceKindAccount, *resource)
-	require.Equal(t, &paginate.Cursor[any]{
-		Data: []any{},
-	}, ret)
-
+	require.Equal(t, []ledger.Account{{
+		Address:     "users:1",
+		ChartLabels: map[string]string{"userID": "1"},
+	}}, ret.Data)
 }
 
-func TestGetMigrationsInfo(t *testing.T) {
+func TestListAccountsWithChartLabelsWithoutSchema(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 
@@ -717,18 +1812,85 @@ func TestGetMigrationsInfo(t *testing.T) {
 	interpreterParser := NewMockNumscriptParser(ctrl)
 	ctx := logging.TestingContext()
 
-	migrationsInfo := make([]migrations.Info, 0)
 	store.EXPECT().
-		GetMigrationsInfo(gomock.Any()).
-		Return(migrationsInfo, nil)
+		FindLatestSchemaVersion(gomock.Any()).
+		Return(nil, nil)
 
 	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
-	ret, err := l.GetMigrationsInfo(ctx)
+	_, err := l.ListAccounts(ctx, common.InitialPaginatedQuery[any]{
+		PageSize: paginate.QueryDefaultPageSize,
+		Options: common.ResourceQuery[any]{
+			Builder: query.Match("chart.userID", "1"),
+		},
+	})
+	require.ErrorIs(t, err, common.ErrInvalidQuery{})
+}
+
+func TestGetBalanceHistory(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	machineParser := NewMockNumscriptParser(ctrl)
+	interpreterParser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+	aggregatedVolumesHistory := NewMockResource[ledger.AggregatedVolumesHistory, ledger.GetAggregatedVolumesOptions](ctrl)
+
+	start, err := time.ParseTime("2024-01-01T00:00:00Z")
 	require.NoError(t, err)
-	require.Equal(t, migrationsInfo, ret)
+	end := start.Add(36 * time.Hour)
+	builder := query.Match("address", "users:1")
+
+	// the end of an interval is inclusive, like the point in time of a query
+	dates := []time.Time{start.Add(24 * time.Hour).Add(-time.DatePrecision), end}
+	store.EXPECT().AggregatedVolumesHistory(dates).Return(aggregatedVolumesHistory)
+	aggregatedVolumesHistory.EXPECT().
+		GetOne(gomock.Any(), common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+			Builder: builder,
+			Opts: ledger.GetAggregatedVolumesOptions{
+				UseInsertionDate: true,
+			},
+		}).
+		Return(&ledger.AggregatedVolumesHistory{Aggregated: []ledger.VolumesByAssets{
+			{"USD": ledger.NewVolumesInt64(100, 0)},
+			{"USD": ledger.NewVolumesInt64(200, 0)},
+		}}, nil)
+
+	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+	history, err := l.GetBalanceHistory(ctx, common.ResourceQuery[ledger.GetBalanceHistoryOptions]{
+		OOT:     &start,
+		PIT:     &end,
+		Builder: builder,
+		Opts: ledger.GetBalanceHistoryOptions{
+			GetAggregatedVolumesOptions: ledger.GetAggregatedVolumesOptions{
+				UseInsertionDate: true,
+			},
+			Interval: ledger.BalanceHistoryIntervalDay,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []ledger.BalanceHistoryPoint{
+		{
+			Date:     dates[0],
+			Balances: ledger.BalancesByAssets{"USD": big.NewInt(100)},
+		},
+		{
+			Date:     dates[1],
+			Balances: ledger.BalancesByAssets{"USD": big.NewInt(200)},
+		},
+	}, history)
+
+	_, err = l.GetBalanceHistory(ctx, common.ResourceQuery[ledger.GetBalanceHistoryOptions]{
+		PIT: &end,
+		Opts: ledger.GetBalanceHistoryOptions{
+			Interval: ledger.BalanceHistoryIntervalDay,
+		},
+	})
+	require.ErrorIs(t, err, common.ErrInvalidQuery{})
 }
 
-func TestIsDatabaseUpToDate(t *testing.T) {
+func TestGetAccountStatement(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 
@@ -737,13 +1899,1968 @@ func TestIsDatabaseUpToDate(t *testing.T) {
 	machineParser := NewMockNumscriptParser(ctrl)
 	interpreterParser := NewMockNumscriptParser(ctrl)
 	ctx := logging.TestingContext()
+	aggregatedBalances := NewMockResource[ledger.AggregatedVolumes, ledger.GetAggregatedVolumesOptions](ctrl)
+
+	end := time.Now()
+	start := end.Add(-24 * time.Hour)
+	openingDate := start.Add(-time.Microsecond)
+	moves := []ledger.Move{
+		{
+			TransactionID:              1,
+			Account:                    "users:1",
+			Asset:                      "USD",
+			Amount:                     (*paginate.BigInt)(big.NewInt(50)),
+			EffectiveDate:              start,
+			PostCommitEffectiveVolumes: pointer.For(ledger.NewVolumesInt64(150, 0)),
+		},
+		{
+			TransactionID:              2,
+			IsSource:                   true,
+			Account:                    "users:1",
+			Asset:                      "USD",
+			Amount:                     (*paginate.BigInt)(big.NewInt(30)),
+			EffectiveDate:              start.Add(time.Minute),
+			PostCommitEffectiveVolumes: pointer.For(ledger.NewVolumesInt64(150, 30)),
+		},
+	}
 
 	store.EXPECT().
-		IsUpToDate(gomock.Any()).
-		Return(true, nil)
+		BeginTX(gomock.Any(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}).
+		Return(store, &bun.Tx{}, nil)
+	store.EXPECT().Rollback(gomock.Any()).Return(nil)
+	store.EXPECT().AggregatedBalances().Return(aggregatedBalances).Times(2)
+	aggregatedBalances.EXPECT().
+		GetOne(gomock.Any(), common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+			PIT:     &openingDate,
+			Builder: query.In("address", []any{"users:1"}),
+		}).
+		Return(&ledger.AggregatedVolumes{Aggregated: ledger.VolumesByAssets{
+			"USD": ledger.NewVolumesInt64(100, 0),
+		}}, nil)
+	aggregatedBalances.EXPECT().
+		GetOne(gomock.Any(), common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+			PIT:     &end,
+			Builder: query.In("address", []any{"users:1"}),
+		}).
+		Return(&ledger.AggregatedVolumes{Aggregated: ledger.VolumesByAssets{
+			"USD": ledger.NewVolumesInt64(150, 80),
+		}}, nil)
+	// one more move than the page size tells a next page exists
+	store.EXPECT().
+		ListAccountMoves(gomock.Any(), ledgerstore.AccountMovesQuery{
+			Account: "users:1",
+			OOT:     start,
+			PIT:     end,
+			Limit:   2,
+		}).
+		Return(moves, nil)
 
 	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
-	ret, err := l.IsDatabaseUpToDate(ctx)
+	statement, err := l.GetAccountStatement(ctx, "users:1", common.InitialPaginatedQuery[ledger.GetAggregatedVolumesOptions]{
+		PageSize: 1,
+		Options: common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+			OOT: &start,
+			PIT: &end,
+		},
+	})
 	require.NoError(t, err)
-	require.True(t, ret)
+	require.Equal(t, ledger.BalancesByAssets{"USD": big.NewInt(100)}, statement.OpeningBalances)
+	require.Equal(t, ledger.BalancesByAssets{"USD": big.NewInt(70)}, statement.ClosingBalances)
+	require.Equal(t, []ledger.AccountStatementLine{{
+		TransactionID: 1,
+		EffectiveDate: start,
+		Asset:         "USD",
+		Amount:        big.NewInt(50),
+		Balance:       big.NewInt(150),
+	}}, statement.Lines.Data)
+	require.True(t, statement.Lines.HasMore)
+
+	next, err := common.UnmarshalCursor[ledger.GetAggregatedVolumesOptions](statement.Lines.Next)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), next.(common.OffsetPaginatedQuery[ledger.GetAggregatedVolumesOptions]).Offset)
+
+	_, err = l.GetAccountStatement(ctx, "users:1", common.InitialPaginatedQuery[ledger.GetAggregatedVolumesOptions]{
+		Options: common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+			OOT: &end,
+			PIT: &start,
+		},
+	})
+	require.ErrorIs(t, err, common.ErrInvalidQuery{})
+}
+
+func TestGetTrialBalance(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	machineParser := NewMockNumscriptParser(ctrl)
+	interpreterParser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+	chartAccountsVolumes := NewMockResource[ledger.ChartAccountsVolumes, ledger.GetAggregatedVolumesOptions](ctrl)
+
+	schema := ledger.Schema{
+		Version: "v1",
+		SchemaData: ledger.SchemaData{
+			Chart: ledger.ChartOfAccounts{
+				"banks": {
+					Class:   ledger.AccountingClassAsset,
+					Account: &ledger.ChartAccount{},
+				},
+				"world": {Account: &ledger.ChartAccount{}},
+			},
+		},
+	}
+	pit := time.Now()
+
+	store.EXPECT().
+		BeginTX(gomock.Any(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}).
+		Return(store, &bun.Tx{}, nil)
+	store.EXPECT().Rollback(gomock.Any()).Return(nil)
+	store.EXPECT().
+		FindSchema(gomock.Any(), "v1").
+		Return(&schema, nil)
+	// the volumes of all the nodes are read with a single query
+	store.EXPECT().ChartAccountsVolumes(schema.Chart.AccountAddresses("")).Return(chartAccountsVolumes)
+	chartAccountsVolumes.EXPECT().
+		GetOne(gomock.Any(), common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+			PIT: &pit,
+		}).
+		Return(&ledger.ChartAccountsVolumes{Volumes: map[string]ledger.VolumesByAssets{
+			"banks": {"USD": ledger.NewVolumesInt64(100, 20)},
+			"world": {"USD": ledger.NewVolumesInt64(20, 100)},
+		}}, nil)
+
+	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+	trialBalance, err := l.GetTrialBalance(ctx, "v1", common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+		PIT: &pit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &ledger.TrialBalance{
+		Nodes: []ledger.TrialBalanceNode{
+			{
+				Segment: "banks",
+				Path:    "banks",
+				Account: true,
+				Class:   ledger.AccountingClassAsset,
+				Totals: ledger.TrialBalanceTotalsByAssets{
+					"USD": {Debit: big.NewInt(100), Credit: big.NewInt(20)},
+				},
+			},
+			{
+				Segment: "world",
+				Path:    "world",
+				Account: true,
+				Totals: ledger.TrialBalanceTotalsByAssets{
+					"USD": {Debit: big.NewInt(20), Credit: big.NewInt(100)},
+				},
+			},
+		},
+		Totals: ledger.TrialBalanceTotalsByAssets{
+			"USD": {Debit: big.NewInt(120), Credit: big.NewInt(120)},
+		},
+	}, trialBalance)
+}
+
+func TestGetFinancialStatements(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	machineParser := NewMockNumscriptParser(ctrl)
+	interpreterParser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+	chartAccountsVolumes := NewMockResource[ledger.ChartAccountsVolumes, ledger.GetAggregatedVolumesOptions](ctrl)
+
+	schema := ledger.Schema{
+		Version: "v1",
+		SchemaData: ledger.SchemaData{
+			Chart: ledger.ChartOfAccounts{
+				"banks":     {Class: ledger.AccountingClassAsset, Account: &ledger.ChartAccount{}},
+				"customers": {Class: ledger.AccountingClassLiability, Account: &ledger.ChartAccount{}},
+				"capital":   {Class: ledger.AccountingClassEquity, Account: &ledger.ChartAccount{}},
+				"fees":      {Class: ledger.AccountingClassIncome, Account: &ledger.ChartAccount{}},
+				"providers": {Class: ledger.AccountingClassExpense, Account: &ledger.ChartAccount{}},
+			},
+		},
+	}
+	pit := time.Now()
+	oot := pit.Add(-24 * time.Hour)
+	openingDate := oot.Add(-time.Microsecond)
+
+	store.EXPECT().
+		BeginTX(gomock.Any(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}).
+		Return(store, &bun.Tx{}, nil).
+		Times(2)
+	store.EXPECT().Rollback(gomock.Any()).Return(nil).Times(2)
+	store.EXPECT().
+		FindSchema(gomock.Any(), "v1").
+		Return(&schema, nil).
+		Times(2)
+	store.EXPECT().ChartAccountsVolumes(schema.Chart.AccountAddresses("")).Return(chartAccountsVolumes).AnyTimes()
+
+	volumes := func(balances map[string]int64) *ledger.ChartAccountsVolumes {
+		ret := &ledger.ChartAccountsVolumes{Volumes: map[string]ledger.VolumesByAssets{}}
+		for path, balance := range balances {
+			ret.Volumes[path] = ledger.VolumesByAssets{
+				"USD": ledger.NewVolumesInt64(max(balance, 0), max(-balance, 0)),
+			}
+		}
+		return ret
+	}
+	// the closing volumes of all the sections are read with a single query, the opening ones with another one
+	chartAccountsVolumes.EXPECT().
+		GetOne(gomock.Any(), common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{PIT: &pit}).
+		Return(volumes(map[string]int64{
+			"banks":     130,
+			"customers": -100,
+			"capital":   -20,
+			"fees":      -15,
+			"providers": 5,
+		}), nil).
+		Times(2)
+	chartAccountsVolumes.EXPECT().
+		GetOne(gomock.Any(), common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{PIT: &openingDate}).
+		Return(volumes(map[string]int64{
+			"fees":      -10,
+			"providers": 2,
+		}), nil)
+
+	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+	balanceSheet, err := l.GetBalanceSheet(ctx, "v1", common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+		PIT: &pit,
+		OOT: &oot,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ledger.BalancesByAssets{"USD": big.NewInt(130)}, balanceSheet.Assets.Total)
+	require.Equal(t, ledger.BalancesByAssets{"USD": big.NewInt(100)}, balanceSheet.Liabilities.Total)
+	require.Equal(t, ledger.BalancesByAssets{"USD": big.NewInt(20)}, balanceSheet.Equity.Total)
+	require.Equal(t, ledger.BalancesByAssets{"USD": big.NewInt(10)}, balanceSheet.NetIncome)
+
+	incomeStatement, err := l.GetIncomeStatement(ctx, "v1", common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+		PIT: &pit,
+		OOT: &oot,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &ledger.IncomeStatement{
+		Income: ledger.FinancialStatementSection{
+			Class: ledger.AccountingClassIncome,
+			Lines: []ledger.FinancialStatementLine{{
+				Path:    "fees",
+				Amounts: ledger.BalancesByAssets{"USD": big.NewInt(5)},
+			}},
+			Total: ledger.BalancesByAssets{"USD": big.NewInt(5)},
+		},
+		Expenses: ledger.FinancialStatementSection{
+			Class: ledger.AccountingClassExpense,
+			Lines: []ledger.FinancialStatementLine{{
+				Path:    "providers",
+				Amounts: ledger.BalancesByAssets{"USD": big.NewInt(3)},
+			}},
+			Total: ledger.BalancesByAssets{"USD": big.NewInt(3)},
+		},
+		NetIncome: ledger.BalancesByAssets{"USD": big.NewInt(2)},
+	}, incomeStatement)
+}
+
+func TestGetAggregatedBalances(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	machineParser := NewMockNumscriptParser(ctrl)
+	interpreterParser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+	aggregatedBalances := NewMockResource[ledger.AggregatedVolumes, ledger.GetAggregatedVolumesOptions](ctrl)
+
+	store.EXPECT().AggregatedBalances().Return(aggregatedBalances)
+	aggregatedBalances.EXPECT().GetOne(gomock.Any(), common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{}).
+		Return(&ledger.AggregatedVolumes{}, nil)
+
+	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+	ret, err := l.GetAggregatedBalances(ctx, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{})
+	require.NoError(t, err)
+	require.Equal(t, ledger.BalancesByAssets{}, ret)
+}
+
+func TestListLogs(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	machineParser := NewMockNumscriptParser(ctrl)
+	interpreterParser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+	logs := NewMockPaginatedResource[ledger.Log, any](ctrl)
+
+	cursor := &paginate.Cursor[ledger.Log]{}
+	store.EXPECT().Logs().Return(logs)
+	logs.EXPECT().Paginate(gomock.Any(), common.InitialPaginatedQuery[any]{
+		PageSize: paginate.QueryDefaultPageSize,
+		Order:    pointer.For(paginate.Order(paginate.OrderDesc)),
+		Column:   "id",
+	}).Return(cursor, nil)
+
+	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+	ret, err := l.ListLogs(ctx, common.InitialPaginatedQuery[any]{
+		PageSize: paginate.QueryDefaultPageSize,
+		Order:    pointer.For(paginate.Order(paginate.OrderDesc)),
+		Column:   "id",
+	})
+	require.NoError(t, err)
+	require.Equal(t, cursor, ret)
+}
+
+func TestGetVolumesWithBalances(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	machineParser := NewMockNumscriptParser(ctrl)
+	interpreterParser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+	volumes := NewMockPaginatedResource[ledger.VolumesWithBalanceByAssetByAccount, ledger.GetVolumesOptions](ctrl)
+
+	balancesByAssets := &paginate.Cursor[ledger.VolumesWithBalanceByAssetByAccount]{}
+	store.EXPECT().Volumes().Return(volumes)
+	volumes.EXPECT().Paginate(gomock.Any(), common.InitialPaginatedQuery[ledger.GetVolumesOptions]{
+		PageSize: paginate.QueryDefaultPageSize,
+		Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
+	}).Return(balancesByAssets, nil)
+
+	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+	ret, err := l.GetVolumesWithBalances(ctx, common.InitialPaginatedQuery[ledger.GetVolumesOptions]{
+		PageSize: paginate.QueryDefaultPageSize,
+		Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, balancesByAssets, ret)
+}
+
+func TestRunQuery(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	machineParser := NewMockNumscriptParser(ctrl)
+	interpreterParser := NewMockNumscriptParser(ctrl)
+	accounts := NewMockPaginatedResource[ledger.Account, any](ctrl)
+
+	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+
+	schemaVersion := "v1.0"
+	schema := ledger.Schema{
+		SchemaData: ledger.SchemaData{
+			Chart:        ledger.ChartOfAccounts{},
+			Transactions: ledger.TransactionTemplates{},
+			Queries: ledger.QueryTemplates{
+				"FOO": {
+					Description: "Foo template",
+					Resource:    "accounts",
+					Vars: map[string]queries.VarDecl{
+						"aaa": {
+							Type:    queries.NewTypeString(),
+							Default: nil,
+						},
+					},
+					Body: json.RawMessage(`{
+						"$match": {
+							"address": "${aaa}"
+						}
+					}`),
+				},
+			},
+		},
+		Version: schemaVersion,
+	}
+
+	store.EXPECT().
+		BeginTX(gomock.Any(), nil).
+		Return(store, &bun.Tx{}, nil)
+
+	store.EXPECT().
+		InsertSchema(gomock.Any(), &schema).
+		Return(nil)
+
+	store.EXPECT().
+		Commit(gomock.Any()).
+		Return(nil)
+
+	store.EXPECT().
+		InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+			return x.(*ledger.Log).Type == ledger.InsertedSchemaLogType
+		})).
+		DoAndReturn(func(_ context.Context, log *ledger.Log) any {
+			log.ID = pointer.For(uint64(0))
+			return log
+		})
+
+	_, _, _, err := l.InsertSchema(context.Background(), Parameters[InsertSchema]{
+		Input: InsertSchema{
+			Version: schema.Version,
+			Data:    schema.SchemaData,
+		},
+	})
+	require.NoError(t, err)
+
+	store.EXPECT().
+		FindSchema(gomock.Any(), "v1.0").
+		Return(&schema, nil)
+
+	store.EXPECT().Accounts().Return(accounts)
+
+	expectedQuery, err := query.ParseJSON(`{"$match": {"address": "bbb"}}`)
+	require.NoError(t, err)
+	cursor := &paginate.Cursor[ledger.Account]{}
+	accounts.EXPECT().Paginate(gomock.Any(), common.InitialPaginatedQuery[any]{
+		PageSize: paginate.QueryDefaultPageSize,
+		Column:   "address",
+		Order:    pointer.For(paginate.Order(paginate.OrderAsc)),
+		Options: common.ResourceQuery[any]{
+			Builder: expectedQuery,
+		},
+	}).Return(cursor, nil)
+
+	resource, ret, err := l.RunQuery(context.Background(), schema.Version, "FOO", common.RunQuery{
+		Vars: map[string]any{
+			"aaa": "bbb",
+		},
+	}, storagecommon.PaginationConfig{
+		MaxPageSize:     paginate.MaxPageSize,
+		DefaultPageSize: paginate.QueryDefaultPageSize,
+	})
+	require.NoError(t, err)
+	require.Equal(t, queries.ResourceKindAccount, *resource)
+	require.Equal(t, &paginate.Cursor[any]{
+		Data: []any{},
+	}, ret)
+
+}
+
+func TestRunQueryWithAggregation(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	machineParser := NewMockNumscriptParser(ctrl)
+	interpreterParser := NewMockNumscriptParser(ctrl)
+	transactions := NewMockPaginatedResource[ledger.Transaction, any](ctrl)
+
+	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+
+	aggregation := queries.Aggregation{
+		GroupBy: []string{"metadata[merchant_id]", "asset"},
+		Measures: map[string]queries.Measure{
+			"total": {Function: queries.MeasureSum, Property: "amount"},
+		},
+	}
+	schema := ledger.Schema{
+		SchemaData: ledger.SchemaData{
+			Queries: ledger.QueryTemplates{
+				"SALES": {
+					Resource:    queries.ResourceKindTransaction,
+					Aggregation: &aggregation,
+				},
+			},
+		},
+		Version: "v1.0",
+	}
+	require.NoError(t, schema.Queries.Validate())
+
+	store.EXPECT().
+		FindSchema(gomock.Any(), "v1.0").
+		Return(&schema, nil)
+	store.EXPECT().Transactions().Return(transactions)
+
+	group := queries.AggregationGroup{
+		Keys: map[string]*string{
+			"metadata[merchant_id]": pointer.For("1"),
+			"asset":                 pointer.For("USD"),
+		},
+		Measures: map[string]*big.Int{
+			"total": big.NewInt(100),
+		},
+	}
+	transactions.EXPECT().Aggregate(gomock.Any(), common.AggregatedQuery[any]{
+		Aggregation: aggregation,
+		PageSize:    paginate.QueryDefaultPageSize,
+	}).Return(&paginate.Cursor[queries.AggregationGroup]{
+		PageSize: paginate.QueryDefaultPageSize,
+		Data:     []queries.AggregationGroup{group},
+	}, nil)
+
+	resource, ret, err := l.RunQuery(context.Background(), schema.Version, "SALES", common.RunQuery{}, storagecommon.PaginationConfig{
+		MaxPageSize:     paginate.MaxPageSize,
+		DefaultPageSize: paginate.QueryDefaultPageSize,
+	})
+	require.NoError(t, err)
+	require.Equal(t, queries.ResourceKindTransaction, *resource)
+	require.Equal(t, &paginate.Cursor[any]{
+		PageSize: paginate.QueryDefaultPageSize,
+		Data:     []any{group},
+	}, ret)
+}
+
+func TestRunQueryAggregatedBalances(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	machineParser := NewMockNumscriptParser(ctrl)
+	interpreterParser := NewMockNumscriptParser(ctrl)
+	aggregatedBalances := NewMockResource[ledger.AggregatedVolumes, ledger.GetAggregatedVolumesOptions](ctrl)
+
+	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+
+	schema := ledger.Schema{
+		SchemaData: ledger.SchemaData{
+			Queries: ledger.QueryTemplates{
+				"LIABILITIES": {
+					Resource: queries.ResourceKindAggregatedBalance,
+					Params:   json.RawMessage(`{"useInsertionDate": true}`),
+					Body: json.RawMessage(`{
+						"$match": {
+							"address": "users:"
+						}
+					}`),
+				},
+			},
+		},
+		Version: "v1.0",
+	}
+	require.NoError(t, schema.Queries.Validate())
+
+	store.EXPECT().
+		FindSchema(gomock.Any(), "v1.0").
+		Return(&schema, nil)
+	store.EXPECT().AggregatedBalances().Return(aggregatedBalances)
+
+	pit := time.Now()
+	expectedQuery, err := query.ParseJSON(`{"$match": {"address": "users:"}}`)
+	require.NoError(t, err)
+	aggregatedBalances.EXPECT().GetOne(gomock.Any(), common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+		PIT:     &pit,
+		Builder: expectedQuery,
+		Opts: ledger.GetAggregatedVolumesOptions{
+			UseInsertionDate: true,
+		},
+	}).Return(&ledger.AggregatedVolumes{
+		Aggregated: ledger.VolumesByAssets{
+			"USD": ledger.NewVolumesInt64(0, 100),
+		},
+	}, nil)
+
+	resource, ret, err := l.RunQuery(context.Background(), schema.Version, "LIABILITIES", common.RunQuery{
+		Params: json.RawMessage(`{"endTime": "` + pit.Format(time.RFC3339Nano) + `"}`),
+	}, storagecommon.PaginationConfig{
+		MaxPageSize:     paginate.MaxPageSize,
+		DefaultPageSize: paginate.QueryDefaultPageSize,
+	})
+	require.NoError(t, err)
+	require.Equal(t, queries.ResourceKindAggregatedBalance, *resource)
+	require.Equal(t, &paginate.Cursor[any]{
+		PageSize: 1,
+		Data: []any{ledger.BalancesByAssets{
+			"USD": big.NewInt(-100),
+		}},
+	}, ret)
+}
+
+func TestGetMigrationsInfo(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	machineParser := NewMockNumscriptParser(ctrl)
+	interpreterParser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+
+	migrationsInfo := make([]migrations.Info, 0)
+	store.EXPECT().
+		GetMigrationsInfo(gomock.Any()).
+		Return(migrationsInfo, nil)
+
+	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+	ret, err := l.GetMigrationsInfo(ctx)
+	require.NoError(t, err)
+	require.Equal(t, migrationsInfo, ret)
+}
+
+func TestIsDatabaseUpToDate(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	machineParser := NewMockNumscriptParser(ctrl)
+	interpreterParser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+
+	store.EXPECT().
+		IsUpToDate(gomock.Any()).
+		Return(true, nil)
+
+	l := NewDefaultController(ledger.Ledger{}, store, parser, machineParser, interpreterParser)
+	ret, err := l.IsDatabaseUpToDate(ctx)
+	require.NoError(t, err)
+	require.True(t, ret)
+}
+
+func TestCreateHold(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		source        string
+		amount        *big.Int
+		expiresAt     *time.Time
+		balance       *big.Int
+		schema        *ledger.Schema
+		expectedError error
+	}
+
+	overdraftSchema := func(rule ledger.ChartOverdraftRule) *ledger.Schema {
+		return &ledger.Schema{
+			SchemaData: ledger.SchemaData{
+				Chart: ledger.ChartOfAccounts{
+					"bank": {
+						Account: &ledger.ChartAccount{
+							Rules: ledger.ChartAccountRules{
+								Overdraft: map[string]ledger.ChartOverdraftRule{"USD": rule},
+							},
+						},
+					},
+					"merchant": {
+						Account: &ledger.ChartAccount{},
+					},
+				},
+			},
+			Version: "v1",
+		}
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:    "nominal",
+			source:  "bank",
+			amount:  big.NewInt(100),
+			balance: big.NewInt(100),
+		},
+		{
+			name:      "with expiration",
+			source:    "bank",
+			amount:    big.NewInt(100),
+			expiresAt: pointer.For(time.Now().Add(time.Hour)),
+			balance:   big.NewInt(100),
+		},
+		{
+			name:   "from world",
+			source: "world",
+			amount: big.NewInt(100),
+		},
+		{
+			name:          "insufficient funds",
+			source:        "bank",
+			amount:        big.NewInt(100),
+			balance:       big.NewInt(99),
+			expectedError: &ErrInsufficientFunds{},
+		},
+		{
+			name:    "overdraft allowed by the chart",
+			source:  "bank",
+			amount:  big.NewInt(100),
+			balance: big.NewInt(50),
+			schema:  overdraftSchema(ledger.ChartOverdraftRule{Limit: big.NewInt(50)}),
+		},
+		{
+			name:          "overdraft exceeding the limit of the chart",
+			source:        "bank",
+			amount:        big.NewInt(100),
+			balance:       big.NewInt(49),
+			schema:        overdraftSchema(ledger.ChartOverdraftRule{Limit: big.NewInt(50)}),
+			expectedError: &ErrInsufficientFunds{},
+		},
+		{
+			name:   "unbounded overdraft allowed by the chart",
+			source: "bank",
+			amount: big.NewInt(100),
+			schema: overdraftSchema(ledger.ChartOverdraftRule{Unbounded: true}),
+		},
+		{
+			name:          "negative amount",
+			source:        "bank",
+			amount:        big.NewInt(-1),
+			expectedError: ErrInvalidHold{},
+		},
+		{
+			name:          "already expired",
+			source:        "bank",
+			amount:        big.NewInt(100),
+			expiresAt:     pointer.For(time.Now().Add(-time.Hour)),
+			balance:       big.NewInt(100),
+			expectedError: ErrInvalidHold{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			store := NewMockStore(ctrl)
+			parser := NewMockNumscriptParser(ctrl)
+			ctx := logging.TestingContext()
+			l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser)
+
+			store.EXPECT().
+				BeginTX(gomock.Any(), nil).
+				Return(store, &bun.Tx{}, nil)
+
+			schemaVersion := ""
+			if tc.schema != nil {
+				schemaVersion = tc.schema.Version
+				store.EXPECT().
+					FindSchema(gomock.Any(), schemaVersion).
+					Return(tc.schema, nil)
+			} else {
+				store.EXPECT().
+					FindLatestSchemaVersion(gomock.Any()).
+					Return(nil, nil)
+			}
+
+			if tc.balance != nil {
+				store.EXPECT().
+					GetBalances(gomock.Any(), ledgerstore.BalanceQuery{
+						tc.source: {"USD"},
+					}).
+					Return(ledger.Balances{
+						tc.source: {"USD": tc.balance},
+					}, nil)
+			}
+
+			if tc.expectedError == nil || tc.expiresAt != nil {
+				store.EXPECT().
+					InsertHold(gomock.Any(), gomock.Cond(func(x any) bool {
+						hold := x.(*ledger.Hold)
+						return hold.Status == ledger.HoldStatusPending && hold.Amount.Cmp(tc.amount) == 0 && hold.InsertedAt.IsZero()
+					})).
+					DoAndReturn(func(_ context.Context, hold *ledger.Hold) error {
+						hold.InsertedAt = time.Now()
+						return nil
+					})
+			}
+
+			if tc.expectedError == nil {
+				store.EXPECT().
+					InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+						return x.(*ledger.Log).Type == ledger.CreatedHoldLogType
+					})).
+					DoAndReturn(func(ctx context.Context, v *ledger.Log) error {
+						v.ID = pointer.For(uint64(0))
+						return nil
+					})
+
+				store.EXPECT().
+					Commit(gomock.Any()).
+					Return(nil)
+			} else {
+				store.EXPECT().
+					Rollback(gomock.Any()).
+					Return(nil)
+			}
+
+			_, createdHold, _, err := l.CreateHold(ctx, Parameters[CreateHold]{
+				SchemaVersion: schemaVersion,
+				Input: CreateHold{
+					Source:      tc.source,
+					Destination: "merchant",
+					Asset:       "USD",
+					Amount:      tc.amount,
+					ExpiresAt:   tc.expiresAt,
+				},
+			})
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotEmpty(t, createdHold.Hold.ID)
+			require.Equal(t, tc.expiresAt, createdHold.Hold.ExpiresAt)
+		})
+	}
+}
+
+func TestCaptureHold(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	type testCase struct {
+		name           string
+		hold           ledger.Hold
+		ended          bool
+		amount         *big.Int
+		expectedAmount *big.Int
+		expectedError  error
+	}
+
+	pendingHold := ledger.Hold{
+		ID:          "hold",
+		Source:      "bank",
+		Destination: "merchant",
+		Asset:       "USD",
+		Amount:      big.NewInt(100),
+		Status:      ledger.HoldStatusCaptured,
+		EndedAt:     &now,
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:           "full amount",
+			hold:           pendingHold,
+			ended:          true,
+			expectedAmount: big.NewInt(100),
+		},
+		{
+			name:           "partial amount",
+			hold:           pendingHold,
+			ended:          true,
+			amount:         big.NewInt(40),
+			expectedAmount: big.NewInt(40),
+		},
+		{
+			name:          "exceeding amount",
+			hold:          pendingHold,
+			ended:         true,
+			amount:        big.NewInt(101),
+			expectedError: ErrInvalidHold{},
+		},
+		{
+			name: "expired",
+			hold: func() ledger.Hold {
+				hold := pendingHold
+				hold.ExpiresAt = pointer.For(now.Add(-time.Minute))
+				return hold
+			}(),
+			ended:         true,
+			expectedError: ErrHoldExpired{},
+		},
+		{
+			name: "already voided",
+			hold: func() ledger.Hold {
+				hold := pendingHold
+				hold.Status = ledger.HoldStatusVoided
+				return hold
+			}(),
+			expectedError: ErrHoldNotPending{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			store := NewMockStore(ctrl)
+			parser := NewMockNumscriptParser(ctrl)
+			ctx := logging.TestingContext()
+			l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser)
+
+			store.EXPECT().
+				BeginTX(gomock.Any(), nil).
+				Return(store, &bun.Tx{}, nil)
+
+			store.EXPECT().
+				FindLatestSchemaVersion(gomock.Any()).
+				Return(nil, nil)
+
+			store.EXPECT().
+				EndHold(gomock.Any(), tc.hold.ID, ledger.HoldStatusCaptured, time.Time{}).
+				Return(&tc.hold, tc.ended, nil)
+
+			if tc.expectedError == nil {
+				store.EXPECT().
+					CommitTransaction(gomock.Any(), gomock.Cond(func(x any) bool {
+						tx := x.(*ledger.Transaction)
+						return len(tx.Postings) == 1 &&
+							tx.Postings[0].Amount.Cmp(tc.expectedAmount) == 0 &&
+							tx.Metadata[ledger.CaptureMetadataSpecKey()] == tc.hold.ID
+					})).
+					Return(nil)
+
+				store.EXPECT().
+					UpsertAccounts(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				store.EXPECT().
+					InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+						return x.(*ledger.Log).Type == ledger.CapturedHoldLogType
+					})).
+					DoAndReturn(func(ctx context.Context, v *ledger.Log) error {
+						v.ID = pointer.For(uint64(0))
+						return nil
+					})
+
+				store.EXPECT().
+					Commit(gomock.Any()).
+					Return(nil)
+			} else {
+				store.EXPECT().
+					Rollback(gomock.Any()).
+					Return(nil)
+			}
+
+			_, capturedHold, _, err := l.CaptureHold(ctx, Parameters[CaptureHold]{
+				Input: CaptureHold{
+					ID:     tc.hold.ID,
+					Amount: tc.amount,
+				},
+			})
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, ledger.HoldStatusCaptured, capturedHold.Hold.Status)
+		})
+	}
+}
+
+func TestCaptureHoldAccountRules(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+	l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser, WithSchemaEnforcementMode(SchemaEnforcementStrict))
+
+	schema := ledger.Schema{
+		SchemaData: ledger.SchemaData{
+			Chart: ledger.ChartOfAccounts{
+				"bank": {
+					Account: &ledger.ChartAccount{
+						Rules: ledger.ChartAccountRules{
+							Overdraft: map[string]ledger.ChartOverdraftRule{"USD": {}},
+						},
+					},
+				},
+				"merchant": {
+					Account: &ledger.ChartAccount{},
+				},
+			},
+		},
+		Version: "v1",
+	}
+
+	store.EXPECT().
+		BeginTX(gomock.Any(), nil).
+		Return(store, &bun.Tx{}, nil)
+	store.EXPECT().
+		FindSchema(gomock.Any(), schema.Version).
+		Return(&schema, nil)
+	store.EXPECT().
+		EndHold(gomock.Any(), "hold", ledger.HoldStatusCaptured, time.Time{}).
+		Return(&ledger.Hold{
+			ID:          "hold",
+			Source:      "bank",
+			Destination: "merchant",
+			Asset:       "USD",
+			Amount:      big.NewInt(100),
+			Status:      ledger.HoldStatusCaptured,
+			EndedAt:     pointer.For(time.Now()),
+		}, true, nil)
+	// The source has been overdrafted by a forced transaction since the creation of the hold
+	store.EXPECT().
+		CommitTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, tx *ledger.Transaction) error {
+			tx.PostCommitVolumes = ledger.PostCommitVolumes{
+				"bank": {"USD": ledger.NewVolumesInt64(0, 100)},
+			}
+			return nil
+		})
+	store.EXPECT().
+		UpsertAccounts(gomock.Any(), gomock.Any()).
+		Return(nil)
+	store.EXPECT().
+		Rollback(gomock.Any()).
+		Return(nil)
+
+	_, _, _, err := l.CaptureHold(ctx, Parameters[CaptureHold]{
+		SchemaVersion: schema.Version,
+		Input: CaptureHold{
+			ID: "hold",
+		},
+	})
+	require.ErrorIs(t, err, ledger.ErrAccountRuleViolation{})
+}
+
+func TestVoidHold(t *testing.T) {
+	t.Parallel()
+
+	for _, ended := range []bool{true, false} {
+		t.Run(fmt.Sprintf("ended=%v", ended), func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			store := NewMockStore(ctrl)
+			parser := NewMockNumscriptParser(ctrl)
+			ctx := logging.TestingContext()
+			l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser)
+
+			store.EXPECT().
+				BeginTX(gomock.Any(), nil).
+				Return(store, &bun.Tx{}, nil)
+
+			store.EXPECT().
+				EndHold(gomock.Any(), "hold", ledger.HoldStatusVoided, time.Time{}).
+				Return(&ledger.Hold{
+					ID:     "hold",
+					Status: ledger.HoldStatusVoided,
+				}, ended, nil)
+
+			if ended {
+				store.EXPECT().
+					InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+						return x.(*ledger.Log).Type == ledger.VoidedHoldLogType
+					})).
+					DoAndReturn(func(ctx context.Context, v *ledger.Log) error {
+						v.ID = pointer.For(uint64(0))
+						return nil
+					})
+
+				store.EXPECT().
+					Commit(gomock.Any()).
+					Return(nil)
+			} else {
+				store.EXPECT().
+					Rollback(gomock.Any()).
+					Return(nil)
+			}
+
+			_, _, _, err := l.VoidHold(ctx, Parameters[VoidHold]{
+				Input: VoidHold{
+					ID: "hold",
+				},
+			})
+			if !ended {
+				require.ErrorIs(t, err, ErrHoldNotPending{})
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCreateRevertJob(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+	transactions := NewMockPaginatedResource[ledger.Transaction, any](ctrl)
+	l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser)
+
+	_, err := l.CreateRevertJob(ctx, CreateRevertJob{
+		Query: json.RawMessage(`{"$unknown": {}}`),
+	})
+	require.ErrorIs(t, err, common.ErrInvalidQuery{})
+
+	// The scope of the job is bounded by the last transaction of the ledger
+	store.EXPECT().Transactions().Return(transactions).Times(2)
+	transactions.EXPECT().
+		Paginate(gomock.Any(), gomock.Any()).
+		Return(&paginate.Cursor[ledger.Transaction]{
+			Data: []ledger.Transaction{{ID: pointer.For(uint64(10))}},
+		}, nil)
+	transactions.EXPECT().
+		Count(gomock.Any(), gomock.Any()).
+		Return(2, nil)
+
+	// The job is inserted with its log
+	store.EXPECT().
+		BeginTX(gomock.Any(), nil).
+		Return(store, &bun.Tx{}, nil)
+	store.EXPECT().
+		InsertRevertJob(gomock.Any(), gomock.Cond(func(x any) bool {
+			job := x.(*ledger.RevertJob)
+			return job.Status == ledger.RevertJobStatusPending && job.Total == 2 && job.Force &&
+				*job.MaxTransactionID == 10
+		})).
+		Return(nil)
+	store.EXPECT().
+		InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+			payload, ok := x.(*ledger.Log).Data.(ledger.CreatedRevertJob)
+			return ok && *payload.MaxTransactionID == 10
+		})).
+		Return(nil)
+	store.EXPECT().
+		Commit(gomock.Any()).
+		Return(nil)
+
+	job, err := l.CreateRevertJob(ctx, CreateRevertJob{
+		Query:    json.RawMessage(`{"$match": {"account": "users:001"}}`),
+		Force:    true,
+		Metadata: metadata.Metadata{"reason": "bug"},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, job.ID)
+	require.Equal(t, uint64(2), job.Total)
+}
+
+func TestRunRevertJob(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+	transactions := NewMockPaginatedResource[ledger.Transaction, any](ctrl)
+	l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser)
+
+	job := ledger.RevertJob{
+		ID:         "job",
+		Query:      json.RawMessage(`{"$match": {"account": "users:001"}}`),
+		Metadata:   metadata.Metadata{"reason": "bug"},
+		Status:     ledger.RevertJobStatusPending,
+		Total:      2,
+		InsertedAt: time.Now(),
+
+		MaxTransactionID: pointer.For(uint64(2)),
+	}
+	// The job is locked, then each transaction is reverted in a nested sql transaction
+	store.EXPECT().
+		BeginTX(gomock.Any(), nil).
+		Return(store, &bun.Tx{}, nil)
+	store.EXPECT().
+		LockRevertJob(gomock.Any(), job.ID).
+		Return(&job, nil)
+
+	store.EXPECT().Transactions().Return(transactions)
+	transactions.EXPECT().
+		Paginate(gomock.Any(), gomock.Any()).
+		Return(&paginate.Cursor[ledger.Transaction]{
+			Data: []ledger.Transaction{
+				{ID: pointer.For(uint64(1))},
+				{ID: pointer.For(uint64(2))},
+			},
+		}, nil)
+
+	// The first transaction is reverted
+	store.EXPECT().
+		BeginTX(gomock.Any(), nil).
+		Return(store, &bun.Tx{}, nil).
+		Times(2)
+	store.EXPECT().
+		FindLatestSchemaVersion(gomock.Any()).
+		Return(nil, nil).
+		Times(2)
+	store.EXPECT().
+		RevertTransaction(gomock.Any(), uint64(1), time.Time{}).
+		Return(&ledger.Transaction{
+			ID:         pointer.For(uint64(1)),
+			RevertedAt: pointer.For(time.Now()),
+		}, true, nil)
+	store.EXPECT().
+		GetBalances(gomock.Any(), gomock.Any()).
+		Return(map[string]map[string]*big.Int{}, nil)
+	store.EXPECT().
+		CommitTransaction(gomock.Any(), gomock.Cond(func(x any) bool {
+			return x.(*ledger.Transaction).Metadata[ledger.RevertJobMetadataSpecKey()] == job.ID
+		})).
+		Return(nil)
+	store.EXPECT().
+		InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+			return x.(*ledger.Log).Type == ledger.RevertedTransactionLogType
+		})).
+		DoAndReturn(func(ctx context.Context, v *ledger.Log) error {
+			v.ID = pointer.For(uint64(0))
+			return nil
+		})
+	store.EXPECT().
+		Commit(gomock.Any()).
+		Return(nil).
+		Times(2)
+
+	// The second one has been reverted since the job has listed it
+	store.EXPECT().
+		RevertTransaction(gomock.Any(), uint64(2), time.Time{}).
+		Return(&ledger.Transaction{
+			ID: pointer.For(uint64(2)),
+		}, false, nil)
+	store.EXPECT().
+		Rollback(gomock.Any()).
+		Return(nil)
+
+	// The progress of the job is logged after the reverts of the batch
+	store.EXPECT().
+		UpdateRevertJob(gomock.Any(), gomock.Any()).
+		Return(nil)
+	store.EXPECT().
+		InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+			return x.(*ledger.Log).Type == ledger.UpdatedRevertJobLogType
+		})).
+		Return(nil)
+
+	ret, revertedTransactions, err := l.RunRevertJob(ctx, job.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, revertedTransactions, 1)
+	require.Equal(t, uint64(1), *revertedTransactions[0].RevertedTransaction.ID)
+	require.Equal(t, ledger.RevertJobStatusSucceeded, ret.Status)
+	require.Equal(t, uint64(1), ret.Reverted)
+	require.Equal(t, uint64(1), ret.Failed)
+	require.NotEmpty(t, ret.LastError)
+	require.Equal(t, uint64(2), *ret.LastTransactionID)
+	require.NotNil(t, ret.EndedAt)
+}
+
+func TestImportWorkerLogs(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+	logs := NewMockPaginatedResource[ledger.Log, any](ctrl)
+	l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser)
+
+	job := ledger.RevertJob{
+		ID:               "job",
+		Status:           ledger.RevertJobStatusPending,
+		MaxTransactionID: pointer.For(uint64(10)),
+	}
+	scheduledTransaction := ledger.ScheduledTransaction{
+		ID:     "scheduled",
+		Status: ledger.ScheduledTransactionStatusCanceled,
+	}
+	recurrence := ledger.Recurrence{
+		ID:             "recurrence",
+		Status:         ledger.RecurrenceStatusActive,
+		AccountsOffset: 20,
+	}
+	payloads := []ledger.LogPayload{
+		ledger.NewCreatedRevertJob(job),
+		ledger.UpdatedScheduledTransaction{ScheduledTransaction: scheduledTransaction},
+		ledger.NewUpdatedRecurrence(recurrence),
+		ledger.DeletedRecurrence{ID: recurrence.ID},
+	}
+
+	// The logs are read as exported, the fields hidden on the entities must be replayed
+	stream := make(chan ledger.Log, len(payloads))
+	for i, payload := range payloads {
+		data, err := json.Marshal(payload)
+		require.NoError(t, err)
+		hydrated, err := ledger.HydrateLog(payload.Type(), data)
+		require.NoError(t, err)
+
+		log := ledger.NewLog(hydrated)
+		log.ID = pointer.For(uint64(i))
+		stream <- log
+	}
+	close(stream)
+
+	store.EXPECT().Logs().Return(logs)
+	logs.EXPECT().
+		Paginate(gomock.Any(), gomock.Any()).
+		Return(&paginate.Cursor[ledger.Log]{}, nil)
+	store.EXPECT().
+		BeginTX(gomock.Any(), nil).
+		Return(store, &bun.Tx{}, nil).
+		Times(len(payloads))
+	store.EXPECT().
+		InsertRevertJob(gomock.Any(), gomock.Cond(func(x any) bool {
+			return *x.(*ledger.RevertJob).MaxTransactionID == 10
+		})).
+		Return(nil)
+	store.EXPECT().
+		UpdateScheduledTransaction(gomock.Any(), gomock.Cond(func(x any) bool {
+			return x.(*ledger.ScheduledTransaction).Status == ledger.ScheduledTransactionStatusCanceled
+		})).
+		Return(nil)
+	store.EXPECT().
+		UpdateRecurrence(gomock.Any(), gomock.Cond(func(x any) bool {
+			return x.(*ledger.Recurrence).AccountsOffset == 20
+		})).
+		Return(nil)
+	store.EXPECT().
+		DeleteRecurrence(gomock.Any(), recurrence.ID).
+		Return(nil)
+	store.EXPECT().
+		InsertLog(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(len(payloads))
+	store.EXPECT().
+		Commit(gomock.Any()).
+		Return(nil).
+		Times(len(payloads))
+	store.EXPECT().
+		Rollback(gomock.Any()).
+		Return(nil).
+		AnyTimes()
+
+	require.NoError(t, l.Import(ctx, stream))
+}
+
+func TestScheduleTransaction(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+	l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser)
+
+	data := ledger.ScheduledTransactionData{
+		Postings: ledger.Postings{ledger.NewPosting("world", "bank", "USD", big.NewInt(100))},
+	}
+
+	_, err := l.ScheduleTransaction(ctx, ScheduleTransaction{
+		ScheduledAt: time.Now().Add(-time.Minute),
+		Transaction: data,
+	})
+	require.ErrorIs(t, err, ErrInvalidScheduledTransaction{})
+
+	_, err = l.ScheduleTransaction(ctx, ScheduleTransaction{
+		ScheduledAt: time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, ErrInvalidScheduledTransaction{})
+
+	store.EXPECT().
+		BeginTX(gomock.Any(), nil).
+		Return(store, &bun.Tx{}, nil)
+	store.EXPECT().
+		InsertScheduledTransaction(gomock.Any(), gomock.Cond(func(x any) bool {
+			return x.(*ledger.ScheduledTransaction).Status == ledger.ScheduledTransactionStatusPending
+		})).
+		Return(nil)
+	store.EXPECT().
+		InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+			return x.(*ledger.Log).Type == ledger.CreatedScheduledTransactionLogType
+		})).
+		Return(nil)
+	store.EXPECT().
+		Commit(gomock.Any()).
+		Return(nil)
+
+	scheduledTransaction, err := l.ScheduleTransaction(ctx, ScheduleTransaction{
+		ScheduledAt: time.Now().Add(time.Hour),
+		Transaction: data,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, scheduledTransaction.ID)
+	require.NotNil(t, scheduledTransaction.Transaction.Metadata)
+}
+
+func TestCancelScheduledTransaction(t *testing.T) {
+	t.Parallel()
+
+	for _, status := range []ledger.ScheduledTransactionStatus{
+		ledger.ScheduledTransactionStatusPending,
+		ledger.ScheduledTransactionStatusExecuted,
+	} {
+		t.Run(string(status), func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			store := NewMockStore(ctrl)
+			parser := NewMockNumscriptParser(ctrl)
+			ctx := logging.TestingContext()
+			l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser)
+
+			store.EXPECT().
+				BeginTX(gomock.Any(), nil).
+				Return(store, &bun.Tx{}, nil)
+
+			store.EXPECT().
+				LockScheduledTransaction(gomock.Any(), "scheduled").
+				Return(&ledger.ScheduledTransaction{
+					ID:     "scheduled",
+					Status: status,
+				}, nil)
+
+			if status == ledger.ScheduledTransactionStatusPending {
+				store.EXPECT().
+					UpdateScheduledTransaction(gomock.Any(), gomock.Cond(func(x any) bool {
+						return x.(*ledger.ScheduledTransaction).Status == ledger.ScheduledTransactionStatusCanceled
+					})).
+					Return(nil)
+				store.EXPECT().
+					InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+						return x.(*ledger.Log).Type == ledger.UpdatedScheduledTransactionLogType
+					})).
+					Return(nil)
+				store.EXPECT().
+					Commit(gomock.Any()).
+					Return(nil)
+			} else {
+				store.EXPECT().
+					Rollback(gomock.Any()).
+					Return(nil)
+			}
+
+			scheduledTransaction, err := l.CancelScheduledTransaction(ctx, "scheduled")
+			if status != ledger.ScheduledTransactionStatusPending {
+				require.ErrorIs(t, err, ErrScheduledTransactionNotPending{})
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, ledger.ScheduledTransactionStatusCanceled, scheduledTransaction.Status)
+			require.NotNil(t, scheduledTransaction.EndedAt)
+		})
+	}
+}
+
+func TestExecuteScheduledTransaction(t *testing.T) {
+	t.Parallel()
+
+	for _, insufficientFunds := range []bool{false, true} {
+		t.Run(fmt.Sprintf("insufficient funds=%v", insufficientFunds), func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			store := NewMockStore(ctrl)
+			parser := NewMockNumscriptParser(ctrl)
+			numscriptRuntime := NewMockNumscriptRuntime(ctrl)
+			ctx := logging.TestingContext()
+			l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser)
+
+			scheduledTransaction := ledger.ScheduledTransaction{
+				ID:          "scheduled",
+				ScheduledAt: time.Now().Add(-time.Minute),
+				Transaction: ledger.ScheduledTransactionData{
+					Script:   "send [USD 100] (source = @bank destination = @merchant)",
+					Metadata: metadata.Metadata{},
+				},
+				Status: ledger.ScheduledTransactionStatusPending,
+			}
+
+			// The scheduled transaction is locked, then the transaction is created in a nested sql transaction
+			store.EXPECT().
+				BeginTX(gomock.Any(), nil).
+				Return(store, &bun.Tx{}, nil).
+				Times(2)
+			store.EXPECT().
+				LockScheduledTransaction(gomock.Any(), scheduledTransaction.ID).
+				Return(&scheduledTransaction, nil)
+			store.EXPECT().
+				ReadLogWithIdempotencyKey(gomock.Any(), scheduledTransaction.IdempotencyKey()).
+				Return(nil, postgres.ErrNotFound)
+			store.EXPECT().
+				FindLatestSchemaVersion(gomock.Any()).
+				Return(nil, nil)
+			store.EXPECT().
+				FindClosedPeriod(gomock.Any()).
+				Return(nil, nil)
+			parser.EXPECT().
+				Parse(scheduledTransaction.Transaction.Script).
+				Return(numscriptRuntime, nil)
+
+			if insufficientFunds {
+				numscriptRuntime.EXPECT().
+					Execute(gomock.Any(), store, gomock.Any(), gomock.Any()).
+					Return(nil, &ErrInsufficientFunds{})
+				store.EXPECT().
+					Rollback(gomock.Any()).
+					Return(nil)
+				store.EXPECT().
+					Commit(gomock.Any()).
+					Return(nil)
+			} else {
+				numscriptRuntime.EXPECT().
+					Execute(gomock.Any(), store, gomock.Any(), gomock.Any()).
+					Return(&NumscriptExecutionResult{
+						Postings: ledger.Postings{ledger.NewPosting("bank", "merchant", "USD", big.NewInt(100))},
+					}, nil)
+				store.EXPECT().
+					CommitTransaction(gomock.Any(), gomock.Cond(func(x any) bool {
+						return x.(*ledger.Transaction).Timestamp.Equal(scheduledTransaction.ScheduledAt)
+					})).
+					DoAndReturn(func(_ context.Context, tx *ledger.Transaction) error {
+						tx.ID = pointer.For(uint64(1))
+						return nil
+					})
+				store.EXPECT().UpsertAccounts(gomock.Any(), gomock.Any())
+				store.EXPECT().
+					InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+						return x.(*ledger.Log).IdempotencyKey == scheduledTransaction.IdempotencyKey()
+					})).
+					DoAndReturn(func(_ context.Context, log *ledger.Log) error {
+						log.ID = pointer.For(uint64(0))
+						return nil
+					})
+				store.EXPECT().
+					Commit(gomock.Any()).
+					Return(nil).
+					Times(2)
+			}
+
+			store.EXPECT().
+				UpdateScheduledTransaction(gomock.Any(), gomock.Any()).
+				Return(nil)
+			store.EXPECT().
+				InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+					return x.(*ledger.Log).Type == ledger.UpdatedScheduledTransactionLogType
+				})).
+				Return(nil)
+
+			ret, createdTransaction, err := l.ExecuteScheduledTransaction(ctx, scheduledTransaction.ID)
+			require.NoError(t, err)
+			require.NotNil(t, ret.EndedAt)
+			if insufficientFunds {
+				require.Equal(t, ledger.ScheduledTransactionStatusFailed, ret.Status)
+				require.NotEmpty(t, ret.Error)
+				require.Nil(t, createdTransaction)
+				return
+			}
+			require.Equal(t, ledger.ScheduledTransactionStatusExecuted, ret.Status)
+			require.Equal(t, uint64(1), *ret.TransactionID)
+			require.Equal(t, ret.TransactionID, createdTransaction.Transaction.ID)
+		})
+	}
+}
+
+func recurrenceTestSchema() ledger.Schema {
+	return ledger.Schema{
+		SchemaData: ledger.SchemaData{
+			Chart: ledger.ChartOfAccounts{
+				"world": {
+					Account: &ledger.ChartAccount{},
+				},
+				"users": {
+					VariableSegment: &ledger.ChartVariableSegment{
+						Label: "userID",
+						ChartSegment: ledger.ChartSegment{
+							Account: &ledger.ChartAccount{},
+						},
+					},
+				},
+			},
+			Transactions: ledger.TransactionTemplates{
+				"FEES": {
+					Script: "script",
+					Vars: map[string]ledger.TransactionTemplateVar{
+						"user": {
+							Type:  "account",
+							Chart: "users:$userID",
+						},
+						"amount": {
+							Type: "monetary",
+						},
+					},
+				},
+			},
+		},
+		Version: "v1.0",
+	}
+}
+
+func TestCreateRecurrence(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		input         CreateRecurrence
+		expectedError string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "for each account",
+			input: CreateRecurrence{
+				Schedule:   "@monthly",
+				Template:   "FEES",
+				Vars:       map[string]string{"amount": "USD 100"},
+				Accounts:   "users:$userID",
+				AccountVar: "user",
+			},
+		},
+		{
+			name: "single transaction",
+			input: CreateRecurrence{
+				Schedule: "FREQ=MONTHLY",
+				Template: "FEES",
+				Vars:     map[string]string{"amount": "USD 100", "user": "users:1"},
+			},
+		},
+		{
+			name: "invalid schedule",
+			input: CreateRecurrence{
+				Schedule: "monthly",
+				Template: "FEES",
+			},
+			expectedError: "invalid schedule",
+		},
+		{
+			name: "unknown template",
+			input: CreateRecurrence{
+				Schedule: "@monthly",
+				Template: "UNKNOWN",
+			},
+			expectedError: "template `UNKNOWN` not found in schema version `v1.0`",
+		},
+		{
+			name: "missing variable",
+			input: CreateRecurrence{
+				Schedule: "@monthly",
+				Template: "FEES",
+				Vars:     map[string]string{"amount": "USD 100"},
+			},
+			expectedError: "variable is required",
+		},
+		{
+			name: "account variable of another type",
+			input: CreateRecurrence{
+				Schedule:   "@monthly",
+				Template:   "FEES",
+				Accounts:   "users:$userID",
+				AccountVar: "amount",
+			},
+			expectedError: "variable `amount` is not an account variable of template `FEES`",
+		},
+		{
+			name: "accounts not in the chart",
+			input: CreateRecurrence{
+				Schedule:   "@monthly",
+				Template:   "FEES",
+				Accounts:   "merchants:$merchantID",
+				AccountVar: "user",
+			},
+			expectedError: "invalid accounts",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			store := NewMockStore(ctrl)
+			parser := NewMockNumscriptParser(ctrl)
+			ctx := logging.TestingContext()
+			l := NewDefaultController(ledger.Ledger{
+				Configuration: ledger.Configuration{
+					SchemaSettings: ledger.SchemaSettings{
+						DefaultSchemaVersion: "v1.0",
+					},
+				},
+			}, store, parser, parser, parser)
+
+			schema := recurrenceTestSchema()
+			store.EXPECT().
+				FindSchema(gomock.Any(), "v1.0").
+				Return(&schema, nil).
+				MaxTimes(1)
+			if tc.expectedError == "" {
+				store.EXPECT().
+					BeginTX(gomock.Any(), nil).
+					Return(store, &bun.Tx{}, nil)
+				store.EXPECT().
+					InsertRecurrence(gomock.Any(), gomock.Any()).
+					Return(nil)
+				store.EXPECT().
+					InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+						return x.(*ledger.Log).Type == ledger.CreatedRecurrenceLogType
+					})).
+					Return(nil)
+				store.EXPECT().
+					Commit(gomock.Any()).
+					Return(nil)
+			}
+
+			recurrence, err := l.CreateRecurrence(ctx, tc.input)
+			if tc.expectedError != "" {
+				require.ErrorIs(t, err, ErrInvalidRecurrence{})
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, ledger.RecurrenceStatusActive, recurrence.Status)
+			require.Equal(t, "v1.0", recurrence.SchemaVersion)
+			require.NotNil(t, recurrence.NextOccurrence)
+			require.True(t, recurrence.NextOccurrence.After(recurrence.InsertedAt))
+		})
+	}
+}
+
+func TestPauseAndResumeRecurrence(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	ctx := logging.TestingContext()
+	l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser)
+
+	missed := time.Now().Add(-time.Hour)
+	recurrence := ledger.Recurrence{
+		ID:             "recurrence",
+		Schedule:       "FREQ=MINUTELY",
+		StartAt:        missed,
+		Status:         ledger.RecurrenceStatusActive,
+		NextOccurrence: &missed,
+	}
+
+	store.EXPECT().
+		BeginTX(gomock.Any(), nil).
+		Return(store, &bun.Tx{}, nil).
+		Times(2)
+	store.EXPECT().
+		LockRecurrence(gomock.Any(), recurrence.ID).
+		Return(&recurrence, nil).
+		Times(2)
+	store.EXPECT().
+		UpdateRecurrence(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(2)
+	store.EXPECT().
+		InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+			return x.(*ledger.Log).Type == ledger.UpdatedRecurrenceLogType
+		})).
+		Return(nil).
+		Times(2)
+	store.EXPECT().
+		Commit(gomock.Any()).
+		Return(nil).
+		Times(2)
+
+	paused, err := l.PauseRecurrence(ctx, recurrence.ID)
+	require.NoError(t, err)
+	require.Equal(t, ledger.RecurrenceStatusPaused, paused.Status)
+
+	resumed, err := l.ResumeRecurrence(ctx, recurrence.ID)
+	require.NoError(t, err)
+	require.Equal(t, ledger.RecurrenceStatusActive, resumed.Status)
+	// The occurrences missed while paused are skipped
+	require.True(t, resumed.NextOccurrence.After(time.Now().Add(-time.Second)))
+
+	completed := ledger.Recurrence{
+		ID:     "completed",
+		Status: ledger.RecurrenceStatusCompleted,
+	}
+	store.EXPECT().
+		BeginTX(gomock.Any(), nil).
+		Return(store, &bun.Tx{}, nil)
+	store.EXPECT().
+		LockRecurrence(gomock.Any(), completed.ID).
+		Return(&completed, nil)
+	store.EXPECT().
+		Rollback(gomock.Any()).
+		Return(nil)
+
+	_, err = l.PauseRecurrence(ctx, completed.ID)
+	require.ErrorIs(t, err, ErrRecurrenceCompleted{})
+}
+
+func TestRunRecurrence(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	numscriptRuntime := NewMockNumscriptRuntime(ctrl)
+	accounts := NewMockPaginatedResource[ledger.Account, any](ctrl)
+	ctx := logging.TestingContext()
+	l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser)
+
+	schema := recurrenceTestSchema()
+	occurrence := time.Now().Add(-time.Minute)
+	recurrence := ledger.Recurrence{
+		ID:             "recurrence",
+		Schedule:       "FREQ=DAILY",
+		StartAt:        occurrence,
+		Template:       "FEES",
+		Vars:           map[string]string{"amount": "USD 100"},
+		Metadata:       metadata.Metadata{},
+		SchemaVersion:  schema.Version,
+		Accounts:       "users:$userID",
+		AccountVar:     "user",
+		Status:         ledger.RecurrenceStatusActive,
+		NextOccurrence: &occurrence,
+	}
+
+	// The recurrence is locked, then each transaction is created in a nested sql transaction
+	store.EXPECT().
+		BeginTX(gomock.Any(), nil).
+		Return(store, &bun.Tx{}, nil).
+		Times(3)
+	store.EXPECT().
+		LockRecurrence(gomock.Any(), recurrence.ID).
+		Return(&recurrence, nil)
+	store.EXPECT().
+		FindSchema(gomock.Any(), schema.Version).
+		Return(&schema, nil).
+		Times(3)
+	store.EXPECT().Accounts().Return(accounts)
+	accounts.EXPECT().
+		Paginate(gomock.Any(), gomock.Any()).
+		Return(&paginate.Cursor[ledger.Account]{
+			Data: []ledger.Account{
+				{Address: "users:1"},
+				{Address: "users:2"},
+			},
+		}, nil)
+
+	for _, account := range []string{"users:1", "users:2"} {
+		store.EXPECT().
+			ReadLogWithIdempotencyKey(gomock.Any(), recurrence.IdempotencyKey(occurrence, account)).
+			Return(nil, postgres.ErrNotFound)
+	}
+	store.EXPECT().
+		FindClosedPeriod(gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+	parser.EXPECT().
+		Parse("script").
+		Return(numscriptRuntime, nil).
+		Times(2)
+
+	// The first account is charged, the second one has insufficient funds
+	numscriptRuntime.EXPECT().
+		Execute(gomock.Any(), store, map[string]string{"amount": "USD 100", "user": "users:1"}, gomock.Any()).
+		Return(&NumscriptExecutionResult{
+			Postings: ledger.Postings{ledger.NewPosting("users:1", "world", "USD", big.NewInt(100))},
+		}, nil)
+	numscriptRuntime.EXPECT().
+		Execute(gomock.Any(), store, map[string]string{"amount": "USD 100", "user": "users:2"}, gomock.Any()).
+		Return(nil, &ErrInsufficientFunds{})
+	store.EXPECT().
+		CommitTransaction(gomock.Any(), gomock.Cond(func(x any) bool {
+			return x.(*ledger.Transaction).Timestamp.Equal(occurrence)
+		})).
+		DoAndReturn(func(_ context.Context, tx *ledger.Transaction) error {
+			tx.ID = pointer.For(uint64(1))
+			return nil
+		})
+	store.EXPECT().UpsertAccounts(gomock.Any(), gomock.Any())
+	store.EXPECT().
+		InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+			return x.(*ledger.Log).IdempotencyKey == recurrence.IdempotencyKey(occurrence, "users:1")
+		})).
+		DoAndReturn(func(_ context.Context, log *ledger.Log) error {
+			log.ID = pointer.For(uint64(0))
+			return nil
+		})
+	store.EXPECT().
+		Rollback(gomock.Any()).
+		Return(nil)
+	store.EXPECT().
+		Commit(gomock.Any()).
+		Return(nil).
+		Times(2)
+	store.EXPECT().
+		UpdateRecurrence(gomock.Any(), gomock.Any()).
+		Return(nil)
+	store.EXPECT().
+		InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+			return x.(*ledger.Log).Type == ledger.UpdatedRecurrenceLogType
+		})).
+		Return(nil)
+
+	ret, createdTransactions, err := l.RunRecurrence(ctx, recurrence.ID, 10)
+	require.NoError(t, err)
+	require.Equal(t, occurrence, *ret.LastOccurrence)
+	require.Equal(t, occurrence.Add(24*time.Hour), *ret.NextOccurrence)
+	require.Equal(t, uint64(1), ret.Failed)
+	require.NotEmpty(t, ret.LastError)
+	require.Equal(t, ledger.RecurrenceStatusActive, ret.Status)
+	require.Zero(t, ret.AccountsOffset)
+	require.Len(t, createdTransactions, 1)
+	require.Equal(t, uint64(1), *createdTransactions[0].Transaction.ID)
+}
+
+func TestRunRecurrenceByBatches(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStore(ctrl)
+	parser := NewMockNumscriptParser(ctrl)
+	numscriptRuntime := NewMockNumscriptRuntime(ctrl)
+	accounts := NewMockPaginatedResource[ledger.Account, any](ctrl)
+	ctx := logging.TestingContext()
+	l := NewDefaultController(ledger.Ledger{}, store, parser, parser, parser)
+
+	schema := recurrenceTestSchema()
+	occurrence := time.Now().Add(-time.Minute)
+	recurrence := ledger.Recurrence{
+		ID:             "recurrence",
+		Schedule:       "FREQ=DAILY",
+		StartAt:        occurrence,
+		Template:       "FEES",
+		Vars:           map[string]string{"amount": "USD 100"},
+		Metadata:       metadata.Metadata{},
+		SchemaVersion:  schema.Version,
+		Accounts:       "users:$userID",
+		AccountVar:     "user",
+		Status:         ledger.RecurrenceStatusActive,
+		NextOccurrence: &occurrence,
+		AccountsOffset: 1,
+	}
+
+	store.EXPECT().
+		BeginTX(gomock.Any(), nil).
+		Return(store, &bun.Tx{}, nil).
+		Times(2)
+	store.EXPECT().
+		LockRecurrence(gomock.Any(), recurrence.ID).
+		Return(&recurrence, nil)
+	store.EXPECT().
+		FindSchema(gomock.Any(), schema.Version).
+		Return(&schema, nil).
+		Times(2)
+	store.EXPECT().Accounts().Return(accounts)
+	// The batch starts after the accounts already processed for the occurrence
+	accounts.EXPECT().
+		Paginate(gomock.Any(), gomock.Cond(func(x any) bool {
+			return x.(storagecommon.OffsetPaginatedQuery[any]).Offset == 1
+		})).
+		Return(&paginate.Cursor[ledger.Account]{
+			Data: []ledger.Account{
+				{Address: "users:2"},
+			},
+			HasMore: true,
+		}, nil)
+	store.EXPECT().
+		ReadLogWithIdempotencyKey(gomock.Any(), recurrence.IdempotencyKey(occurrence, "users:2")).
+		Return(nil, postgres.ErrNotFound)
+	store.EXPECT().
+		FindClosedPeriod(gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+	parser.EXPECT().
+		Parse("script").
+		Return(numscriptRuntime, nil)
+	numscriptRuntime.EXPECT().
+		Execute(gomock.Any(), store, map[string]string{"amount": "USD 100", "user": "users:2"}, gomock.Any()).
+		Return(nil, &ErrInsufficientFunds{})
+	store.EXPECT().
+		Rollback(gomock.Any()).
+		Return(nil)
+	store.EXPECT().
+		Commit(gomock.Any()).
+		Return(nil)
+	store.EXPECT().
+		UpdateRecurrence(gomock.Any(), gomock.Any()).
+		Return(nil)
+	// The progress of the occurrence is logged to be replayed
+	store.EXPECT().
+		InsertLog(gomock.Any(), gomock.Cond(func(x any) bool {
+			payload, ok := x.(*ledger.Log).Data.(ledger.UpdatedRecurrence)
+			return ok && payload.AccountsOffset == 2
+		})).
+		Return(nil)
+
+	ret, createdTransactions, err := l.RunRecurrence(ctx, recurrence.ID, 1)
+	require.NoError(t, err)
+	require.Empty(t, createdTransactions)
+	require.Equal(t, uint64(1), ret.Failed)
+	// The occurrence is not completed until all the accounts have been processed
+	require.Equal(t, uint64(2), ret.AccountsOffset)
+	require.Nil(t, ret.LastOccurrence)
+	require.Equal(t, occurrence, *ret.NextOccurrence)
 }
@@ -85,6 +85,127 @@ func (c *MockControllerBeginTXCall) DoAndReturn(f func(context.Context, *sql.TxO
 	return c
 }
 
+// CancelScheduledTransaction mocks base method.
+func (m *MockController) CancelScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelScheduledTransaction", ctx, id)
+	ret0, _ := ret[0].(*ledger.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelScheduledTransaction indicates an expected call of CancelScheduledTransaction.
+func (mr *MockControllerMockRecorder) CancelScheduledTransaction(ctx, id any) *MockControllerCancelScheduledTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelScheduledTransaction", reflect.TypeOf((*MockController)(nil).CancelScheduledTransaction), ctx, id)
+	return &MockControllerCancelScheduledTransactionCall{Call: call}
+}
+
+// MockControllerCancelScheduledTransactionCall wrap *gomock.Call
+type MockControllerCancelScheduledTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerCancelScheduledTransactionCall) Return(arg0 *ledger.ScheduledTransaction, arg1 error) *MockControllerCancelScheduledTransactionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerCancelScheduledTransactionCall) Do(f func(context.Context, string) (*ledger.ScheduledTransaction, error)) *MockControllerCancelScheduledTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerCancelScheduledTransactionCall) DoAndReturn(f func(context.Context, string) (*ledger.ScheduledTransaction, error)) *MockControllerCancelScheduledTransactionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CaptureHold mocks base method.
+func (m *MockController) CaptureHold(ctx context.Context, parameters Parameters[CaptureHold]) (*ledger.Log, *ledger.CapturedHold, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureHold", ctx, parameters)
+	ret0, _ := ret[0].(*ledger.Log)
+	ret1, _ := ret[1].(*ledger.CapturedHold)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// CaptureHold indicates an expected call of CaptureHold.
+func (mr *MockControllerMockRecorder) CaptureHold(ctx, parameters any) *MockControllerCaptureHoldCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureHold", reflect.TypeOf((*MockController)(nil).CaptureHold), ctx, parameters)
+	return &MockControllerCaptureHoldCall{Call: call}
+}
+
+// MockControllerCaptureHoldCall wrap *gomock.Call
+type MockControllerCaptureHoldCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerCaptureHoldCall) Return(arg0 *ledger.Log, arg1 *ledger.CapturedHold, arg2 bool, arg3 error) *MockControllerCaptureHoldCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2, arg3)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerCaptureHoldCall) Do(f func(context.Context, Parameters[CaptureHold]) (*ledger.Log, *ledger.CapturedHold, bool, error)) *MockControllerCaptureHoldCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerCaptureHoldCall) DoAndReturn(f func(context.Context, Parameters[CaptureHold]) (*ledger.Log, *ledger.CapturedHold, bool, error)) *MockControllerCaptureHoldCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ClosePeriod mocks base method.
+func (m *MockController) ClosePeriod(ctx context.Context, parameters Parameters[ClosePeriod]) (*ledger.Log, *ledger.ClosedPeriod, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePeriod", ctx, parameters)
+	ret0, _ := ret[0].(*ledger.Log)
+	ret1, _ := ret[1].(*ledger.ClosedPeriod)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// ClosePeriod indicates an expected call of ClosePeriod.
+func (mr *MockControllerMockRecorder) ClosePeriod(ctx, parameters any) *MockControllerClosePeriodCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePeriod", reflect.TypeOf((*MockController)(nil).ClosePeriod), ctx, parameters)
+	return &MockControllerClosePeriodCall{Call: call}
+}
+
+// MockControllerClosePeriodCall wrap *gomock.Call
+type MockControllerClosePeriodCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerClosePeriodCall) Return(arg0 *ledger.Log, arg1 *ledger.ClosedPeriod, arg2 bool, arg3 error) *MockControllerClosePeriodCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2, arg3)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerClosePeriodCall) Do(f func(context.Context, Parameters[ClosePeriod]) (*ledger.Log, *ledger.ClosedPeriod, bool, error)) *MockControllerClosePeriodCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerClosePeriodCall) DoAndReturn(f func(context.Context, Parameters[ClosePeriod]) (*ledger.Log, *ledger.ClosedPeriod, bool, error)) *MockControllerClosePeriodCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Commit mocks base method.
 func (m *MockController) Commit(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -201,6 +322,125 @@ func (c *MockControllerCountTransactionsCall) DoAndReturn(f func(context.Context
 	return c
 }
 
+// CreateHold mocks base method.
+func (m *MockController) CreateHold(ctx context.Context, parameters Parameters[CreateHold]) (*ledger.Log, *ledger.CreatedHold, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, parameters)
+	ret0, _ := ret[0].(*ledger.Log)
+	ret1, _ := ret[1].(*ledger.CreatedHold)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockControllerMockRecorder) CreateHold(ctx, parameters any) *MockControllerCreateHoldCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockController)(nil).CreateHold), ctx, parameters)
+	return &MockControllerCreateHoldCall{Call: call}
+}
+
+// MockControllerCreateHoldCall wrap *gomock.Call
+type MockControllerCreateHoldCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerCreateHoldCall) Return(arg0 *ledger.Log, arg1 *ledger.CreatedHold, arg2 bool, arg3 error) *MockControllerCreateHoldCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2, arg3)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerCreateHoldCall) Do(f func(context.Context, Parameters[CreateHold]) (*ledger.Log, *ledger.CreatedHold, bool, error)) *MockControllerCreateHoldCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerCreateHoldCall) DoAndReturn(f func(context.Context, Parameters[CreateHold]) (*ledger.Log, *ledger.CreatedHold, bool, error)) *MockControllerCreateHoldCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateRecurrence mocks base method.
+func (m *MockController) CreateRecurrence(ctx context.Context, input CreateRecurrence) (*ledger.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecurrence", ctx, input)
+	ret0, _ := ret[0].(*ledger.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecurrence indicates an expected call of CreateRecurrence.
+func (mr *MockControllerMockRecorder) CreateRecurrence(ctx, input any) *MockControllerCreateRecurrenceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecurrence", reflect.TypeOf((*MockController)(nil).CreateRecurrence), ctx, input)
+	return &MockControllerCreateRecurrenceCall{Call: call}
+}
+
+// MockControllerCreateRecurrenceCall wrap *gomock.Call
+type MockControllerCreateRecurrenceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerCreateRecurrenceCall) Return(arg0 *ledger.Recurrence, arg1 error) *MockControllerCreateRecurrenceCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerCreateRecurrenceCall) Do(f func(context.Context, CreateRecurrence) (*ledger.Recurrence, error)) *MockControllerCreateRecurrenceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerCreateRecurrenceCall) DoAndReturn(f func(context.Context, CreateRecurrence) (*ledger.Recurrence, error)) *MockControllerCreateRecurrenceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateRevertJob mocks base method.
+func (m *MockController) CreateRevertJob(ctx context.Context, input CreateRevertJob) (*ledger.RevertJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRevertJob", ctx, input)
+	ret0, _ := ret[0].(*ledger.RevertJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRevertJob indicates an expected call of CreateRevertJob.
+func (mr *MockControllerMockRecorder) CreateRevertJob(ctx, input any) *MockControllerCreateRevertJobCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRevertJob", reflect.TypeOf((*MockController)(nil).CreateRevertJob), ctx, input)
+	return &MockControllerCreateRevertJobCall{Call: call}
+}
+
+// MockControllerCreateRevertJobCall wrap *gomock.Call
+type MockControllerCreateRevertJobCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerCreateRevertJobCall) Return(arg0 *ledger.RevertJob, arg1 error) *MockControllerCreateRevertJobCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerCreateRevertJobCall) Do(f func(context.Context, CreateRevertJob) (*ledger.RevertJob, error)) *MockControllerCreateRevertJobCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerCreateRevertJobCall) DoAndReturn(f func(context.Context, CreateRevertJob) (*ledger.RevertJob, error)) *MockControllerCreateRevertJobCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // CreateTransaction mocks base method.
 func (m *MockController) CreateTransaction(ctx context.Context, parameters Parameters[CreateTransaction]) (*ledger.Log, *ledger.CreatedTransaction, bool, error) {
 	m.ctrl.T.Helper()
@@ -282,6 +522,44 @@ func (c *MockControllerDeleteAccountMetadataCall) DoAndReturn(f func(context.Con
 	return c
 }
 
+// DeleteRecurrence mocks base method.
+func (m *MockController) DeleteRecurrence(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecurrence", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecurrence indicates an expected call of DeleteRecurrence.
+func (mr *MockControllerMockRecorder) DeleteRecurrence(ctx, id any) *MockControllerDeleteRecurrenceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecurrence", reflect.TypeOf((*MockController)(nil).DeleteRecurrence), ctx, id)
+	return &MockControllerDeleteRecurrenceCall{Call: call}
+}
+
+// MockControllerDeleteRecurrenceCall wrap *gomock.Call
+type MockControllerDeleteRecurrenceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerDeleteRecurrenceCall) Return(arg0 error) *MockControllerDeleteRecurrenceCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerDeleteRecurrenceCall) Do(f func(context.Context, string) error) *MockControllerDeleteRecurrenceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerDeleteRecurrenceCall) DoAndReturn(f func(context.Context, string) error) *MockControllerDeleteRecurrenceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // DeleteTransactionMetadata mocks base method.
 func (m *MockController) DeleteTransactionMetadata(ctx context.Context, parameters Parameters[DeleteTransactionMetadata]) (*ledger.Log, bool, error) {
 	m.ctrl.T.Helper()
@@ -322,6 +600,86 @@ func (c *MockControllerDeleteTransactionMetadataCall) DoAndReturn(f func(context
 	return c
 }
 
+// DiffSchema mocks base method.
+func (m *MockController) DiffSchema(ctx context.Context, version string, data ledger.SchemaData, query common.PaginatedQuery[any]) (*ledger.SchemaDiff, *paginate.Cursor[ledger.Account], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffSchema", ctx, version, data, query)
+	ret0, _ := ret[0].(*ledger.SchemaDiff)
+	ret1, _ := ret[1].(*paginate.Cursor[ledger.Account])
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DiffSchema indicates an expected call of DiffSchema.
+func (mr *MockControllerMockRecorder) DiffSchema(ctx, version, data, query any) *MockControllerDiffSchemaCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffSchema", reflect.TypeOf((*MockController)(nil).DiffSchema), ctx, version, data, query)
+	return &MockControllerDiffSchemaCall{Call: call}
+}
+
+// MockControllerDiffSchemaCall wrap *gomock.Call
+type MockControllerDiffSchemaCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerDiffSchemaCall) Return(arg0 *ledger.SchemaDiff, arg1 *paginate.Cursor[ledger.Account], arg2 error) *MockControllerDiffSchemaCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerDiffSchemaCall) Do(f func(context.Context, string, ledger.SchemaData, common.PaginatedQuery[any]) (*ledger.SchemaDiff, *paginate.Cursor[ledger.Account], error)) *MockControllerDiffSchemaCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerDiffSchemaCall) DoAndReturn(f func(context.Context, string, ledger.SchemaData, common.PaginatedQuery[any]) (*ledger.SchemaDiff, *paginate.Cursor[ledger.Account], error)) *MockControllerDiffSchemaCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ExecuteScheduledTransaction mocks base method.
+func (m *MockController) ExecuteScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, *ledger.CreatedTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteScheduledTransaction", ctx, id)
+	ret0, _ := ret[0].(*ledger.ScheduledTransaction)
+	ret1, _ := ret[1].(*ledger.CreatedTransaction)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ExecuteScheduledTransaction indicates an expected call of ExecuteScheduledTransaction.
+func (mr *MockControllerMockRecorder) ExecuteScheduledTransaction(ctx, id any) *MockControllerExecuteScheduledTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteScheduledTransaction", reflect.TypeOf((*MockController)(nil).ExecuteScheduledTransaction), ctx, id)
+	return &MockControllerExecuteScheduledTransactionCall{Call: call}
+}
+
+// MockControllerExecuteScheduledTransactionCall wrap *gomock.Call
+type MockControllerExecuteScheduledTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerExecuteScheduledTransactionCall) Return(arg0 *ledger.ScheduledTransaction, arg1 *ledger.CreatedTransaction, arg2 error) *MockControllerExecuteScheduledTransactionCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerExecuteScheduledTransactionCall) Do(f func(context.Context, string) (*ledger.ScheduledTransaction, *ledger.CreatedTransaction, error)) *MockControllerExecuteScheduledTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerExecuteScheduledTransactionCall) DoAndReturn(f func(context.Context, string) (*ledger.ScheduledTransaction, *ledger.CreatedTransaction, error)) *MockControllerExecuteScheduledTransactionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Export mocks base method.
 func (m *MockController) Export(ctx context.Context, w ExportWriter) error {
 	m.ctrl.T.Helper()
@@ -399,47 +757,320 @@ func (c *MockControllerGetAccountCall) DoAndReturn(f func(context.Context, commo
 	return c
 }
 
-// GetAggregatedBalances mocks base method.
-func (m *MockController) GetAggregatedBalances(ctx context.Context, q common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (ledger.BalancesByAssets, error) {
+// GetAccountStatement mocks base method.
+func (m *MockController) GetAccountStatement(ctx context.Context, address string, query common.PaginatedQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.AccountStatement, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAggregatedBalances", ctx, q)
-	ret0, _ := ret[0].(ledger.BalancesByAssets)
+	ret := m.ctrl.Call(m, "GetAccountStatement", ctx, address, query)
+	ret0, _ := ret[0].(*ledger.AccountStatement)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAggregatedBalances indicates an expected call of GetAggregatedBalances.
-func (mr *MockControllerMockRecorder) GetAggregatedBalances(ctx, q any) *MockControllerGetAggregatedBalancesCall {
+// GetAccountStatement indicates an expected call of GetAccountStatement.
+func (mr *MockControllerMockRecorder) GetAccountStatement(ctx, address, query any) *MockControllerGetAccountStatementCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregatedBalances", reflect.TypeOf((*MockController)(nil).GetAggregatedBalances), ctx, q)
-	return &MockControllerGetAggregatedBalancesCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountStatement", reflect.TypeOf((*MockController)(nil).GetAccountStatement), ctx, address, query)
+	return &MockControllerGetAccountStatementCall{Call: call}
 }
 
-// MockControllerGetAggregatedBalancesCall wrap *gomock.Call
-type MockControllerGetAggregatedBalancesCall struct {
+// MockControllerGetAccountStatementCall wrap *gomock.Call
+type MockControllerGetAccountStatementCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockControllerGetAggregatedBalancesCall) Return(arg0 ledger.BalancesByAssets, arg1 error) *MockControllerGetAggregatedBalancesCall {
+func (c *MockControllerGetAccountStatementCall) Return(arg0 *ledger.AccountStatement, arg1 error) *MockControllerGetAccountStatementCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockControllerGetAggregatedBalancesCall) Do(f func(context.Context, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (ledger.BalancesByAssets, error)) *MockControllerGetAggregatedBalancesCall {
+func (c *MockControllerGetAccountStatementCall) Do(f func(context.Context, string, common.PaginatedQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.AccountStatement, error)) *MockControllerGetAccountStatementCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockControllerGetAggregatedBalancesCall) DoAndReturn(f func(context.Context, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (ledger.BalancesByAssets, error)) *MockControllerGetAggregatedBalancesCall {
+func (c *MockControllerGetAccountStatementCall) DoAndReturn(f func(context.Context, string, common.PaginatedQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.AccountStatement, error)) *MockControllerGetAccountStatementCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// GetMigrationsInfo mocks base method.
-func (m *MockController) GetMigrationsInfo(ctx context.Context) ([]migrations.Info, error) {
+// GetAggregatedBalances mocks base method.
+func (m *MockController) GetAggregatedBalances(ctx context.Context, q common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (ledger.BalancesByAssets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAggregatedBalances", ctx, q)
+	ret0, _ := ret[0].(ledger.BalancesByAssets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAggregatedBalances indicates an expected call of GetAggregatedBalances.
+func (mr *MockControllerMockRecorder) GetAggregatedBalances(ctx, q any) *MockControllerGetAggregatedBalancesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregatedBalances", reflect.TypeOf((*MockController)(nil).GetAggregatedBalances), ctx, q)
+	return &MockControllerGetAggregatedBalancesCall{Call: call}
+}
+
+// MockControllerGetAggregatedBalancesCall wrap *gomock.Call
+type MockControllerGetAggregatedBalancesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerGetAggregatedBalancesCall) Return(arg0 ledger.BalancesByAssets, arg1 error) *MockControllerGetAggregatedBalancesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerGetAggregatedBalancesCall) Do(f func(context.Context, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (ledger.BalancesByAssets, error)) *MockControllerGetAggregatedBalancesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerGetAggregatedBalancesCall) DoAndReturn(f func(context.Context, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (ledger.BalancesByAssets, error)) *MockControllerGetAggregatedBalancesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetBalanceHistory mocks base method.
+func (m *MockController) GetBalanceHistory(ctx context.Context, query common.ResourceQuery[ledger.GetBalanceHistoryOptions]) ([]ledger.BalanceHistoryPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceHistory", ctx, query)
+	ret0, _ := ret[0].([]ledger.BalanceHistoryPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceHistory indicates an expected call of GetBalanceHistory.
+func (mr *MockControllerMockRecorder) GetBalanceHistory(ctx, query any) *MockControllerGetBalanceHistoryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceHistory", reflect.TypeOf((*MockController)(nil).GetBalanceHistory), ctx, query)
+	return &MockControllerGetBalanceHistoryCall{Call: call}
+}
+
+// MockControllerGetBalanceHistoryCall wrap *gomock.Call
+type MockControllerGetBalanceHistoryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerGetBalanceHistoryCall) Return(arg0 []ledger.BalanceHistoryPoint, arg1 error) *MockControllerGetBalanceHistoryCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerGetBalanceHistoryCall) Do(f func(context.Context, common.ResourceQuery[ledger.GetBalanceHistoryOptions]) ([]ledger.BalanceHistoryPoint, error)) *MockControllerGetBalanceHistoryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerGetBalanceHistoryCall) DoAndReturn(f func(context.Context, common.ResourceQuery[ledger.GetBalanceHistoryOptions]) ([]ledger.BalanceHistoryPoint, error)) *MockControllerGetBalanceHistoryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetBalanceSheet mocks base method.
+func (m *MockController) GetBalanceSheet(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.BalanceSheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceSheet", ctx, version, query)
+	ret0, _ := ret[0].(*ledger.BalanceSheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceSheet indicates an expected call of GetBalanceSheet.
+func (mr *MockControllerMockRecorder) GetBalanceSheet(ctx, version, query any) *MockControllerGetBalanceSheetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceSheet", reflect.TypeOf((*MockController)(nil).GetBalanceSheet), ctx, version, query)
+	return &MockControllerGetBalanceSheetCall{Call: call}
+}
+
+// MockControllerGetBalanceSheetCall wrap *gomock.Call
+type MockControllerGetBalanceSheetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerGetBalanceSheetCall) Return(arg0 *ledger.BalanceSheet, arg1 error) *MockControllerGetBalanceSheetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerGetBalanceSheetCall) Do(f func(context.Context, string, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.BalanceSheet, error)) *MockControllerGetBalanceSheetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerGetBalanceSheetCall) DoAndReturn(f func(context.Context, string, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.BalanceSheet, error)) *MockControllerGetBalanceSheetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetChartTree mocks base method.
+func (m *MockController) GetChartTree(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) ([]ledger.ChartTreeNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChartTree", ctx, version, query)
+	ret0, _ := ret[0].([]ledger.ChartTreeNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChartTree indicates an expected call of GetChartTree.
+func (mr *MockControllerMockRecorder) GetChartTree(ctx, version, query any) *MockControllerGetChartTreeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChartTree", reflect.TypeOf((*MockController)(nil).GetChartTree), ctx, version, query)
+	return &MockControllerGetChartTreeCall{Call: call}
+}
+
+// MockControllerGetChartTreeCall wrap *gomock.Call
+type MockControllerGetChartTreeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerGetChartTreeCall) Return(arg0 []ledger.ChartTreeNode, arg1 error) *MockControllerGetChartTreeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerGetChartTreeCall) Do(f func(context.Context, string, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) ([]ledger.ChartTreeNode, error)) *MockControllerGetChartTreeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerGetChartTreeCall) DoAndReturn(f func(context.Context, string, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) ([]ledger.ChartTreeNode, error)) *MockControllerGetChartTreeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetClosedPeriod mocks base method.
+func (m *MockController) GetClosedPeriod(ctx context.Context) (*ledger.ClosedPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClosedPeriod", ctx)
+	ret0, _ := ret[0].(*ledger.ClosedPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClosedPeriod indicates an expected call of GetClosedPeriod.
+func (mr *MockControllerMockRecorder) GetClosedPeriod(ctx any) *MockControllerGetClosedPeriodCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClosedPeriod", reflect.TypeOf((*MockController)(nil).GetClosedPeriod), ctx)
+	return &MockControllerGetClosedPeriodCall{Call: call}
+}
+
+// MockControllerGetClosedPeriodCall wrap *gomock.Call
+type MockControllerGetClosedPeriodCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerGetClosedPeriodCall) Return(arg0 *ledger.ClosedPeriod, arg1 error) *MockControllerGetClosedPeriodCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerGetClosedPeriodCall) Do(f func(context.Context) (*ledger.ClosedPeriod, error)) *MockControllerGetClosedPeriodCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerGetClosedPeriodCall) DoAndReturn(f func(context.Context) (*ledger.ClosedPeriod, error)) *MockControllerGetClosedPeriodCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetHold mocks base method.
+func (m *MockController) GetHold(ctx context.Context, id string) (*ledger.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHold", ctx, id)
+	ret0, _ := ret[0].(*ledger.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHold indicates an expected call of GetHold.
+func (mr *MockControllerMockRecorder) GetHold(ctx, id any) *MockControllerGetHoldCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHold", reflect.TypeOf((*MockController)(nil).GetHold), ctx, id)
+	return &MockControllerGetHoldCall{Call: call}
+}
+
+// MockControllerGetHoldCall wrap *gomock.Call
+type MockControllerGetHoldCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerGetHoldCall) Return(arg0 *ledger.Hold, arg1 error) *MockControllerGetHoldCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerGetHoldCall) Do(f func(context.Context, string) (*ledger.Hold, error)) *MockControllerGetHoldCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerGetHoldCall) DoAndReturn(f func(context.Context, string) (*ledger.Hold, error)) *MockControllerGetHoldCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetIncomeStatement mocks base method.
+func (m *MockController) GetIncomeStatement(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.IncomeStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncomeStatement", ctx, version, query)
+	ret0, _ := ret[0].(*ledger.IncomeStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncomeStatement indicates an expected call of GetIncomeStatement.
+func (mr *MockControllerMockRecorder) GetIncomeStatement(ctx, version, query any) *MockControllerGetIncomeStatementCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncomeStatement", reflect.TypeOf((*MockController)(nil).GetIncomeStatement), ctx, version, query)
+	return &MockControllerGetIncomeStatementCall{Call: call}
+}
+
+// MockControllerGetIncomeStatementCall wrap *gomock.Call
+type MockControllerGetIncomeStatementCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerGetIncomeStatementCall) Return(arg0 *ledger.IncomeStatement, arg1 error) *MockControllerGetIncomeStatementCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerGetIncomeStatementCall) Do(f func(context.Context, string, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.IncomeStatement, error)) *MockControllerGetIncomeStatementCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerGetIncomeStatementCall) DoAndReturn(f func(context.Context, string, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.IncomeStatement, error)) *MockControllerGetIncomeStatementCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetMigrationsInfo mocks base method.
+func (m *MockController) GetMigrationsInfo(ctx context.Context) ([]migrations.Info, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetMigrationsInfo", ctx)
 	ret0, _ := ret[0].([]migrations.Info)
@@ -477,6 +1108,123 @@ func (c *MockControllerGetMigrationsInfoCall) DoAndReturn(f func(context.Context
 	return c
 }
 
+// GetRecurrence mocks base method.
+func (m *MockController) GetRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurrence", ctx, id)
+	ret0, _ := ret[0].(*ledger.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurrence indicates an expected call of GetRecurrence.
+func (mr *MockControllerMockRecorder) GetRecurrence(ctx, id any) *MockControllerGetRecurrenceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurrence", reflect.TypeOf((*MockController)(nil).GetRecurrence), ctx, id)
+	return &MockControllerGetRecurrenceCall{Call: call}
+}
+
+// MockControllerGetRecurrenceCall wrap *gomock.Call
+type MockControllerGetRecurrenceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerGetRecurrenceCall) Return(arg0 *ledger.Recurrence, arg1 error) *MockControllerGetRecurrenceCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerGetRecurrenceCall) Do(f func(context.Context, string) (*ledger.Recurrence, error)) *MockControllerGetRecurrenceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerGetRecurrenceCall) DoAndReturn(f func(context.Context, string) (*ledger.Recurrence, error)) *MockControllerGetRecurrenceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetRevertJob mocks base method.
+func (m *MockController) GetRevertJob(ctx context.Context, id string) (*ledger.RevertJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevertJob", ctx, id)
+	ret0, _ := ret[0].(*ledger.RevertJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevertJob indicates an expected call of GetRevertJob.
+func (mr *MockControllerMockRecorder) GetRevertJob(ctx, id any) *MockControllerGetRevertJobCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevertJob", reflect.TypeOf((*MockController)(nil).GetRevertJob), ctx, id)
+	return &MockControllerGetRevertJobCall{Call: call}
+}
+
+// MockControllerGetRevertJobCall wrap *gomock.Call
+type MockControllerGetRevertJobCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerGetRevertJobCall) Return(arg0 *ledger.RevertJob, arg1 error) *MockControllerGetRevertJobCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerGetRevertJobCall) Do(f func(context.Context, string) (*ledger.RevertJob, error)) *MockControllerGetRevertJobCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerGetRevertJobCall) DoAndReturn(f func(context.Context, string) (*ledger.RevertJob, error)) *MockControllerGetRevertJobCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetScheduledTransaction mocks base method.
+func (m *MockController) GetScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledTransaction", ctx, id)
+	ret0, _ := ret[0].(*ledger.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledTransaction indicates an expected call of GetScheduledTransaction.
+func (mr *MockControllerMockRecorder) GetScheduledTransaction(ctx, id any) *MockControllerGetScheduledTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledTransaction", reflect.TypeOf((*MockController)(nil).GetScheduledTransaction), ctx, id)
+	return &MockControllerGetScheduledTransactionCall{Call: call}
+}
+
+// MockControllerGetScheduledTransactionCall wrap *gomock.Call
+type MockControllerGetScheduledTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerGetScheduledTransactionCall) Return(arg0 *ledger.ScheduledTransaction, arg1 error) *MockControllerGetScheduledTransactionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerGetScheduledTransactionCall) Do(f func(context.Context, string) (*ledger.ScheduledTransaction, error)) *MockControllerGetScheduledTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerGetScheduledTransactionCall) DoAndReturn(f func(context.Context, string) (*ledger.ScheduledTransaction, error)) *MockControllerGetScheduledTransactionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // GetSchema mocks base method.
 func (m *MockController) GetSchema(ctx context.Context, version string) (*ledger.Schema, error) {
 	m.ctrl.T.Helper()
@@ -550,46 +1298,85 @@ func (c *MockControllerGetStatsCall) Do(f func(context.Context) (Stats, error))
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockControllerGetStatsCall) DoAndReturn(f func(context.Context) (Stats, error)) *MockControllerGetStatsCall {
+func (c *MockControllerGetStatsCall) DoAndReturn(f func(context.Context) (Stats, error)) *MockControllerGetStatsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetTransaction mocks base method.
+func (m *MockController) GetTransaction(ctx context.Context, query common.ResourceQuery[any]) (*ledger.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransaction", ctx, query)
+	ret0, _ := ret[0].(*ledger.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransaction indicates an expected call of GetTransaction.
+func (mr *MockControllerMockRecorder) GetTransaction(ctx, query any) *MockControllerGetTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransaction", reflect.TypeOf((*MockController)(nil).GetTransaction), ctx, query)
+	return &MockControllerGetTransactionCall{Call: call}
+}
+
+// MockControllerGetTransactionCall wrap *gomock.Call
+type MockControllerGetTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerGetTransactionCall) Return(arg0 *ledger.Transaction, arg1 error) *MockControllerGetTransactionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerGetTransactionCall) Do(f func(context.Context, common.ResourceQuery[any]) (*ledger.Transaction, error)) *MockControllerGetTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerGetTransactionCall) DoAndReturn(f func(context.Context, common.ResourceQuery[any]) (*ledger.Transaction, error)) *MockControllerGetTransactionCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// GetTransaction mocks base method.
-func (m *MockController) GetTransaction(ctx context.Context, query common.ResourceQuery[any]) (*ledger.Transaction, error) {
+// GetTrialBalance mocks base method.
+func (m *MockController) GetTrialBalance(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.TrialBalance, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransaction", ctx, query)
-	ret0, _ := ret[0].(*ledger.Transaction)
+	ret := m.ctrl.Call(m, "GetTrialBalance", ctx, version, query)
+	ret0, _ := ret[0].(*ledger.TrialBalance)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTransaction indicates an expected call of GetTransaction.
-func (mr *MockControllerMockRecorder) GetTransaction(ctx, query any) *MockControllerGetTransactionCall {
+// GetTrialBalance indicates an expected call of GetTrialBalance.
+func (mr *MockControllerMockRecorder) GetTrialBalance(ctx, version, query any) *MockControllerGetTrialBalanceCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransaction", reflect.TypeOf((*MockController)(nil).GetTransaction), ctx, query)
-	return &MockControllerGetTransactionCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrialBalance", reflect.TypeOf((*MockController)(nil).GetTrialBalance), ctx, version, query)
+	return &MockControllerGetTrialBalanceCall{Call: call}
 }
 
-// MockControllerGetTransactionCall wrap *gomock.Call
-type MockControllerGetTransactionCall struct {
+// MockControllerGetTrialBalanceCall wrap *gomock.Call
+type MockControllerGetTrialBalanceCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockControllerGetTransactionCall) Return(arg0 *ledger.Transaction, arg1 error) *MockControllerGetTransactionCall {
+func (c *MockControllerGetTrialBalanceCall) Return(arg0 *ledger.TrialBalance, arg1 error) *MockControllerGetTrialBalanceCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockControllerGetTransactionCall) Do(f func(context.Context, common.ResourceQuery[any]) (*ledger.Transaction, error)) *MockControllerGetTransactionCall {
+func (c *MockControllerGetTrialBalanceCall) Do(f func(context.Context, string, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.TrialBalance, error)) *MockControllerGetTrialBalanceCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockControllerGetTransactionCall) DoAndReturn(f func(context.Context, common.ResourceQuery[any]) (*ledger.Transaction, error)) *MockControllerGetTransactionCall {
+func (c *MockControllerGetTrialBalanceCall) DoAndReturn(f func(context.Context, string, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.TrialBalance, error)) *MockControllerGetTrialBalanceCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -867,6 +1654,45 @@ func (c *MockControllerListLogsCall) DoAndReturn(f func(context.Context, common.
 	return c
 }
 
+// ListRecurrences mocks base method.
+func (m *MockController) ListRecurrences(ctx context.Context, query common.PaginatedQuery[any]) (*paginate.Cursor[ledger.Recurrence], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecurrences", ctx, query)
+	ret0, _ := ret[0].(*paginate.Cursor[ledger.Recurrence])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecurrences indicates an expected call of ListRecurrences.
+func (mr *MockControllerMockRecorder) ListRecurrences(ctx, query any) *MockControllerListRecurrencesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecurrences", reflect.TypeOf((*MockController)(nil).ListRecurrences), ctx, query)
+	return &MockControllerListRecurrencesCall{Call: call}
+}
+
+// MockControllerListRecurrencesCall wrap *gomock.Call
+type MockControllerListRecurrencesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerListRecurrencesCall) Return(arg0 *paginate.Cursor[ledger.Recurrence], arg1 error) *MockControllerListRecurrencesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerListRecurrencesCall) Do(f func(context.Context, common.PaginatedQuery[any]) (*paginate.Cursor[ledger.Recurrence], error)) *MockControllerListRecurrencesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerListRecurrencesCall) DoAndReturn(f func(context.Context, common.PaginatedQuery[any]) (*paginate.Cursor[ledger.Recurrence], error)) *MockControllerListRecurrencesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // ListSchemas mocks base method.
 func (m *MockController) ListSchemas(ctx context.Context, query common.PaginatedQuery[any]) (*paginate.Cursor[ledger.Schema], error) {
 	m.ctrl.T.Helper()
@@ -986,6 +1812,166 @@ func (c *MockControllerLockLedgerCall) DoAndReturn(f func(context.Context) (Cont
 	return c
 }
 
+// PartiallyRevertTransaction mocks base method.
+func (m *MockController) PartiallyRevertTransaction(ctx context.Context, parameters Parameters[PartiallyRevertTransaction]) (*ledger.Log, *ledger.PartiallyRevertedTransaction, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PartiallyRevertTransaction", ctx, parameters)
+	ret0, _ := ret[0].(*ledger.Log)
+	ret1, _ := ret[1].(*ledger.PartiallyRevertedTransaction)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// PartiallyRevertTransaction indicates an expected call of PartiallyRevertTransaction.
+func (mr *MockControllerMockRecorder) PartiallyRevertTransaction(ctx, parameters any) *MockControllerPartiallyRevertTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PartiallyRevertTransaction", reflect.TypeOf((*MockController)(nil).PartiallyRevertTransaction), ctx, parameters)
+	return &MockControllerPartiallyRevertTransactionCall{Call: call}
+}
+
+// MockControllerPartiallyRevertTransactionCall wrap *gomock.Call
+type MockControllerPartiallyRevertTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerPartiallyRevertTransactionCall) Return(arg0 *ledger.Log, arg1 *ledger.PartiallyRevertedTransaction, arg2 bool, arg3 error) *MockControllerPartiallyRevertTransactionCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2, arg3)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerPartiallyRevertTransactionCall) Do(f func(context.Context, Parameters[PartiallyRevertTransaction]) (*ledger.Log, *ledger.PartiallyRevertedTransaction, bool, error)) *MockControllerPartiallyRevertTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerPartiallyRevertTransactionCall) DoAndReturn(f func(context.Context, Parameters[PartiallyRevertTransaction]) (*ledger.Log, *ledger.PartiallyRevertedTransaction, bool, error)) *MockControllerPartiallyRevertTransactionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PauseRecurrence mocks base method.
+func (m *MockController) PauseRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseRecurrence", ctx, id)
+	ret0, _ := ret[0].(*ledger.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PauseRecurrence indicates an expected call of PauseRecurrence.
+func (mr *MockControllerMockRecorder) PauseRecurrence(ctx, id any) *MockControllerPauseRecurrenceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseRecurrence", reflect.TypeOf((*MockController)(nil).PauseRecurrence), ctx, id)
+	return &MockControllerPauseRecurrenceCall{Call: call}
+}
+
+// MockControllerPauseRecurrenceCall wrap *gomock.Call
+type MockControllerPauseRecurrenceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerPauseRecurrenceCall) Return(arg0 *ledger.Recurrence, arg1 error) *MockControllerPauseRecurrenceCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerPauseRecurrenceCall) Do(f func(context.Context, string) (*ledger.Recurrence, error)) *MockControllerPauseRecurrenceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerPauseRecurrenceCall) DoAndReturn(f func(context.Context, string) (*ledger.Recurrence, error)) *MockControllerPauseRecurrenceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ReopenPeriod mocks base method.
+func (m *MockController) ReopenPeriod(ctx context.Context, parameters Parameters[ReopenPeriod]) (*ledger.Log, *ledger.ReopenedPeriod, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenPeriod", ctx, parameters)
+	ret0, _ := ret[0].(*ledger.Log)
+	ret1, _ := ret[1].(*ledger.ReopenedPeriod)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// ReopenPeriod indicates an expected call of ReopenPeriod.
+func (mr *MockControllerMockRecorder) ReopenPeriod(ctx, parameters any) *MockControllerReopenPeriodCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenPeriod", reflect.TypeOf((*MockController)(nil).ReopenPeriod), ctx, parameters)
+	return &MockControllerReopenPeriodCall{Call: call}
+}
+
+// MockControllerReopenPeriodCall wrap *gomock.Call
+type MockControllerReopenPeriodCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerReopenPeriodCall) Return(arg0 *ledger.Log, arg1 *ledger.ReopenedPeriod, arg2 bool, arg3 error) *MockControllerReopenPeriodCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2, arg3)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerReopenPeriodCall) Do(f func(context.Context, Parameters[ReopenPeriod]) (*ledger.Log, *ledger.ReopenedPeriod, bool, error)) *MockControllerReopenPeriodCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerReopenPeriodCall) DoAndReturn(f func(context.Context, Parameters[ReopenPeriod]) (*ledger.Log, *ledger.ReopenedPeriod, bool, error)) *MockControllerReopenPeriodCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ResumeRecurrence mocks base method.
+func (m *MockController) ResumeRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeRecurrence", ctx, id)
+	ret0, _ := ret[0].(*ledger.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeRecurrence indicates an expected call of ResumeRecurrence.
+func (mr *MockControllerMockRecorder) ResumeRecurrence(ctx, id any) *MockControllerResumeRecurrenceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeRecurrence", reflect.TypeOf((*MockController)(nil).ResumeRecurrence), ctx, id)
+	return &MockControllerResumeRecurrenceCall{Call: call}
+}
+
+// MockControllerResumeRecurrenceCall wrap *gomock.Call
+type MockControllerResumeRecurrenceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerResumeRecurrenceCall) Return(arg0 *ledger.Recurrence, arg1 error) *MockControllerResumeRecurrenceCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerResumeRecurrenceCall) Do(f func(context.Context, string) (*ledger.Recurrence, error)) *MockControllerResumeRecurrenceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerResumeRecurrenceCall) DoAndReturn(f func(context.Context, string) (*ledger.Recurrence, error)) *MockControllerResumeRecurrenceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // RevertTransaction mocks base method.
 func (m *MockController) RevertTransaction(ctx context.Context, parameters Parameters[RevertTransaction]) (*ledger.Log, *ledger.RevertedTransaction, bool, error) {
 	m.ctrl.T.Helper()
@@ -1105,6 +2091,86 @@ func (c *MockControllerRunQueryCall) DoAndReturn(f func(context.Context, string,
 	return c
 }
 
+// RunRecurrence mocks base method.
+func (m *MockController) RunRecurrence(ctx context.Context, id string, batchSize int) (*ledger.Recurrence, []ledger.CreatedTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunRecurrence", ctx, id, batchSize)
+	ret0, _ := ret[0].(*ledger.Recurrence)
+	ret1, _ := ret[1].([]ledger.CreatedTransaction)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RunRecurrence indicates an expected call of RunRecurrence.
+func (mr *MockControllerMockRecorder) RunRecurrence(ctx, id, batchSize any) *MockControllerRunRecurrenceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunRecurrence", reflect.TypeOf((*MockController)(nil).RunRecurrence), ctx, id, batchSize)
+	return &MockControllerRunRecurrenceCall{Call: call}
+}
+
+// MockControllerRunRecurrenceCall wrap *gomock.Call
+type MockControllerRunRecurrenceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerRunRecurrenceCall) Return(arg0 *ledger.Recurrence, arg1 []ledger.CreatedTransaction, arg2 error) *MockControllerRunRecurrenceCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerRunRecurrenceCall) Do(f func(context.Context, string, int) (*ledger.Recurrence, []ledger.CreatedTransaction, error)) *MockControllerRunRecurrenceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerRunRecurrenceCall) DoAndReturn(f func(context.Context, string, int) (*ledger.Recurrence, []ledger.CreatedTransaction, error)) *MockControllerRunRecurrenceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RunRevertJob mocks base method.
+func (m *MockController) RunRevertJob(ctx context.Context, id string, batchSize int) (*ledger.RevertJob, []ledger.RevertedTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunRevertJob", ctx, id, batchSize)
+	ret0, _ := ret[0].(*ledger.RevertJob)
+	ret1, _ := ret[1].([]ledger.RevertedTransaction)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RunRevertJob indicates an expected call of RunRevertJob.
+func (mr *MockControllerMockRecorder) RunRevertJob(ctx, id, batchSize any) *MockControllerRunRevertJobCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunRevertJob", reflect.TypeOf((*MockController)(nil).RunRevertJob), ctx, id, batchSize)
+	return &MockControllerRunRevertJobCall{Call: call}
+}
+
+// MockControllerRunRevertJobCall wrap *gomock.Call
+type MockControllerRunRevertJobCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerRunRevertJobCall) Return(arg0 *ledger.RevertJob, arg1 []ledger.RevertedTransaction, arg2 error) *MockControllerRunRevertJobCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerRunRevertJobCall) Do(f func(context.Context, string, int) (*ledger.RevertJob, []ledger.RevertedTransaction, error)) *MockControllerRunRevertJobCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerRunRevertJobCall) DoAndReturn(f func(context.Context, string, int) (*ledger.RevertJob, []ledger.RevertedTransaction, error)) *MockControllerRunRevertJobCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // SaveAccountMetadata mocks base method.
 func (m *MockController) SaveAccountMetadata(ctx context.Context, parameters Parameters[SaveAccountMetadata]) (*ledger.Log, bool, error) {
 	m.ctrl.T.Helper()
@@ -1184,3 +2250,83 @@ func (c *MockControllerSaveTransactionMetadataCall) DoAndReturn(f func(context.C
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// ScheduleTransaction mocks base method.
+func (m *MockController) ScheduleTransaction(ctx context.Context, input ScheduleTransaction) (*ledger.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleTransaction", ctx, input)
+	ret0, _ := ret[0].(*ledger.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleTransaction indicates an expected call of ScheduleTransaction.
+func (mr *MockControllerMockRecorder) ScheduleTransaction(ctx, input any) *MockControllerScheduleTransactionCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleTransaction", reflect.TypeOf((*MockController)(nil).ScheduleTransaction), ctx, input)
+	return &MockControllerScheduleTransactionCall{Call: call}
+}
+
+// MockControllerScheduleTransactionCall wrap *gomock.Call
+type MockControllerScheduleTransactionCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerScheduleTransactionCall) Return(arg0 *ledger.ScheduledTransaction, arg1 error) *MockControllerScheduleTransactionCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerScheduleTransactionCall) Do(f func(context.Context, ScheduleTransaction) (*ledger.ScheduledTransaction, error)) *MockControllerScheduleTransactionCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerScheduleTransactionCall) DoAndReturn(f func(context.Context, ScheduleTransaction) (*ledger.ScheduledTransaction, error)) *MockControllerScheduleTransactionCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// VoidHold mocks base method.
+func (m *MockController) VoidHold(ctx context.Context, parameters Parameters[VoidHold]) (*ledger.Log, *ledger.VoidedHold, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidHold", ctx, parameters)
+	ret0, _ := ret[0].(*ledger.Log)
+	ret1, _ := ret[1].(*ledger.VoidedHold)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// VoidHold indicates an expected call of VoidHold.
+func (mr *MockControllerMockRecorder) VoidHold(ctx, parameters any) *MockControllerVoidHoldCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidHold", reflect.TypeOf((*MockController)(nil).VoidHold), ctx, parameters)
+	return &MockControllerVoidHoldCall{Call: call}
+}
+
+// MockControllerVoidHoldCall wrap *gomock.Call
+type MockControllerVoidHoldCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockControllerVoidHoldCall) Return(arg0 *ledger.Log, arg1 *ledger.VoidedHold, arg2 bool, arg3 error) *MockControllerVoidHoldCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2, arg3)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockControllerVoidHoldCall) Do(f func(context.Context, Parameters[VoidHold]) (*ledger.Log, *ledger.VoidedHold, bool, error)) *MockControllerVoidHoldCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockControllerVoidHoldCall) DoAndReturn(f func(context.Context, Parameters[VoidHold]) (*ledger.Log, *ledger.VoidedHold, bool, error)) *MockControllerVoidHoldCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
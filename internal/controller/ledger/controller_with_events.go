@@ -73,6 +73,25 @@ func (c *ControllerWithEvents) RevertTransaction(ctx context.Context, parameters
 	return log, ret, idempotencyHit, nil
 }
 
+func (c *ControllerWithEvents) PartiallyRevertTransaction(ctx context.Context, parameters Parameters[PartiallyRevertTransaction]) (*ledger.Log, *ledger.PartiallyRevertedTransaction, bool, error) {
+	log, ret, idempotencyHit, err := c.Controller.PartiallyRevertTransaction(ctx, parameters)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if !parameters.DryRun {
+		c.handleEvent(ctx, func() {
+			c.listener.PartiallyRevertedTransaction(
+				ctx,
+				c.ledger.Name,
+				ret.RevertedTransaction,
+				ret.RevertTransaction,
+			)
+		})
+	}
+
+	return log, ret, idempotencyHit, nil
+}
+
 func (c *ControllerWithEvents) SaveTransactionMetadata(ctx context.Context, parameters Parameters[SaveTransactionMetadata]) (*ledger.Log, bool, error) {
 	log, idempotencyHit, err := c.Controller.SaveTransactionMetadata(ctx, parameters)
 	if err != nil {
@@ -167,6 +186,67 @@ func (c *ControllerWithEvents) InsertSchema(ctx context.Context, parameters Para
 	return log, ret, idempotencyHit, nil
 }
 
+func (c *ControllerWithEvents) CaptureHold(ctx context.Context, parameters Parameters[CaptureHold]) (*ledger.Log, *ledger.CapturedHold, bool, error) {
+	log, ret, idempotencyHit, err := c.Controller.CaptureHold(ctx, parameters)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if !parameters.DryRun {
+		c.handleEvent(ctx, func() {
+			c.listener.CommittedTransactions(ctx, c.ledger.Name, ret.Transaction, ledger.AccountMetadata{})
+		})
+	}
+
+	return log, ret, idempotencyHit, nil
+}
+
+func (c *ControllerWithEvents) RunRevertJob(ctx context.Context, id string, batchSize int) (*ledger.RevertJob, []ledger.RevertedTransaction, error) {
+	job, revertedTransactions, err := c.Controller.RunRevertJob(ctx, id, batchSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, ret := range revertedTransactions {
+		c.handleEvent(ctx, func() {
+			c.listener.RevertedTransaction(
+				ctx,
+				c.ledger.Name,
+				ret.RevertedTransaction,
+				ret.RevertTransaction,
+			)
+		})
+	}
+
+	return job, revertedTransactions, nil
+}
+
+func (c *ControllerWithEvents) ExecuteScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, *ledger.CreatedTransaction, error) {
+	scheduledTransaction, ret, err := c.Controller.ExecuteScheduledTransaction(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ret != nil {
+		c.handleEvent(ctx, func() {
+			c.listener.CommittedTransactions(ctx, c.ledger.Name, ret.Transaction, ret.AccountMetadata)
+		})
+	}
+
+	return scheduledTransaction, ret, nil
+}
+
+func (c *ControllerWithEvents) RunRecurrence(ctx context.Context, id string, batchSize int) (*ledger.Recurrence, []ledger.CreatedTransaction, error) {
+	recurrence, createdTransactions, err := c.Controller.RunRecurrence(ctx, id, batchSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, ret := range createdTransactions {
+		c.handleEvent(ctx, func() {
+			c.listener.CommittedTransactions(ctx, c.ledger.Name, ret.Transaction, ret.AccountMetadata)
+		})
+	}
+
+	return recurrence, createdTransactions, nil
+}
+
 func (c *ControllerWithEvents) BeginTX(ctx context.Context, options *sql.TxOptions) (Controller, *bun.Tx, error) {
 	ctrl, tx, err := c.Controller.BeginTX(ctx, options)
 	if err != nil {
@@ -76,6 +76,20 @@ func (c *ControllerWithTooManyClientHandling) RevertTransaction(ctx context.Cont
 	return log, revertedTransaction, idempotencyHit, err
 }
 
+func (c *ControllerWithTooManyClientHandling) PartiallyRevertTransaction(ctx context.Context, parameters Parameters[PartiallyRevertTransaction]) (*ledger.Log, *ledger.PartiallyRevertedTransaction, bool, error) {
+	var (
+		log            *ledger.Log
+		ret            *ledger.PartiallyRevertedTransaction
+		idempotencyHit bool
+		err            error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		log, ret, idempotencyHit, err = c.Controller.PartiallyRevertTransaction(ctx, parameters)
+		return err
+	})
+	return log, ret, idempotencyHit, err
+}
+
 func (c *ControllerWithTooManyClientHandling) SaveTransactionMetadata(ctx context.Context, parameters Parameters[SaveTransactionMetadata]) (*ledger.Log, bool, error) {
 	var (
 		log            *ledger.Log
@@ -147,6 +161,243 @@ func (c *ControllerWithTooManyClientHandling) InsertSchema(ctx context.Context,
 	return log, ret, idempotencyHit, err
 }
 
+func (c *ControllerWithTooManyClientHandling) GetClosedPeriod(ctx context.Context) (*ledger.ClosedPeriod, error) {
+	var (
+		period *ledger.ClosedPeriod
+		err    error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		period, err = c.Controller.GetClosedPeriod(ctx)
+		return err
+	})
+
+	return period, err
+}
+
+func (c *ControllerWithTooManyClientHandling) ClosePeriod(ctx context.Context, parameters Parameters[ClosePeriod]) (*ledger.Log, *ledger.ClosedPeriod, bool, error) {
+	var (
+		log            *ledger.Log
+		ret            *ledger.ClosedPeriod
+		idempotencyHit bool
+		err            error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		log, ret, idempotencyHit, err = c.Controller.ClosePeriod(ctx, parameters)
+		return err
+	})
+
+	return log, ret, idempotencyHit, err
+}
+
+func (c *ControllerWithTooManyClientHandling) ReopenPeriod(ctx context.Context, parameters Parameters[ReopenPeriod]) (*ledger.Log, *ledger.ReopenedPeriod, bool, error) {
+	var (
+		log            *ledger.Log
+		ret            *ledger.ReopenedPeriod
+		idempotencyHit bool
+		err            error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		log, ret, idempotencyHit, err = c.Controller.ReopenPeriod(ctx, parameters)
+		return err
+	})
+
+	return log, ret, idempotencyHit, err
+}
+
+func (c *ControllerWithTooManyClientHandling) GetHold(ctx context.Context, id string) (*ledger.Hold, error) {
+	var (
+		hold *ledger.Hold
+		err  error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		hold, err = c.Controller.GetHold(ctx, id)
+		return err
+	})
+
+	return hold, err
+}
+
+func (c *ControllerWithTooManyClientHandling) CreateHold(ctx context.Context, parameters Parameters[CreateHold]) (*ledger.Log, *ledger.CreatedHold, bool, error) {
+	var (
+		log            *ledger.Log
+		ret            *ledger.CreatedHold
+		idempotencyHit bool
+		err            error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		log, ret, idempotencyHit, err = c.Controller.CreateHold(ctx, parameters)
+		return err
+	})
+
+	return log, ret, idempotencyHit, err
+}
+
+func (c *ControllerWithTooManyClientHandling) CaptureHold(ctx context.Context, parameters Parameters[CaptureHold]) (*ledger.Log, *ledger.CapturedHold, bool, error) {
+	var (
+		log            *ledger.Log
+		ret            *ledger.CapturedHold
+		idempotencyHit bool
+		err            error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		log, ret, idempotencyHit, err = c.Controller.CaptureHold(ctx, parameters)
+		return err
+	})
+
+	return log, ret, idempotencyHit, err
+}
+
+func (c *ControllerWithTooManyClientHandling) VoidHold(ctx context.Context, parameters Parameters[VoidHold]) (*ledger.Log, *ledger.VoidedHold, bool, error) {
+	var (
+		log            *ledger.Log
+		ret            *ledger.VoidedHold
+		idempotencyHit bool
+		err            error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		log, ret, idempotencyHit, err = c.Controller.VoidHold(ctx, parameters)
+		return err
+	})
+
+	return log, ret, idempotencyHit, err
+}
+
+func (c *ControllerWithTooManyClientHandling) GetRevertJob(ctx context.Context, id string) (*ledger.RevertJob, error) {
+	var (
+		job *ledger.RevertJob
+		err error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		job, err = c.Controller.GetRevertJob(ctx, id)
+		return err
+	})
+
+	return job, err
+}
+
+func (c *ControllerWithTooManyClientHandling) CreateRevertJob(ctx context.Context, input CreateRevertJob) (*ledger.RevertJob, error) {
+	var (
+		job *ledger.RevertJob
+		err error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		job, err = c.Controller.CreateRevertJob(ctx, input)
+		return err
+	})
+
+	return job, err
+}
+
+func (c *ControllerWithTooManyClientHandling) GetScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	var (
+		scheduledTransaction *ledger.ScheduledTransaction
+		err                  error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		scheduledTransaction, err = c.Controller.GetScheduledTransaction(ctx, id)
+		return err
+	})
+
+	return scheduledTransaction, err
+}
+
+func (c *ControllerWithTooManyClientHandling) ScheduleTransaction(ctx context.Context, input ScheduleTransaction) (*ledger.ScheduledTransaction, error) {
+	var (
+		scheduledTransaction *ledger.ScheduledTransaction
+		err                  error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		scheduledTransaction, err = c.Controller.ScheduleTransaction(ctx, input)
+		return err
+	})
+
+	return scheduledTransaction, err
+}
+
+func (c *ControllerWithTooManyClientHandling) CancelScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	var (
+		scheduledTransaction *ledger.ScheduledTransaction
+		err                  error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		scheduledTransaction, err = c.Controller.CancelScheduledTransaction(ctx, id)
+		return err
+	})
+
+	return scheduledTransaction, err
+}
+
+func (c *ControllerWithTooManyClientHandling) ListRecurrences(ctx context.Context, query common.PaginatedQuery[any]) (*paginate.Cursor[ledger.Recurrence], error) {
+	var (
+		recurrences *paginate.Cursor[ledger.Recurrence]
+		err         error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		recurrences, err = c.Controller.ListRecurrences(ctx, query)
+		return err
+	})
+
+	return recurrences, err
+}
+
+func (c *ControllerWithTooManyClientHandling) GetRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	var (
+		recurrence *ledger.Recurrence
+		err        error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		recurrence, err = c.Controller.GetRecurrence(ctx, id)
+		return err
+	})
+
+	return recurrence, err
+}
+
+func (c *ControllerWithTooManyClientHandling) CreateRecurrence(ctx context.Context, input CreateRecurrence) (*ledger.Recurrence, error) {
+	var (
+		recurrence *ledger.Recurrence
+		err        error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		recurrence, err = c.Controller.CreateRecurrence(ctx, input)
+		return err
+	})
+
+	return recurrence, err
+}
+
+func (c *ControllerWithTooManyClientHandling) PauseRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	var (
+		recurrence *ledger.Recurrence
+		err        error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		recurrence, err = c.Controller.PauseRecurrence(ctx, id)
+		return err
+	})
+
+	return recurrence, err
+}
+
+func (c *ControllerWithTooManyClientHandling) ResumeRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	var (
+		recurrence *ledger.Recurrence
+		err        error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		recurrence, err = c.Controller.ResumeRecurrence(ctx, id)
+		return err
+	})
+
+	return recurrence, err
+}
+
+func (c *ControllerWithTooManyClientHandling) DeleteRecurrence(ctx context.Context, id string) error {
+	return handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		return c.Controller.DeleteRecurrence(ctx, id)
+	})
+}
+
 func (c *ControllerWithTooManyClientHandling) GetSchema(ctx context.Context, version string) (*ledger.Schema, error) {
 	var (
 		schema *ledger.Schema
@@ -173,6 +424,98 @@ func (c *ControllerWithTooManyClientHandling) ListSchemas(ctx context.Context, q
 	return schemas, err
 }
 
+func (c *ControllerWithTooManyClientHandling) DiffSchema(ctx context.Context, version string, data ledger.SchemaData, query common.PaginatedQuery[any]) (*ledger.SchemaDiff, *paginate.Cursor[ledger.Account], error) {
+	var (
+		diff     *ledger.SchemaDiff
+		accounts *paginate.Cursor[ledger.Account]
+		err      error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		diff, accounts, err = c.Controller.DiffSchema(ctx, version, data, query)
+		return err
+	})
+
+	return diff, accounts, err
+}
+
+func (c *ControllerWithTooManyClientHandling) GetChartTree(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) ([]ledger.ChartTreeNode, error) {
+	var (
+		tree []ledger.ChartTreeNode
+		err  error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		tree, err = c.Controller.GetChartTree(ctx, version, query)
+		return err
+	})
+
+	return tree, err
+}
+
+func (c *ControllerWithTooManyClientHandling) GetTrialBalance(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.TrialBalance, error) {
+	var (
+		trialBalance *ledger.TrialBalance
+		err          error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		trialBalance, err = c.Controller.GetTrialBalance(ctx, version, query)
+		return err
+	})
+
+	return trialBalance, err
+}
+
+func (c *ControllerWithTooManyClientHandling) GetBalanceSheet(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.BalanceSheet, error) {
+	var (
+		balanceSheet *ledger.BalanceSheet
+		err          error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		balanceSheet, err = c.Controller.GetBalanceSheet(ctx, version, query)
+		return err
+	})
+
+	return balanceSheet, err
+}
+
+func (c *ControllerWithTooManyClientHandling) GetIncomeStatement(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.IncomeStatement, error) {
+	var (
+		incomeStatement *ledger.IncomeStatement
+		err             error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		incomeStatement, err = c.Controller.GetIncomeStatement(ctx, version, query)
+		return err
+	})
+
+	return incomeStatement, err
+}
+
+func (c *ControllerWithTooManyClientHandling) GetBalanceHistory(ctx context.Context, query common.ResourceQuery[ledger.GetBalanceHistoryOptions]) ([]ledger.BalanceHistoryPoint, error) {
+	var (
+		history []ledger.BalanceHistoryPoint
+		err     error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		history, err = c.Controller.GetBalanceHistory(ctx, query)
+		return err
+	})
+
+	return history, err
+}
+
+func (c *ControllerWithTooManyClientHandling) GetAccountStatement(ctx context.Context, address string, query common.PaginatedQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.AccountStatement, error) {
+	var (
+		statement *ledger.AccountStatement
+		err       error
+	)
+	err = handleRetry(ctx, c.tracer, c.delayCalculator, func(ctx context.Context) error {
+		statement, err = c.Controller.GetAccountStatement(ctx, address, query)
+		return err
+	})
+
+	return statement, err
+}
+
 func (c *ControllerWithTooManyClientHandling) RunQuery(ctx context.Context, schemaVersion string, id string, q common.RunQuery, paginationConfig common.PaginationConfig) (*queries.ResourceKind, *paginate.Cursor[any], error) {
 	var (
 		resource *queries.ResourceKind
@@ -39,6 +39,7 @@ type ControllerWithTraces struct {
 	getStatsHistogram                  metric.Int64Histogram
 	createTransactionHistogram         metric.Int64Histogram
 	revertTransactionHistogram         metric.Int64Histogram
+	partiallyRevertTxHistogram         metric.Int64Histogram
 	saveTransactionMetadataHistogram   metric.Int64Histogram
 	saveAccountMetadataHistogram       metric.Int64Histogram
 	deleteTransactionMetadataHistogram metric.Int64Histogram
@@ -47,6 +48,34 @@ type ControllerWithTraces struct {
 	insertSchemaHistogram              metric.Int64Histogram
 	getSchemaHistogram                 metric.Int64Histogram
 	listSchemasHistogram               metric.Int64Histogram
+	diffSchemaHistogram                metric.Int64Histogram
+	getChartTreeHistogram              metric.Int64Histogram
+	getTrialBalanceHistogram           metric.Int64Histogram
+	getBalanceSheetHistogram           metric.Int64Histogram
+	getIncomeStatementHistogram        metric.Int64Histogram
+	getBalanceHistoryHistogram         metric.Int64Histogram
+	getAccountStatementHistogram       metric.Int64Histogram
+	getClosedPeriodHistogram           metric.Int64Histogram
+	closePeriodHistogram               metric.Int64Histogram
+	reopenPeriodHistogram              metric.Int64Histogram
+	getHoldHistogram                   metric.Int64Histogram
+	createHoldHistogram                metric.Int64Histogram
+	captureHoldHistogram               metric.Int64Histogram
+	voidHoldHistogram                  metric.Int64Histogram
+	getRevertJobHistogram              metric.Int64Histogram
+	createRevertJobHistogram           metric.Int64Histogram
+	runRevertJobHistogram              metric.Int64Histogram
+	getScheduledTxHistogram            metric.Int64Histogram
+	scheduleTransactionHistogram       metric.Int64Histogram
+	cancelScheduledTxHistogram         metric.Int64Histogram
+	executeScheduledTxHistogram        metric.Int64Histogram
+	listRecurrencesHistogram           metric.Int64Histogram
+	getRecurrenceHistogram             metric.Int64Histogram
+	createRecurrenceHistogram          metric.Int64Histogram
+	pauseRecurrenceHistogram           metric.Int64Histogram
+	resumeRecurrenceHistogram          metric.Int64Histogram
+	deleteRecurrenceHistogram          metric.Int64Histogram
+	runRecurrenceHistogram             metric.Int64Histogram
 	runQueryHistogram                  metric.Int64Histogram
 }
 
@@ -133,6 +162,10 @@ func NewControllerWithTraces(underlying Controller, tracer trace.Tracer, meter m
 	if err != nil {
 		panic(err)
 	}
+	ret.partiallyRevertTxHistogram, err = meter.Int64Histogram("controller.partially_revert_transaction", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
 	ret.saveTransactionMetadataHistogram, err = meter.Int64Histogram("controller.save_transaction_metadata", metric.WithUnit("ms"))
 	if err != nil {
 		panic(err)
@@ -165,6 +198,118 @@ func NewControllerWithTraces(underlying Controller, tracer trace.Tracer, meter m
 	if err != nil {
 		panic(err)
 	}
+	ret.diffSchemaHistogram, err = meter.Int64Histogram("controller.diff_schema", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.getChartTreeHistogram, err = meter.Int64Histogram("controller.get_chart_tree", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.getTrialBalanceHistogram, err = meter.Int64Histogram("controller.get_trial_balance", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.getBalanceSheetHistogram, err = meter.Int64Histogram("controller.get_balance_sheet", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.getIncomeStatementHistogram, err = meter.Int64Histogram("controller.get_income_statement", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.getBalanceHistoryHistogram, err = meter.Int64Histogram("controller.get_balance_history", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.getAccountStatementHistogram, err = meter.Int64Histogram("controller.get_account_statement", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.getClosedPeriodHistogram, err = meter.Int64Histogram("controller.get_closed_period", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.closePeriodHistogram, err = meter.Int64Histogram("controller.close_period", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.reopenPeriodHistogram, err = meter.Int64Histogram("controller.reopen_period", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.getHoldHistogram, err = meter.Int64Histogram("controller.get_hold", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.createHoldHistogram, err = meter.Int64Histogram("controller.create_hold", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.captureHoldHistogram, err = meter.Int64Histogram("controller.capture_hold", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.voidHoldHistogram, err = meter.Int64Histogram("controller.void_hold", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.getRevertJobHistogram, err = meter.Int64Histogram("controller.get_revert_job", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.createRevertJobHistogram, err = meter.Int64Histogram("controller.create_revert_job", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.runRevertJobHistogram, err = meter.Int64Histogram("controller.run_revert_job", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.getScheduledTxHistogram, err = meter.Int64Histogram("controller.get_scheduled_transaction", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.scheduleTransactionHistogram, err = meter.Int64Histogram("controller.schedule_transaction", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.cancelScheduledTxHistogram, err = meter.Int64Histogram("controller.cancel_scheduled_transaction", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.executeScheduledTxHistogram, err = meter.Int64Histogram("controller.execute_scheduled_transaction", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.listRecurrencesHistogram, err = meter.Int64Histogram("controller.list_recurrences", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.getRecurrenceHistogram, err = meter.Int64Histogram("controller.get_recurrence", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.createRecurrenceHistogram, err = meter.Int64Histogram("controller.create_recurrence", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.pauseRecurrenceHistogram, err = meter.Int64Histogram("controller.pause_recurrence", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.resumeRecurrenceHistogram, err = meter.Int64Histogram("controller.resume_recurrence", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.deleteRecurrenceHistogram, err = meter.Int64Histogram("controller.delete_recurrence", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+	ret.runRecurrenceHistogram, err = meter.Int64Histogram("controller.run_recurrence", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
 	ret.runQueryHistogram, err = meter.Int64Histogram("controller.run_query", metric.WithUnit("ms"))
 	if err != nil {
 		panic(err)
@@ -430,6 +575,30 @@ func (c *ControllerWithTraces) RevertTransaction(ctx context.Context, parameters
 	return log, revertedTransaction, idempotencyHit, nil
 }
 
+func (c *ControllerWithTraces) PartiallyRevertTransaction(ctx context.Context, parameters Parameters[PartiallyRevertTransaction]) (*ledger.Log, *ledger.PartiallyRevertedTransaction, bool, error) {
+	var (
+		partiallyRevertedTransaction *ledger.PartiallyRevertedTransaction
+		log                          *ledger.Log
+		err                          error
+		idempotencyHit               bool
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"PartiallyRevertTransaction",
+		c.tracer,
+		c.partiallyRevertTxHistogram,
+		func(ctx context.Context) (any, error) {
+			log, partiallyRevertedTransaction, idempotencyHit, err = c.underlying.PartiallyRevertTransaction(ctx, parameters)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	return log, partiallyRevertedTransaction, idempotencyHit, nil
+}
+
 func (c *ControllerWithTraces) SaveTransactionMetadata(ctx context.Context, parameters Parameters[SaveTransactionMetadata]) (*ledger.Log, bool, error) {
 	var (
 		idempotencyHit bool
@@ -589,6 +758,626 @@ func (c *ControllerWithTraces) ListSchemas(ctx context.Context, query common.Pag
 	return schemas, nil
 }
 
+func (c *ControllerWithTraces) DiffSchema(ctx context.Context, version string, data ledger.SchemaData, query common.PaginatedQuery[any]) (*ledger.SchemaDiff, *paginate.Cursor[ledger.Account], error) {
+	var (
+		diff     *ledger.SchemaDiff
+		accounts *paginate.Cursor[ledger.Account]
+		err      error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"DiffSchema",
+		c.tracer,
+		c.diffSchemaHistogram,
+		func(ctx context.Context) (any, error) {
+			diff, accounts, err = c.underlying.DiffSchema(ctx, version, data, query)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return diff, accounts, nil
+}
+
+func (c *ControllerWithTraces) GetChartTree(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) ([]ledger.ChartTreeNode, error) {
+	var (
+		tree []ledger.ChartTreeNode
+		err  error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"GetChartTree",
+		c.tracer,
+		c.getChartTreeHistogram,
+		func(ctx context.Context) (any, error) {
+			tree, err = c.underlying.GetChartTree(ctx, version, query)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+func (c *ControllerWithTraces) GetTrialBalance(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.TrialBalance, error) {
+	var (
+		trialBalance *ledger.TrialBalance
+		err          error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"GetTrialBalance",
+		c.tracer,
+		c.getTrialBalanceHistogram,
+		func(ctx context.Context) (any, error) {
+			trialBalance, err = c.underlying.GetTrialBalance(ctx, version, query)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return trialBalance, nil
+}
+
+func (c *ControllerWithTraces) GetBalanceSheet(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.BalanceSheet, error) {
+	var (
+		balanceSheet *ledger.BalanceSheet
+		err          error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"GetBalanceSheet",
+		c.tracer,
+		c.getBalanceSheetHistogram,
+		func(ctx context.Context) (any, error) {
+			balanceSheet, err = c.underlying.GetBalanceSheet(ctx, version, query)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return balanceSheet, nil
+}
+
+func (c *ControllerWithTraces) GetIncomeStatement(ctx context.Context, version string, query common.ResourceQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.IncomeStatement, error) {
+	var (
+		incomeStatement *ledger.IncomeStatement
+		err             error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"GetIncomeStatement",
+		c.tracer,
+		c.getIncomeStatementHistogram,
+		func(ctx context.Context) (any, error) {
+			incomeStatement, err = c.underlying.GetIncomeStatement(ctx, version, query)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return incomeStatement, nil
+}
+
+func (c *ControllerWithTraces) GetBalanceHistory(ctx context.Context, query common.ResourceQuery[ledger.GetBalanceHistoryOptions]) ([]ledger.BalanceHistoryPoint, error) {
+	var (
+		history []ledger.BalanceHistoryPoint
+		err     error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"GetBalanceHistory",
+		c.tracer,
+		c.getBalanceHistoryHistogram,
+		func(ctx context.Context) (any, error) {
+			history, err = c.underlying.GetBalanceHistory(ctx, query)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+func (c *ControllerWithTraces) GetAccountStatement(ctx context.Context, address string, query common.PaginatedQuery[ledger.GetAggregatedVolumesOptions]) (*ledger.AccountStatement, error) {
+	var (
+		statement *ledger.AccountStatement
+		err       error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"GetAccountStatement",
+		c.tracer,
+		c.getAccountStatementHistogram,
+		func(ctx context.Context) (any, error) {
+			statement, err = c.underlying.GetAccountStatement(ctx, address, query)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return statement, nil
+}
+
+func (c *ControllerWithTraces) GetClosedPeriod(ctx context.Context) (*ledger.ClosedPeriod, error) {
+	var (
+		period *ledger.ClosedPeriod
+		err    error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"GetClosedPeriod",
+		c.tracer,
+		c.getClosedPeriodHistogram,
+		func(ctx context.Context) (any, error) {
+			period, err = c.underlying.GetClosedPeriod(ctx)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return period, nil
+}
+
+func (c *ControllerWithTraces) ClosePeriod(ctx context.Context, parameters Parameters[ClosePeriod]) (*ledger.Log, *ledger.ClosedPeriod, bool, error) {
+	var (
+		closedPeriod   *ledger.ClosedPeriod
+		log            *ledger.Log
+		idempotencyHit bool
+		err            error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"ClosePeriod",
+		c.tracer,
+		c.closePeriodHistogram,
+		func(ctx context.Context) (any, error) {
+			log, closedPeriod, idempotencyHit, err = c.underlying.ClosePeriod(ctx, parameters)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	return log, closedPeriod, idempotencyHit, nil
+}
+
+func (c *ControllerWithTraces) ReopenPeriod(ctx context.Context, parameters Parameters[ReopenPeriod]) (*ledger.Log, *ledger.ReopenedPeriod, bool, error) {
+	var (
+		reopenedPeriod *ledger.ReopenedPeriod
+		log            *ledger.Log
+		idempotencyHit bool
+		err            error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"ReopenPeriod",
+		c.tracer,
+		c.reopenPeriodHistogram,
+		func(ctx context.Context) (any, error) {
+			log, reopenedPeriod, idempotencyHit, err = c.underlying.ReopenPeriod(ctx, parameters)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	return log, reopenedPeriod, idempotencyHit, nil
+}
+
+func (c *ControllerWithTraces) GetHold(ctx context.Context, id string) (*ledger.Hold, error) {
+	var (
+		hold *ledger.Hold
+		err  error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"GetHold",
+		c.tracer,
+		c.getHoldHistogram,
+		func(ctx context.Context) (any, error) {
+			hold, err = c.underlying.GetHold(ctx, id)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+func (c *ControllerWithTraces) CreateHold(ctx context.Context, parameters Parameters[CreateHold]) (*ledger.Log, *ledger.CreatedHold, bool, error) {
+	var (
+		createdHold    *ledger.CreatedHold
+		log            *ledger.Log
+		idempotencyHit bool
+		err            error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"CreateHold",
+		c.tracer,
+		c.createHoldHistogram,
+		func(ctx context.Context) (any, error) {
+			log, createdHold, idempotencyHit, err = c.underlying.CreateHold(ctx, parameters)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	return log, createdHold, idempotencyHit, nil
+}
+
+func (c *ControllerWithTraces) CaptureHold(ctx context.Context, parameters Parameters[CaptureHold]) (*ledger.Log, *ledger.CapturedHold, bool, error) {
+	var (
+		capturedHold   *ledger.CapturedHold
+		log            *ledger.Log
+		idempotencyHit bool
+		err            error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"CaptureHold",
+		c.tracer,
+		c.captureHoldHistogram,
+		func(ctx context.Context) (any, error) {
+			log, capturedHold, idempotencyHit, err = c.underlying.CaptureHold(ctx, parameters)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	return log, capturedHold, idempotencyHit, nil
+}
+
+func (c *ControllerWithTraces) VoidHold(ctx context.Context, parameters Parameters[VoidHold]) (*ledger.Log, *ledger.VoidedHold, bool, error) {
+	var (
+		voidedHold     *ledger.VoidedHold
+		log            *ledger.Log
+		idempotencyHit bool
+		err            error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"VoidHold",
+		c.tracer,
+		c.voidHoldHistogram,
+		func(ctx context.Context) (any, error) {
+			log, voidedHold, idempotencyHit, err = c.underlying.VoidHold(ctx, parameters)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	return log, voidedHold, idempotencyHit, nil
+}
+
+func (c *ControllerWithTraces) GetRevertJob(ctx context.Context, id string) (*ledger.RevertJob, error) {
+	var (
+		job *ledger.RevertJob
+		err error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"GetRevertJob",
+		c.tracer,
+		c.getRevertJobHistogram,
+		func(ctx context.Context) (any, error) {
+			job, err = c.underlying.GetRevertJob(ctx, id)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (c *ControllerWithTraces) CreateRevertJob(ctx context.Context, input CreateRevertJob) (*ledger.RevertJob, error) {
+	var (
+		job *ledger.RevertJob
+		err error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"CreateRevertJob",
+		c.tracer,
+		c.createRevertJobHistogram,
+		func(ctx context.Context) (any, error) {
+			job, err = c.underlying.CreateRevertJob(ctx, input)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (c *ControllerWithTraces) RunRevertJob(ctx context.Context, id string, batchSize int) (*ledger.RevertJob, []ledger.RevertedTransaction, error) {
+	var (
+		job                  *ledger.RevertJob
+		revertedTransactions []ledger.RevertedTransaction
+		err                  error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"RunRevertJob",
+		c.tracer,
+		c.runRevertJobHistogram,
+		func(ctx context.Context) (any, error) {
+			job, revertedTransactions, err = c.underlying.RunRevertJob(ctx, id, batchSize)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return job, revertedTransactions, nil
+}
+
+func (c *ControllerWithTraces) GetScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	var (
+		scheduledTransaction *ledger.ScheduledTransaction
+		err                  error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"GetScheduledTransaction",
+		c.tracer,
+		c.getScheduledTxHistogram,
+		func(ctx context.Context) (any, error) {
+			scheduledTransaction, err = c.underlying.GetScheduledTransaction(ctx, id)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduledTransaction, nil
+}
+
+func (c *ControllerWithTraces) ScheduleTransaction(ctx context.Context, input ScheduleTransaction) (*ledger.ScheduledTransaction, error) {
+	var (
+		scheduledTransaction *ledger.ScheduledTransaction
+		err                  error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"ScheduleTransaction",
+		c.tracer,
+		c.scheduleTransactionHistogram,
+		func(ctx context.Context) (any, error) {
+			scheduledTransaction, err = c.underlying.ScheduleTransaction(ctx, input)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduledTransaction, nil
+}
+
+func (c *ControllerWithTraces) CancelScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	var (
+		scheduledTransaction *ledger.ScheduledTransaction
+		err                  error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"CancelScheduledTransaction",
+		c.tracer,
+		c.cancelScheduledTxHistogram,
+		func(ctx context.Context) (any, error) {
+			scheduledTransaction, err = c.underlying.CancelScheduledTransaction(ctx, id)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduledTransaction, nil
+}
+
+func (c *ControllerWithTraces) ExecuteScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, *ledger.CreatedTransaction, error) {
+	var (
+		scheduledTransaction *ledger.ScheduledTransaction
+		createdTransaction   *ledger.CreatedTransaction
+		err                  error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"ExecuteScheduledTransaction",
+		c.tracer,
+		c.executeScheduledTxHistogram,
+		func(ctx context.Context) (any, error) {
+			scheduledTransaction, createdTransaction, err = c.underlying.ExecuteScheduledTransaction(ctx, id)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return scheduledTransaction, createdTransaction, nil
+}
+
+func (c *ControllerWithTraces) ListRecurrences(ctx context.Context, query common.PaginatedQuery[any]) (*paginate.Cursor[ledger.Recurrence], error) {
+	var (
+		recurrences *paginate.Cursor[ledger.Recurrence]
+		err         error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"ListRecurrences",
+		c.tracer,
+		c.listRecurrencesHistogram,
+		func(ctx context.Context) (any, error) {
+			recurrences, err = c.underlying.ListRecurrences(ctx, query)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return recurrences, nil
+}
+
+func (c *ControllerWithTraces) GetRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	var (
+		recurrence *ledger.Recurrence
+		err        error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"GetRecurrence",
+		c.tracer,
+		c.getRecurrenceHistogram,
+		func(ctx context.Context) (any, error) {
+			recurrence, err = c.underlying.GetRecurrence(ctx, id)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return recurrence, nil
+}
+
+func (c *ControllerWithTraces) CreateRecurrence(ctx context.Context, input CreateRecurrence) (*ledger.Recurrence, error) {
+	var (
+		recurrence *ledger.Recurrence
+		err        error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"CreateRecurrence",
+		c.tracer,
+		c.createRecurrenceHistogram,
+		func(ctx context.Context) (any, error) {
+			recurrence, err = c.underlying.CreateRecurrence(ctx, input)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return recurrence, nil
+}
+
+func (c *ControllerWithTraces) PauseRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	var (
+		recurrence *ledger.Recurrence
+		err        error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"PauseRecurrence",
+		c.tracer,
+		c.pauseRecurrenceHistogram,
+		func(ctx context.Context) (any, error) {
+			recurrence, err = c.underlying.PauseRecurrence(ctx, id)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return recurrence, nil
+}
+
+func (c *ControllerWithTraces) ResumeRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	var (
+		recurrence *ledger.Recurrence
+		err        error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"ResumeRecurrence",
+		c.tracer,
+		c.resumeRecurrenceHistogram,
+		func(ctx context.Context) (any, error) {
+			recurrence, err = c.underlying.ResumeRecurrence(ctx, id)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return recurrence, nil
+}
+
+func (c *ControllerWithTraces) DeleteRecurrence(ctx context.Context, id string) error {
+	return tracing.SkipResult(tracing.TraceWithMetric(
+		ctx,
+		"DeleteRecurrence",
+		c.tracer,
+		c.deleteRecurrenceHistogram,
+		tracing.NoResult(func(ctx context.Context) error {
+			return c.underlying.DeleteRecurrence(ctx, id)
+		}),
+	))
+}
+
+func (c *ControllerWithTraces) RunRecurrence(ctx context.Context, id string, batchSize int) (*ledger.Recurrence, []ledger.CreatedTransaction, error) {
+	var (
+		recurrence          *ledger.Recurrence
+		createdTransactions []ledger.CreatedTransaction
+		err                 error
+	)
+	_, err = tracing.TraceWithMetric(
+		ctx,
+		"RunRecurrence",
+		c.tracer,
+		c.runRecurrenceHistogram,
+		func(ctx context.Context) (any, error) {
+			recurrence, createdTransactions, err = c.underlying.RunRecurrence(ctx, id, batchSize)
+			return nil, err
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return recurrence, createdTransactions, nil
+}
+
 func (c *ControllerWithTraces) RunQuery(ctx context.Context, schemaVersion string, id string, query common.RunQuery, paginationConfig common.PaginationConfig) (*queries.ResourceKind, *paginate.Cursor[any], error) {
 	var (
 		resource *queries.ResourceKind
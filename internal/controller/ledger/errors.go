@@ -6,8 +6,10 @@ import (
 	"fmt"
 
 	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
 	"github.com/formancehq/numscript"
 
+	ledger "github.com/formancehq/ledger/internal"
 	"github.com/formancehq/ledger/internal/machine"
 )
 
@@ -84,6 +86,8 @@ func newErrInvalidHash(logID uint64, got, expected []byte) ErrImport {
 // notes(gfyrag): Waiting new interpreter
 type ErrInsufficientFunds = machine.ErrInsufficientFund
 
+type ErrAccountRuleViolation = ledger.ErrAccountRuleViolation
+
 var ErrNoPostings = errors.New("numscript execution returned no postings")
 
 type ErrAlreadyReverted struct {
@@ -341,3 +345,222 @@ func newErrQueryValidation(err error) ErrQueryValidation {
 		err,
 	}
 }
+
+type ErrPeriodClosed struct {
+	date        time.Time
+	closedUntil time.Time
+}
+
+func (e ErrPeriodClosed) Error() string {
+	return fmt.Sprintf("cannot write at %s, periods are closed until %s", e.date, e.closedUntil)
+}
+
+func (e ErrPeriodClosed) Is(err error) bool {
+	_, ok := err.(ErrPeriodClosed)
+	return ok
+}
+
+func newErrPeriodClosed(date, closedUntil time.Time) ErrPeriodClosed {
+	return ErrPeriodClosed{
+		date:        date,
+		closedUntil: closedUntil,
+	}
+}
+
+type ErrInvalidPeriod struct {
+	err error
+}
+
+func (e ErrInvalidPeriod) Error() string {
+	return fmt.Sprintf("invalid period: %s", e.err)
+}
+
+func (e ErrInvalidPeriod) Is(err error) bool {
+	_, ok := err.(ErrInvalidPeriod)
+	return ok
+}
+
+func newErrInvalidPeriod(err error) ErrInvalidPeriod {
+	return ErrInvalidPeriod{
+		err: err,
+	}
+}
+
+type ErrInvalidHold struct {
+	err error
+}
+
+func (e ErrInvalidHold) Error() string {
+	return fmt.Sprintf("invalid hold: %s", e.err)
+}
+
+func (e ErrInvalidHold) Is(err error) bool {
+	_, ok := err.(ErrInvalidHold)
+	return ok
+}
+
+func newErrInvalidHold(err error) ErrInvalidHold {
+	return ErrInvalidHold{
+		err: err,
+	}
+}
+
+type ErrHoldNotPending struct {
+	id     string
+	status ledger.HoldStatus
+}
+
+func (e ErrHoldNotPending) Error() string {
+	return fmt.Sprintf("hold %s is not pending, its status is %s", e.id, e.status)
+}
+
+func (e ErrHoldNotPending) Is(err error) bool {
+	_, ok := err.(ErrHoldNotPending)
+	return ok
+}
+
+func newErrHoldNotPending(id string, status ledger.HoldStatus) ErrHoldNotPending {
+	return ErrHoldNotPending{
+		id:     id,
+		status: status,
+	}
+}
+
+type ErrHoldExpired struct {
+	id        string
+	expiresAt time.Time
+}
+
+func (e ErrHoldExpired) Error() string {
+	return fmt.Sprintf("hold %s has expired at %s", e.id, e.expiresAt)
+}
+
+func (e ErrHoldExpired) Is(err error) bool {
+	_, ok := err.(ErrHoldExpired)
+	return ok
+}
+
+func newErrHoldExpired(id string, expiresAt time.Time) ErrHoldExpired {
+	return ErrHoldExpired{
+		id:        id,
+		expiresAt: expiresAt,
+	}
+}
+
+type ErrInvalidPartialRevert struct {
+	err error
+}
+
+func (e ErrInvalidPartialRevert) Error() string {
+	return fmt.Sprintf("invalid partial revert: %s", e.err)
+}
+
+func (e ErrInvalidPartialRevert) Is(err error) bool {
+	_, ok := err.(ErrInvalidPartialRevert)
+	return ok
+}
+
+func newErrInvalidPartialRevert(err error) ErrInvalidPartialRevert {
+	return ErrInvalidPartialRevert{
+		err: err,
+	}
+}
+
+type ErrInvalidScheduledTransaction struct {
+	err error
+}
+
+func (e ErrInvalidScheduledTransaction) Error() string {
+	return fmt.Sprintf("invalid scheduled transaction: %s", e.err)
+}
+
+func (e ErrInvalidScheduledTransaction) Is(err error) bool {
+	_, ok := err.(ErrInvalidScheduledTransaction)
+	return ok
+}
+
+func newErrInvalidScheduledTransaction(err error) ErrInvalidScheduledTransaction {
+	return ErrInvalidScheduledTransaction{
+		err: err,
+	}
+}
+
+type ErrScheduledTransactionNotPending struct {
+	id     string
+	status ledger.ScheduledTransactionStatus
+}
+
+func (e ErrScheduledTransactionNotPending) Error() string {
+	return fmt.Sprintf("scheduled transaction %s is not pending, its status is %s", e.id, e.status)
+}
+
+func (e ErrScheduledTransactionNotPending) Is(err error) bool {
+	_, ok := err.(ErrScheduledTransactionNotPending)
+	return ok
+}
+
+func newErrScheduledTransactionNotPending(id string, status ledger.ScheduledTransactionStatus) ErrScheduledTransactionNotPending {
+	return ErrScheduledTransactionNotPending{
+		id:     id,
+		status: status,
+	}
+}
+
+type ErrInvalidRecurrence struct {
+	err error
+}
+
+func (e ErrInvalidRecurrence) Error() string {
+	return fmt.Sprintf("invalid recurrence: %s", e.err)
+}
+
+func (e ErrInvalidRecurrence) Is(err error) bool {
+	_, ok := err.(ErrInvalidRecurrence)
+	return ok
+}
+
+func newErrInvalidRecurrence(err error) ErrInvalidRecurrence {
+	return ErrInvalidRecurrence{
+		err: err,
+	}
+}
+
+type ErrRecurrenceCompleted struct {
+	id string
+}
+
+func (e ErrRecurrenceCompleted) Error() string {
+	return fmt.Sprintf("recurrence %s is completed, its schedule has no more occurrence", e.id)
+}
+
+func (e ErrRecurrenceCompleted) Is(err error) bool {
+	_, ok := err.(ErrRecurrenceCompleted)
+	return ok
+}
+
+func newErrRecurrenceCompleted(id string) ErrRecurrenceCompleted {
+	return ErrRecurrenceCompleted{
+		id: id,
+	}
+}
+
+// ErrPreconditionFailed denotes a write whose preconditions were not met,
+// the state read by the client has changed in between
+type ErrPreconditionFailed struct {
+	reason string
+}
+
+func (e ErrPreconditionFailed) Error() string {
+	return fmt.Sprintf("precondition failed: %s", e.reason)
+}
+
+func (e ErrPreconditionFailed) Is(err error) bool {
+	_, ok := err.(ErrPreconditionFailed)
+	return ok
+}
+
+func newErrPreconditionFailed(format string, args ...any) ErrPreconditionFailed {
+	return ErrPreconditionFailed{
+		reason: fmt.Sprintf(format, args...),
+	}
+}
@@ -76,6 +76,18 @@ func (mr *MockListenerMockRecorder) InsertedSchema(ctx, arg1, data any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertedSchema", reflect.TypeOf((*MockListener)(nil).InsertedSchema), ctx, arg1, data)
 }
 
+// PartiallyRevertedTransaction mocks base method.
+func (m *MockListener) PartiallyRevertedTransaction(ctx context.Context, arg1 string, reverted, revert ledger.Transaction) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PartiallyRevertedTransaction", ctx, arg1, reverted, revert)
+}
+
+// PartiallyRevertedTransaction indicates an expected call of PartiallyRevertedTransaction.
+func (mr *MockListenerMockRecorder) PartiallyRevertedTransaction(ctx, arg1, reverted, revert any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PartiallyRevertedTransaction", reflect.TypeOf((*MockListener)(nil).PartiallyRevertedTransaction), ctx, arg1, reverted, revert)
+}
+
 // RevertedTransaction mocks base method.
 func (m *MockListener) RevertedTransaction(ctx context.Context, arg1 string, reverted, revert ledger.Transaction) {
 	m.ctrl.T.Helper()
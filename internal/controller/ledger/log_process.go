@@ -21,13 +21,16 @@ type logProcessor[INPUT any, OUTPUT ledger.LogPayload] struct {
 	deadLockCounter       metric.Int64Counter
 	operation             string
 	schemaEnforcementMode SchemaEnforcementMode
+	// defaultSchemaVersion is used by the writes needing a schema when none is specified
+	defaultSchemaVersion string
 }
 
-func newLogProcessor[INPUT any, OUTPUT ledger.LogPayload](operation string, deadlockCounter metric.Int64Counter, schemaEnforcementMode SchemaEnforcementMode) *logProcessor[INPUT, OUTPUT] {
+func newLogProcessor[INPUT any, OUTPUT ledger.LogPayload](operation string, deadlockCounter metric.Int64Counter, schemaEnforcementMode SchemaEnforcementMode, defaultSchemaVersion string) *logProcessor[INPUT, OUTPUT] {
 	return &logProcessor[INPUT, OUTPUT]{
 		operation:             operation,
 		deadLockCounter:       deadlockCounter,
 		schemaEnforcementMode: schemaEnforcementMode,
+		defaultSchemaVersion:  defaultSchemaVersion,
 	}
 }
 
@@ -71,6 +74,11 @@ func (lp *logProcessor[INPUT, OUTPUT]) runLog(
 	fn func(ctx context.Context, sqlTX Store, schema *ledger.Schema, parameters Parameters[INPUT]) (*OUTPUT, error),
 ) (*ledger.Log, *OUTPUT, error) {
 
+	var payload OUTPUT
+	if parameters.SchemaVersion == "" && payload.NeedsSchema() {
+		parameters.SchemaVersion = lp.defaultSchemaVersion
+	}
+
 	var schema *ledger.Schema
 	if parameters.SchemaVersion != "" {
 		var err error
@@ -85,25 +93,26 @@ func (lp *logProcessor[INPUT, OUTPUT]) runLog(
 			}
 			return nil, nil, err
 		}
-	} else {
-		var payload OUTPUT
-		if payload.NeedsSchema() {
-			// Only allow a missing schema validation if the ledger doesn't have one
-			latestVersion, err := store.FindLatestSchemaVersion(ctx)
-			if err != nil {
-				return nil, nil, err
-			}
-			if latestVersion != nil {
-				if lp.schemaEnforcementMode == SchemaEnforcementStrict {
-					return nil, nil, newErrSchemaNotSpecified(*latestVersion)
-				} else {
-					trace.SpanFromContext(ctx).SetAttributes(attribute.Bool("schema_not_specified", true))
-					logging.FromContext(ctx).Error("schema not specified")
-				}
+	} else if payload.NeedsSchema() && lp.schemaEnforcementMode != SchemaEnforcementOff {
+		// Only allow a missing schema validation if the ledger doesn't have one
+		latestVersion, err := store.FindLatestSchemaVersion(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if latestVersion != nil {
+			if lp.schemaEnforcementMode == SchemaEnforcementStrict {
+				return nil, nil, newErrSchemaNotSpecified(*latestVersion)
+			} else {
+				trace.SpanFromContext(ctx).SetAttributes(attribute.Bool("schema_not_specified", true))
+				logging.FromContext(ctx).Error("schema not specified")
 			}
 		}
 	}
 
+	if err := parameters.Preconditions.checkEntities(ctx, store); err != nil {
+		return nil, nil, err
+	}
+
 	output, err := fn(ctx, store, schema, parameters)
 	if err != nil {
 		return nil, nil, err
@@ -113,7 +122,7 @@ func (lp *logProcessor[INPUT, OUTPUT]) runLog(
 	log.IdempotencyHash = ledger.ComputeIdempotencyHash(parameters.Input)
 	log.SchemaVersion = parameters.SchemaVersion
 
-	if schema != nil {
+	if schema != nil && lp.schemaEnforcementMode != SchemaEnforcementOff {
 		if err := log.ValidateWithSchema(*schema); err != nil {
 			err := newErrSchemaValidationError(parameters.SchemaVersion, err)
 			if lp.schemaEnforcementMode == SchemaEnforcementStrict {
@@ -125,6 +134,10 @@ func (lp *logProcessor[INPUT, OUTPUT]) runLog(
 		}
 	}
 
+	if err := parameters.Preconditions.checkLastLog(ctx, store); err != nil {
+		return nil, nil, err
+	}
+
 	err = store.InsertLog(ctx, &log)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to insert log: %w", err)
@@ -2,6 +2,7 @@ package ledger
 
 import (
 	"context"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -12,6 +13,7 @@ import (
 	logging "github.com/formancehq/go-libs/v5/pkg/observe/log"
 	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
 	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
 
 	ledger "github.com/formancehq/ledger/internal"
 	ledgerstore "github.com/formancehq/ledger/internal/storage/ledger"
@@ -60,7 +62,7 @@ func TestForgeLogWithIKConflict(t *testing.T) {
 			Data: ledger.CreatedTransaction{},
 		}, nil)
 
-	lp := newLogProcessor[RunScript, ledger.CreatedTransaction]("foo", noop.Int64Counter{}, SchemaEnforcementAudit)
+	lp := newLogProcessor[RunScript, ledger.CreatedTransaction]("foo", noop.Int64Counter{}, SchemaEnforcementAudit, "")
 	_, _, _, err := lp.forgeLog(ctx, store, Parameters[RunScript]{
 		IdempotencyKey: "foo",
 	}, func(ctx context.Context, store Store, schema *ledger.Schema, parameters Parameters[RunScript]) (*ledger.CreatedTransaction, error) {
@@ -110,7 +112,7 @@ func TestForgeLogWithDeadlock(t *testing.T) {
 		Return(nil)
 
 	firstCall := true
-	lp := newLogProcessor[RunScript, ledger.CreatedTransaction]("foo", noop.Int64Counter{}, SchemaEnforcementAudit)
+	lp := newLogProcessor[RunScript, ledger.CreatedTransaction]("foo", noop.Int64Counter{}, SchemaEnforcementAudit, "")
 	_, _, _, err := lp.forgeLog(ctx, store, Parameters[RunScript]{}, func(ctx context.Context, store Store, schema *ledger.Schema, parameters Parameters[RunScript]) (*ledger.CreatedTransaction, error) {
 		if firstCall {
 			firstCall = false
@@ -120,3 +122,150 @@ func TestForgeLogWithDeadlock(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestForgeLogWithPreconditions(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	type testCase struct {
+		name          string
+		preconditions Preconditions
+		expect        func(store *MockStore)
+		expectedError bool
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "all met",
+			preconditions: Preconditions{
+				Balances: map[string]map[string]*big.Int{
+					"bank": {"USD": big.NewInt(100)},
+				},
+				AccountVersions:     map[string]time.Time{"bank": now},
+				TransactionVersions: map[uint64]time.Time{1: now},
+				LastLogID:           pointer.For(uint64(10)),
+			},
+			expect: func(store *MockStore) {
+				store.EXPECT().
+					LockBalances(gomock.Any(), ledgerstore.BalanceQuery{"bank": {"USD"}}).
+					Return(ledger.Balances{"bank": {"USD": big.NewInt(100)}}, nil)
+				store.EXPECT().
+					LockAccount(gomock.Any(), "bank").
+					Return(&ledger.Account{Address: "bank", UpdatedAt: now}, nil)
+				store.EXPECT().
+					LockTransaction(gomock.Any(), uint64(1)).
+					Return(&ledger.Transaction{UpdatedAt: now}, nil)
+				store.EXPECT().
+					LockLastLogID(gomock.Any()).
+					Return(pointer.For(uint64(10)), nil)
+			},
+		},
+		{
+			name: "balance changed",
+			preconditions: Preconditions{
+				Balances: map[string]map[string]*big.Int{
+					"bank": {"USD": big.NewInt(100)},
+				},
+			},
+			expect: func(store *MockStore) {
+				store.EXPECT().
+					LockBalances(gomock.Any(), ledgerstore.BalanceQuery{"bank": {"USD"}}).
+					Return(ledger.Balances{"bank": {"USD": big.NewInt(50)}}, nil)
+			},
+			expectedError: true,
+		},
+		{
+			name: "account updated",
+			preconditions: Preconditions{
+				AccountVersions: map[string]time.Time{"bank": now},
+			},
+			expect: func(store *MockStore) {
+				store.EXPECT().
+					LockAccount(gomock.Any(), "bank").
+					Return(&ledger.Account{Address: "bank", UpdatedAt: now.Add(time.Second)}, nil)
+			},
+			expectedError: true,
+		},
+		{
+			name: "account not existing",
+			preconditions: Preconditions{
+				AccountVersions: map[string]time.Time{"bank": now},
+			},
+			expect: func(store *MockStore) {
+				store.EXPECT().
+					LockAccount(gomock.Any(), "bank").
+					Return(nil, postgres.ErrNotFound)
+			},
+			expectedError: true,
+		},
+		{
+			name: "transaction updated",
+			preconditions: Preconditions{
+				TransactionVersions: map[uint64]time.Time{1: now},
+			},
+			expect: func(store *MockStore) {
+				store.EXPECT().
+					LockTransaction(gomock.Any(), uint64(1)).
+					Return(&ledger.Transaction{UpdatedAt: now.Add(time.Second)}, nil)
+			},
+			expectedError: true,
+		},
+		{
+			name: "new log inserted",
+			preconditions: Preconditions{
+				LastLogID: pointer.For(uint64(10)),
+			},
+			expect: func(store *MockStore) {
+				store.EXPECT().
+					LockLastLogID(gomock.Any()).
+					Return(pointer.For(uint64(11)), nil)
+			},
+			expectedError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := logging.TestingContext()
+			ctrl := gomock.NewController(t)
+			store := NewMockStore(ctrl)
+
+			store.EXPECT().
+				BeginTX(gomock.Any(), gomock.Any()).
+				Return(store, &bun.Tx{}, nil)
+			store.EXPECT().
+				FindLatestSchemaVersion(gomock.Any()).
+				Return(nil, nil)
+			tc.expect(store)
+
+			if tc.expectedError {
+				store.EXPECT().
+					Rollback(gomock.Any()).
+					Return(nil)
+			} else {
+				store.EXPECT().
+					InsertLog(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, log *ledger.Log) error {
+						log.ID = pointer.For(uint64(11))
+						return nil
+					})
+				store.EXPECT().
+					Commit(gomock.Any()).
+					Return(nil)
+			}
+
+			lp := newLogProcessor[RunScript, ledger.CreatedTransaction]("foo", noop.Int64Counter{}, SchemaEnforcementAudit, "")
+			_, _, _, err := lp.forgeLog(ctx, store, Parameters[RunScript]{
+				Preconditions: tc.preconditions,
+			}, func(ctx context.Context, store Store, schema *ledger.Schema, parameters Parameters[RunScript]) (*ledger.CreatedTransaction, error) {
+				return &ledger.CreatedTransaction{}, nil
+			})
+			if tc.expectedError {
+				require.ErrorIs(t, err, ErrPreconditionFailed{})
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -234,6 +234,21 @@ func (m *MockPaginatedResource[ResourceType, OptionsType]) EXPECT() *MockPaginat
 	return m.recorder
 }
 
+// Aggregate mocks base method.
+func (m *MockPaginatedResource[ResourceType, OptionsType]) Aggregate(ctx context.Context, query common.AggregatedQuery[OptionsType]) (*paginate.Cursor[queries.AggregationGroup], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Aggregate", ctx, query)
+	ret0, _ := ret[0].(*paginate.Cursor[queries.AggregationGroup])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Aggregate indicates an expected call of Aggregate.
+func (mr *MockPaginatedResourceMockRecorder[ResourceType, OptionsType]) Aggregate(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aggregate", reflect.TypeOf((*MockPaginatedResource[ResourceType, OptionsType])(nil).Aggregate), ctx, query)
+}
+
 // Count mocks base method.
 func (m *MockPaginatedResource[ResourceType, OptionsType]) Count(ctx context.Context, query common.ResourceQuery[OptionsType]) (int, error) {
 	m.ctrl.T.Helper()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/formancehq/go-libs/v5/pkg/types/collections"
 	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
@@ -23,17 +24,33 @@ type NumscriptExecutionResult struct {
 
 //go:generate mockgen -write_source_comment=false -write_package_comment=false -source numscript_runtime.go -destination numscript_runtime_generated_test.go -package ledger . NumscriptRuntime
 type NumscriptRuntime interface {
-	Execute(context.Context, Store, map[string]string) (*NumscriptExecutionResult, error)
+	// Execute runs the script, the overdraft rules of the chart of accounts of the schema, if any,
+	// apply to the sources of the script: the bounded ones replace the overdrafts allowed by the script,
+	// and the sources with an unbounded overdraft provide the missing funds
+	Execute(ctx context.Context, store Store, vars map[string]string, schema *ledger.Schema) (*NumscriptExecutionResult, error)
 }
 
 type MachineNumscriptRuntimeAdapter struct {
 	program program.Program
 }
 
-func (d *MachineNumscriptRuntimeAdapter) Execute(ctx context.Context, store Store, vars map[string]string) (*NumscriptExecutionResult, error) {
+func (d *MachineNumscriptRuntimeAdapter) Execute(ctx context.Context, store Store, vars map[string]string, schema *ledger.Schema) (*NumscriptExecutionResult, error) {
 	storeAdapter := newVmStoreAdapter(store)
 
 	machineInstance := vm.NewMachine(d.program)
+	if schema != nil {
+		machineInstance.Overdrafts = func(account machine.AccountAddress, asset machine.Asset) (*machine.MonetaryInt, bool) {
+			rule := overdraftRule(schema, string(account), string(asset))
+			switch {
+			case rule == nil:
+				return nil, false
+			case rule.Unbounded:
+				return nil, true
+			default:
+				return machine.NewMonetaryIntFromBigInt(new(big.Int).Neg(rule.Floor())), true
+			}
+		}
+	}
 
 	// notes(gfyrag): machines modify the map, copy it to keep our original parameters unchanged
 	varsCopy := make(map[string]string)
@@ -101,9 +118,31 @@ func NewDefaultInterpreterMachineAdapter(parseResult numscript.ParseResult, feat
 	}
 }
 
-func (d *DefaultInterpreterMachineAdapter) Execute(ctx context.Context, store Store, vars map[string]string) (*NumscriptExecutionResult, error) {
-	execResult, err := d.parseResult.RunWithFeatureFlags(ctx, vars, newNumscriptRewriteAdapter(store), d.featureFlags)
-	if err != nil {
+// maxInterpreterRuns limits the runs of a script needing funds from sources allowed an unbounded overdraft by the chart
+const maxInterpreterRuns = 10
+
+// Execute runs the script with the interpreter, which has no hook for the overdrafts: the overdrafts allowed by the chart
+// of accounts are added to the balances read by the script. The bounded ones are known upfront, while the amounts missing
+// to the script are added to the sources with an unbounded overdraft before running the script again.
+func (d *DefaultInterpreterMachineAdapter) Execute(ctx context.Context, store Store, vars map[string]string, schema *ledger.Schema) (*NumscriptExecutionResult, error) {
+	storeAdapter := newNumscriptRewriteAdapter(store, schema)
+
+	var (
+		execResult numscript.ExecutionResult
+		err        numscript.InterpreterError
+	)
+	for run := 1; ; run++ {
+		execResult, err = d.parseResult.RunWithFeatureFlags(ctx, vars, storeAdapter, d.featureFlags)
+		if err == nil {
+			break
+		}
+
+		missingFunds := numscript.MissingFundsErr{}
+		if run < maxInterpreterRuns && errors.As(err, &missingFunds) &&
+			storeAdapter.addUnboundedOverdraft(missingFunds.Asset, new(big.Int).Sub(&missingFunds.Needed, &missingFunds.Available)) {
+			continue
+		}
+
 		return nil, ErrRuntime{
 			Source:           d.parseResult.GetSource(),
 			InterpreterError: err,
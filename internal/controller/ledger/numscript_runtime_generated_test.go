@@ -11,6 +11,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	ledger "github.com/formancehq/ledger/internal"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -39,16 +40,16 @@ func (m *MockNumscriptRuntime) EXPECT() *MockNumscriptRuntimeMockRecorder {
 }
 
 // Execute mocks base method.
-func (m *MockNumscriptRuntime) Execute(arg0 context.Context, arg1 Store, arg2 map[string]string) (*NumscriptExecutionResult, error) {
+func (m *MockNumscriptRuntime) Execute(ctx context.Context, store Store, vars map[string]string, schema *ledger.Schema) (*NumscriptExecutionResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Execute", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "Execute", ctx, store, vars, schema)
 	ret0, _ := ret[0].(*NumscriptExecutionResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Execute indicates an expected call of Execute.
-func (mr *MockNumscriptRuntimeMockRecorder) Execute(arg0, arg1, arg2 any) *gomock.Call {
+func (mr *MockNumscriptRuntimeMockRecorder) Execute(ctx, store, vars, schema any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockNumscriptRuntime)(nil).Execute), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockNumscriptRuntime)(nil).Execute), ctx, store, vars, schema)
 }
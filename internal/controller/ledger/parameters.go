@@ -5,4 +5,6 @@ type Parameters[INPUT any] struct {
 	IdempotencyKey string
 	Input          INPUT
 	SchemaVersion  string
+	// Preconditions are checked in the SQL transaction of the write, before the write itself
+	Preconditions Preconditions
 }
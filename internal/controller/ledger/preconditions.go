@@ -0,0 +1,103 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"math/big"
+	"slices"
+
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledgerstore "github.com/formancehq/ledger/internal/storage/ledger"
+)
+
+// Preconditions allow optimistic concurrency on writes: the client reads a state, computes its write from it,
+// and the write fails with ErrPreconditionFailed if the state changed in between.
+// The entities involved are locked until the end of the write, so they can't change between the check and the commit.
+type Preconditions struct {
+	// Balances are the expected balances (input - output) by account and asset, as returned by the api.
+	// Unlike the funds checks, the amounts of the pending holds are not deducted from the balances.
+	Balances map[string]map[string]*big.Int `json:"balances,omitempty"`
+	// AccountVersions are the expected dates of the last update of accounts (the field `updatedAt` of an account)
+	AccountVersions map[string]time.Time `json:"accountVersions,omitempty"`
+	// TransactionVersions are the expected dates of the last update of transactions (the field `updatedAt` of a transaction)
+	TransactionVersions map[uint64]time.Time `json:"transactionVersions,omitempty"`
+	// LastLogID is the expected id of the last log of the ledger.
+	// It requires the logs to be hashed synchronously (feature HASH_LOGS=SYNC, the default),
+	// as the other writes take the lock of the logs only in this case.
+	LastLogID *uint64 `json:"lastLogId,omitempty"`
+}
+
+// checkEntities checks the preconditions on balances and versions, it has to be called before the write modifies them.
+func (p Preconditions) checkEntities(ctx context.Context, store Store) error {
+	if len(p.Balances) > 0 {
+		query := ledgerstore.BalanceQuery{}
+		for account, assets := range p.Balances {
+			query[account] = slices.Sorted(maps.Keys(assets))
+		}
+		balances, err := store.LockBalances(ctx, query)
+		if err != nil {
+			return err
+		}
+		for _, account := range slices.Sorted(maps.Keys(p.Balances)) {
+			for _, asset := range slices.Sorted(maps.Keys(p.Balances[account])) {
+				expected := p.Balances[account][asset]
+				if balance := balances[account][asset]; expected == nil || balance.Cmp(expected) != 0 {
+					return newErrPreconditionFailed("balance of account %s for asset %s is %s, expected %s", account, asset, balance, expected)
+				}
+			}
+		}
+	}
+
+	// entities are locked in a stable order to limit deadlocks
+	for _, address := range slices.Sorted(maps.Keys(p.AccountVersions)) {
+		account, err := store.LockAccount(ctx, address)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return newErrPreconditionFailed("account %s does not exist", address)
+			}
+			return err
+		}
+		if expected := p.AccountVersions[address]; !account.UpdatedAt.Equal(expected) {
+			return newErrPreconditionFailed("account %s was updated at %s, expected %s", address, account.UpdatedAt, expected)
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(p.TransactionVersions)) {
+		tx, err := store.LockTransaction(ctx, id)
+		if err != nil {
+			if errors.Is(err, postgres.ErrNotFound) {
+				return newErrPreconditionFailed("transaction %d does not exist", id)
+			}
+			return err
+		}
+		if expected := p.TransactionVersions[id]; !tx.UpdatedAt.Equal(expected) {
+			return newErrPreconditionFailed("transaction %d was updated at %s, expected %s", id, tx.UpdatedAt, expected)
+		}
+	}
+
+	return nil
+}
+
+// checkLastLog checks the precondition on the last log, it has to be called right before the insertion of the log
+// as it takes the lock of the logs, which is taken after the locks of the entities by the other writes.
+func (p Preconditions) checkLastLog(ctx context.Context, store Store) error {
+	if p.LastLogID == nil {
+		return nil
+	}
+
+	lastLogID, err := store.LockLastLogID(ctx)
+	if err != nil {
+		return err
+	}
+	if lastLogID == nil {
+		return newErrPreconditionFailed("the ledger has no log, expected last log %d", *p.LastLogID)
+	}
+	if *lastLogID != *p.LastLogID {
+		return newErrPreconditionFailed("last log is %d, expected %d", *lastLogID, *p.LastLogID)
+	}
+
+	return nil
+}
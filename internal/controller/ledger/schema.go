@@ -1,10 +1,16 @@
 package ledger
 
-type SchemaEnforcementMode string
+import (
+	ledger "github.com/formancehq/ledger/internal"
+)
+
+type SchemaEnforcementMode = ledger.SchemaEnforcementMode
 
 const (
 	// emit error on failing validation & missing schema
-	SchemaEnforcementStrict SchemaEnforcementMode = "strict"
+	SchemaEnforcementStrict = ledger.SchemaEnforcementStrict
 	// only emit warnings on failing validation & missing schema
-	SchemaEnforcementAudit SchemaEnforcementMode = "audit"
+	SchemaEnforcementAudit = ledger.SchemaEnforcementAudit
+	// skip validation, schemas are only used to resolve templates
+	SchemaEnforcementOff = ledger.SchemaEnforcementOff
 )
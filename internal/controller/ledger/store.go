@@ -31,8 +31,11 @@ type Store interface {
 	Commit(ctx context.Context) error
 	Rollback(ctx context.Context) error
 
-	// GetBalances must returns balance and lock account until the end of the TX
+	// GetBalances must returns balance and lock account until the end of the TX,
+	// the pending holds not expired at the date of the TX are deducted
 	GetBalances(ctx context.Context, query ledgerstore.BalanceQuery) (ledger.Balances, error)
+	// LockBalances returns the balances (input - output) without deducting the pending holds, and locks the accounts until the end of the TX
+	LockBalances(ctx context.Context, query ledgerstore.BalanceQuery) (ledger.Balances, error)
 	CommitTransaction(ctx context.Context, transaction *ledger.Transaction) error
 	// RevertTransaction revert the transaction with identifier id
 	// It returns :
@@ -40,9 +43,16 @@ type Store interface {
 	//  * a boolean indicating if the transaction has been reverted. false indicates an already reverted transaction (unless error != nil)
 	//  * an error
 	RevertTransaction(ctx context.Context, id uint64, at time.Time) (*ledger.Transaction, bool, error)
+	// LockTransaction returns the transaction with identifier id and locks it until the end of the TX
+	LockTransaction(ctx context.Context, id uint64) (*ledger.Transaction, error)
+	// UpdateTransactionRevertedAmounts saves the amounts reverted by partial reverts of a not reverted transaction,
+	// reverted indicates the transaction must also be marked as reverted
+	UpdateTransactionRevertedAmounts(ctx context.Context, id uint64, amounts []*big.Int, at time.Time, reverted bool) (*ledger.Transaction, error)
 	UpdateTransactionMetadata(ctx context.Context, transactionID uint64, m metadata.Metadata, at time.Time) (*ledger.Transaction, bool, error)
 	DeleteTransactionMetadata(ctx context.Context, transactionID uint64, key string, at time.Time) (*ledger.Transaction, bool, error)
 	UpdateAccountsMetadata(ctx context.Context, m map[string]metadata.Metadata, at time.Time) error
+	// LockAccount returns the account with the given address and locks it until the end of the TX
+	LockAccount(ctx context.Context, address string) (*ledger.Account, error)
 	// UpsertAccount returns a boolean indicating if the account was upserted
 	UpsertAccounts(ctx context.Context, accounts ...ledger.AccountWithDefaultMetadata) error
 	DeleteAccountMetadata(ctx context.Context, address, key string) error
@@ -51,6 +61,41 @@ type Store interface {
 	FindSchemas(ctx context.Context, query common.PaginatedQuery[any]) (*paginate.Cursor[ledger.Schema], error)
 	FindLatestSchemaVersion(ctx context.Context) (*string, error)
 	InsertLog(ctx context.Context, log *ledger.Log) error
+	// LockLastLogID returns the id of the last log, or nil if there is no log.
+	// The last log can't change until the end of the TX, it requires the logs to be hashed synchronously.
+	LockLastLogID(ctx context.Context) (*uint64, error)
+	// FindClosedPeriod returns the closed period of the ledger, or nil if no period is closed
+	FindClosedPeriod(ctx context.Context) (*ledger.ClosedPeriod, error)
+	// LockClosedPeriod returns the closed period of the ledger, or nil if no period is closed,
+	// and locks it until the end of the TX
+	LockClosedPeriod(ctx context.Context) (*ledger.ClosedPeriod, error)
+	SaveClosedPeriod(ctx context.Context, period ledger.ClosedPeriod) error
+	DeleteClosedPeriod(ctx context.Context) error
+	// InsertHold inserts the hold, at the date of the TX if its insertion date is zero
+	InsertHold(ctx context.Context, hold *ledger.Hold) error
+	FindHold(ctx context.Context, id string) (*ledger.Hold, error)
+	// EndHold ends the hold at the given date, or at the date of the TX if zero.
+	// It returns the hold and a boolean indicating if the hold has been ended, false indicates an already ended hold
+	EndHold(ctx context.Context, id string, status ledger.HoldStatus, at time.Time) (*ledger.Hold, bool, error)
+	ListExpiredHolds(ctx context.Context, at time.Time, limit int) ([]ledger.Hold, error)
+	InsertRevertJob(ctx context.Context, job *ledger.RevertJob) error
+	FindRevertJob(ctx context.Context, id string) (*ledger.RevertJob, error)
+	LockRevertJob(ctx context.Context, id string) (*ledger.RevertJob, error)
+	UpdateRevertJob(ctx context.Context, job *ledger.RevertJob) error
+	ListUnfinishedRevertJobs(ctx context.Context) ([]ledger.RevertJob, error)
+	InsertScheduledTransaction(ctx context.Context, scheduledTransaction *ledger.ScheduledTransaction) error
+	FindScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error)
+	LockScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error)
+	UpdateScheduledTransaction(ctx context.Context, scheduledTransaction *ledger.ScheduledTransaction) error
+	ListDueScheduledTransactions(ctx context.Context, at time.Time, limit int) ([]ledger.ScheduledTransaction, error)
+	InsertRecurrence(ctx context.Context, recurrence *ledger.Recurrence) error
+	FindRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error)
+	LockRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error)
+	UpdateRecurrence(ctx context.Context, recurrence *ledger.Recurrence) error
+	DeleteRecurrence(ctx context.Context, id string) error
+	ListRecurrences(ctx context.Context, query common.PaginatedQuery[any]) (*paginate.Cursor[ledger.Recurrence], error)
+	ListDueRecurrences(ctx context.Context, at time.Time, limit int) ([]ledger.Recurrence, error)
+	ListAccountMoves(ctx context.Context, query ledgerstore.AccountMovesQuery) ([]ledger.Move, error)
 
 	LockLedger(ctx context.Context) (Store, bun.IDB, func() error, error)
 
@@ -63,6 +108,10 @@ type Store interface {
 	Logs() common.PaginatedResource[ledger.Log, any]
 	Transactions() common.PaginatedResource[ledger.Transaction, any]
 	AggregatedBalances() common.Resource[ledger.AggregatedVolumes, ledger.GetAggregatedVolumesOptions]
+	// AggregatedVolumesHistory aggregates the volumes at each of the dates in a single query
+	AggregatedVolumesHistory(dates []time.Time) common.Resource[ledger.AggregatedVolumesHistory, ledger.GetAggregatedVolumesOptions]
+	// ChartAccountsVolumes aggregates the volumes by account of the chart in a single query
+	ChartAccountsVolumes(accounts []ledger.ChartAccountAddresses) common.Resource[ledger.ChartAccountsVolumes, ledger.GetAggregatedVolumesOptions]
 	Volumes() common.PaginatedResource[ledger.VolumesWithBalanceByAssetByAccount, ledger.GetVolumesOptions]
 }
 
@@ -96,14 +145,22 @@ func newVmStoreAdapter(tx Store) *vmStoreAdapter {
 
 var _ numscript.Store = (*numscriptRewriteAdapter)(nil)
 
-func newNumscriptRewriteAdapter(store Store) *numscriptRewriteAdapter {
+func newNumscriptRewriteAdapter(store Store, schema *ledger.Schema) *numscriptRewriteAdapter {
 	return &numscriptRewriteAdapter{
-		Store: store,
+		Store:               store,
+		schema:              schema,
+		unboundedOverdrafts: ledger.Balances{},
 	}
 }
 
+// numscriptRewriteAdapter adds to the balances read by the interpreter the overdrafts allowed by the chart of accounts,
+// as the interpreter has no hook for them
 type numscriptRewriteAdapter struct {
-	Store Store
+	Store  Store
+	schema *ledger.Schema
+	// unboundedOverdrafts are the amounts added to the balances of the accounts read by the script
+	// and allowed an unbounded overdraft by the chart, by account and asset
+	unboundedOverdrafts ledger.Balances
 }
 
 func (s *numscriptRewriteAdapter) GetBalances(ctx context.Context, q numscript.BalanceQuery) (numscript.Balances, error) {
@@ -111,8 +168,51 @@ func (s *numscriptRewriteAdapter) GetBalances(ctx context.Context, q numscript.B
 	if err != nil {
 		return nil, err
 	}
+	if s.schema == nil {
+		return vmBalances, nil
+	}
+
+	balances := numscript.Balances{}
+	for account, assets := range vmBalances {
+		balances[account] = map[string]*big.Int{}
+		for asset, balance := range assets {
+			balances[account][asset] = balance
+			if account == "world" {
+				continue
+			}
+
+			rule := overdraftRule(s.schema, account, asset)
+			switch {
+			case rule == nil:
+			case rule.Unbounded:
+				if _, ok := s.unboundedOverdrafts[account]; !ok {
+					s.unboundedOverdrafts[account] = map[string]*big.Int{}
+				}
+				if _, ok := s.unboundedOverdrafts[account][asset]; !ok {
+					s.unboundedOverdrafts[account][asset] = new(big.Int)
+				}
+				balances[account][asset] = new(big.Int).Add(balance, s.unboundedOverdrafts[account][asset])
+			default:
+				balances[account][asset] = new(big.Int).Sub(balance, rule.Floor())
+			}
+		}
+	}
 
-	return vmBalances, nil
+	return balances, nil
+}
+
+// addUnboundedOverdraft adds the missing amount to the overdraft of the accounts read by the script
+// and allowed an unbounded overdraft by the chart for the asset.
+// It returns false if there is no such account.
+func (s *numscriptRewriteAdapter) addUnboundedOverdraft(asset string, missing *big.Int) bool {
+	added := false
+	for _, assets := range s.unboundedOverdrafts {
+		if overdraft, ok := assets[asset]; ok {
+			overdraft.Add(overdraft, missing)
+			added = true
+		}
+	}
+	return added
 }
 
 func (s *numscriptRewriteAdapter) GetAccountsMetadata(ctx context.Context, q numscript.MetadataQuery) (numscript.AccountsMetadata, error) {
@@ -10,6 +10,7 @@ package ledger
 import (
 	context "context"
 	sql "database/sql"
+	big "math/big"
 	reflect "reflect"
 
 	paginate "github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
@@ -75,6 +76,20 @@ func (mr *MockStoreMockRecorder) AggregatedBalances() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregatedBalances", reflect.TypeOf((*MockStore)(nil).AggregatedBalances))
 }
 
+// AggregatedVolumesHistory mocks base method.
+func (m *MockStore) AggregatedVolumesHistory(dates []time.Time) common.Resource[ledger.AggregatedVolumesHistory, ledger.GetAggregatedVolumesOptions] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregatedVolumesHistory", dates)
+	ret0, _ := ret[0].(common.Resource[ledger.AggregatedVolumesHistory, ledger.GetAggregatedVolumesOptions])
+	return ret0
+}
+
+// AggregatedVolumesHistory indicates an expected call of AggregatedVolumesHistory.
+func (mr *MockStoreMockRecorder) AggregatedVolumesHistory(dates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregatedVolumesHistory", reflect.TypeOf((*MockStore)(nil).AggregatedVolumesHistory), dates)
+}
+
 // BeginTX mocks base method.
 func (m *MockStore) BeginTX(ctx context.Context, options *sql.TxOptions) (Store, *bun.Tx, error) {
 	m.ctrl.T.Helper()
@@ -91,6 +106,20 @@ func (mr *MockStoreMockRecorder) BeginTX(ctx, options any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTX", reflect.TypeOf((*MockStore)(nil).BeginTX), ctx, options)
 }
 
+// ChartAccountsVolumes mocks base method.
+func (m *MockStore) ChartAccountsVolumes(accounts []ledger.ChartAccountAddresses) common.Resource[ledger.ChartAccountsVolumes, ledger.GetAggregatedVolumesOptions] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChartAccountsVolumes", accounts)
+	ret0, _ := ret[0].(common.Resource[ledger.ChartAccountsVolumes, ledger.GetAggregatedVolumesOptions])
+	return ret0
+}
+
+// ChartAccountsVolumes indicates an expected call of ChartAccountsVolumes.
+func (mr *MockStoreMockRecorder) ChartAccountsVolumes(accounts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChartAccountsVolumes", reflect.TypeOf((*MockStore)(nil).ChartAccountsVolumes), accounts)
+}
+
 // Commit mocks base method.
 func (m *MockStore) Commit(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -133,6 +162,34 @@ func (mr *MockStoreMockRecorder) DeleteAccountMetadata(ctx, address, key any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccountMetadata", reflect.TypeOf((*MockStore)(nil).DeleteAccountMetadata), ctx, address, key)
 }
 
+// DeleteClosedPeriod mocks base method.
+func (m *MockStore) DeleteClosedPeriod(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClosedPeriod", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClosedPeriod indicates an expected call of DeleteClosedPeriod.
+func (mr *MockStoreMockRecorder) DeleteClosedPeriod(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClosedPeriod", reflect.TypeOf((*MockStore)(nil).DeleteClosedPeriod), ctx)
+}
+
+// DeleteRecurrence mocks base method.
+func (m *MockStore) DeleteRecurrence(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecurrence", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecurrence indicates an expected call of DeleteRecurrence.
+func (mr *MockStoreMockRecorder) DeleteRecurrence(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecurrence", reflect.TypeOf((*MockStore)(nil).DeleteRecurrence), ctx, id)
+}
+
 // DeleteTransactionMetadata mocks base method.
 func (m *MockStore) DeleteTransactionMetadata(ctx context.Context, transactionID uint64, key string, at time.Time) (*ledger.Transaction, bool, error) {
 	m.ctrl.T.Helper()
@@ -149,6 +206,52 @@ func (mr *MockStoreMockRecorder) DeleteTransactionMetadata(ctx, transactionID, k
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransactionMetadata", reflect.TypeOf((*MockStore)(nil).DeleteTransactionMetadata), ctx, transactionID, key, at)
 }
 
+// EndHold mocks base method.
+func (m *MockStore) EndHold(ctx context.Context, id string, status ledger.HoldStatus, at time.Time) (*ledger.Hold, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndHold", ctx, id, status, at)
+	ret0, _ := ret[0].(*ledger.Hold)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// EndHold indicates an expected call of EndHold.
+func (mr *MockStoreMockRecorder) EndHold(ctx, id, status, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndHold", reflect.TypeOf((*MockStore)(nil).EndHold), ctx, id, status, at)
+}
+
+// FindClosedPeriod mocks base method.
+func (m *MockStore) FindClosedPeriod(ctx context.Context) (*ledger.ClosedPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindClosedPeriod", ctx)
+	ret0, _ := ret[0].(*ledger.ClosedPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindClosedPeriod indicates an expected call of FindClosedPeriod.
+func (mr *MockStoreMockRecorder) FindClosedPeriod(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindClosedPeriod", reflect.TypeOf((*MockStore)(nil).FindClosedPeriod), ctx)
+}
+
+// FindHold mocks base method.
+func (m *MockStore) FindHold(ctx context.Context, id string) (*ledger.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindHold", ctx, id)
+	ret0, _ := ret[0].(*ledger.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindHold indicates an expected call of FindHold.
+func (mr *MockStoreMockRecorder) FindHold(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindHold", reflect.TypeOf((*MockStore)(nil).FindHold), ctx, id)
+}
+
 // FindLatestSchemaVersion mocks base method.
 func (m *MockStore) FindLatestSchemaVersion(ctx context.Context) (*string, error) {
 	m.ctrl.T.Helper()
@@ -164,6 +267,51 @@ func (mr *MockStoreMockRecorder) FindLatestSchemaVersion(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestSchemaVersion", reflect.TypeOf((*MockStore)(nil).FindLatestSchemaVersion), ctx)
 }
 
+// FindRecurrence mocks base method.
+func (m *MockStore) FindRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRecurrence", ctx, id)
+	ret0, _ := ret[0].(*ledger.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRecurrence indicates an expected call of FindRecurrence.
+func (mr *MockStoreMockRecorder) FindRecurrence(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRecurrence", reflect.TypeOf((*MockStore)(nil).FindRecurrence), ctx, id)
+}
+
+// FindRevertJob mocks base method.
+func (m *MockStore) FindRevertJob(ctx context.Context, id string) (*ledger.RevertJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRevertJob", ctx, id)
+	ret0, _ := ret[0].(*ledger.RevertJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRevertJob indicates an expected call of FindRevertJob.
+func (mr *MockStoreMockRecorder) FindRevertJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRevertJob", reflect.TypeOf((*MockStore)(nil).FindRevertJob), ctx, id)
+}
+
+// FindScheduledTransaction mocks base method.
+func (m *MockStore) FindScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindScheduledTransaction", ctx, id)
+	ret0, _ := ret[0].(*ledger.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindScheduledTransaction indicates an expected call of FindScheduledTransaction.
+func (mr *MockStoreMockRecorder) FindScheduledTransaction(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindScheduledTransaction", reflect.TypeOf((*MockStore)(nil).FindScheduledTransaction), ctx, id)
+}
+
 // FindSchema mocks base method.
 func (m *MockStore) FindSchema(ctx context.Context, version string) (*ledger.Schema, error) {
 	m.ctrl.T.Helper()
@@ -224,6 +372,20 @@ func (mr *MockStoreMockRecorder) GetMigrationsInfo(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMigrationsInfo", reflect.TypeOf((*MockStore)(nil).GetMigrationsInfo), ctx)
 }
 
+// InsertHold mocks base method.
+func (m *MockStore) InsertHold(ctx context.Context, hold *ledger.Hold) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertHold", ctx, hold)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertHold indicates an expected call of InsertHold.
+func (mr *MockStoreMockRecorder) InsertHold(ctx, hold any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertHold", reflect.TypeOf((*MockStore)(nil).InsertHold), ctx, hold)
+}
+
 // InsertLog mocks base method.
 func (m *MockStore) InsertLog(ctx context.Context, log *ledger.Log) error {
 	m.ctrl.T.Helper()
@@ -238,6 +400,48 @@ func (mr *MockStoreMockRecorder) InsertLog(ctx, log any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertLog", reflect.TypeOf((*MockStore)(nil).InsertLog), ctx, log)
 }
 
+// InsertRecurrence mocks base method.
+func (m *MockStore) InsertRecurrence(ctx context.Context, recurrence *ledger.Recurrence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRecurrence", ctx, recurrence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertRecurrence indicates an expected call of InsertRecurrence.
+func (mr *MockStoreMockRecorder) InsertRecurrence(ctx, recurrence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRecurrence", reflect.TypeOf((*MockStore)(nil).InsertRecurrence), ctx, recurrence)
+}
+
+// InsertRevertJob mocks base method.
+func (m *MockStore) InsertRevertJob(ctx context.Context, job *ledger.RevertJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRevertJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertRevertJob indicates an expected call of InsertRevertJob.
+func (mr *MockStoreMockRecorder) InsertRevertJob(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRevertJob", reflect.TypeOf((*MockStore)(nil).InsertRevertJob), ctx, job)
+}
+
+// InsertScheduledTransaction mocks base method.
+func (m *MockStore) InsertScheduledTransaction(ctx context.Context, scheduledTransaction *ledger.ScheduledTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertScheduledTransaction", ctx, scheduledTransaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertScheduledTransaction indicates an expected call of InsertScheduledTransaction.
+func (mr *MockStoreMockRecorder) InsertScheduledTransaction(ctx, scheduledTransaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertScheduledTransaction", reflect.TypeOf((*MockStore)(nil).InsertScheduledTransaction), ctx, scheduledTransaction)
+}
+
 // InsertSchema mocks base method.
 func (m *MockStore) InsertSchema(ctx context.Context, data *ledger.Schema) error {
 	m.ctrl.T.Helper()
@@ -267,6 +471,156 @@ func (mr *MockStoreMockRecorder) IsUpToDate(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUpToDate", reflect.TypeOf((*MockStore)(nil).IsUpToDate), ctx)
 }
 
+// ListAccountMoves mocks base method.
+func (m *MockStore) ListAccountMoves(ctx context.Context, query ledger0.AccountMovesQuery) ([]ledger.Move, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountMoves", ctx, query)
+	ret0, _ := ret[0].([]ledger.Move)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountMoves indicates an expected call of ListAccountMoves.
+func (mr *MockStoreMockRecorder) ListAccountMoves(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountMoves", reflect.TypeOf((*MockStore)(nil).ListAccountMoves), ctx, query)
+}
+
+// ListDueRecurrences mocks base method.
+func (m *MockStore) ListDueRecurrences(ctx context.Context, at time.Time, limit int) ([]ledger.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueRecurrences", ctx, at, limit)
+	ret0, _ := ret[0].([]ledger.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueRecurrences indicates an expected call of ListDueRecurrences.
+func (mr *MockStoreMockRecorder) ListDueRecurrences(ctx, at, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueRecurrences", reflect.TypeOf((*MockStore)(nil).ListDueRecurrences), ctx, at, limit)
+}
+
+// ListDueScheduledTransactions mocks base method.
+func (m *MockStore) ListDueScheduledTransactions(ctx context.Context, at time.Time, limit int) ([]ledger.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueScheduledTransactions", ctx, at, limit)
+	ret0, _ := ret[0].([]ledger.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueScheduledTransactions indicates an expected call of ListDueScheduledTransactions.
+func (mr *MockStoreMockRecorder) ListDueScheduledTransactions(ctx, at, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueScheduledTransactions", reflect.TypeOf((*MockStore)(nil).ListDueScheduledTransactions), ctx, at, limit)
+}
+
+// ListExpiredHolds mocks base method.
+func (m *MockStore) ListExpiredHolds(ctx context.Context, at time.Time, limit int) ([]ledger.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredHolds", ctx, at, limit)
+	ret0, _ := ret[0].([]ledger.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredHolds indicates an expected call of ListExpiredHolds.
+func (mr *MockStoreMockRecorder) ListExpiredHolds(ctx, at, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredHolds", reflect.TypeOf((*MockStore)(nil).ListExpiredHolds), ctx, at, limit)
+}
+
+// ListRecurrences mocks base method.
+func (m *MockStore) ListRecurrences(ctx context.Context, query common.PaginatedQuery[any]) (*paginate.Cursor[ledger.Recurrence], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecurrences", ctx, query)
+	ret0, _ := ret[0].(*paginate.Cursor[ledger.Recurrence])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecurrences indicates an expected call of ListRecurrences.
+func (mr *MockStoreMockRecorder) ListRecurrences(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecurrences", reflect.TypeOf((*MockStore)(nil).ListRecurrences), ctx, query)
+}
+
+// ListUnfinishedRevertJobs mocks base method.
+func (m *MockStore) ListUnfinishedRevertJobs(ctx context.Context) ([]ledger.RevertJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnfinishedRevertJobs", ctx)
+	ret0, _ := ret[0].([]ledger.RevertJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnfinishedRevertJobs indicates an expected call of ListUnfinishedRevertJobs.
+func (mr *MockStoreMockRecorder) ListUnfinishedRevertJobs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnfinishedRevertJobs", reflect.TypeOf((*MockStore)(nil).ListUnfinishedRevertJobs), ctx)
+}
+
+// LockAccount mocks base method.
+func (m *MockStore) LockAccount(ctx context.Context, address string) (*ledger.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockAccount", ctx, address)
+	ret0, _ := ret[0].(*ledger.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockAccount indicates an expected call of LockAccount.
+func (mr *MockStoreMockRecorder) LockAccount(ctx, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAccount", reflect.TypeOf((*MockStore)(nil).LockAccount), ctx, address)
+}
+
+// LockBalances mocks base method.
+func (m *MockStore) LockBalances(ctx context.Context, query ledger0.BalanceQuery) (ledger.Balances, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockBalances", ctx, query)
+	ret0, _ := ret[0].(ledger.Balances)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockBalances indicates an expected call of LockBalances.
+func (mr *MockStoreMockRecorder) LockBalances(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockBalances", reflect.TypeOf((*MockStore)(nil).LockBalances), ctx, query)
+}
+
+// LockClosedPeriod mocks base method.
+func (m *MockStore) LockClosedPeriod(ctx context.Context) (*ledger.ClosedPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockClosedPeriod", ctx)
+	ret0, _ := ret[0].(*ledger.ClosedPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockClosedPeriod indicates an expected call of LockClosedPeriod.
+func (mr *MockStoreMockRecorder) LockClosedPeriod(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockClosedPeriod", reflect.TypeOf((*MockStore)(nil).LockClosedPeriod), ctx)
+}
+
+// LockLastLogID mocks base method.
+func (m *MockStore) LockLastLogID(ctx context.Context) (*uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockLastLogID", ctx)
+	ret0, _ := ret[0].(*uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockLastLogID indicates an expected call of LockLastLogID.
+func (mr *MockStoreMockRecorder) LockLastLogID(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLastLogID", reflect.TypeOf((*MockStore)(nil).LockLastLogID), ctx)
+}
+
 // LockLedger mocks base method.
 func (m *MockStore) LockLedger(ctx context.Context) (Store, bun.IDB, func() error, error) {
 	m.ctrl.T.Helper()
@@ -284,6 +638,66 @@ func (mr *MockStoreMockRecorder) LockLedger(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLedger", reflect.TypeOf((*MockStore)(nil).LockLedger), ctx)
 }
 
+// LockRecurrence mocks base method.
+func (m *MockStore) LockRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockRecurrence", ctx, id)
+	ret0, _ := ret[0].(*ledger.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockRecurrence indicates an expected call of LockRecurrence.
+func (mr *MockStoreMockRecorder) LockRecurrence(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockRecurrence", reflect.TypeOf((*MockStore)(nil).LockRecurrence), ctx, id)
+}
+
+// LockRevertJob mocks base method.
+func (m *MockStore) LockRevertJob(ctx context.Context, id string) (*ledger.RevertJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockRevertJob", ctx, id)
+	ret0, _ := ret[0].(*ledger.RevertJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockRevertJob indicates an expected call of LockRevertJob.
+func (mr *MockStoreMockRecorder) LockRevertJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockRevertJob", reflect.TypeOf((*MockStore)(nil).LockRevertJob), ctx, id)
+}
+
+// LockScheduledTransaction mocks base method.
+func (m *MockStore) LockScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockScheduledTransaction", ctx, id)
+	ret0, _ := ret[0].(*ledger.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockScheduledTransaction indicates an expected call of LockScheduledTransaction.
+func (mr *MockStoreMockRecorder) LockScheduledTransaction(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockScheduledTransaction", reflect.TypeOf((*MockStore)(nil).LockScheduledTransaction), ctx, id)
+}
+
+// LockTransaction mocks base method.
+func (m *MockStore) LockTransaction(ctx context.Context, id uint64) (*ledger.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockTransaction", ctx, id)
+	ret0, _ := ret[0].(*ledger.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockTransaction indicates an expected call of LockTransaction.
+func (mr *MockStoreMockRecorder) LockTransaction(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockTransaction", reflect.TypeOf((*MockStore)(nil).LockTransaction), ctx, id)
+}
+
 // Logs mocks base method.
 func (m *MockStore) Logs() common.PaginatedResource[ledger.Log, any] {
 	m.ctrl.T.Helper()
@@ -343,6 +757,20 @@ func (mr *MockStoreMockRecorder) Rollback(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockStore)(nil).Rollback), ctx)
 }
 
+// SaveClosedPeriod mocks base method.
+func (m *MockStore) SaveClosedPeriod(ctx context.Context, period ledger.ClosedPeriod) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveClosedPeriod", ctx, period)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveClosedPeriod indicates an expected call of SaveClosedPeriod.
+func (mr *MockStoreMockRecorder) SaveClosedPeriod(ctx, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveClosedPeriod", reflect.TypeOf((*MockStore)(nil).SaveClosedPeriod), ctx, period)
+}
+
 // Transactions mocks base method.
 func (m *MockStore) Transactions() common.PaginatedResource[ledger.Transaction, any] {
 	m.ctrl.T.Helper()
@@ -371,6 +799,48 @@ func (mr *MockStoreMockRecorder) UpdateAccountsMetadata(ctx, m, at any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountsMetadata", reflect.TypeOf((*MockStore)(nil).UpdateAccountsMetadata), ctx, m, at)
 }
 
+// UpdateRecurrence mocks base method.
+func (m *MockStore) UpdateRecurrence(ctx context.Context, recurrence *ledger.Recurrence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecurrence", ctx, recurrence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRecurrence indicates an expected call of UpdateRecurrence.
+func (mr *MockStoreMockRecorder) UpdateRecurrence(ctx, recurrence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecurrence", reflect.TypeOf((*MockStore)(nil).UpdateRecurrence), ctx, recurrence)
+}
+
+// UpdateRevertJob mocks base method.
+func (m *MockStore) UpdateRevertJob(ctx context.Context, job *ledger.RevertJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRevertJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRevertJob indicates an expected call of UpdateRevertJob.
+func (mr *MockStoreMockRecorder) UpdateRevertJob(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRevertJob", reflect.TypeOf((*MockStore)(nil).UpdateRevertJob), ctx, job)
+}
+
+// UpdateScheduledTransaction mocks base method.
+func (m *MockStore) UpdateScheduledTransaction(ctx context.Context, scheduledTransaction *ledger.ScheduledTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduledTransaction", ctx, scheduledTransaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScheduledTransaction indicates an expected call of UpdateScheduledTransaction.
+func (mr *MockStoreMockRecorder) UpdateScheduledTransaction(ctx, scheduledTransaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledTransaction", reflect.TypeOf((*MockStore)(nil).UpdateScheduledTransaction), ctx, scheduledTransaction)
+}
+
 // UpdateTransactionMetadata mocks base method.
 func (m_2 *MockStore) UpdateTransactionMetadata(ctx context.Context, transactionID uint64, m metadata.Metadata, at time.Time) (*ledger.Transaction, bool, error) {
 	m_2.ctrl.T.Helper()
@@ -387,6 +857,21 @@ func (mr *MockStoreMockRecorder) UpdateTransactionMetadata(ctx, transactionID, m
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransactionMetadata", reflect.TypeOf((*MockStore)(nil).UpdateTransactionMetadata), ctx, transactionID, m, at)
 }
 
+// UpdateTransactionRevertedAmounts mocks base method.
+func (m *MockStore) UpdateTransactionRevertedAmounts(ctx context.Context, id uint64, amounts []*big.Int, at time.Time, reverted bool) (*ledger.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransactionRevertedAmounts", ctx, id, amounts, at, reverted)
+	ret0, _ := ret[0].(*ledger.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransactionRevertedAmounts indicates an expected call of UpdateTransactionRevertedAmounts.
+func (mr *MockStoreMockRecorder) UpdateTransactionRevertedAmounts(ctx, id, amounts, at, reverted any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransactionRevertedAmounts", reflect.TypeOf((*MockStore)(nil).UpdateTransactionRevertedAmounts), ctx, id, amounts, at, reverted)
+}
+
 // UpsertAccounts mocks base method.
 func (m *MockStore) UpsertAccounts(ctx context.Context, accounts ...ledger.AccountWithDefaultMetadata) error {
 	m.ctrl.T.Helper()
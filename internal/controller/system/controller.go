@@ -2,6 +2,7 @@ package system
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"time"
@@ -13,6 +14,7 @@ import (
 	nooptracer "go.opentelemetry.io/otel/trace/noop"
 
 	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
 
 	ledger "github.com/formancehq/ledger/internal"
 	ledgercontroller "github.com/formancehq/ledger/internal/controller/ledger"
@@ -49,6 +51,11 @@ type Controller interface {
 	// It create the ledger in system store and the underlying storage
 	CreateLedger(ctx context.Context, name string, configuration ledger.Configuration) error
 	UpdateLedgerMetadata(ctx context.Context, name string, m map[string]string) error
+	// UpdateLedgerSchemaSettings can return following errors:
+	//  * ErrInvalidLedgerConfiguration
+	//  * ErrLedgerNotFound
+	// The default schema version must exist on the ledger
+	UpdateLedgerSchemaSettings(ctx context.Context, name string, settings ledger.SchemaSettings) error
 	DeleteLedgerMetadata(ctx context.Context, param string, key string) error
 	DeleteBucket(ctx context.Context, bucket string) error
 	RestoreBucket(ctx context.Context, bucket string) error
@@ -245,6 +252,32 @@ func (ctrl *DefaultController) UpdateLedgerMetadata(ctx context.Context, name st
 	})))
 }
 
+func (ctrl *DefaultController) UpdateLedgerSchemaSettings(ctx context.Context, name string, settings ledger.SchemaSettings) error {
+	return tracing.SkipResult(tracing.Trace(ctx, ctrl.tracerProvider.Tracer("system"), "UpdateLedgerSchemaSettings", tracing.NoResult(func(ctx context.Context) error {
+		if err := settings.Validate(); err != nil {
+			return newErrInvalidLedgerConfiguration(err)
+		}
+
+		if settings.DefaultSchemaVersion != "" {
+			store, _, err := ctrl.driver.OpenLedger(ctx, name)
+			if err != nil {
+				if postgres.IsNotFoundError(err) {
+					return ErrLedgerNotFound
+				}
+				return err
+			}
+			if _, err := store.FindSchema(ctx, settings.DefaultSchemaVersion); err != nil {
+				if errors.Is(err, postgres.ErrNotFound) {
+					return newErrInvalidLedgerConfiguration(fmt.Errorf("schema version `%s` not found", settings.DefaultSchemaVersion))
+				}
+				return err
+			}
+		}
+
+		return ctrl.driver.GetSystemStore().UpdateLedgerSchemaSettings(ctx, name, settings)
+	})))
+}
+
 func (ctrl *DefaultController) DeleteLedgerMetadata(ctx context.Context, param string, key string) error {
 	return tracing.SkipResult(tracing.Trace(ctx, ctrl.tracerProvider.Tracer("system"), "DeleteLedgerMetadata", tracing.NoResult(func(ctx context.Context) error {
 		return ctrl.driver.GetSystemStore().DeleteLedgerMetadata(ctx, param, key)
@@ -10,6 +10,7 @@ import (
 
 var (
 	ErrLedgerAlreadyExists          = systemstore.ErrLedgerAlreadyExists
+	ErrLedgerNotFound               = systemstore.ErrLedgerNotFound
 	ErrBucketOutdated               = driver.ErrBucketOutdated
 	ErrExperimentalFeaturesDisabled = errors.New("experimental features are disabled")
 )
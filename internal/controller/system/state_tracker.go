@@ -141,6 +141,21 @@ func (c *controllerFacade) RevertTransaction(ctx context.Context, parameters led
 	return log, ret, idempotencyHit, err
 }
 
+func (c *controllerFacade) PartiallyRevertTransaction(ctx context.Context, parameters ledgercontroller.Parameters[ledgercontroller.PartiallyRevertTransaction]) (*ledger.Log, *ledger.PartiallyRevertedTransaction, bool, error) {
+	var (
+		log            *ledger.Log
+		ret            *ledger.PartiallyRevertedTransaction
+		idempotencyHit bool
+		err            error
+	)
+	err = c.handleState(ctx, parameters.DryRun, func(ctrl ledgercontroller.Controller) error {
+		log, ret, idempotencyHit, err = ctrl.PartiallyRevertTransaction(ctx, parameters)
+		return err
+	})
+
+	return log, ret, idempotencyHit, err
+}
+
 func (c *controllerFacade) SaveTransactionMetadata(ctx context.Context, parameters ledgercontroller.Parameters[ledgercontroller.SaveTransactionMetadata]) (*ledger.Log, bool, error) {
 	var (
 		log            *ledger.Log
@@ -210,6 +225,193 @@ func (c *controllerFacade) InsertSchema(ctx context.Context, parameters ledgerco
 	return log, ret, idempotencyHit, err
 }
 
+func (c *controllerFacade) ClosePeriod(ctx context.Context, parameters ledgercontroller.Parameters[ledgercontroller.ClosePeriod]) (*ledger.Log, *ledger.ClosedPeriod, bool, error) {
+	var (
+		log            *ledger.Log
+		ret            *ledger.ClosedPeriod
+		idempotencyHit bool
+		err            error
+	)
+	err = c.handleState(ctx, parameters.DryRun, func(ctrl ledgercontroller.Controller) error {
+		log, ret, idempotencyHit, err = ctrl.ClosePeriod(ctx, parameters)
+		return err
+	})
+	return log, ret, idempotencyHit, err
+}
+
+func (c *controllerFacade) ReopenPeriod(ctx context.Context, parameters ledgercontroller.Parameters[ledgercontroller.ReopenPeriod]) (*ledger.Log, *ledger.ReopenedPeriod, bool, error) {
+	var (
+		log            *ledger.Log
+		ret            *ledger.ReopenedPeriod
+		idempotencyHit bool
+		err            error
+	)
+	err = c.handleState(ctx, parameters.DryRun, func(ctrl ledgercontroller.Controller) error {
+		log, ret, idempotencyHit, err = ctrl.ReopenPeriod(ctx, parameters)
+		return err
+	})
+	return log, ret, idempotencyHit, err
+}
+
+func (c *controllerFacade) CreateHold(ctx context.Context, parameters ledgercontroller.Parameters[ledgercontroller.CreateHold]) (*ledger.Log, *ledger.CreatedHold, bool, error) {
+	var (
+		log            *ledger.Log
+		ret            *ledger.CreatedHold
+		idempotencyHit bool
+		err            error
+	)
+	err = c.handleState(ctx, parameters.DryRun, func(ctrl ledgercontroller.Controller) error {
+		log, ret, idempotencyHit, err = ctrl.CreateHold(ctx, parameters)
+		return err
+	})
+	return log, ret, idempotencyHit, err
+}
+
+func (c *controllerFacade) CaptureHold(ctx context.Context, parameters ledgercontroller.Parameters[ledgercontroller.CaptureHold]) (*ledger.Log, *ledger.CapturedHold, bool, error) {
+	var (
+		log            *ledger.Log
+		ret            *ledger.CapturedHold
+		idempotencyHit bool
+		err            error
+	)
+	err = c.handleState(ctx, parameters.DryRun, func(ctrl ledgercontroller.Controller) error {
+		log, ret, idempotencyHit, err = ctrl.CaptureHold(ctx, parameters)
+		return err
+	})
+	return log, ret, idempotencyHit, err
+}
+
+func (c *controllerFacade) VoidHold(ctx context.Context, parameters ledgercontroller.Parameters[ledgercontroller.VoidHold]) (*ledger.Log, *ledger.VoidedHold, bool, error) {
+	var (
+		log            *ledger.Log
+		ret            *ledger.VoidedHold
+		idempotencyHit bool
+		err            error
+	)
+	err = c.handleState(ctx, parameters.DryRun, func(ctrl ledgercontroller.Controller) error {
+		log, ret, idempotencyHit, err = ctrl.VoidHold(ctx, parameters)
+		return err
+	})
+	return log, ret, idempotencyHit, err
+}
+
+func (c *controllerFacade) CreateRevertJob(ctx context.Context, input ledgercontroller.CreateRevertJob) (*ledger.RevertJob, error) {
+	var (
+		ret *ledger.RevertJob
+		err error
+	)
+	err = c.handleState(ctx, false, func(ctrl ledgercontroller.Controller) error {
+		ret, err = ctrl.CreateRevertJob(ctx, input)
+		return err
+	})
+	return ret, err
+}
+
+func (c *controllerFacade) RunRevertJob(ctx context.Context, id string, batchSize int) (*ledger.RevertJob, []ledger.RevertedTransaction, error) {
+	var (
+		ret                  *ledger.RevertJob
+		revertedTransactions []ledger.RevertedTransaction
+		err                  error
+	)
+	err = c.handleState(ctx, false, func(ctrl ledgercontroller.Controller) error {
+		ret, revertedTransactions, err = ctrl.RunRevertJob(ctx, id, batchSize)
+		return err
+	})
+	return ret, revertedTransactions, err
+}
+
+func (c *controllerFacade) ScheduleTransaction(ctx context.Context, input ledgercontroller.ScheduleTransaction) (*ledger.ScheduledTransaction, error) {
+	var (
+		ret *ledger.ScheduledTransaction
+		err error
+	)
+	err = c.handleState(ctx, false, func(ctrl ledgercontroller.Controller) error {
+		ret, err = ctrl.ScheduleTransaction(ctx, input)
+		return err
+	})
+	return ret, err
+}
+
+func (c *controllerFacade) CancelScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	var (
+		ret *ledger.ScheduledTransaction
+		err error
+	)
+	err = c.handleState(ctx, false, func(ctrl ledgercontroller.Controller) error {
+		ret, err = ctrl.CancelScheduledTransaction(ctx, id)
+		return err
+	})
+	return ret, err
+}
+
+func (c *controllerFacade) ExecuteScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, *ledger.CreatedTransaction, error) {
+	var (
+		ret                *ledger.ScheduledTransaction
+		createdTransaction *ledger.CreatedTransaction
+		err                error
+	)
+	err = c.handleState(ctx, false, func(ctrl ledgercontroller.Controller) error {
+		ret, createdTransaction, err = ctrl.ExecuteScheduledTransaction(ctx, id)
+		return err
+	})
+	return ret, createdTransaction, err
+}
+
+func (c *controllerFacade) CreateRecurrence(ctx context.Context, input ledgercontroller.CreateRecurrence) (*ledger.Recurrence, error) {
+	var (
+		ret *ledger.Recurrence
+		err error
+	)
+	err = c.handleState(ctx, false, func(ctrl ledgercontroller.Controller) error {
+		ret, err = ctrl.CreateRecurrence(ctx, input)
+		return err
+	})
+	return ret, err
+}
+
+func (c *controllerFacade) PauseRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	var (
+		ret *ledger.Recurrence
+		err error
+	)
+	err = c.handleState(ctx, false, func(ctrl ledgercontroller.Controller) error {
+		ret, err = ctrl.PauseRecurrence(ctx, id)
+		return err
+	})
+	return ret, err
+}
+
+func (c *controllerFacade) ResumeRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	var (
+		ret *ledger.Recurrence
+		err error
+	)
+	err = c.handleState(ctx, false, func(ctrl ledgercontroller.Controller) error {
+		ret, err = ctrl.ResumeRecurrence(ctx, id)
+		return err
+	})
+	return ret, err
+}
+
+func (c *controllerFacade) DeleteRecurrence(ctx context.Context, id string) error {
+	return c.handleState(ctx, false, func(ctrl ledgercontroller.Controller) error {
+		return ctrl.DeleteRecurrence(ctx, id)
+	})
+}
+
+func (c *controllerFacade) RunRecurrence(ctx context.Context, id string, batchSize int) (*ledger.Recurrence, []ledger.CreatedTransaction, error) {
+	var (
+		ret                 *ledger.Recurrence
+		createdTransactions []ledger.CreatedTransaction
+		err                 error
+	)
+	err = c.handleState(ctx, false, func(ctrl ledgercontroller.Controller) error {
+		ret, createdTransactions, err = ctrl.RunRecurrence(ctx, id, batchSize)
+		return err
+	})
+	return ret, createdTransactions, err
+}
+
 func (c *controllerFacade) Import(ctx context.Context, stream chan ledger.Log) error {
 	return withLock(ctx, c.Controller, func(ctrl ledgercontroller.Controller, conn bun.IDB) error {
 		// todo: remove that in a later version
@@ -15,6 +15,7 @@ type Store interface {
 	GetLedger(ctx context.Context, name string) (*ledger.Ledger, error)
 	Ledgers() common.PaginatedResource[ledger.Ledger, system.ListLedgersQueryPayload]
 	UpdateLedgerMetadata(ctx context.Context, name string, m metadata.Metadata) error
+	UpdateLedgerSchemaSettings(ctx context.Context, name string, settings ledger.SchemaSettings) error
 	DeleteLedgerMetadata(ctx context.Context, param string, key string) error
 	DeleteBucket(ctx context.Context, bucket string) error
 	RestoreBucket(ctx context.Context, bucket string) error
@@ -2,6 +2,7 @@ package ledger
 
 import (
 	"fmt"
+	"math/big"
 	"strings"
 )
 
@@ -127,3 +128,82 @@ func (e ErrInvalidAccount) Is(err error) bool {
 	_, ok := err.(ErrInvalidAccount)
 	return ok
 }
+
+// ErrAccountRuleViolation denotes a balance breaking an overdraft rule of the chart of accounts
+type ErrAccountRuleViolation struct {
+	account string
+	asset   string
+	balance *big.Int
+	floor   *big.Int
+}
+
+func (e ErrAccountRuleViolation) Error() string {
+	if e.floor == nil || e.floor.Sign() == 0 {
+		return fmt.Sprintf("account `%s` cannot have a negative balance for asset `%s` (balance would be %s)", e.account, e.asset, e.balance)
+	}
+	return fmt.Sprintf("account `%s` cannot have a balance below %s for asset `%s` (balance would be %s)", e.account, e.floor, e.asset, e.balance)
+}
+func (e ErrAccountRuleViolation) Is(err error) bool {
+	_, ok := err.(ErrAccountRuleViolation)
+	return ok
+}
+
+// ErrAssetNotAllowed denotes a posting using an asset not allowed on one of its accounts by the chart of accounts
+type ErrAssetNotAllowed struct {
+	account string
+	asset   string
+	allowed []string
+}
+
+func (e ErrAssetNotAllowed) Error() string {
+	return fmt.Sprintf("asset `%s` is not allowed on account `%s` by the chart of accounts, allowed assets: %s", e.asset, e.account, strings.Join(e.allowed, ", "))
+}
+func (e ErrAssetNotAllowed) Is(err error) bool {
+	_, ok := err.(ErrAssetNotAllowed)
+	return ok
+}
+
+// ErrInvalidAccountMetadata denotes a metadata write violating the constraints of the chart of accounts
+type ErrInvalidAccountMetadata struct {
+	account string
+	key     string
+	err     error
+}
+
+func (e ErrInvalidAccountMetadata) Error() string {
+	return fmt.Sprintf("invalid metadata `%s` on account `%s`: %v", e.key, e.account, e.err)
+}
+func (e ErrInvalidAccountMetadata) Is(err error) bool {
+	_, ok := err.(ErrInvalidAccountMetadata)
+	return ok
+}
+
+// ErrInvalidTransactionMetadata denotes a metadata write violating the metadata declared by a transaction template
+type ErrInvalidTransactionMetadata struct {
+	template string
+	key      string
+	err      error
+}
+
+func (e ErrInvalidTransactionMetadata) Error() string {
+	return fmt.Sprintf("invalid metadata `%s` for transaction template `%s`: %v", e.key, e.template, e.err)
+}
+func (e ErrInvalidTransactionMetadata) Is(err error) bool {
+	_, ok := err.(ErrInvalidTransactionMetadata)
+	return ok
+}
+
+// ErrInvalidTransactionVariable denotes a variable not matching its declaration on a transaction template
+type ErrInvalidTransactionVariable struct {
+	template string
+	name     string
+	err      error
+}
+
+func (e ErrInvalidTransactionVariable) Error() string {
+	return fmt.Sprintf("invalid variable `%s` for transaction template `%s`: %v", e.name, e.template, e.err)
+}
+func (e ErrInvalidTransactionVariable) Is(err error) bool {
+	_, ok := err.(ErrInvalidTransactionVariable)
+	return ok
+}
@@ -0,0 +1,72 @@
+package ledger
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/uptrace/bun"
+
+	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+)
+
+const captureKey = "state/captures"
+
+type HoldStatus string
+
+const (
+	HoldStatusPending  HoldStatus = "PENDING"
+	HoldStatusCaptured HoldStatus = "CAPTURED"
+	HoldStatusVoided   HoldStatus = "VOIDED"
+)
+
+// Hold reserves an amount on a source account for a destination.
+// While pending and not expired, the amount reduces the available balance of the source,
+// funds only move when the hold is captured into a transaction.
+type Hold struct {
+	bun.BaseModel `bun:"table:holds,alias:holds"`
+
+	ID          string            `json:"id" bun:"id,type:varchar"`
+	Source      string            `json:"source" bun:"source,type:varchar"`
+	Destination string            `json:"destination" bun:"destination,type:varchar"`
+	Asset       string            `json:"asset" bun:"asset,type:varchar"`
+	Amount      *big.Int          `json:"amount" bun:"amount,type:numeric"`
+	Metadata    metadata.Metadata `json:"metadata" bun:"metadata,type:jsonb"`
+	Status      HoldStatus        `json:"status" bun:"status,type:varchar"`
+	ExpiresAt   *time.Time        `json:"expiresAt,omitempty" bun:"expires_at,type:timestamp without time zone,nullzero"`
+	InsertedAt  time.Time         `json:"insertedAt" bun:"inserted_at,type:timestamp without time zone"`
+	// EndedAt is the date of the capture or the void of the hold
+	EndedAt *time.Time `json:"endedAt,omitempty" bun:"ended_at,type:timestamp without time zone,nullzero"`
+}
+
+// IsExpired indicates if the hold cannot be captured anymore at the given date
+func (h Hold) IsExpired(at time.Time) bool {
+	return h.ExpiresAt != nil && !h.ExpiresAt.After(at)
+}
+
+// Capture returns the transaction moving the captured amount from the source to the destination,
+// the amount cannot exceed the amount of the hold, and defaults to it when nil
+func (h Hold) Capture(amount *big.Int, m metadata.Metadata) (Transaction, error) {
+	if amount == nil {
+		amount = h.Amount
+	}
+	if amount.Sign() <= 0 {
+		return Transaction{}, fmt.Errorf("the captured amount must be positive")
+	}
+	if amount.Cmp(h.Amount) > 0 {
+		return Transaction{}, fmt.Errorf("cannot capture %s, the hold is %s", amount, h.Amount)
+	}
+
+	return NewTransaction().
+		WithPostings(NewPosting(h.Source, h.Destination, h.Asset, new(big.Int).Set(amount))).
+		WithMetadata(MarkCaptures(m, h.ID)), nil
+}
+
+func CaptureMetadataSpecKey() string {
+	return SpecMetadata(captureKey)
+}
+
+// MarkCaptures marks the metadata of a transaction with the hold it captures
+func MarkCaptures(m metadata.Metadata, holdID string) metadata.Metadata {
+	return m.Merge(ComputeMetadata(CaptureMetadataSpecKey(), holdID))
+}
@@ -93,10 +93,26 @@ var (
 	}
 )
 
+// SchemaSettings configures how the schemas of a ledger apply to writes
+type SchemaSettings struct {
+	// SchemaEnforcementMode overrides the enforcement mode of the instance when set
+	SchemaEnforcementMode SchemaEnforcementMode `json:"schemaEnforcementMode,omitempty" bun:"schema_enforcement_mode,type:varchar(255),nullzero"`
+	// DefaultSchemaVersion is used by writes not specifying a schema version
+	DefaultSchemaVersion string `json:"defaultSchemaVersion,omitempty" bun:"default_schema_version,type:varchar(255),nullzero"`
+}
+
+func (s SchemaSettings) Validate() error {
+	if s.SchemaEnforcementMode != "" {
+		return s.SchemaEnforcementMode.Validate()
+	}
+	return nil
+}
+
 type Configuration struct {
 	Bucket   string              `json:"bucket" bun:"bucket,type:varchar(255)"`
 	Metadata metadata.Metadata   `json:"metadata" bun:"metadata,type:jsonb,nullzero"`
 	Features features.FeatureSet `json:"features" bun:"features,type:jsonb"`
+	SchemaSettings
 }
 
 func (c *Configuration) SetDefaults() {
@@ -115,6 +131,9 @@ func (c *Configuration) SetDefaults() {
 }
 
 func (c *Configuration) Validate() error {
+	if err := c.SchemaSettings.Validate(); err != nil {
+		return err
+	}
 	for feature, value := range c.Features {
 		if err := features.ValidateFeatureWithValue(feature, value); err != nil {
 			return err
@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"reflect"
 	"strconv"
 	"strings"
@@ -18,11 +19,24 @@ import (
 )
 
 const (
-	SetMetadataLogType         LogType = iota // "SET_METADATA"
-	NewTransactionLogType                     // "NEW_TRANSACTION"
-	RevertedTransactionLogType                // "REVERTED_TRANSACTION"
-	DeleteMetadataLogType                     // "DELETE_METADATA"
-	InsertedSchemaLogType                     // "INSERTED_SCHEMA"
+	SetMetadataLogType                  LogType = iota // "SET_METADATA"
+	NewTransactionLogType                              // "NEW_TRANSACTION"
+	RevertedTransactionLogType                         // "REVERTED_TRANSACTION"
+	DeleteMetadataLogType                              // "DELETE_METADATA"
+	InsertedSchemaLogType                              // "INSERTED_SCHEMA"
+	ClosedPeriodLogType                                // "CLOSED_PERIOD"
+	ReopenedPeriodLogType                              // "REOPENED_PERIOD"
+	CreatedHoldLogType                                 // "CREATED_HOLD"
+	CapturedHoldLogType                                // "CAPTURED_HOLD"
+	VoidedHoldLogType                                  // "VOIDED_HOLD"
+	PartiallyRevertedTransactionLogType                // "PARTIALLY_REVERTED_TRANSACTION"
+	CreatedRevertJobLogType                            // "CREATED_REVERT_JOB"
+	UpdatedRevertJobLogType                            // "UPDATED_REVERT_JOB"
+	CreatedScheduledTransactionLogType                 // "CREATED_SCHEDULED_TRANSACTION"
+	UpdatedScheduledTransactionLogType                 // "UPDATED_SCHEDULED_TRANSACTION"
+	CreatedRecurrenceLogType                           // "CREATED_RECURRENCE"
+	UpdatedRecurrenceLogType                           // "UPDATED_RECURRENCE"
+	DeletedRecurrenceLogType                           // "DELETED_RECURRENCE"
 )
 
 type LogType int16
@@ -63,6 +77,32 @@ func (lt LogType) String() string {
 		return "DELETE_METADATA"
 	case InsertedSchemaLogType:
 		return "INSERTED_SCHEMA"
+	case ClosedPeriodLogType:
+		return "CLOSED_PERIOD"
+	case ReopenedPeriodLogType:
+		return "REOPENED_PERIOD"
+	case CreatedHoldLogType:
+		return "CREATED_HOLD"
+	case CapturedHoldLogType:
+		return "CAPTURED_HOLD"
+	case VoidedHoldLogType:
+		return "VOIDED_HOLD"
+	case PartiallyRevertedTransactionLogType:
+		return "PARTIALLY_REVERTED_TRANSACTION"
+	case CreatedRevertJobLogType:
+		return "CREATED_REVERT_JOB"
+	case UpdatedRevertJobLogType:
+		return "UPDATED_REVERT_JOB"
+	case CreatedScheduledTransactionLogType:
+		return "CREATED_SCHEDULED_TRANSACTION"
+	case UpdatedScheduledTransactionLogType:
+		return "UPDATED_SCHEDULED_TRANSACTION"
+	case CreatedRecurrenceLogType:
+		return "CREATED_RECURRENCE"
+	case UpdatedRecurrenceLogType:
+		return "UPDATED_RECURRENCE"
+	case DeletedRecurrenceLogType:
+		return "DELETED_RECURRENCE"
 	}
 
 	panic("invalid log type")
@@ -80,6 +120,32 @@ func LogTypeFromString(logType string) LogType {
 		return DeleteMetadataLogType
 	case "INSERTED_SCHEMA":
 		return InsertedSchemaLogType
+	case "CLOSED_PERIOD":
+		return ClosedPeriodLogType
+	case "REOPENED_PERIOD":
+		return ReopenedPeriodLogType
+	case "CREATED_HOLD":
+		return CreatedHoldLogType
+	case "CAPTURED_HOLD":
+		return CapturedHoldLogType
+	case "VOIDED_HOLD":
+		return VoidedHoldLogType
+	case "PARTIALLY_REVERTED_TRANSACTION":
+		return PartiallyRevertedTransactionLogType
+	case "CREATED_REVERT_JOB":
+		return CreatedRevertJobLogType
+	case "UPDATED_REVERT_JOB":
+		return UpdatedRevertJobLogType
+	case "CREATED_SCHEDULED_TRANSACTION":
+		return CreatedScheduledTransactionLogType
+	case "UPDATED_SCHEDULED_TRANSACTION":
+		return UpdatedScheduledTransactionLogType
+	case "CREATED_RECURRENCE":
+		return CreatedRecurrenceLogType
+	case "UPDATED_RECURRENCE":
+		return UpdatedRecurrenceLogType
+	case "DELETED_RECURRENCE":
+		return DeletedRecurrenceLogType
 	}
 
 	panic("invalid log type")
@@ -413,6 +479,61 @@ func (r RevertedTransaction) GetMemento() any {
 
 var _ Memento = (*RevertedTransaction)(nil)
 
+// PartiallyRevertedTransaction reverts a part of the amounts of the postings of a transaction.
+// RevertedTransaction holds the amounts reverted after the revert.
+type PartiallyRevertedTransaction struct {
+	RevertedTransaction Transaction `json:"revertedTransaction"`
+	RevertTransaction   Transaction `json:"transaction"`
+}
+
+func (p PartiallyRevertedTransaction) NeedsSchema() bool {
+	return true
+}
+
+func (p PartiallyRevertedTransaction) ValidateWithSchema(schema Schema) error {
+	for _, posting := range p.RevertTransaction.Postings {
+		if err := schema.Chart.ValidatePosting(posting); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p PartiallyRevertedTransaction) Type() LogType {
+	return PartiallyRevertedTransactionLogType
+}
+
+var _ LogPayload = (*PartiallyRevertedTransaction)(nil)
+
+func (p PartiallyRevertedTransaction) GetMemento() any {
+
+	type transactionResume struct {
+		Postings  Postings          `json:"postings"`
+		Metadata  metadata.Metadata `json:"metadata"`
+		Timestamp time.Time         `json:"timestamp"`
+		Reference string            `json:"reference,omitempty"`
+		ID        *uint64           `json:"id"`
+	}
+
+	return struct {
+		RevertedTransactionID uint64            `json:"revertedTransactionID"`
+		RevertedAmounts       []*big.Int        `json:"revertedAmounts"`
+		RevertTransaction     transactionResume `json:"transaction"`
+	}{
+		RevertedTransactionID: *p.RevertedTransaction.ID,
+		RevertedAmounts:       p.RevertedTransaction.RevertedAmounts,
+		RevertTransaction: transactionResume{
+			Postings:  p.RevertTransaction.Postings,
+			Metadata:  p.RevertTransaction.Metadata,
+			Timestamp: p.RevertTransaction.Timestamp,
+			Reference: p.RevertTransaction.Reference,
+			ID:        p.RevertTransaction.ID,
+		},
+	}
+}
+
+var _ Memento = (*PartiallyRevertedTransaction)(nil)
+
 type InsertedSchema struct {
 	Schema Schema `json:"schema"`
 }
@@ -430,6 +551,281 @@ func (u InsertedSchema) Type() LogType {
 
 var _ LogPayload = (*InsertedSchema)(nil)
 
+// ClosedPeriod forbids the writes effective before the close date
+type ClosedPeriod struct {
+	ClosedUntil time.Time `json:"closedUntil"`
+}
+
+func (p ClosedPeriod) NeedsSchema() bool {
+	return false
+}
+
+func (p ClosedPeriod) ValidateWithSchema(schema Schema) error {
+	return nil
+}
+
+func (p ClosedPeriod) Type() LogType {
+	return ClosedPeriodLogType
+}
+
+var _ LogPayload = (*ClosedPeriod)(nil)
+
+// ReopenedPeriod moves the close date back, ClosedUntil is nil when all the periods are reopened
+type ReopenedPeriod struct {
+	ClosedUntil         *time.Time `json:"closedUntil"`
+	PreviousClosedUntil time.Time  `json:"previousClosedUntil"`
+}
+
+func (p ReopenedPeriod) NeedsSchema() bool {
+	return false
+}
+
+func (p ReopenedPeriod) ValidateWithSchema(schema Schema) error {
+	return nil
+}
+
+func (p ReopenedPeriod) Type() LogType {
+	return ReopenedPeriodLogType
+}
+
+var _ LogPayload = (*ReopenedPeriod)(nil)
+
+type CreatedHold struct {
+	Hold Hold `json:"hold"`
+}
+
+func (p CreatedHold) NeedsSchema() bool {
+	return true
+}
+
+// ValidateWithSchema checks the accounts of the hold like the posting it will be captured as
+func (p CreatedHold) ValidateWithSchema(schema Schema) error {
+	return schema.Chart.ValidatePosting(NewPosting(p.Hold.Source, p.Hold.Destination, p.Hold.Asset, p.Hold.Amount))
+}
+
+func (p CreatedHold) Type() LogType {
+	return CreatedHoldLogType
+}
+
+var _ LogPayload = (*CreatedHold)(nil)
+
+// CapturedHold ends a hold with the transaction moving the captured amount
+type CapturedHold struct {
+	Hold        Hold        `json:"hold"`
+	Transaction Transaction `json:"transaction"`
+}
+
+func (p CapturedHold) NeedsSchema() bool {
+	return true
+}
+
+func (p CapturedHold) ValidateWithSchema(schema Schema) error {
+	for _, posting := range p.Transaction.Postings {
+		if err := schema.Chart.ValidatePosting(posting); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p CapturedHold) Type() LogType {
+	return CapturedHoldLogType
+}
+
+var _ LogPayload = (*CapturedHold)(nil)
+
+func (p CapturedHold) GetMemento() any {
+	// Exclude postCommitVolumes and postCommitEffectiveVolumes fields from the transaction, as for CreatedTransaction
+	type transactionResume struct {
+		Postings  Postings          `json:"postings"`
+		Metadata  metadata.Metadata `json:"metadata"`
+		Timestamp time.Time         `json:"timestamp"`
+		ID        *uint64           `json:"id"`
+	}
+
+	return struct {
+		Hold        Hold              `json:"hold"`
+		Transaction transactionResume `json:"transaction"`
+	}{
+		Hold: p.Hold,
+		Transaction: transactionResume{
+			Postings:  p.Transaction.Postings,
+			Metadata:  p.Transaction.Metadata,
+			Timestamp: p.Transaction.Timestamp,
+			ID:        p.Transaction.ID,
+		},
+	}
+}
+
+var _ Memento = (*CapturedHold)(nil)
+
+type VoidedHold struct {
+	Hold Hold `json:"hold"`
+}
+
+func (p VoidedHold) NeedsSchema() bool {
+	return false
+}
+
+func (p VoidedHold) ValidateWithSchema(schema Schema) error {
+	return nil
+}
+
+func (p VoidedHold) Type() LogType {
+	return VoidedHoldLogType
+}
+
+var _ LogPayload = (*VoidedHold)(nil)
+
+// CreatedRevertJob registers a job reverting the transactions matching a filter.
+// MaxTransactionID is not exposed on the job but is required to replay it.
+type CreatedRevertJob struct {
+	RevertJob        RevertJob `json:"revertJob"`
+	MaxTransactionID *uint64   `json:"maxTransactionId,omitempty"`
+}
+
+func NewCreatedRevertJob(job RevertJob) CreatedRevertJob {
+	return CreatedRevertJob{
+		RevertJob:        job,
+		MaxTransactionID: job.MaxTransactionID,
+	}
+}
+
+func (p CreatedRevertJob) NeedsSchema() bool {
+	return false
+}
+
+func (p CreatedRevertJob) ValidateWithSchema(schema Schema) error {
+	return nil
+}
+
+func (p CreatedRevertJob) Type() LogType {
+	return CreatedRevertJobLogType
+}
+
+var _ LogPayload = (*CreatedRevertJob)(nil)
+
+// UpdatedRevertJob saves the progress of a job, the reverts of the batch are logged before
+type UpdatedRevertJob struct {
+	RevertJob RevertJob `json:"revertJob"`
+}
+
+func (p UpdatedRevertJob) NeedsSchema() bool {
+	return false
+}
+
+func (p UpdatedRevertJob) ValidateWithSchema(schema Schema) error {
+	return nil
+}
+
+func (p UpdatedRevertJob) Type() LogType {
+	return UpdatedRevertJobLogType
+}
+
+var _ LogPayload = (*UpdatedRevertJob)(nil)
+
+type CreatedScheduledTransaction struct {
+	ScheduledTransaction ScheduledTransaction `json:"scheduledTransaction"`
+}
+
+func (p CreatedScheduledTransaction) NeedsSchema() bool {
+	return false
+}
+
+func (p CreatedScheduledTransaction) ValidateWithSchema(schema Schema) error {
+	return nil
+}
+
+func (p CreatedScheduledTransaction) Type() LogType {
+	return CreatedScheduledTransactionLogType
+}
+
+var _ LogPayload = (*CreatedScheduledTransaction)(nil)
+
+// UpdatedScheduledTransaction saves the cancellation or the outcome of the execution of a scheduled transaction,
+// the created transaction is logged before
+type UpdatedScheduledTransaction struct {
+	ScheduledTransaction ScheduledTransaction `json:"scheduledTransaction"`
+}
+
+func (p UpdatedScheduledTransaction) NeedsSchema() bool {
+	return false
+}
+
+func (p UpdatedScheduledTransaction) ValidateWithSchema(schema Schema) error {
+	return nil
+}
+
+func (p UpdatedScheduledTransaction) Type() LogType {
+	return UpdatedScheduledTransactionLogType
+}
+
+var _ LogPayload = (*UpdatedScheduledTransaction)(nil)
+
+type CreatedRecurrence struct {
+	Recurrence Recurrence `json:"recurrence"`
+}
+
+func (p CreatedRecurrence) NeedsSchema() bool {
+	return false
+}
+
+func (p CreatedRecurrence) ValidateWithSchema(schema Schema) error {
+	return nil
+}
+
+func (p CreatedRecurrence) Type() LogType {
+	return CreatedRecurrenceLogType
+}
+
+var _ LogPayload = (*CreatedRecurrence)(nil)
+
+// UpdatedRecurrence saves the status or the progress of a recurrence, the transactions of the batch are logged before.
+// AccountsOffset is not exposed on the recurrence but is required to replay it.
+type UpdatedRecurrence struct {
+	Recurrence     Recurrence `json:"recurrence"`
+	AccountsOffset uint64     `json:"accountsOffset"`
+}
+
+func NewUpdatedRecurrence(recurrence Recurrence) UpdatedRecurrence {
+	return UpdatedRecurrence{
+		Recurrence:     recurrence,
+		AccountsOffset: recurrence.AccountsOffset,
+	}
+}
+
+func (p UpdatedRecurrence) NeedsSchema() bool {
+	return false
+}
+
+func (p UpdatedRecurrence) ValidateWithSchema(schema Schema) error {
+	return nil
+}
+
+func (p UpdatedRecurrence) Type() LogType {
+	return UpdatedRecurrenceLogType
+}
+
+var _ LogPayload = (*UpdatedRecurrence)(nil)
+
+type DeletedRecurrence struct {
+	ID string `json:"id"`
+}
+
+func (p DeletedRecurrence) NeedsSchema() bool {
+	return false
+}
+
+func (p DeletedRecurrence) ValidateWithSchema(schema Schema) error {
+	return nil
+}
+
+func (p DeletedRecurrence) Type() LogType {
+	return DeletedRecurrenceLogType
+}
+
+var _ LogPayload = (*DeletedRecurrence)(nil)
+
 func HydrateLog(_type LogType, data []byte) (LogPayload, error) {
 	var payload any
 	switch _type {
@@ -443,6 +839,32 @@ func HydrateLog(_type LogType, data []byte) (LogPayload, error) {
 		payload = &RevertedTransaction{}
 	case InsertedSchemaLogType:
 		payload = &InsertedSchema{}
+	case ClosedPeriodLogType:
+		payload = &ClosedPeriod{}
+	case ReopenedPeriodLogType:
+		payload = &ReopenedPeriod{}
+	case CreatedHoldLogType:
+		payload = &CreatedHold{}
+	case CapturedHoldLogType:
+		payload = &CapturedHold{}
+	case VoidedHoldLogType:
+		payload = &VoidedHold{}
+	case PartiallyRevertedTransactionLogType:
+		payload = &PartiallyRevertedTransaction{}
+	case CreatedRevertJobLogType:
+		payload = &CreatedRevertJob{}
+	case UpdatedRevertJobLogType:
+		payload = &UpdatedRevertJob{}
+	case CreatedScheduledTransactionLogType:
+		payload = &CreatedScheduledTransaction{}
+	case UpdatedScheduledTransactionLogType:
+		payload = &UpdatedScheduledTransaction{}
+	case CreatedRecurrenceLogType:
+		payload = &CreatedRecurrence{}
+	case UpdatedRecurrenceLogType:
+		payload = &UpdatedRecurrence{}
+	case DeletedRecurrenceLogType:
+		payload = &DeletedRecurrence{}
 	default:
 		return nil, fmt.Errorf("unknown type '%s'", _type)
 	}
@@ -14,6 +14,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 
 	"github.com/logrusorgru/aurora"
 
@@ -40,6 +41,11 @@ type Machine struct {
 	Printer                    func(chan machine.Value)
 	printChan                  chan machine.Value
 	Debug                      bool
+
+	// Overdrafts returns the overdraft allowed to an account for an asset when it is defined outside of the script,
+	// a nil amount indicating an unbounded overdraft. It replaces the overdraft allowed by the script for the sources
+	// with a bounded overdraft, and the sources with an unbounded overdraft provide the amounts missing to the takes
+	Overdrafts func(account machine.AccountAddress, asset machine.Asset) (*machine.MonetaryInt, bool)
 }
 
 type Posting struct {
@@ -152,6 +158,32 @@ func (m *Machine) withdrawAlways(account machine.AccountAddress, mon machine.Mon
 	}, nil
 }
 
+// withUnboundedOverdraft returns the funding with the amount it misses to fund the amount taken
+// from the first of its sources allowed an unbounded overdraft outside of the script, if any
+func (m *Machine) withUnboundedOverdraft(funding machine.Funding, amount *machine.MonetaryInt) machine.Funding {
+	missing := amount.Sub(funding.Total())
+	if m.Overdrafts == nil || !missing.Gt(machine.Zero) {
+		return funding
+	}
+	for i, part := range funding.Parts {
+		if overdraft, ok := m.Overdrafts(part.Account, funding.Asset); !ok || overdraft != nil {
+			continue
+		}
+		if accBalances, ok := m.Balances[part.Account]; ok {
+			if balance, ok := accBalances[funding.Asset]; ok {
+				accBalances[funding.Asset] = balance.Sub(missing)
+			}
+		}
+		parts := slices.Clone(funding.Parts)
+		parts[i].Amount = part.Amount.Add(missing)
+		return machine.Funding{
+			Asset: funding.Asset,
+			Parts: parts,
+		}
+	}
+	return funding
+}
+
 func (m *Machine) credit(account machine.AccountAddress, funding machine.Funding) {
 	if account == "world" {
 		return
@@ -293,6 +325,11 @@ func (m *Machine) tick() (bool, error) {
 	case program.OP_TAKE_ALL:
 		overdraft := pop[machine.Monetary](m)
 		account := pop[machine.AccountAddress](m)
+		if m.Overdrafts != nil {
+			if amount, ok := m.Overdrafts(account, overdraft.Asset); ok && amount != nil {
+				overdraft.Amount = amount
+			}
+		}
 		funding, err := m.withdrawAll(account, overdraft.Asset, overdraft.Amount)
 		if err != nil {
 			return true, machine.NewErrInvalidScript("%s", err)
@@ -314,7 +351,7 @@ func (m *Machine) tick() (bool, error) {
 		if funding.Asset != mon.Asset {
 			return true, machine.NewErrInvalidScript("cannot take from different assets: %v and %v", funding.Asset, mon.Asset)
 		}
-		result, remainder, err := funding.Take(mon.Amount)
+		result, remainder, err := m.withUnboundedOverdraft(funding, mon.Amount).Take(mon.Amount)
 		if err != nil {
 			return true, machine.NewErrInsufficientFund("%s", err)
 		}
@@ -65,6 +65,124 @@ func TestOverdraftUnbounded(t *testing.T) {
 	test(t, tc)
 }
 
+func TestOverdraftDefinedOutsideOfTheScript(t *testing.T) {
+	tc := NewTestCase()
+	tc.compile(t, `send [GEM 100] (
+			source = @foo
+			destination = @world
+		)`)
+	tc.setBalance("foo", "GEM", 90)
+	tc.setOverdraft("foo", "GEM", 10)
+	tc.expected = CaseResult{
+		Printed: []machine.Value{},
+		Postings: []Posting{
+			{
+				Asset:       "GEM",
+				Amount:      machine.NewMonetaryInt(100),
+				Source:      "foo",
+				Destination: "world",
+			},
+		},
+		Error: nil,
+	}
+	test(t, tc)
+}
+
+func TestOverdraftDefinedOutsideOfTheScriptReplacesTheScriptOne(t *testing.T) {
+	tc := NewTestCase()
+	tc.compile(t, `send [GEM 100] (
+			source = @foo allowing overdraft up to [GEM 50]
+			destination = @world
+		)`)
+	tc.setBalance("foo", "GEM", 90)
+	tc.setOverdraft("foo", "GEM", 0)
+	tc.expected = CaseResult{
+		Printed:  []machine.Value{},
+		Postings: []Posting{},
+		Error:    &machine.ErrInsufficientFund{},
+	}
+	test(t, tc)
+}
+
+func TestUnboundedOverdraftDefinedOutsideOfTheScript(t *testing.T) {
+	tc := NewTestCase()
+	tc.compile(t, `send [GEM 100] (
+			source = @foo
+			destination = @world
+		)`)
+	tc.setBalance("foo", "GEM", 90)
+	tc.setUnboundedOverdraft("foo", "GEM")
+	tc.expected = CaseResult{
+		Printed: []machine.Value{},
+		Postings: []Posting{
+			{
+				Asset:       "GEM",
+				Amount:      machine.NewMonetaryInt(100),
+				Source:      "foo",
+				Destination: "world",
+			},
+		},
+		Error: nil,
+	}
+	test(t, tc)
+}
+
+func TestUnboundedOverdraftDefinedOutsideOfTheScriptInOrder(t *testing.T) {
+	tc := NewTestCase()
+	tc.compile(t, `send [GEM 100] (
+			source = {
+				@foo
+				@bar
+			}
+			destination = @world
+		)`)
+	tc.setBalance("foo", "GEM", 10)
+	tc.setBalance("bar", "GEM", 50)
+	tc.setUnboundedOverdraft("foo", "GEM")
+	tc.expected = CaseResult{
+		Printed: []machine.Value{},
+		Postings: []Posting{
+			{
+				Asset:       "GEM",
+				Amount:      machine.NewMonetaryInt(50),
+				Source:      "foo",
+				Destination: "world",
+			},
+			{
+				Asset:       "GEM",
+				Amount:      machine.NewMonetaryInt(50),
+				Source:      "bar",
+				Destination: "world",
+			},
+		},
+		Error: nil,
+	}
+	test(t, tc)
+}
+
+func TestUnboundedOverdraftDefinedOutsideOfTheScriptSendAll(t *testing.T) {
+	tc := NewTestCase()
+	tc.compile(t, `send [GEM *] (
+			source = @foo
+			destination = @world
+		)`)
+	tc.setBalance("foo", "GEM", 90)
+	tc.setUnboundedOverdraft("foo", "GEM")
+	tc.expected = CaseResult{
+		Printed: []machine.Value{},
+		Postings: []Posting{
+			{
+				Asset:       "GEM",
+				Amount:      machine.NewMonetaryInt(90),
+				Source:      "foo",
+				Destination: "world",
+			},
+		},
+		Error: nil,
+	}
+	test(t, tc)
+}
+
 func TestOverdraftSourceAllotmentSuccess(t *testing.T) {
 	tc := NewTestCase()
 	tc.compile(t, `send [GEM 100] (
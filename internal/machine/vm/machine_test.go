@@ -38,7 +38,9 @@ type TestCase struct {
 	vars     map[string]string
 	meta     map[string]metadata.Metadata
 	balances map[string]map[string]*machine.MonetaryInt
-	expected CaseResult
+	// overdrafts are the overdrafts defined outside of the script
+	overdrafts map[string]map[string]*machine.MonetaryInt
+	expected   CaseResult
 }
 
 func NewTestCase() TestCase {
@@ -71,6 +73,26 @@ func (c *TestCase) setVarsFromJSON(t *testing.T, str string) {
 	c.vars = jsonVars
 }
 
+func (c *TestCase) setOverdraft(account, asset string, amount int64) {
+	if c.overdrafts == nil {
+		c.overdrafts = make(map[string]map[string]*machine.MonetaryInt)
+	}
+	if _, ok := c.overdrafts[account]; !ok {
+		c.overdrafts[account] = make(map[string]*machine.MonetaryInt)
+	}
+	c.overdrafts[account][asset] = machine.NewMonetaryInt(amount)
+}
+
+func (c *TestCase) setUnboundedOverdraft(account, asset string) {
+	if c.overdrafts == nil {
+		c.overdrafts = make(map[string]map[string]*machine.MonetaryInt)
+	}
+	if _, ok := c.overdrafts[account]; !ok {
+		c.overdrafts[account] = make(map[string]*machine.MonetaryInt)
+	}
+	c.overdrafts[account][asset] = nil
+}
+
 func (c *TestCase) setBalance(account, asset string, amount int64) {
 	if _, ok := c.balances[account]; !ok {
 		c.balances[account] = make(map[string]*machine.MonetaryInt)
@@ -101,6 +123,13 @@ func test(t *testing.T, testCase TestCase) {
 			}
 		}
 
+		if testCase.overdrafts != nil {
+			m.Overdrafts = func(account machine.AccountAddress, asset machine.Asset) (*machine.MonetaryInt, bool) {
+				overdraft, ok := testCase.overdrafts[string(account)][string(asset)]
+				return overdraft, ok
+			}
+		}
+
 		err := m.ResolveResources(context.Background(), store)
 		if err != nil {
 			return err
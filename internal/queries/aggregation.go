@@ -0,0 +1,136 @@
+package queries
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+const (
+	MeasureSum   = "sum"
+	MeasureCount = "count"
+	MeasureMin   = "min"
+	MeasureMax   = "max"
+)
+
+var Measures = []string{MeasureSum, MeasureCount, MeasureMin, MeasureMax}
+
+// AggregationSchema lists the properties usable in the aggregations of a resource
+type AggregationSchema struct {
+	// Keys are the properties usable as group keys, map properties must be indexed (ex: `metadata[merchant_id]`)
+	Keys []string
+	// MapKeys are the keys which must be indexed
+	MapKeys []string
+	// Measures are the numeric properties which can be measured
+	Measures []string
+}
+
+var AccountAggregationSchema = AggregationSchema{
+	Keys:    []string{"address"},
+	MapKeys: []string{"metadata"},
+}
+
+var LogAggregationSchema = AggregationSchema{
+	Keys: []string{"type"},
+}
+
+// TransactionAggregationSchema aggregates transactions by posting when using
+// the `asset`, `source` or `destination` keys, or the `amount` measure
+var TransactionAggregationSchema = AggregationSchema{
+	Keys:     []string{"reference", "asset", "source", "destination"},
+	MapKeys:  []string{"metadata"},
+	Measures: []string{"amount"},
+}
+
+var VolumeAggregationSchema = AggregationSchema{
+	Keys:     []string{"account", "asset"},
+	Measures: []string{"input", "output", "balance"},
+}
+
+func GetAggregationSchema(kind ResourceKind) (*AggregationSchema, error) {
+	switch kind {
+	case ResourceKindAccount:
+		return &AccountAggregationSchema, nil
+	case ResourceKindLog:
+		return &LogAggregationSchema, nil
+	case ResourceKindTransaction:
+		return &TransactionAggregationSchema, nil
+	case ResourceKindVolume:
+		return &VolumeAggregationSchema, nil
+	default:
+		return nil, fmt.Errorf("resource %s does not support aggregations", kind)
+	}
+}
+
+// Measure is a value computed on each group of an aggregation
+type Measure struct {
+	// Function is one of `sum`, `count`, `min` and `max`
+	Function string `json:"fn"`
+	// Property is the measured property, not used by `count`
+	Property string `json:"property,omitempty"`
+}
+
+// Aggregation groups the results of a query
+type Aggregation struct {
+	GroupBy  []string           `json:"groupBy"`
+	Measures map[string]Measure `json:"measures"`
+}
+
+func (a Aggregation) Validate(kind ResourceKind) error {
+	schema, err := GetAggregationSchema(kind)
+	if err != nil {
+		return err
+	}
+	if len(a.GroupBy) == 0 {
+		return fmt.Errorf("at least one group key is required")
+	}
+	for i, key := range a.GroupBy {
+		if err := schema.validateKey(key); err != nil {
+			return err
+		}
+		if slices.Contains(a.GroupBy[:i], key) {
+			return fmt.Errorf("duplicate group key `%s`", key)
+		}
+	}
+	for name, measure := range a.Measures {
+		if name == "" {
+			return fmt.Errorf("measures must be named")
+		}
+		if !slices.Contains(Measures, measure.Function) {
+			return fmt.Errorf("invalid function `%s` for measure `%s`, expected one of %v", measure.Function, name, Measures)
+		}
+		switch {
+		case measure.Function == MeasureCount && measure.Property != "":
+			return fmt.Errorf("measure `%s`: function `count` does not take a property", name)
+		case measure.Function != MeasureCount && !slices.Contains(schema.Measures, measure.Property):
+			return fmt.Errorf("measure `%s`: cannot measure property `%s`, expected one of %v", name, measure.Property, schema.Measures)
+		}
+	}
+	return nil
+}
+
+func (s AggregationSchema) validateKey(key string) error {
+	name, idx, err := parseAccess(key)
+	if err != nil {
+		return fmt.Errorf("invalid group key `%s`: %w", key, err)
+	}
+	switch {
+	case slices.Contains(s.Keys, name) && idx == "":
+	case slices.Contains(s.MapKeys, name) && idx != "":
+	default:
+		return fmt.Errorf("invalid group key `%s`", key)
+	}
+	return nil
+}
+
+// ParseAggregationKey splits a group key into a property and an optional index (ex: `metadata[merchant_id]`)
+func ParseAggregationKey(key string) (string, string, error) {
+	return parseAccess(key)
+}
+
+// AggregationGroup is a group of an aggregation, with the values of its keys and of the measures
+type AggregationGroup struct {
+	// Keys are the values of the group keys, nil when the value is missing (ex: a missing metadata)
+	Keys     map[string]*string  `json:"keys"`
+	Measures map[string]*big.Int `json:"measures"`
+}
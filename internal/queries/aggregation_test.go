@@ -0,0 +1,112 @@
+package queries
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregationValidation(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		resource      ResourceKind
+		aggregation   Aggregation
+		expectedError string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:     "nominal",
+			resource: ResourceKindTransaction,
+			aggregation: Aggregation{
+				GroupBy: []string{"metadata[merchant_id]", "asset"},
+				Measures: map[string]Measure{
+					"total": {Function: MeasureSum, Property: "amount"},
+					"count": {Function: MeasureCount},
+				},
+			},
+		},
+		{
+			name:     "count by metadata",
+			resource: ResourceKindAccount,
+			aggregation: Aggregation{
+				GroupBy: []string{"metadata[tier]"},
+				Measures: map[string]Measure{
+					"count": {Function: MeasureCount},
+				},
+			},
+		},
+		{
+			name:          "unsupported resource",
+			resource:      ResourceKindAggregatedBalance,
+			aggregation:   Aggregation{GroupBy: []string{"address"}},
+			expectedError: "resource aggregatedBalances does not support aggregations",
+		},
+		{
+			name:          "missing group keys",
+			resource:      ResourceKindAccount,
+			aggregation:   Aggregation{},
+			expectedError: "at least one group key is required",
+		},
+		{
+			name:          "unknown group key",
+			resource:      ResourceKindAccount,
+			aggregation:   Aggregation{GroupBy: []string{"asset"}},
+			expectedError: "invalid group key `asset`",
+		},
+		{
+			name:          "map group key without index",
+			resource:      ResourceKindAccount,
+			aggregation:   Aggregation{GroupBy: []string{"metadata"}},
+			expectedError: "invalid group key `metadata`",
+		},
+		{
+			name:          "duplicate group key",
+			resource:      ResourceKindVolume,
+			aggregation:   Aggregation{GroupBy: []string{"asset", "asset"}},
+			expectedError: "duplicate group key `asset`",
+		},
+		{
+			name:     "invalid function",
+			resource: ResourceKindVolume,
+			aggregation: Aggregation{
+				GroupBy: []string{"asset"},
+				Measures: map[string]Measure{
+					"avg": {Function: "avg", Property: "balance"},
+				},
+			},
+			expectedError: "invalid function `avg` for measure `avg`",
+		},
+		{
+			name:     "invalid measured property",
+			resource: ResourceKindAccount,
+			aggregation: Aggregation{
+				GroupBy: []string{"address"},
+				Measures: map[string]Measure{
+					"total": {Function: MeasureSum, Property: "address"},
+				},
+			},
+			expectedError: "measure `total`: cannot measure property `address`",
+		},
+		{
+			name:     "count with a property",
+			resource: ResourceKindLog,
+			aggregation: Aggregation{
+				GroupBy: []string{"type"},
+				Measures: map[string]Measure{
+					"count": {Function: MeasureCount, Property: "id"},
+				},
+			},
+			expectedError: "function `count` does not take a property",
+		},
+	} {
+		err := tc.aggregation.Validate(tc.resource)
+		if tc.expectedError != "" {
+			require.ErrorContains(t, err, tc.expectedError, tc.name)
+		} else {
+			require.NoError(t, err, tc.name)
+		}
+	}
+}
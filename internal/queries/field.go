@@ -3,6 +3,7 @@ package queries
 import (
 	"fmt"
 	"math/big"
+	"regexp"
 
 	"github.com/formancehq/go-libs/v5/pkg/types/time"
 )
@@ -17,8 +18,12 @@ func FieldTypeFromString(s string) (FieldType, error) {
 		return NewTypeNumeric(), nil
 	case "string":
 		return NewTypeString(), nil
+	case "[]int":
+		return NewTypeList(NewTypeNumeric()), nil
+	case "[]string":
+		return NewTypeList(NewTypeString()), nil
 	default:
-		return nil, fmt.Errorf("invalid type `%s`, expected one of `boolean`, `date`, `int`, `string`", s)
+		return nil, fmt.Errorf("invalid type `%s`, expected one of `boolean`, `date`, `int`, `string`, `[]int`, `[]string`", s)
 	}
 }
 
@@ -30,6 +35,8 @@ func FieldTypeToString(t FieldType) string {
 		return "date"
 	case TypeMap:
 		return fmt.Sprintf("map[string]%s", FieldTypeToString(v.underlyingType))
+	case TypeList:
+		return fmt.Sprintf("[]%s", FieldTypeToString(v.underlyingType))
 	case TypeNumeric:
 		return "int"
 	case TypeString:
@@ -132,6 +139,9 @@ func (t TypeString) Operators() []string {
 		OperatorMatch,
 		OperatorLike,
 		OperatorIn,
+		OperatorRegex,
+		OperatorILike,
+		OperatorStartsWith,
 	}
 }
 
@@ -148,6 +158,14 @@ func (t TypeString) ValidateValue(operator string, value any) error {
 				return fmt.Errorf("expected string value in array for operator %s, got %T", OperatorIn, v)
 			}
 		}
+	case OperatorRegex:
+		pattern, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected string value for operator %s, got %T", OperatorRegex, value)
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid regular expression: %w", err)
+		}
 	default:
 		_, ok := value.(string)
 		if !ok {
@@ -298,3 +316,48 @@ func NewTypeBoolean() TypeBoolean {
 }
 
 var _ FieldType = (*TypeBoolean)(nil)
+
+// TypeList is the type of the list variables of the query templates.
+// Lists are only usable as the value of a `$in` filter on a field of their underlying type.
+type TypeList struct {
+	underlyingType FieldType
+}
+
+func (t TypeList) IsPaginated() bool {
+	return false
+}
+
+func (t TypeList) Index() FieldType {
+	return nil
+}
+
+func (t TypeList) UnderlyingType() FieldType {
+	return t.underlyingType
+}
+
+func (t TypeList) Operators() []string {
+	return []string{
+		OperatorIn,
+	}
+}
+
+func (t TypeList) ValidateValue(operator string, value any) error {
+	values, ok := value.([]any)
+	if !ok {
+		return fmt.Errorf("expected array value, got %T", value)
+	}
+	for _, v := range values {
+		if err := t.underlyingType.ValidateValue(operator, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func NewTypeList(underlyingType FieldType) TypeList {
+	return TypeList{
+		underlyingType: underlyingType,
+	}
+}
+
+var _ FieldType = (*TypeList)(nil)
@@ -35,7 +35,7 @@ func ValidateFilterBody(resource ResourceKind, body json.RawMessage, varDecls ma
 		return err
 	}
 
-	builder, err := query.ParseJSON(string(body))
+	builder, err := ParseJSON(string(body))
 	if err != nil {
 		return err
 	}
@@ -50,7 +50,7 @@ func ValidateFilterBody(resource ResourceKind, body json.RawMessage, varDecls ma
 		}
 		switch operator {
 		case OperatorIn:
-			// we expect the value to be a slice of the same type as fieldType
+			// we expect the value to be a slice of the same type as fieldType, or a list variable
 			if values, ok := (*value).([]any); ok {
 				for _, v := range values {
 					err := validateValue(fieldType, v, vars)
@@ -58,6 +58,8 @@ func ValidateFilterBody(resource ResourceKind, body json.RawMessage, varDecls ma
 						return err
 					}
 				}
+			} else if valueStr, ok := (*value).(string); ok && varRegex.MatchString(valueStr) {
+				return validateVarRef(NewTypeList(fieldType), valueStr, vars)
 			} else {
 				return fmt.Errorf("expected array, got `%T`", *value)
 			}
@@ -68,7 +70,7 @@ func ValidateFilterBody(resource ResourceKind, body json.RawMessage, varDecls ma
 			} else {
 				return fmt.Errorf("$exists can only be called on a map field, got: %T", fieldType)
 			}
-		case OperatorMatch, OperatorLike, OperatorLT, OperatorGT, OperatorLTE, OperatorGTE:
+		case OperatorMatch, OperatorLike, OperatorLT, OperatorGT, OperatorLTE, OperatorGTE, OperatorRegex, OperatorILike, OperatorStartsWith:
 			return validateValue(fieldType, *value, vars)
 		default:
 			return fmt.Errorf("unexpected operator: %s", operator)
@@ -91,6 +93,12 @@ func validateValue(expectedType FieldType, value any, vars map[string]FieldType)
 			if err != nil {
 				return err
 			}
+			_, varRefs, _ := ParseTemplate(valueStr)
+			for _, name := range varRefs {
+				if _, ok := vars[name].(TypeList); ok {
+					return fmt.Errorf("cannot interpolate list variable `%s`", name)
+				}
+			}
 		}
 	} else {
 		// otherwise check that the value's type matches
@@ -140,7 +148,20 @@ func ResolveFilterTemplate(resourceKind ResourceKind, body json.RawMessage, varD
 		return nil, err
 	}
 
-	builder, err := query.ParseJSON(string(body))
+	missingVars := map[string]struct{}{}
+	for k, v := range varDecls {
+		if _, ok := vars[k]; !ok && v.Optional {
+			missingVars[k] = struct{}{}
+		}
+	}
+	if len(missingVars) > 0 {
+		body, err = pruneFilterBody(body, missingVars)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	builder, err := ParseJSON(string(body))
 	if err != nil {
 		return nil, err
 	}
@@ -167,10 +188,112 @@ func ResolveFilterTemplate(resourceKind ResourceKind, body json.RawMessage, varD
 	return builder, nil
 }
 
+// pruneFilterBody removes the filter nodes using a missing optional variable.
+// Logical nodes left without operands are removed too.
+func pruneFilterBody(body json.RawMessage, missingVars map[string]struct{}) (json.RawMessage, error) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return body, nil
+	}
+	var filter map[string]any
+	if err := unmarshalWithNumber(body, &filter); err != nil {
+		return nil, err
+	}
+	if filter == nil {
+		return body, nil
+	}
+	pruned, err := pruneFilter(filter, missingVars)
+	if err != nil {
+		return nil, err
+	}
+	if pruned == nil {
+		return json.RawMessage("null"), nil
+	}
+	return json.Marshal(pruned)
+}
+
+func pruneFilter(filter map[string]any, missingVars map[string]struct{}) (map[string]any, error) {
+	for operator, value := range filter {
+		switch operator {
+		case "$and", "$or":
+			items, ok := value.([]any)
+			if !ok {
+				return nil, fmt.Errorf("expected array for operator %s, got %T", operator, value)
+			}
+			kept := make([]any, 0, len(items))
+			for _, item := range items {
+				itemFilter, ok := item.(map[string]any)
+				if !ok {
+					return nil, fmt.Errorf("expected object in operator %s, got %T", operator, item)
+				}
+				pruned, err := pruneFilter(itemFilter, missingVars)
+				if err != nil {
+					return nil, err
+				}
+				if pruned != nil {
+					kept = append(kept, pruned)
+				}
+			}
+			if len(kept) == 0 {
+				return nil, nil
+			}
+			filter[operator] = kept
+		case "$not":
+			notFilter, ok := value.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("expected object for operator %s, got %T", operator, value)
+			}
+			pruned, err := pruneFilter(notFilter, missingVars)
+			if err != nil {
+				return nil, err
+			}
+			if pruned == nil {
+				return nil, nil
+			}
+			filter[operator] = pruned
+		default:
+			keyValue, ok := value.(map[string]any)
+			if !ok {
+				continue
+			}
+			for _, v := range keyValue {
+				if usesVariable(v, missingVars) {
+					return nil, nil
+				}
+			}
+		}
+	}
+	return filter, nil
+}
+
+func usesVariable(value any, vars map[string]struct{}) bool {
+	switch v := value.(type) {
+	case string:
+		_, varRefs, err := ParseTemplate(v)
+		if err != nil {
+			return false
+		}
+		for _, name := range varRefs {
+			if _, ok := vars[name]; ok {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if usesVariable(item, vars) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func resolveFilter(operator string, fieldType FieldType, value any, vars map[string]any) (any, error) {
 	var err error
 	switch operator {
 	case OperatorIn:
+		if valueStr, ok := value.(string); ok {
+			return resolveList(fieldType, valueStr, vars)
+		}
 		// we expect the value to be a values of the same type as fieldType
 		if values, ok := value.([]any); ok {
 			for idx := range values {
@@ -198,7 +321,7 @@ func resolveFilter(operator string, fieldType FieldType, value any, vars map[str
 			return nil, fmt.Errorf("$exists can only be called on a map field, got: %T", fieldType)
 		}
 		return value, nil
-	case OperatorMatch, OperatorLike, OperatorLT, OperatorGT, OperatorLTE, OperatorGTE:
+	case OperatorMatch, OperatorLike, OperatorLT, OperatorGT, OperatorLTE, OperatorGTE, OperatorRegex, OperatorILike, OperatorStartsWith:
 		// we expect the field to be a map, and the value to match its underlying type
 		if valueStr, ok := value.(string); ok {
 			value, err = resolveValue(fieldType, valueStr, vars)
@@ -253,6 +376,61 @@ func resolveValue(fieldType FieldType, value string, vars map[string]any) (any,
 	}
 }
 
+// resolveList resolves a list variable used as the value of a `$in` filter
+func resolveList(fieldType FieldType, value string, vars map[string]any) ([]any, error) {
+	name, err := extractVariableName(value)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := vars[name]
+	if !ok {
+		return nil, fmt.Errorf("missing variable: `%s`", name)
+	}
+	values, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("cannot use variable `%s` as type `%s`", name, FieldTypeToString(NewTypeList(fieldType)))
+	}
+
+	ret := make([]any, 0, len(values))
+	for _, item := range values {
+		switch fieldType.(type) {
+		case TypeString:
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected string values in variable `%s`, got %T", name, item)
+			}
+			ret = append(ret, str)
+		case TypeNumeric:
+			n, err := toBigInt(item)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value in variable `%s`: %w", name, err)
+			}
+			ret = append(ret, n)
+		default:
+			return nil, fmt.Errorf("unexpected FieldType: %#v", fieldType)
+		}
+	}
+	return ret, nil
+}
+
+func toBigInt(value any) (*big.Int, error) {
+	switch v := value.(type) {
+	case json.Number:
+		if x, ok := new(big.Int).SetString(string(v), 10); ok {
+			return x, nil
+		}
+	case float64:
+		if x, acc := new(big.Float).SetFloat64(v).Int(nil); acc == big.Exact {
+			return x, nil
+		}
+	case *big.Int:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("expected numeric value, got %T", value)
+	}
+	return nil, fmt.Errorf("provided number should be an integer: %v", value)
+}
+
 var varRegex = regexp.MustCompile(`^\${([a-z_]+)}$`)
 
 func extractVariableName(s string) (string, error) {
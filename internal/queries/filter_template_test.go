@@ -5,8 +5,6 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
-
-	"github.com/formancehq/go-libs/v5/pkg/query"
 )
 
 func TestFilterTemplateValidation(t *testing.T) {
@@ -118,6 +116,49 @@ func TestFilterTemplateValidation(t *testing.T) {
 			}}`,
 			expectedError: "$exists can only be called on a map field",
 		},
+		{
+			name:            "list variable",
+			resource:        ResourceKindAccount,
+			varDeclarations: map[string]VarDecl{"addresses": {Type: NewTypeList(NewTypeString())}},
+			source: `{"$in": {
+				"address": "${addresses}"
+			}}`,
+		},
+		{
+			name:            "list variable of the wrong type",
+			resource:        ResourceKindAccount,
+			varDeclarations: map[string]VarDecl{"addresses": {Type: NewTypeList(NewTypeNumeric())}},
+			source: `{"$in": {
+				"address": "${addresses}"
+			}}`,
+			expectedError: "cannot use variable `addresses` as type `[]string`",
+		},
+		{
+			name:            "list variable outside of $in",
+			resource:        ResourceKindAccount,
+			varDeclarations: map[string]VarDecl{"addresses": {Type: NewTypeList(NewTypeString())}},
+			source: `{"$match": {
+				"address": "${addresses}"
+			}}`,
+			expectedError: "cannot interpolate list variable `addresses`",
+		},
+		{
+			name:            "chart label",
+			resource:        ResourceKindAccount,
+			varDeclarations: map[string]VarDecl{"user": {Type: NewTypeString()}},
+			source: `{"$match": {
+				"chart.userID": "${user}"
+			}}`,
+		},
+		{
+			name:            "label on a non-map field",
+			resource:        ResourceKindAccount,
+			varDeclarations: map[string]VarDecl{},
+			source: `{"$match": {
+				"address.userID": "foo"
+			}}`,
+			expectedError: "unexpected field indexing",
+		},
 	} {
 		err := ValidateFilterBody(tc.resource, json.RawMessage(tc.source), tc.varDeclarations)
 
@@ -151,6 +192,26 @@ func TestFilterTemplateResolution(t *testing.T) {
 			vars:            map[string]any{},
 			expectedFilter:  `null`,
 		},
+		{
+			name:     "pattern operator substitution",
+			resource: ResourceKindTransaction,
+			varDeclarations: map[string]VarDecl{
+				"prefix": {Type: NewTypeString()},
+			},
+			source: `{
+				"$startsWith": {
+					"reference": "INV-${prefix}"
+				}
+			}`,
+			vars: map[string]any{
+				"prefix": "2024",
+			},
+			expectedFilter: `{
+				"$startsWith": {
+					"reference": "INV-2024"
+				}
+			}`,
+		},
 		{
 			name:            "simple filter",
 			resource:        ResourceKindAccount,
@@ -332,6 +393,105 @@ func TestFilterTemplateResolution(t *testing.T) {
 			},
 			expectedError: "invalid value `nope` for type `int`",
 		},
+		{
+			name:     "string list variable",
+			resource: ResourceKindAccount,
+			varDeclarations: map[string]VarDecl{
+				"addresses": {Type: NewTypeList(NewTypeString())},
+			},
+			source: `{"$in": {
+				"address": "${addresses}"
+			}}`,
+			vars: map[string]any{
+				"addresses": []any{"users:001", "users:002"},
+			},
+			expectedFilter: `{"$in": {
+				"address": ["users:001", "users:002"]
+			}}`,
+		},
+		{
+			name:     "int list variable",
+			resource: ResourceKindTransaction,
+			varDeclarations: map[string]VarDecl{
+				"ids": {Type: NewTypeList(NewTypeNumeric())},
+			},
+			source: `{"$in": {
+				"id": "${ids}"
+			}}`,
+			vars: map[string]any{
+				"ids": []any{json.Number("1"), float64(2)},
+			},
+			expectedFilter: `{"$in": {
+				"id": [1, 2]
+			}}`,
+		},
+		{
+			name:     "invalid list variable value",
+			resource: ResourceKindTransaction,
+			varDeclarations: map[string]VarDecl{
+				"ids": {Type: NewTypeList(NewTypeNumeric())},
+			},
+			source: `{"$in": {
+				"id": "${ids}"
+			}}`,
+			vars: map[string]any{
+				"ids": []any{"nope"},
+			},
+			expectedError: "invalid value `[nope]` for type `[]int`",
+		},
+		{
+			name:     "optional variable provided",
+			resource: ResourceKindAccount,
+			varDeclarations: map[string]VarDecl{
+				"foo": {Type: NewTypeString(), Optional: true},
+			},
+			source: `{"$and": [
+				{"$match": {"metadata[foo]": "${foo}"}},
+				{"$match": {"address": "users:"}}
+			]}`,
+			vars: map[string]any{
+				"foo": "bar",
+			},
+			expectedFilter: `{"$and": [
+				{"$match": {"metadata[foo]": "bar"}},
+				{"$match": {"address": "users:"}}
+			]}`,
+		},
+		{
+			name:     "optional variables not provided",
+			resource: ResourceKindAccount,
+			varDeclarations: map[string]VarDecl{
+				"foo":       {Type: NewTypeString(), Optional: true},
+				"addresses": {Type: NewTypeList(NewTypeString()), Optional: true},
+				"balance":   {Type: NewTypeNumeric(), Optional: true},
+			},
+			source: `{"$and": [
+				{"$match": {"metadata[foo]": "prefix:${foo}"}},
+				{"$not": {"$in": {"address": "${addresses}"}}},
+				{"$or": [
+					{"$gt": {"balance[USD]": "${balance}"}},
+					{"$match": {"address": "users:"}}
+				]}
+			]}`,
+			vars: map[string]any{},
+			expectedFilter: `{"$and": [
+				{"$or": [
+					{"$match": {"address": "users:"}}
+				]}
+			]}`,
+		},
+		{
+			name:     "whole filter pruned",
+			resource: ResourceKindAccount,
+			varDeclarations: map[string]VarDecl{
+				"foo": {Type: NewTypeString(), Optional: true},
+			},
+			source: `{"$and": [
+				{"$match": {"metadata[foo]": "${foo}"}}
+			]}`,
+			vars:           map[string]any{},
+			expectedFilter: `null`,
+		},
 	} {
 		err := ValidateFilterBody(tc.resource, json.RawMessage(tc.source), tc.varDeclarations)
 		require.NoError(t, err, tc.name)
@@ -340,7 +500,7 @@ func TestFilterTemplateResolution(t *testing.T) {
 		if tc.expectedError == "" {
 			require.NoError(t, err, tc.name)
 
-			expected, err := query.ParseJSON(tc.expectedFilter)
+			expected, err := ParseJSON(tc.expectedFilter)
 			require.NoError(t, err, tc.name)
 			require.Equal(t, expected, resolved, tc.name)
 		} else {
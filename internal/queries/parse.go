@@ -0,0 +1,190 @@
+package queries
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/formancehq/go-libs/v5/pkg/query"
+)
+
+// keyValue is a leaf of a filter using an operator unknown to the query package
+type keyValue struct {
+	operator string
+	key      string
+	value    any
+}
+
+func (kv *keyValue) Walk(f func(operator string, key string, value *any) error) error {
+	return f(kv.operator, kv.key, &kv.value)
+}
+
+func (kv *keyValue) Build(ctx query.Context) (string, []any, error) {
+	return ctx.BuildMatcher(kv.key, kv.operator, kv.value)
+}
+
+func (kv *keyValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		kv.operator: map[string]any{
+			kv.key: kv.value,
+		},
+	})
+}
+
+var _ query.Builder = (*keyValue)(nil)
+
+func Regex(key string, value any) query.Builder {
+	return &keyValue{operator: OperatorRegex, key: key, value: value}
+}
+
+func ILike(key string, value any) query.Builder {
+	return &keyValue{operator: OperatorILike, key: key, value: value}
+}
+
+func StartsWith(key string, value any) query.Builder {
+	return &keyValue{operator: OperatorStartsWith, key: key, value: value}
+}
+
+var leafOperators = map[string]func(key string, value any) query.Builder{
+	OperatorMatch:      func(key string, value any) query.Builder { return query.Match(key, value) },
+	OperatorLike:       func(key string, value any) query.Builder { return query.Like(key, value) },
+	OperatorIn:         func(key string, value any) query.Builder { return query.In(key, value) },
+	OperatorExists:     func(key string, value any) query.Builder { return query.Exists(key, value) },
+	OperatorLT:         func(key string, value any) query.Builder { return query.Lt(key, value) },
+	OperatorGT:         func(key string, value any) query.Builder { return query.Gt(key, value) },
+	OperatorLTE:        func(key string, value any) query.Builder { return query.Lte(key, value) },
+	OperatorGTE:        func(key string, value any) query.Builder { return query.Gte(key, value) },
+	OperatorRegex:      Regex,
+	OperatorILike:      ILike,
+	OperatorStartsWith: StartsWith,
+}
+
+// ParseJSON parses a filter like query.ParseJSON, with the pattern operators of the ledger
+func ParseJSON(data string) (query.Builder, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader([]byte(data)))
+	dec.UseNumber()
+	var decoded any
+	if err := dec.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	if decoded == nil {
+		return nil, nil
+	}
+
+	m, ok := decoded.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T", decoded)
+	}
+	if len(m) == 0 {
+		return nil, nil
+	}
+
+	return parseExpression(m)
+}
+
+func parseExpression(m map[string]any) (query.Builder, error) {
+	operator, value, err := singleKey(m)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operator {
+	case "$and", "$or":
+		items, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("parsing %s: unexpected type %T", operator, value)
+		}
+		var builders []query.Builder
+		for i, item := range items {
+			sub, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("unexpected type %T when decoding %s clause at index %d", item, operator, i)
+			}
+			builder, err := parseExpression(sub)
+			if err != nil {
+				return nil, err
+			}
+			builders = append(builders, builder)
+		}
+		if operator == "$and" {
+			return query.And(builders...), nil
+		}
+		return query.Or(builders...), nil
+	case "$not":
+		sub, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("parsing %s: unexpected type %T", operator, value)
+		}
+		builder, err := parseExpression(sub)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", operator, err)
+		}
+		return query.Not(builder), nil
+	default:
+		newLeaf, ok := leafOperators[operator]
+		if !ok {
+			return nil, fmt.Errorf("unexpected operator %s", operator)
+		}
+		kv, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("parsing %s: unexpected type %T", operator, value)
+		}
+		key, value, err := singleKey(kv)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", operator, err)
+		}
+		value, err = convertNumbersToBigInt(value)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", operator, err)
+		}
+		return newLeaf(key, value), nil
+	}
+}
+
+func singleKey(m map[string]any) (string, any, error) {
+	switch len(m) {
+	case 0:
+		return "", nil, fmt.Errorf("expected single key, found none")
+	case 1:
+		for key, value := range m {
+			return key, value, nil
+		}
+	}
+	return "", nil, fmt.Errorf("expected single key, found more then one")
+}
+
+// convertNumbersToBigInt converts the numbers of a filter value to big.Int, as done by the query package
+func convertNumbersToBigInt(v any) (any, error) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			converted, err := convertNumbersToBigInt(item)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = converted
+		}
+		return v, nil
+	case []any:
+		for i, item := range v {
+			converted, err := convertNumbersToBigInt(item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = converted
+		}
+		return v, nil
+	case json.Number:
+		if ret, ok := new(big.Int).SetString(v.String(), 10); ok {
+			return ret, nil
+		}
+		return nil, fmt.Errorf("provided json number was not an integer")
+	default:
+		return v, nil
+	}
+}
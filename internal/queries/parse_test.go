@@ -0,0 +1,89 @@
+package queries
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/formancehq/go-libs/v5/pkg/query"
+)
+
+func TestParseJSON(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name            string
+		source          string
+		expectedBuilder query.Builder
+		expectedError   string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:   "empty",
+			source: `{}`,
+		},
+		{
+			name:            "match",
+			source:          `{"$match": {"metadata[foo]": "bar"}}`,
+			expectedBuilder: query.Match("metadata[foo]", "bar"),
+		},
+		{
+			name:            "numbers",
+			source:          `{"$gt": {"balance[USD]": 100}}`,
+			expectedBuilder: query.Gt("balance[USD]", big.NewInt(100)),
+		},
+		{
+			name: "pattern operators",
+			source: `{"$and": [
+				{"$regex": {"reference": "^INV-[0-9]+$"}},
+				{"$not": {"$ilike": {"metadata[customer]": "%acme%"}}},
+				{"$or": [{"$startsWith": {"reference": "INV-"}}]}
+			]}`,
+			expectedBuilder: query.And(
+				Regex("reference", "^INV-[0-9]+$"),
+				query.Not(ILike("metadata[customer]", "%acme%")),
+				query.Or(StartsWith("reference", "INV-")),
+			),
+		},
+		{
+			name:          "unknown operator",
+			source:        `{"$nope": {"reference": "INV"}}`,
+			expectedError: "unexpected operator $nope",
+		},
+		{
+			name:          "multiple keys",
+			source:        `{"$regex": {"reference": "INV", "address": "INV"}}`,
+			expectedError: "expected single key",
+		},
+	} {
+		builder, err := ParseJSON(tc.source)
+		if tc.expectedError != "" {
+			require.ErrorContains(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.expectedBuilder, builder, tc.name)
+
+		if builder != nil {
+			// the builders must round trip, as they are stored in the cursors
+			data, err := json.Marshal(builder)
+			require.NoError(t, err, tc.name)
+			parsed, err := ParseJSON(string(data))
+			require.NoError(t, err, tc.name)
+			require.Equal(t, builder, parsed, tc.name)
+		}
+	}
+}
+
+func TestValidatePatternOperators(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, NewTypeString().ValidateValue(OperatorRegex, "^INV-[0-9]+$"))
+	require.NoError(t, NewTypeString().ValidateValue(OperatorStartsWith, "INV-"))
+	require.ErrorContains(t, NewTypeString().ValidateValue(OperatorRegex, "INV-(["), "invalid regular expression")
+	require.ErrorContains(t, NewTypeString().ValidateValue(OperatorILike, 42), "expected string value")
+	require.Contains(t, NewStringMapField().Type.Operators(), OperatorStartsWith)
+}
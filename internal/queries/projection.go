@@ -0,0 +1,111 @@
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// AccountFields are the fields of the accounts usable in a projection
+var AccountFields = []string{"address", "metadata", "firstUsage", "insertionDate", "updatedAt", "volumes", "effectiveVolumes", "chart"}
+
+// LogFields are the fields of the logs usable in a projection
+var LogFields = []string{"id", "type", "data", "date", "idempotencyKey", "idempotencyHash", "hash", "schemaVersion"}
+
+// TransactionFields are the fields of the transactions usable in a projection
+var TransactionFields = []string{"id", "timestamp", "reference", "postings", "metadata", "insertedAt", "updatedAt", "revertedAt", "template", "revertedAmounts", "postCommitVolumes", "postCommitEffectiveVolumes"}
+
+// fieldExpansions are the expansions fetching the fields of a projection
+var fieldExpansions = map[string]string{
+	"volumes":                    "volumes",
+	"effectiveVolumes":           "effectiveVolumes",
+	"chart":                      "chart",
+	"postCommitVolumes":          "volumes",
+	"postCommitEffectiveVolumes": "effectiveVolumes",
+}
+
+// derivedFields are the rendered fields computed from a field of a projection
+var derivedFields = map[string][]string{
+	"revertedAt":                 {"reverted"},
+	"revertedAmounts":            {"revertibleAmounts"},
+	"postCommitVolumes":          {"preCommitVolumes"},
+	"postCommitEffectiveVolumes": {"preCommitEffectiveVolumes"},
+}
+
+func GetProjectableFields(kind ResourceKind) ([]string, error) {
+	switch kind {
+	case ResourceKindAccount:
+		return AccountFields, nil
+	case ResourceKindLog:
+		return LogFields, nil
+	case ResourceKindTransaction:
+		return TransactionFields, nil
+	default:
+		return nil, fmt.Errorf("resource %s does not support projections", kind)
+	}
+}
+
+// ValidateFields checks the fields of a projection
+func ValidateFields(kind ResourceKind, fields []string) error {
+	projectableFields, err := GetProjectableFields(kind)
+	if err != nil {
+		return err
+	}
+	for i, field := range fields {
+		if !slices.Contains(projectableFields, field) {
+			return fmt.Errorf("unknown field `%s`, expected one of %v", field, projectableFields)
+		}
+		if slices.Contains(fields[:i], field) {
+			return fmt.Errorf("duplicate field `%s`", field)
+		}
+	}
+	return nil
+}
+
+// ProjectExpand returns the expansions of a query, when the query has a projection
+// they are governed by its fields instead of the requested expansions
+func ProjectExpand(fields, expand []string) []string {
+	if len(fields) == 0 {
+		return expand
+	}
+	ret := make([]string, 0)
+	for _, field := range fields {
+		if expansion, ok := fieldExpansions[field]; ok && !slices.Contains(ret, expansion) {
+			ret = append(ret, expansion)
+		}
+	}
+	return ret
+}
+
+// Projection is an item returned by a query with a projection, only the projected fields
+// and the fields derived from them are rendered
+type Projection struct {
+	Item   any
+	Fields []string
+}
+
+func (p Projection) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(p.Item)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Fields) == 0 {
+		return data, nil
+	}
+
+	rendered := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &rendered); err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]json.RawMessage)
+	for _, field := range p.Fields {
+		for _, key := range append([]string{field}, derivedFields[field]...) {
+			if value, ok := rendered[key]; ok {
+				ret[key] = value
+			}
+		}
+	}
+
+	return json.Marshal(ret)
+}
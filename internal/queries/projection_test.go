@@ -0,0 +1,86 @@
+package queries
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFields(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		resource      ResourceKind
+		fields        []string
+		expectedError string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:     "transactions",
+			resource: ResourceKindTransaction,
+			fields:   []string{"id", "timestamp", "metadata"},
+		},
+		{
+			name:     "logs",
+			resource: ResourceKindLog,
+			fields:   []string{"id", "type"},
+		},
+		{
+			name:          "unknown field",
+			resource:      ResourceKindAccount,
+			fields:        []string{"address", "id"},
+			expectedError: "unknown field `id`",
+		},
+		{
+			name:          "duplicate field",
+			resource:      ResourceKindAccount,
+			fields:        []string{"address", "address"},
+			expectedError: "duplicate field `address`",
+		},
+		{
+			name:          "unsupported resource",
+			resource:      ResourceKindVolume,
+			fields:        []string{"account"},
+			expectedError: "resource volumes does not support projections",
+		},
+	} {
+		err := ValidateFields(tc.resource, tc.fields)
+		if tc.expectedError != "" {
+			require.ErrorContains(t, err, tc.expectedError, tc.name)
+		} else {
+			require.NoError(t, err, tc.name)
+		}
+	}
+}
+
+func TestProjectExpand(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{"volumes"}, ProjectExpand(nil, []string{"volumes"}))
+	require.Empty(t, ProjectExpand([]string{"id"}, []string{"volumes", "effectiveVolumes"}))
+	require.Equal(t, []string{"effectiveVolumes", "volumes"}, ProjectExpand(
+		[]string{"postCommitEffectiveVolumes", "id", "postCommitVolumes"},
+		nil,
+	))
+	require.Equal(t, []string{"chart"}, ProjectExpand([]string{"address", "chart"}, []string{"volumes"}))
+}
+
+func TestProjectionMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(Projection{
+		Item: map[string]any{
+			"id":               1,
+			"postings":         []any{},
+			"revertedAt":       nil,
+			"reverted":         false,
+			"preCommitVolumes": map[string]any{},
+		},
+		Fields: []string{"id", "revertedAt"},
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id": 1, "revertedAt": null, "reverted": false}`, string(data))
+}
@@ -11,6 +11,8 @@ const (
 	ResourceKindAccount     ResourceKind = "accounts"
 	ResourceKindLog         ResourceKind = "logs"
 	ResourceKindVolume      ResourceKind = "volumes"
+	// ResourceKindAggregatedBalance is not paginated, queries return a single page with the balances aggregated by asset
+	ResourceKindAggregatedBalance ResourceKind = "aggregatedBalances"
 )
 
 var Resources []ResourceKind = []ResourceKind{
@@ -18,6 +20,7 @@ var Resources []ResourceKind = []ResourceKind{
 	ResourceKindAccount,
 	ResourceKindLog,
 	ResourceKindVolume,
+	ResourceKindAggregatedBalance,
 }
 
 var AccountSchema EntitySchema = EntitySchema{
@@ -28,6 +31,8 @@ var AccountSchema EntitySchema = EntitySchema{
 		"metadata":       NewStringMapField(),
 		"insertion_date": NewDateField().Paginated(),
 		"updated_at":     NewDateField().Paginated(),
+		// chart labels (`chart.userID`) are resolved to address filters by the controller
+		"chart": NewStringMapField(),
 	},
 }
 
@@ -53,6 +58,15 @@ var SchemaSchema EntitySchema = EntitySchema{
 	},
 }
 
+var RecurrenceSchema EntitySchema = EntitySchema{
+	Fields: map[string]Field{
+		"inserted_at":     NewDateField().Paginated(),
+		"next_occurrence": NewDateField(),
+		"status":          NewStringField(),
+		"template":        NewStringField(),
+	},
+}
+
 var TransactionSchema EntitySchema = EntitySchema{
 	Fields: map[string]Field{
 		"reverted":    NewBooleanField(),
@@ -90,6 +104,8 @@ func GetResourceSchema(kind ResourceKind) (*EntitySchema, error) {
 		return &TransactionSchema, nil
 	case ResourceKindVolume:
 		return &VolumeSchema, nil
+	case ResourceKindAggregatedBalance:
+		return &AggregatedBalanceSchema, nil
 	default:
 		return nil, fmt.Errorf("unexpected resources.ResourceKind: %#v", kind)
 	}
@@ -16,8 +16,17 @@ const (
 	OperatorGT     = "$gt"
 	OperatorLTE    = "$lte"
 	OperatorGTE    = "$gte"
+	// OperatorRegex matches a regular expression, which must also be valid for the RE2 syntax
+	OperatorRegex = "$regex"
+	// OperatorILike is the case-insensitive version of OperatorLike
+	OperatorILike = "$ilike"
+	// OperatorStartsWith matches a prefix, the wildcards of the prefix being escaped
+	OperatorStartsWith = "$startsWith"
 )
 
+// PatternOperators are the operators matching a pattern on a string
+var PatternOperators = []string{OperatorRegex, OperatorILike, OperatorStartsWith}
+
 type EntitySchema struct {
 	Fields map[string]Field
 }
@@ -52,12 +61,16 @@ func (s EntitySchema) GetFieldType(access string) (FieldType, error) {
 	return fieldType, nil
 }
 
-var accessRegex = regexp.MustCompile(`^([a-z_]+)(?:\[([a-zA-Z0-9_/]+)\])?$`)
+// accessRegex matches a field, optionally indexed with `[key]`, or `.key` for the labels of the chart of accounts
+var accessRegex = regexp.MustCompile(`^([a-z_]+)(?:\[([a-zA-Z0-9_/]+)\]|\.([a-zA-Z0-9_-]+))?$`)
 
 func parseAccess(input string) (string, string, error) {
 	m := accessRegex.FindStringSubmatch(input)
 	if m == nil {
 		return "", "", errors.New("invalid field name")
 	}
+	if m[3] != "" {
+		return m[1], m[3], nil
+	}
 	return m[1], m[2], nil
 }
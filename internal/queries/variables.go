@@ -12,6 +12,8 @@ import (
 type VarDecl struct {
 	Type    FieldType
 	Default any
+	// Optional variables can be omitted, the filter nodes using them are then pruned
+	Optional bool
 }
 
 func (p *VarDecl) UnmarshalJSON(b []byte) error {
@@ -23,30 +25,37 @@ func (p *VarDecl) UnmarshalJSON(b []byte) error {
 	}
 	// handle full object case
 	var a struct {
-		Type    string `json:"type,omitempty"`
-		Default any    `json:"default"`
+		Type     string `json:"type,omitempty"`
+		Default  any    `json:"default"`
+		Optional bool   `json:"optional,omitempty"`
 	}
 	if err := unmarshalWithNumber(b, &a); err != nil {
 		return err
 	}
 	var err error
 	p.Default = a.Default
+	p.Optional = a.Optional
 	p.Type, err = FieldTypeFromString(a.Type)
 	return err
 }
 
 func (p VarDecl) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
-		Type    string `json:"type"`
-		Default any    `json:"default,omitempty"`
+		Type     string `json:"type"`
+		Default  any    `json:"default,omitempty"`
+		Optional bool   `json:"optional,omitempty"`
 	}{
-		Type:    FieldTypeToString(p.Type),
-		Default: p.Default,
+		Type:     FieldTypeToString(p.Type),
+		Default:  p.Default,
+		Optional: p.Optional,
 	})
 }
 
 func ValidateVarDeclarations(vars map[string]VarDecl) error {
 	for name, decl := range vars {
+		if decl.Optional && decl.Default != nil {
+			return fmt.Errorf("variable `%s` cannot be optional and have a default", name)
+		}
 		// validate default
 		err := validateValueType(decl.Type, decl.Default)
 		if err != nil {
@@ -59,7 +68,7 @@ func ValidateVarDeclarations(vars map[string]VarDecl) error {
 // expectedType must be non-nil
 func validateValueType(expectedType FieldType, v any) error {
 	var err error
-	switch expectedType.(type) {
+	switch t := expectedType.(type) {
 	case TypeBoolean:
 		err = castAndValidateValue[bool](v, nil)
 	case TypeDate:
@@ -91,6 +100,18 @@ func validateValueType(expectedType FieldType, v any) error {
 
 	case TypeString:
 		err = castAndValidateValue[string](v, nil)
+	case TypeList:
+		err = castAndValidateValue(v, func(values []any) error {
+			for _, value := range values {
+				if value == nil {
+					return errors.New("list values cannot be null")
+				}
+				if err := validateValueType(t.underlyingType, value); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 	default:
 		err = fmt.Errorf("type cannot be constructed, you may need to specify a key with `[my_key]`")
 	}
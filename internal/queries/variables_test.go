@@ -52,6 +52,29 @@ func TestVariableDeclarationMarshal(t *testing.T) {
 			},
 			expectedRoundtrip: true,
 		},
+		{
+			name: "lists and optional variables",
+			source: `{
+				"foo": {
+					"type": "[]string",
+					"default": ["a", "b"]
+				},
+				"bar": {
+					"type": "[]int",
+					"optional": true
+				}
+			}`,
+			expectedVars: map[string]VarDecl{
+				"foo": {Type: NewTypeList(NewTypeString()), Default: []any{"a", "b"}},
+				"bar": {Type: NewTypeList(NewTypeNumeric()), Optional: true},
+			},
+			expectedRoundtrip: true,
+		},
+		{
+			name:          "unknown list type",
+			source:        `{"foo": "[]date"}`,
+			expectedError: "invalid type `[]date`",
+		},
 	} {
 		var actualVars map[string]VarDecl
 		err := json.Unmarshal([]byte(tc.source), &actualVars)
@@ -94,6 +117,20 @@ func TestVariableValidaton(t *testing.T) {
 			},
 			expectedError: "invalid value `133.7` for type `int`",
 		},
+		{
+			name: "invalid list item",
+			vars: map[string]VarDecl{
+				"foo": {Type: NewTypeList(NewTypeNumeric()), Default: []any{json.Number("1"), "2"}},
+			},
+			expectedError: "invalid value `[1 2]` for type `[]int`",
+		},
+		{
+			name: "optional variable with a default",
+			vars: map[string]VarDecl{
+				"foo": {Type: NewTypeString(), Default: "bar", Optional: true},
+			},
+			expectedError: "variable `foo` cannot be optional and have a default",
+		},
 	} {
 		err := ValidateVarDeclarations(tc.vars)
 
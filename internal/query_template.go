@@ -102,6 +102,10 @@ type QueryTemplate struct {
 	Params      json.RawMessage            `json:"params,omitempty"`
 	Vars        map[string]queries.VarDecl `json:"vars,omitempty"`
 	Body        json.RawMessage            `json:"body,omitempty"`
+	// Aggregation groups the results, the query then returns the groups instead of the items
+	Aggregation *queries.Aggregation `json:"aggregation,omitempty"`
+	// Fields restricts the fields of the returned items
+	Fields []string `json:"fields,omitempty"`
 }
 
 // Validate a query template
@@ -132,6 +136,13 @@ func (q QueryTemplate) Validate() error {
 			}
 			var opts GetVolumesOptions
 			err = unmarshalWithNumber(q.Params, &opts)
+		case queries.ResourceKindAggregatedBalance:
+			err = checkForExtraFields(q.Params, []string{"useInsertionDate"})
+			if err != nil {
+				return fmt.Errorf("invalid params: %w", err)
+			}
+			var opts GetAggregatedVolumesOptions
+			err = unmarshalWithNumber(q.Params, &opts)
 		default:
 			err = checkForExtraFields(q.Params, []string{})
 		}
@@ -151,6 +162,19 @@ func (q QueryTemplate) Validate() error {
 			return fmt.Errorf("failed to validate filter body: %w", err)
 		}
 	}
+	if q.Aggregation != nil {
+		if err := q.Aggregation.Validate(q.Resource); err != nil {
+			return fmt.Errorf("invalid aggregation: %w", err)
+		}
+	}
+	if len(q.Fields) > 0 {
+		if q.Aggregation != nil {
+			return fmt.Errorf("fields cannot be used with an aggregation")
+		}
+		if err := queries.ValidateFields(q.Resource, q.Fields); err != nil {
+			return fmt.Errorf("invalid fields: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -90,6 +90,75 @@ func TestQueryTemplateValidation(t *testing.T) {
 				}`),
 			},
 		},
+		{
+			name: "aggregation",
+			source: `{
+				"resource": "transactions",
+				"aggregation": {
+					"groupBy": ["metadata[merchant_id]", "asset"],
+					"measures": {
+						"total": {"fn": "sum", "property": "amount"}
+					}
+				}
+			}`,
+			expectedTemplate: QueryTemplate{
+				Resource: queries.ResourceKindTransaction,
+				Aggregation: &queries.Aggregation{
+					GroupBy: []string{"metadata[merchant_id]", "asset"},
+					Measures: map[string]queries.Measure{
+						"total": {Function: queries.MeasureSum, Property: "amount"},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid aggregation",
+			source: `{
+				"resource": "accounts",
+				"aggregation": {
+					"groupBy": ["asset"]
+				}
+			}`,
+			expectedError: "invalid aggregation: invalid group key `asset`",
+		},
+		{
+			name: "fields",
+			source: `{
+				"resource": "transactions",
+				"fields": ["id", "timestamp"]
+			}`,
+			expectedTemplate: QueryTemplate{
+				Resource: queries.ResourceKindTransaction,
+				Fields:   []string{"id", "timestamp"},
+			},
+		},
+		{
+			name: "unknown field",
+			source: `{
+				"resource": "accounts",
+				"fields": ["address", "postings"]
+			}`,
+			expectedError: "invalid fields: unknown field `postings`",
+		},
+		{
+			name: "fields on volumes",
+			source: `{
+				"resource": "volumes",
+				"fields": ["account"]
+			}`,
+			expectedError: "invalid fields: resource volumes does not support projections",
+		},
+		{
+			name: "fields with an aggregation",
+			source: `{
+				"resource": "transactions",
+				"fields": ["id"],
+				"aggregation": {
+					"groupBy": ["asset"]
+				}
+			}`,
+			expectedError: "fields cannot be used with an aggregation",
+		},
 		{
 			source: `{
 				"description": "unknown resource kind",
@@ -126,6 +195,16 @@ func TestQueryTemplateValidation(t *testing.T) {
 			}`,
 			expectedError: "cannot unmarshal",
 		},
+		{
+			name: "invalid aggregated balances params",
+			source: `{
+				"resource": "aggregatedBalances",
+				"params": {
+					"groupBy": 2
+				}
+			}`,
+			expectedError: "invalid params: unknown field: `groupBy`",
+		},
 		{
 			name: "filter validation error",
 			source: `{
@@ -0,0 +1,236 @@
+package ledger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	stdtime "time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/uptrace/bun"
+
+	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+)
+
+type RecurrenceStatus string
+
+const (
+	RecurrenceStatusActive    RecurrenceStatus = "ACTIVE"
+	RecurrenceStatusPaused    RecurrenceStatus = "PAUSED"
+	RecurrenceStatusCompleted RecurrenceStatus = "COMPLETED"
+)
+
+// Recurrence creates transactions from a template of the schema on each occurrence of a schedule.
+// The transactions are effective at the date of the occurrence.
+type Recurrence struct {
+	bun.BaseModel `bun:"table:recurrences,alias:recurrences"`
+
+	ID string `json:"id" bun:"id,type:varchar"`
+	// Schedule is either a cron expression (ex: `0 0 1 * *`) or a RRULE (ex: `FREQ=MONTHLY;INTERVAL=1`), evaluated in UTC from StartAt
+	Schedule      string            `json:"schedule" bun:"schedule"`
+	StartAt       time.Time         `json:"startAt" bun:"start_at,type:timestamp without time zone"`
+	Template      string            `json:"template" bun:"template"`
+	Vars          map[string]string `json:"vars" bun:"vars,type:jsonb"`
+	Metadata      metadata.Metadata `json:"metadata" bun:"metadata,type:jsonb"`
+	SchemaVersion string            `json:"schemaVersion" bun:"schema_version"`
+	// Accounts is a path of the chart (ex: `users:$userID:main`).
+	// When set, each occurrence creates a transaction for each account matching the path, the address being passed in the variable AccountVar.
+	Accounts   string           `json:"accounts,omitempty" bun:"accounts,nullzero"`
+	AccountVar string           `json:"accountVar,omitempty" bun:"account_var,nullzero"`
+	Status     RecurrenceStatus `json:"status" bun:"status,type:varchar"`
+	// NextOccurrence is nil once the schedule has no more occurrence
+	NextOccurrence *time.Time `json:"nextOccurrence,omitempty" bun:"next_occurrence,type:timestamp without time zone,nullzero"`
+	LastOccurrence *time.Time `json:"lastOccurrence,omitempty" bun:"last_occurrence,type:timestamp without time zone,nullzero"`
+	// AccountsOffset is the number of accounts already processed for the next occurrence, the accounts being processed by batches
+	AccountsOffset uint64 `json:"-" bun:"accounts_offset"`
+	// Failed is the number of transactions which could not be created, LastError holds the error of the last one
+	Failed     uint64    `json:"failed" bun:"failed"`
+	LastError  string    `json:"lastError,omitempty" bun:"last_error,nullzero"`
+	InsertedAt time.Time `json:"insertedAt" bun:"inserted_at,type:timestamp without time zone"`
+	UpdatedAt  time.Time `json:"updatedAt" bun:"updated_at,type:timestamp without time zone"`
+}
+
+// IsDue indicates if the next occurrence has to be processed at the given date
+func (r Recurrence) IsDue(at time.Time) bool {
+	return r.Status == RecurrenceStatusActive && r.NextOccurrence != nil && !r.NextOccurrence.After(at)
+}
+
+// IdempotencyKey is the idempotency key of the transaction created for an occurrence, and an account if the recurrence targets accounts.
+// It prevents to create the transaction twice if the processing of an occurrence is retried.
+func (r Recurrence) IdempotencyKey(occurrence time.Time, account string) string {
+	ret := fmt.Sprintf("recurrence/%s/%s", r.ID, occurrence.Format(time.DateFormat))
+	if account != "" {
+		ret += "/" + account
+	}
+	return ret
+}
+
+// NextOccurrenceAfter returns the first occurrence of the schedule strictly after the given date, or nil if there is none
+func (r Recurrence) NextOccurrenceAfter(after time.Time) (*time.Time, error) {
+	schedule, err := ParseRecurrenceSchedule(r.Schedule, r.StartAt)
+	if err != nil {
+		return nil, err
+	}
+
+	next := schedule.Next(after.Time.UTC())
+	if next.IsZero() {
+		return nil, nil
+	}
+	ret := time.New(next)
+	return &ret, nil
+}
+
+// RecurrenceSchedule computes the occurrences of a recurrence
+type RecurrenceSchedule interface {
+	// Next returns the first occurrence strictly after the given date, or the zero time if there is none
+	Next(after stdtime.Time) stdtime.Time
+}
+
+// ParseRecurrenceSchedule parses a cron expression or a RRULE, the first occurrence being at or after startAt.
+// Cron expressions use the standard five fields or a descriptor (ex: `@monthly`).
+// RRULEs support the FREQ, INTERVAL, COUNT and UNTIL parts, the occurrences are anchored on startAt.
+func ParseRecurrenceSchedule(expr string, startAt time.Time) (RecurrenceSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if rule, ok := strings.CutPrefix(expr, "RRULE:"); ok || strings.Contains(expr, "FREQ=") {
+		return parseRRule(rule, startAt.Time.UTC())
+	}
+
+	schedule, err := cron.ParseStandard(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression: %w", err)
+	}
+	return cronRecurrenceSchedule{
+		schedule: schedule,
+		startAt:  startAt.Time.UTC(),
+	}, nil
+}
+
+type cronRecurrenceSchedule struct {
+	schedule cron.Schedule
+	startAt  stdtime.Time
+}
+
+func (s cronRecurrenceSchedule) Next(after stdtime.Time) stdtime.Time {
+	// cron schedules return the occurrences strictly after a date, the start date itself is a possible occurrence
+	if notBefore := s.startAt.Add(-stdtime.Nanosecond); after.Before(notBefore) {
+		after = notBefore
+	}
+	return s.schedule.Next(after)
+}
+
+type rrule struct {
+	freq     string
+	interval int
+	count    int
+	until    *stdtime.Time
+	startAt  stdtime.Time
+}
+
+func parseRRule(expr string, startAt stdtime.Time) (*rrule, error) {
+	ret := &rrule{
+		interval: 1,
+		startAt:  startAt,
+	}
+	for _, part := range strings.Split(expr, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rule part `%s`", part)
+		}
+		switch name {
+		case "FREQ":
+			switch value {
+			case "MINUTELY", "HOURLY", "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+				ret.freq = value
+			default:
+				return nil, fmt.Errorf("unsupported frequency `%s`", value)
+			}
+		case "INTERVAL", "COUNT":
+			v, err := strconv.Atoi(value)
+			if err != nil || v <= 0 {
+				return nil, fmt.Errorf("%s must be a positive integer", name)
+			}
+			if name == "INTERVAL" {
+				ret.interval = v
+			} else {
+				ret.count = v
+			}
+		case "UNTIL":
+			until, err := stdtime.Parse("20060102T150405Z", value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid UNTIL, expected format `YYYYMMDDTHHMMSSZ`: %w", err)
+			}
+			ret.until = &until
+		default:
+			return nil, fmt.Errorf("unsupported rule part `%s`", name)
+		}
+	}
+	if ret.freq == "" {
+		return nil, fmt.Errorf("FREQ is required")
+	}
+	if ret.count > 0 && ret.until != nil {
+		return nil, fmt.Errorf("COUNT and UNTIL cannot be used together")
+	}
+
+	return ret, nil
+}
+
+// occurrence returns the nth candidate occurrence of the rule, and false if the date does not exist
+// (ex: the 31st of a month with 30 days, which is skipped as specified by the RFC 5545)
+func (r *rrule) occurrence(n int) (stdtime.Time, bool) {
+	step := n * r.interval
+	switch r.freq {
+	case "MINUTELY":
+		return r.startAt.Add(stdtime.Duration(step) * stdtime.Minute), true
+	case "HOURLY":
+		return r.startAt.Add(stdtime.Duration(step) * stdtime.Hour), true
+	case "DAILY":
+		return r.startAt.AddDate(0, 0, step), true
+	case "WEEKLY":
+		return r.startAt.AddDate(0, 0, 7*step), true
+	case "MONTHLY":
+		ret := r.startAt.AddDate(0, step, 0)
+		return ret, ret.Day() == r.startAt.Day()
+	default:
+		ret := r.startAt.AddDate(step, 0, 0)
+		return ret, ret.Day() == r.startAt.Day()
+	}
+}
+
+// period returns the constant duration between two occurrences, or zero when it depends on the calendar
+func (r *rrule) period() stdtime.Duration {
+	switch r.freq {
+	case "MINUTELY":
+		return stdtime.Duration(r.interval) * stdtime.Minute
+	case "HOURLY":
+		return stdtime.Duration(r.interval) * stdtime.Hour
+	case "DAILY":
+		return stdtime.Duration(r.interval) * 24 * stdtime.Hour
+	case "WEEKLY":
+		return stdtime.Duration(r.interval) * 7 * 24 * stdtime.Hour
+	default:
+		return 0
+	}
+}
+
+func (r *rrule) Next(after stdtime.Time) stdtime.Time {
+	n := 0
+	// occurrences at a constant period are never skipped, so the search can start right before the date
+	if period := r.period(); period > 0 && after.After(r.startAt) {
+		n = int(after.Sub(r.startAt) / period)
+	}
+	for emitted := n; r.count == 0 || emitted < r.count; n++ {
+		candidate, ok := r.occurrence(n)
+		if r.until != nil && candidate.After(*r.until) {
+			break
+		}
+		if !ok {
+			continue
+		}
+		if candidate.After(after) {
+			return candidate
+		}
+		emitted++
+	}
+	return stdtime.Time{}
+}
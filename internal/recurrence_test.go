@@ -0,0 +1,148 @@
+package ledger
+
+import (
+	"testing"
+	stdtime "time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+)
+
+func TestRecurrenceNextOccurrence(t *testing.T) {
+	t.Parallel()
+
+	startAt := time.New(stdtime.Date(2026, 1, 31, 10, 0, 0, 0, stdtime.UTC))
+
+	type testCase struct {
+		name          string
+		schedule      string
+		after         time.Time
+		expected      []stdtime.Time
+		expectedError string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:     "cron",
+			schedule: "0 0 1 * *",
+			after:    startAt,
+			expected: []stdtime.Time{
+				stdtime.Date(2026, 2, 1, 0, 0, 0, 0, stdtime.UTC),
+				stdtime.Date(2026, 3, 1, 0, 0, 0, 0, stdtime.UTC),
+			},
+		},
+		{
+			name:     "cron before start",
+			schedule: "@daily",
+			after:    startAt.Add(-48 * time.Hour),
+			expected: []stdtime.Time{
+				stdtime.Date(2026, 2, 1, 0, 0, 0, 0, stdtime.UTC),
+			},
+		},
+		{
+			name:     "rrule includes the start date",
+			schedule: "FREQ=DAILY;INTERVAL=2",
+			after:    startAt.Add(-time.Hour),
+			expected: []stdtime.Time{
+				stdtime.Date(2026, 1, 31, 10, 0, 0, 0, stdtime.UTC),
+				stdtime.Date(2026, 2, 2, 10, 0, 0, 0, stdtime.UTC),
+			},
+		},
+		{
+			name:     "rrule skips missing days",
+			schedule: "RRULE:FREQ=MONTHLY",
+			after:    startAt,
+			expected: []stdtime.Time{
+				stdtime.Date(2026, 3, 31, 10, 0, 0, 0, stdtime.UTC),
+				stdtime.Date(2026, 5, 31, 10, 0, 0, 0, stdtime.UTC),
+				stdtime.Date(2026, 7, 31, 10, 0, 0, 0, stdtime.UTC),
+			},
+		},
+		{
+			name:     "rrule far from the start",
+			schedule: "FREQ=HOURLY",
+			after:    startAt.Add(10*time.Hour + time.Minute),
+			expected: []stdtime.Time{
+				stdtime.Date(2026, 1, 31, 21, 0, 0, 0, stdtime.UTC),
+			},
+		},
+		{
+			name:     "rrule with count",
+			schedule: "FREQ=WEEKLY;COUNT=2",
+			after:    startAt,
+			expected: []stdtime.Time{
+				stdtime.Date(2026, 2, 7, 10, 0, 0, 0, stdtime.UTC),
+				{},
+			},
+		},
+		{
+			name:     "rrule with until",
+			schedule: "FREQ=YEARLY;UNTIL=20270201T000000Z",
+			after:    startAt,
+			expected: []stdtime.Time{
+				stdtime.Date(2027, 1, 31, 10, 0, 0, 0, stdtime.UTC),
+				{},
+			},
+		},
+		{
+			name:          "invalid cron",
+			schedule:      "every month",
+			expectedError: "invalid cron expression",
+		},
+		{
+			name:          "unsupported rrule part",
+			schedule:      "FREQ=MONTHLY;BYDAY=MO",
+			expectedError: "unsupported rule part `BYDAY`",
+		},
+		{
+			name:          "rrule without frequency",
+			schedule:      "RRULE:INTERVAL=2",
+			expectedError: "FREQ is required",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			recurrence := Recurrence{
+				Schedule: tc.schedule,
+				StartAt:  startAt,
+			}
+			after := tc.after
+			for _, expected := range tc.expected {
+				next, err := recurrence.NextOccurrenceAfter(after)
+				require.NoError(t, err)
+				if expected.IsZero() {
+					require.Nil(t, next)
+					return
+				}
+				require.NotNil(t, next)
+				require.Equal(t, expected, next.Time)
+				after = *next
+			}
+			if tc.expectedError != "" {
+				_, err := recurrence.NextOccurrenceAfter(startAt)
+				require.ErrorContains(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestRecurrenceIsDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	recurrence := Recurrence{
+		Status:         RecurrenceStatusActive,
+		NextOccurrence: &now,
+	}
+	require.True(t, recurrence.IsDue(now))
+	require.False(t, recurrence.IsDue(now.Add(-time.Second)))
+
+	recurrence.Status = RecurrenceStatusPaused
+	require.False(t, recurrence.IsDue(now))
+
+	recurrence.Status = RecurrenceStatusCompleted
+	recurrence.NextOccurrence = nil
+	require.False(t, recurrence.IsDue(now))
+}
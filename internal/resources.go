@@ -8,3 +8,8 @@ type GetVolumesOptions struct {
 	UseInsertionDate bool `json:"insertionDate"`
 	GroupLvl         int  `json:"groupBy"`
 }
+
+type GetBalanceHistoryOptions struct {
+	GetAggregatedVolumesOptions
+	Interval BalanceHistoryInterval `json:"interval"`
+}
@@ -0,0 +1,63 @@
+package ledger
+
+import (
+	"encoding/json"
+
+	"github.com/uptrace/bun"
+
+	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+)
+
+const revertJobKey = "state/revert-job"
+
+type RevertJobStatus string
+
+const (
+	RevertJobStatusPending   RevertJobStatus = "PENDING"
+	RevertJobStatusRunning   RevertJobStatus = "RUNNING"
+	RevertJobStatusSucceeded RevertJobStatus = "SUCCEEDED"
+)
+
+// RevertJob reverts asynchronously the transactions matching a filter.
+// Only the transactions existing when the job is created are reverted, in the order of their ids.
+type RevertJob struct {
+	bun.BaseModel `bun:"table:revert_jobs,alias:revert_jobs"`
+
+	ID string `json:"id" bun:"id,type:varchar"`
+	// Query is the filter of the transactions to revert, using the syntax of the transactions list
+	Query           json.RawMessage   `json:"query,omitempty" bun:"query,type:jsonb,nullzero"`
+	Force           bool              `json:"force" bun:"force"`
+	AtEffectiveDate bool              `json:"atEffectiveDate" bun:"at_effective_date"`
+	Metadata        metadata.Metadata `json:"metadata" bun:"metadata,type:jsonb"`
+	Status          RevertJobStatus   `json:"status" bun:"status,type:varchar"`
+	// Total is the number of transactions to revert when the job has been created
+	Total    uint64 `json:"total" bun:"total"`
+	Reverted uint64 `json:"reverted" bun:"reverted"`
+	// Failed is the number of transactions which could not be reverted, LastError holds the error of the last one
+	Failed    uint64 `json:"failed" bun:"failed"`
+	LastError string `json:"lastError,omitempty" bun:"last_error,nullzero"`
+	// LastTransactionID is the id of the last processed transaction
+	LastTransactionID *uint64 `json:"lastTransactionId,omitempty" bun:"last_transaction_id,type:numeric,nullzero"`
+	// MaxTransactionID is the id of the last transaction of the ledger when the job has been created,
+	// the transactions created after, including the reverts of the job, are not part of the job.
+	// It is nil if the ledger had no transaction.
+	MaxTransactionID *uint64    `json:"-" bun:"max_transaction_id,type:numeric,nullzero"`
+	InsertedAt       time.Time  `json:"insertedAt" bun:"inserted_at,type:timestamp without time zone"`
+	UpdatedAt        time.Time  `json:"updatedAt" bun:"updated_at,type:timestamp without time zone"`
+	EndedAt          *time.Time `json:"endedAt,omitempty" bun:"ended_at,type:timestamp without time zone,nullzero"`
+}
+
+// IsFinished indicates if all the transactions of the job have been processed
+func (j RevertJob) IsFinished() bool {
+	return j.Status == RevertJobStatusSucceeded
+}
+
+func RevertJobMetadataSpecKey() string {
+	return SpecMetadata(revertJobKey)
+}
+
+// MarkRevertJob marks the metadata of a revert transaction with the job which created it
+func MarkRevertJob(m metadata.Metadata, jobID string) metadata.Metadata {
+	return m.Merge(ComputeMetadata(RevertJobMetadataSpecKey(), jobID))
+}
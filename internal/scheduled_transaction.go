@@ -0,0 +1,90 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+)
+
+type ScheduledTransactionStatus string
+
+const (
+	ScheduledTransactionStatusPending  ScheduledTransactionStatus = "PENDING"
+	ScheduledTransactionStatusExecuted ScheduledTransactionStatus = "EXECUTED"
+	ScheduledTransactionStatusFailed   ScheduledTransactionStatus = "FAILED"
+	ScheduledTransactionStatusCanceled ScheduledTransactionStatus = "CANCELED"
+)
+
+// ScheduledTransactionData describes the transaction to create, either with postings, a numscript or a template of the schema
+type ScheduledTransactionData struct {
+	Postings        Postings                     `json:"postings,omitempty"`
+	Script          string                       `json:"script,omitempty"`
+	Template        string                       `json:"template,omitempty"`
+	Vars            map[string]string            `json:"vars,omitempty"`
+	Reference       string                       `json:"reference,omitempty"`
+	Metadata        metadata.Metadata            `json:"metadata"`
+	AccountMetadata map[string]metadata.Metadata `json:"accountMetadata,omitempty"`
+	Runtime         RuntimeType                  `json:"runtime,omitempty"`
+	Force           bool                         `json:"force,omitempty"`
+}
+
+func (d ScheduledTransactionData) Validate() error {
+	count := 0
+	if len(d.Postings) > 0 {
+		count++
+	}
+	if d.Script != "" {
+		count++
+	}
+	if d.Template != "" {
+		count++
+	}
+	switch count {
+	case 0:
+		return errors.New("one of postings, script or template is required")
+	case 1:
+	default:
+		return errors.New("only one of postings, script or template can be specified")
+	}
+
+	if _, err := d.Postings.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ScheduledTransaction is a transaction created by the worker once its scheduled date is reached.
+// The transaction is effective at the scheduled date, the outcome of the execution is recorded on the entry.
+type ScheduledTransaction struct {
+	bun.BaseModel `bun:"table:scheduled_transactions,alias:scheduled_transactions"`
+
+	ID            string                     `json:"id" bun:"id,type:varchar"`
+	ScheduledAt   time.Time                  `json:"scheduledAt" bun:"scheduled_at,type:timestamp without time zone"`
+	Transaction   ScheduledTransactionData   `json:"transaction" bun:"transaction,type:jsonb"`
+	SchemaVersion string                     `json:"schemaVersion,omitempty" bun:"schema_version,nullzero"`
+	Status        ScheduledTransactionStatus `json:"status" bun:"status,type:varchar"`
+	// TransactionID is the id of the created transaction once executed
+	TransactionID *uint64 `json:"transactionId,omitempty" bun:"transaction_id,type:numeric,nullzero"`
+	// Error is the reason of the failure of the execution
+	Error      string    `json:"error,omitempty" bun:"error,nullzero"`
+	InsertedAt time.Time `json:"insertedAt" bun:"inserted_at,type:timestamp without time zone"`
+	UpdatedAt  time.Time `json:"updatedAt" bun:"updated_at,type:timestamp without time zone"`
+	// EndedAt is the date of the execution or the cancellation
+	EndedAt *time.Time `json:"endedAt,omitempty" bun:"ended_at,type:timestamp without time zone,nullzero"`
+}
+
+// IdempotencyKey is the idempotency key of the creation of the transaction,
+// it prevents to create the transaction twice if the execution is retried
+func (s ScheduledTransaction) IdempotencyKey() string {
+	return fmt.Sprintf("scheduled-transaction/%s", s.ID)
+}
+
+// IsDue indicates if the transaction has to be executed at the given date
+func (s ScheduledTransaction) IsDue(at time.Time) bool {
+	return s.Status == ScheduledTransactionStatusPending && !s.ScheduledAt.After(at)
+}
@@ -0,0 +1,64 @@
+package ledger
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledTransactionDataValidation(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		data          ScheduledTransactionData
+		expectedError string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "postings",
+			data: ScheduledTransactionData{
+				Postings: Postings{NewPosting("world", "bank", "USD", big.NewInt(100))},
+			},
+		},
+		{
+			name: "script",
+			data: ScheduledTransactionData{
+				Script: `send [USD 100] (source = @world destination = @bank)`,
+			},
+		},
+		{
+			name:          "nothing to create",
+			data:          ScheduledTransactionData{},
+			expectedError: "one of postings, script or template is required",
+		},
+		{
+			name: "postings and template",
+			data: ScheduledTransactionData{
+				Postings: Postings{NewPosting("world", "bank", "USD", big.NewInt(100))},
+				Template: "FEES",
+			},
+			expectedError: "only one of postings, script or template can be specified",
+		},
+		{
+			name: "invalid posting",
+			data: ScheduledTransactionData{
+				Postings: Postings{NewPosting("world", "bank", "USD", big.NewInt(-1))},
+			},
+			expectedError: "negative amount",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.data.Validate()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -2,12 +2,36 @@ package ledger
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/uptrace/bun"
 
 	"github.com/formancehq/go-libs/v5/pkg/types/time"
 )
 
+type SchemaEnforcementMode string
+
+const (
+	// emit error on failing validation & missing schema
+	SchemaEnforcementStrict SchemaEnforcementMode = "strict"
+	// only emit warnings on failing validation & missing schema
+	SchemaEnforcementAudit SchemaEnforcementMode = "audit"
+	// skip validation, schemas are only used to resolve templates
+	SchemaEnforcementOff SchemaEnforcementMode = "off"
+)
+
+func (m SchemaEnforcementMode) Validate() error {
+	switch m {
+	case SchemaEnforcementStrict, SchemaEnforcementAudit, SchemaEnforcementOff:
+		return nil
+	default:
+		return fmt.Errorf("unexpected schema enforcement mode `%s`: should be `%s`, `%s` or `%s`", m, SchemaEnforcementStrict, SchemaEnforcementAudit, SchemaEnforcementOff)
+	}
+}
+
 type SchemaData struct {
 	Chart        ChartOfAccounts      `json:"chart" bun:"chart"`
 	Transactions TransactionTemplates `json:"transactions,omitempty" bun:"transactions"`
@@ -26,7 +50,7 @@ func NewSchema(version string, data SchemaData) (Schema, error) {
 	if data.Chart == nil {
 		return Schema{}, NewErrInvalidSchema(errors.New("missing chart of accounts"))
 	}
-	if err := data.Transactions.Validate(); err != nil {
+	if err := data.Transactions.Validate(data.Chart); err != nil {
 		return Schema{}, NewErrInvalidSchema(err)
 	}
 	if err := data.Queries.Validate(); err != nil {
@@ -37,3 +61,80 @@ func NewSchema(version string, data SchemaData) (Schema, error) {
 		SchemaData: data,
 	}, nil
 }
+
+// SchemaDiffEntries lists the identifiers of the elements added, removed or modified between two schemas
+type SchemaDiffEntries struct {
+	Added    []string `json:"added"`
+	Removed  []string `json:"removed"`
+	Modified []string `json:"modified"`
+}
+
+// SchemaDiff describes the changes between two schemas.
+// Chart segments are identified by their path, variable segments being referenced by their label (ex: `users:$userID:main`).
+type SchemaDiff struct {
+	Chart        SchemaDiffEntries `json:"chart"`
+	Transactions SchemaDiffEntries `json:"transactions"`
+	Queries      SchemaDiffEntries `json:"queries"`
+}
+
+func DiffSchemas(from, to SchemaData) SchemaDiff {
+	return SchemaDiff{
+		Chart:        diffEntries(from.Chart.segments(), to.Chart.segments()),
+		Transactions: diffEntries(from.Transactions, to.Transactions),
+		Queries:      diffEntries(from.Queries, to.Queries),
+	}
+}
+
+func diffEntries[V any](from, to map[string]V) SchemaDiffEntries {
+	ret := SchemaDiffEntries{
+		Added:    []string{},
+		Removed:  []string{},
+		Modified: []string{},
+	}
+	for _, key := range sortedKeys(to) {
+		previous, ok := from[key]
+		switch {
+		case !ok:
+			ret.Added = append(ret.Added, key)
+		case !reflect.DeepEqual(previous, to[key]):
+			ret.Modified = append(ret.Modified, key)
+		}
+	}
+	for _, key := range sortedKeys(from) {
+		if _, ok := to[key]; !ok {
+			ret.Removed = append(ret.Removed, key)
+		}
+	}
+	return ret
+}
+
+// chartSegmentProperties holds the properties of a segment, excluding its subsegments
+type chartSegmentProperties struct {
+	Account *ChartAccount
+	Pattern *string
+}
+
+// segments flattens the chart of accounts, indexing the segments by their path
+func (c ChartOfAccounts) segments() map[string]chartSegmentProperties {
+	ret := make(map[string]chartSegmentProperties)
+	var walk func(path []string, fixedSegments map[string]ChartSegment, variableSegment *ChartVariableSegment)
+	walk = func(path []string, fixedSegments map[string]ChartSegment, variableSegment *ChartVariableSegment) {
+		for name, segment := range fixedSegments {
+			segmentPath := append(slices.Clone(path), name)
+			ret[strings.Join(segmentPath, ":")] = chartSegmentProperties{
+				Account: segment.Account,
+			}
+			walk(segmentPath, segment.FixedSegments, segment.VariableSegment)
+		}
+		if variableSegment != nil {
+			segmentPath := append(slices.Clone(path), "$"+variableSegment.Label)
+			ret[strings.Join(segmentPath, ":")] = chartSegmentProperties{
+				Account: variableSegment.Account,
+				Pattern: variableSegment.Pattern,
+			}
+			walk(segmentPath, variableSegment.FixedSegments, variableSegment.VariableSegment)
+		}
+	}
+	walk(nil, c, nil)
+	return ret
+}
@@ -0,0 +1,493 @@
+package ledger
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/invopop/jsonschema"
+
+	"github.com/formancehq/go-libs/v5/pkg/types/collections"
+
+	"github.com/formancehq/ledger/internal/machine"
+	"github.com/formancehq/ledger/internal/queries"
+	"github.com/formancehq/ledger/pkg/accounts"
+	"github.com/formancehq/ledger/pkg/assets"
+)
+
+// SchemaJSONSchemas are the JSON Schema documents describing a ledger schema.
+// Each document is standalone and can be used as an OpenAPI component.
+type SchemaJSONSchemas struct {
+	// Chart holds the schema of the accounts, indexed by their path in the chart (ex: `users:$userID:main`)
+	Chart map[string]*jsonschema.Schema `json:"chart"`
+	// Transactions holds the schema of the transaction creation requests, indexed by template
+	Transactions map[string]*jsonschema.Schema `json:"transactions"`
+	// Queries holds the schema of the variables of the query templates, indexed by template
+	Queries map[string]*jsonschema.Schema `json:"queries"`
+}
+
+// JSONSchemas renders the schema data as JSON Schema documents
+func (s SchemaData) JSONSchemas() SchemaJSONSchemas {
+	ret := SchemaJSONSchemas{
+		Chart:        map[string]*jsonschema.Schema{},
+		Transactions: map[string]*jsonschema.Schema{},
+		Queries:      map[string]*jsonschema.Schema{},
+	}
+	for chartPath, segment := range s.Chart.segments() {
+		if segment.Account == nil {
+			continue
+		}
+		ret.Chart[chartPath] = accountJSONSchema(s.Chart, chartPath, *segment.Account)
+	}
+	for id, template := range s.Transactions {
+		ret.Transactions[id] = transactionTemplateJSONSchema(s.Chart, id, template)
+	}
+	for id, template := range s.Queries {
+		ret.Queries[id] = queryTemplateJSONSchema(id, template)
+	}
+	return ret
+}
+
+// AddressPattern returns a regex matching the addresses of a path of the chart (ex: `users:$userID:main`),
+// and a regex matching the addresses it also matches but the chart resolves to another path, empty if there are none.
+// Like for the ledger, the pattern of a variable segment matches a part of the segment
+// and the fixed segments of its level take precedence over it.
+// The regexes only use the syntax common to RE2 and ECMA 262, used by JSON Schema.
+func (c ChartOfAccounts) AddressPattern(chartPath string) (string, string) {
+	var (
+		fixedSegments   = map[string]ChartSegment(c)
+		variableSegment *ChartVariableSegment
+		parts           = strings.Split(chartPath, ":")
+		patterns        = make([]string, 0, len(parts))
+		exclusions      = make([]string, 0)
+		emptySegments   bool
+	)
+	for i, part := range parts {
+		var segment ChartSegment
+		if label, ok := strings.CutPrefix(part, "$"); ok && variableSegment != nil && variableSegment.Label == label {
+			if variableSegment.Pattern != nil {
+				patterns = append(patterns, segmentPattern(*variableSegment.Pattern))
+				emptySegments = true
+			} else {
+				patterns = append(patterns, accounts.SegmentRegex)
+			}
+			if len(fixedSegments) > 0 {
+				exclusions = append(exclusions, "^"+strings.Repeat("[^:]*:", i)+"(?:"+strings.Join(collections.Map(sortedKeys(fixedSegments), regexp.QuoteMeta), "|")+")(?::|$)")
+			}
+			segment = variableSegment.ChartSegment
+		} else {
+			patterns = append(patterns, regexp.QuoteMeta(part))
+			segment = fixedSegments[part]
+		}
+		fixedSegments = segment.FixedSegments
+		variableSegment = segment.VariableSegment
+	}
+	if emptySegments {
+		// the pattern of a variable segment can match an empty string, which is not a valid segment
+		exclusions = append(exclusions, "(?:^|:)(?::|$)")
+	}
+	return "^" + strings.Join(patterns, ":") + "$", strings.Join(exclusions, "|")
+}
+
+const (
+	// segmentCharacters is the character class of accounts.SegmentRegex
+	segmentCharacters = "[a-zA-Z0-9_-]"
+	// noMatch is a character class matching nothing
+	noMatch = `[^\w\W]`
+)
+
+// segmentPattern renders a pattern of variable segment as a regex matching the whole segment
+// when the pattern matches a part of it, the anchors of the pattern marking the bounds of the segment.
+// Anchors not starting or ending an alternative of the pattern are ignored, so the regex can match more segments.
+func segmentPattern(pattern string) string {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		// the patterns are validated with the chart
+		return accounts.SegmentRegex
+	}
+
+	alternatives := make([]string, 0)
+	for _, sequence := range regexpSequences(re) {
+		prefix, suffix := segmentCharacters+"*", segmentCharacters+"*"
+		if len(sequence) > 0 && isBeginAnchor(sequence[0]) {
+			prefix, sequence = "", sequence[1:]
+		}
+		if len(sequence) > 0 && isEndAnchor(sequence[len(sequence)-1]) {
+			suffix, sequence = "", sequence[:len(sequence)-1]
+		}
+		alternatives = append(alternatives, prefix+"(?:"+renderSegmentRegexps(sequence)+")"+suffix)
+	}
+
+	if len(alternatives) == 1 {
+		return alternatives[0]
+	}
+	return "(?:" + strings.Join(alternatives, "|") + ")"
+}
+
+// regexpSequences lists the alternatives of a parsed regex as sequences of regexes,
+// the alternatives starting or ending the sequences being developed so their anchors are found
+func regexpSequences(re *syntax.Regexp) [][]*syntax.Regexp {
+	switch re.Op {
+	case syntax.OpAlternate:
+		ret := make([][]*syntax.Regexp, 0)
+		for _, sub := range re.Sub {
+			ret = append(ret, regexpSequences(sub)...)
+		}
+		return ret
+	case syntax.OpCapture:
+		return regexpSequences(re.Sub[0])
+	case syntax.OpConcat:
+		if len(re.Sub) < 2 {
+			return [][]*syntax.Regexp{re.Sub}
+		}
+		ret := make([][]*syntax.Regexp, 0)
+		for _, head := range regexpSequences(re.Sub[0]) {
+			for _, tail := range regexpSequences(re.Sub[len(re.Sub)-1]) {
+				sequence := slices.Concat(head, re.Sub[1:len(re.Sub)-1], tail)
+				ret = append(ret, sequence)
+			}
+		}
+		return ret
+	default:
+		return [][]*syntax.Regexp{{re}}
+	}
+}
+
+func isBeginAnchor(re *syntax.Regexp) bool {
+	return re.Op == syntax.OpBeginText || re.Op == syntax.OpBeginLine
+}
+
+func isEndAnchor(re *syntax.Regexp) bool {
+	return re.Op == syntax.OpEndText || re.Op == syntax.OpEndLine
+}
+
+func renderSegmentRegexps(subs []*syntax.Regexp) string {
+	ret := ""
+	for _, sub := range subs {
+		ret += renderSegmentRegexp(sub)
+	}
+	return ret
+}
+
+// renderSegmentRegexp renders a parsed regex restricting its characters to the ones of a segment
+func renderSegmentRegexp(re *syntax.Regexp) string {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return noMatch
+	case syntax.OpLiteral:
+		ret := ""
+		for _, r := range re.Rune {
+			runes := []rune{r}
+			if re.Flags&syntax.FoldCase != 0 {
+				for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+					runes = append(runes, f)
+				}
+			}
+			ranges := make([]rune, 0, 2*len(runes))
+			for _, r := range runes {
+				ranges = append(ranges, r, r)
+			}
+			ret += renderSegmentCharClass(ranges)
+		}
+		return ret
+	case syntax.OpCharClass:
+		return renderSegmentCharClass(re.Rune)
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		return segmentCharacters
+	case syntax.OpWordBoundary:
+		// the separator of the segments is not a word character, so the boundaries are the same in the address
+		return `\b`
+	case syntax.OpNoWordBoundary:
+		return `\B`
+	case syntax.OpCapture:
+		return "(?:" + renderSegmentRegexp(re.Sub[0]) + ")"
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		ret := renderSegmentRegexp(re.Sub[0])
+		switch sub := re.Sub[0]; {
+		case sub.Op == syntax.OpCharClass, sub.Op == syntax.OpAnyCharNotNL, sub.Op == syntax.OpAnyChar,
+			sub.Op == syntax.OpLiteral && len(sub.Rune) == 1:
+			// rendered as a character class
+		default:
+			ret = "(?:" + ret + ")"
+		}
+		switch re.Op {
+		case syntax.OpStar:
+			ret += "*"
+		case syntax.OpPlus:
+			ret += "+"
+		case syntax.OpQuest:
+			ret += "?"
+		default:
+			switch {
+			case re.Max == -1:
+				ret += fmt.Sprintf("{%d,}", re.Min)
+			case re.Min == re.Max:
+				ret += fmt.Sprintf("{%d}", re.Min)
+			default:
+				ret += fmt.Sprintf("{%d,%d}", re.Min, re.Max)
+			}
+		}
+		if re.Flags&syntax.NonGreedy != 0 {
+			ret += "?"
+		}
+		return ret
+	case syntax.OpConcat:
+		return renderSegmentRegexps(re.Sub)
+	case syntax.OpAlternate:
+		alternatives := make([]string, 0, len(re.Sub))
+		for _, sub := range re.Sub {
+			alternatives = append(alternatives, renderSegmentRegexp(sub))
+		}
+		return "(?:" + strings.Join(alternatives, "|") + ")"
+	default:
+		// empty matches, and anchors inside the segment
+		return ""
+	}
+}
+
+// renderSegmentCharClass renders the ranges of runes of a character class which are allowed in a segment
+func renderSegmentCharClass(ranges []rune) string {
+	allowed := []rune{'-', '-', '0', '9', 'A', 'Z', '_', '_', 'a', 'z'}
+	ret := ""
+	for i := 0; i < len(ranges); i += 2 {
+		for j := 0; j < len(allowed); j += 2 {
+			lo, hi := max(ranges[i], allowed[j]), min(ranges[i+1], allowed[j+1])
+			switch {
+			case lo > hi:
+				continue
+			case lo == '-':
+				ret += `\-`
+			case lo == hi:
+				ret += string(lo)
+			default:
+				ret += string(lo) + "-" + string(hi)
+			}
+		}
+	}
+	if ret == "" {
+		return noMatch
+	}
+	return "[" + ret + "]"
+}
+
+// addressJSONSchema renders the addresses of a path of the chart
+func addressJSONSchema(chart ChartOfAccounts, chartPath string) *jsonschema.Schema {
+	pattern, exclusion := chart.AddressPattern(chartPath)
+	ret := &jsonschema.Schema{
+		Type:    "string",
+		Pattern: pattern,
+	}
+	if exclusion != "" {
+		ret.Not = &jsonschema.Schema{
+			Pattern: exclusion,
+		}
+	}
+	return ret
+}
+
+func accountJSONSchema(chart ChartOfAccounts, chartPath string, account ChartAccount) *jsonschema.Schema {
+	properties := jsonschema.NewProperties()
+	properties.Set("address", addressJSONSchema(chart, chartPath))
+	properties.Set("metadata", metadataJSONSchema(account.Metadata))
+	if len(account.Assets) > 0 {
+		assetsSchema := &jsonschema.Schema{
+			Type:    "string",
+			Pattern: "^" + assets.Pattern + "$",
+			Extras: map[string]any{
+				"x-allowed-assets": account.Assets,
+			},
+		}
+		properties.Set("volumes", &jsonschema.Schema{
+			Type:          "object",
+			PropertyNames: assetsSchema,
+		})
+	}
+
+	return &jsonschema.Schema{
+		Version:    jsonschema.Version,
+		Title:      chartPath,
+		Type:       "object",
+		Properties: properties,
+		Required:   []string{"address"},
+	}
+}
+
+// metadataJSONSchema renders metadata declarations.
+// Required metadata with a default value are not marked as required as the ledger fills them.
+func metadataJSONSchema(declarations map[string]ChartAccountMetadata) *jsonschema.Schema {
+	ret := &jsonschema.Schema{
+		Type: "object",
+		AdditionalProperties: &jsonschema.Schema{
+			Type: "string",
+		},
+	}
+	if len(declarations) == 0 {
+		return ret
+	}
+	ret.Properties = jsonschema.NewProperties()
+	for _, key := range sortedKeys(declarations) {
+		declaration := declarations[key]
+		ret.Properties.Set(key, metadataValueJSONSchema(declaration))
+		if declaration.Required && declaration.Default == nil {
+			ret.Required = append(ret.Required, key)
+		}
+	}
+	return ret
+}
+
+func metadataValueJSONSchema(declaration ChartAccountMetadata) *jsonschema.Schema {
+	ret := &jsonschema.Schema{
+		Type: "string",
+	}
+	switch declaration.Type {
+	case "int":
+		ret.Pattern = `^-?[0-9]+$`
+	case "boolean":
+		ret.Enum = []any{"true", "false"}
+	case "date":
+		ret.Format = "date-time"
+	}
+	if declaration.Pattern != nil {
+		// a schema only has one pattern, the declared one is combined with the type one
+		if ret.Pattern != "" {
+			ret.AllOf = []*jsonschema.Schema{{Pattern: *declaration.Pattern}}
+		} else {
+			ret.Pattern = *declaration.Pattern
+		}
+	}
+	if len(declaration.Enum) > 0 {
+		ret.Enum = make([]any, 0, len(declaration.Enum))
+		for _, value := range declaration.Enum {
+			ret.Enum = append(ret.Enum, value)
+		}
+	}
+	if declaration.Default != nil {
+		ret.Default = *declaration.Default
+	}
+	if declaration.Immutable {
+		ret.Extras = map[string]any{
+			"x-immutable": true,
+		}
+	}
+	return ret
+}
+
+func transactionTemplateJSONSchema(chart ChartOfAccounts, id string, template TransactionTemplate) *jsonschema.Schema {
+	properties := jsonschema.NewProperties()
+	properties.Set("template", &jsonschema.Schema{
+		Type:  "string",
+		Const: id,
+	})
+
+	vars := &jsonschema.Schema{
+		Type: "object",
+		AdditionalProperties: &jsonschema.Schema{
+			Type: "string",
+		},
+	}
+	if len(template.Vars) > 0 {
+		vars.Properties = jsonschema.NewProperties()
+		vars.AdditionalProperties = jsonschema.FalseSchema
+		for _, name := range sortedKeys(template.Vars) {
+			decl := template.Vars[name]
+			vars.Properties.Set(name, transactionTemplateVarJSONSchema(chart, decl))
+			if decl.Default == nil {
+				vars.Required = append(vars.Required, name)
+			}
+		}
+	}
+	properties.Set("vars", vars)
+	properties.Set("metadata", metadataJSONSchema(template.Metadata))
+
+	ret := &jsonschema.Schema{
+		Version:     jsonschema.Version,
+		Title:       id,
+		Description: template.Description,
+		Type:        "object",
+		Properties:  properties,
+		Required:    []string{"template"},
+	}
+	if len(vars.Required) > 0 {
+		ret.Required = append(ret.Required, "vars")
+	}
+	return ret
+}
+
+func transactionTemplateVarJSONSchema(chart ChartOfAccounts, decl TransactionTemplateVar) *jsonschema.Schema {
+	ret := &jsonschema.Schema{
+		Type:        "string",
+		Description: decl.Type,
+	}
+	switch TransactionTemplateVarTypes[decl.Type] {
+	case machine.TypeAccount:
+		if decl.Chart != "" {
+			address := addressJSONSchema(chart, decl.Chart)
+			ret.Pattern, ret.Not = address.Pattern, address.Not
+		} else {
+			ret.Pattern = accounts.Pattern
+		}
+	case machine.TypeMonetary:
+		ret.Pattern = `^` + assets.Pattern + ` [0-9]+$`
+	case machine.TypeNumber:
+		ret.Pattern = `^-?[0-9]+$`
+	case machine.TypePortion:
+		ret.Pattern = `^([0-9]+/[0-9]+|[0-9]+(\.[0-9]+)?%)$`
+	}
+	if decl.Default != nil {
+		ret.Default = *decl.Default
+	}
+	return ret
+}
+
+func queryTemplateJSONSchema(id string, template QueryTemplate) *jsonschema.Schema {
+	properties := jsonschema.NewProperties()
+	required := make([]string, 0)
+	for _, name := range sortedKeys(template.Vars) {
+		decl := template.Vars[name]
+		properties.Set(name, queryVarJSONSchema(decl))
+		if decl.Default == nil && !decl.Optional {
+			required = append(required, name)
+		}
+	}
+
+	return &jsonschema.Schema{
+		Version:              jsonschema.Version,
+		Title:                id,
+		Description:          template.Description,
+		Type:                 "object",
+		Properties:           properties,
+		Required:             required,
+		AdditionalProperties: jsonschema.FalseSchema,
+		Extras: map[string]any{
+			"x-resource": template.Resource,
+		},
+	}
+}
+
+func queryVarJSONSchema(decl queries.VarDecl) *jsonschema.Schema {
+	ret := queryVarTypeJSONSchema(decl.Type)
+	ret.Default = decl.Default
+	return ret
+}
+
+func queryVarTypeJSONSchema(fieldType queries.FieldType) *jsonschema.Schema {
+	ret := &jsonschema.Schema{}
+	switch t := fieldType.(type) {
+	case queries.TypeList:
+		ret.Type = "array"
+		ret.Items = queryVarTypeJSONSchema(t.UnderlyingType())
+	case queries.TypeBoolean:
+		ret.Type = "boolean"
+	case queries.TypeNumeric:
+		ret.Type = "integer"
+	case queries.TypeDate:
+		ret.Type = "string"
+		ret.Format = "date-time"
+	default:
+		ret.Type = "string"
+	}
+	return ret
+}
@@ -0,0 +1,344 @@
+package ledger
+
+import (
+	"math/big"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
+
+	"github.com/formancehq/ledger/internal/queries"
+	"github.com/formancehq/ledger/pkg/accounts"
+)
+
+func TestDiffSchemas(t *testing.T) {
+	t.Parallel()
+
+	from := SchemaData{
+		Chart: testChart(),
+		Transactions: TransactionTemplates{
+			"DEPOSIT":  {Script: "deposit"},
+			"WITHDRAW": {Script: "withdraw"},
+		},
+		Queries: QueryTemplates{
+			"BALANCES": {Resource: queries.ResourceKindVolume},
+		},
+	}
+
+	to := SchemaData{
+		Chart: testChart(),
+		Transactions: TransactionTemplates{
+			"DEPOSIT":  {Script: "deposit v2"},
+			"TRANSFER": {Script: "transfer"},
+		},
+		Queries: QueryTemplates{
+			"BALANCES": {Resource: queries.ResourceKindVolume},
+		},
+	}
+	delete(to.Chart, "shops")
+	to.Chart["users"].VariableSegment.Pattern = pointer.For("^[0-9]+$")
+	to.Chart["orders"] = ChartSegment{
+		VariableSegment: &ChartVariableSegment{
+			Label: "orderID",
+			ChartSegment: ChartSegment{
+				Account: &ChartAccount{},
+			},
+		},
+	}
+
+	require.Equal(t, SchemaDiff{
+		Chart: SchemaDiffEntries{
+			Added:    []string{"orders", "orders:$orderID"},
+			Removed:  []string{"shops", "shops:$shopID"},
+			Modified: []string{"users:$userID"},
+		},
+		Transactions: SchemaDiffEntries{
+			Added:    []string{"TRANSFER"},
+			Removed:  []string{"WITHDRAW"},
+			Modified: []string{"DEPOSIT"},
+		},
+		Queries: SchemaDiffEntries{
+			Added:    []string{},
+			Removed:  []string{},
+			Modified: []string{},
+		},
+	}, DiffSchemas(from, to))
+
+	require.Equal(t, SchemaDiffEntries{
+		Added:    []string{},
+		Removed:  []string{},
+		Modified: []string{},
+	}, DiffSchemas(from, from).Chart)
+}
+
+func TestChartAddressPattern(t *testing.T) {
+	t.Parallel()
+
+	chart := testChart()
+	chart["invoices"] = ChartSegment{
+		VariableSegment: &ChartVariableSegment{
+			Label:   "invoiceID",
+			Pattern: pointer.For(`(?i)^inv-[0-9]+|^x\$?$`),
+			ChartSegment: ChartSegment{
+				Account: &ChartAccount{},
+			},
+		},
+		FixedSegments: map[string]ChartSegment{
+			"pending": {Account: &ChartAccount{}},
+		},
+	}
+
+	type testCase struct {
+		path              string
+		expected          string
+		expectedExclusion string
+		matches           []string
+		rejects           []string
+	}
+
+	for _, tc := range []testCase{
+		{
+			path:     "world",
+			expected: "^world$",
+			matches:  []string{"world"},
+			rejects:  []string{"world:1", "worlds"},
+		},
+		{
+			path:              "users:$userID:main",
+			expected:          "^users:(?:[0-9]{3}):main$",
+			expectedExclusion: "(?:^|:)(?::|$)",
+			matches:           []string{"users:001:main"},
+			rejects:           []string{"users:abc:main", "users:001"},
+		},
+		{
+			path:     "shops:$shopID",
+			expected: "^shops:[a-zA-Z0-9_-]+$",
+			matches:  []string{"shops:foo"},
+			rejects:  []string{"shops:foo:bar"},
+		},
+		{
+			// the pattern matches a part of the segment, the escaped dollar is not an anchor
+			path:              "invoices:$invoiceID",
+			expected:          `^invoices:(?:(?:[Ii][Nn][Vv][\-][0-9]+)[a-zA-Z0-9_-]*|(?:[Xx][^\w\W]?))$`,
+			expectedExclusion: `^[^:]*:(?:pending)(?::|$)|(?:^|:)(?::|$)`,
+			matches:           []string{"invoices:INV-12", "invoices:inv-12a", "invoices:x", "invoices:X"},
+			rejects:           []string{"invoices:ainv-12", "invoices:xy", "invoices:x:y"},
+		},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			t.Parallel()
+
+			pattern, exclusion := chart.AddressPattern(tc.path)
+			require.Equal(t, tc.expected, pattern)
+			require.Equal(t, tc.expectedExclusion, exclusion)
+			for _, address := range tc.matches {
+				require.Regexp(t, pattern, address)
+			}
+			for _, address := range tc.rejects {
+				require.NotRegexp(t, pattern, address)
+			}
+		})
+	}
+}
+
+func TestChartJSONSchemasMatchValidatedAddresses(t *testing.T) {
+	t.Parallel()
+
+	chart := ChartOfAccounts{
+		"world": {Account: &ChartAccount{}},
+		"users": {
+			VariableSegment: &ChartVariableSegment{
+				Label:   "userID",
+				Pattern: pointer.For("^[0-9]{3}$"),
+				ChartSegment: ChartSegment{
+					Account: &ChartAccount{},
+					FixedSegments: map[string]ChartSegment{
+						"main": {Account: &ChartAccount{}},
+					},
+				},
+			},
+		},
+		"shops": {
+			VariableSegment: &ChartVariableSegment{
+				Label: "shopID",
+				ChartSegment: ChartSegment{
+					Account: &ChartAccount{},
+				},
+			},
+			FixedSegments: map[string]ChartSegment{
+				"main": {Account: &ChartAccount{}},
+			},
+		},
+		"orders": {
+			VariableSegment: &ChartVariableSegment{
+				Label:   "orderID",
+				Pattern: pointer.For("abc"),
+				ChartSegment: ChartSegment{
+					Account: &ChartAccount{},
+				},
+			},
+		},
+		"fees": {
+			VariableSegment: &ChartVariableSegment{
+				Label:   "currency",
+				Pattern: pointer.For(`^(?:EUR|USD)\$?$`),
+				ChartSegment: ChartSegment{
+					Account: &ChartAccount{},
+				},
+			},
+		},
+		"invoices": {
+			VariableSegment: &ChartVariableSegment{
+				Label:   "invoiceID",
+				Pattern: pointer.For(`(?i)^inv-[0-9]+$|^x`),
+				ChartSegment: ChartSegment{
+					Account: &ChartAccount{},
+				},
+			},
+		},
+		"tags": {
+			VariableSegment: &ChartVariableSegment{
+				Label:   "tag",
+				Pattern: pointer.For("z*"),
+				ChartSegment: ChartSegment{
+					Account: &ChartAccount{},
+				},
+			},
+		},
+	}
+	addresses := []string{
+		"world", "bank",
+		"users:001", "users:1234", "users:abc", "users:001:main", "users:main",
+		"shops:main", "shops:foo", "shops:foo:main",
+		"orders:abc", "orders:xabcx", "orders:ab", "orders:abc:x",
+		"fees:EUR", "fees:eur", "fees:EURO",
+		"invoices:INV-12", "invoices:inv-12", "invoices:inv-12a", "invoices:x", "invoices:xy", "invoices:yx",
+		"tags:foo", "tags:foo-bar_1",
+	}
+
+	schemas := SchemaData{Chart: chart}.JSONSchemas()
+	require.Len(t, schemas.Chart, 9)
+	for chartPath, schema := range schemas.Chart {
+		segment, err := chart.FindSegment(chartPath)
+		require.NoError(t, err)
+		address, _ := schema.Properties.Get("address")
+
+		for _, account := range addresses {
+			require.Regexp(t, accounts.Pattern, account)
+
+			// the address belongs to the path when the ledger accepts it and resolves it to the account of the path
+			accountSchema, _ := chart.FindAccountSchema(account)
+			expected := chart.ValidatePosting(NewPosting("world", account, "USD", big.NewInt(1))) == nil &&
+				accountSchema == segment.Account
+
+			matches := regexp.MustCompile(address.Pattern).MatchString(account)
+			if address.Not != nil {
+				matches = matches && !regexp.MustCompile(address.Not.Pattern).MatchString(account)
+			}
+			require.Equal(t, expected, matches, "address %s for path %s", account, chartPath)
+		}
+	}
+}
+
+func TestSchemaJSONSchemas(t *testing.T) {
+	t.Parallel()
+
+	data := SchemaData{
+		Chart: ChartOfAccounts{
+			"world": {
+				Account: &ChartAccount{},
+			},
+			"users": {
+				VariableSegment: &ChartVariableSegment{
+					Label:   "userID",
+					Pattern: pointer.For("^[0-9]{3}$"),
+					ChartSegment: ChartSegment{
+						Account: &ChartAccount{
+							Metadata: map[string]ChartAccountMetadata{
+								"kyc": {
+									Type:     "boolean",
+									Required: true,
+								},
+								"tier": {
+									Enum:     []string{"gold", "silver"},
+									Default:  pointer.For("silver"),
+									Required: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Transactions: TransactionTemplates{
+			"DEPOSIT": {
+				Description: "Deposit",
+				Script:      "deposit",
+				Vars: map[string]TransactionTemplateVar{
+					"user":   {Type: "account", Chart: "users:$userID"},
+					"amount": {Type: "monetary", Default: pointer.For("USD/2 100")},
+				},
+				Metadata: map[string]TransactionTemplateMetadata{
+					"reference": {
+						Required: true,
+					},
+					"channel": {
+						Default:  pointer.For("api"),
+						Required: true,
+					},
+				},
+			},
+		},
+		Queries: QueryTemplates{
+			"BALANCES": {
+				Resource: queries.ResourceKindVolume,
+				Vars: map[string]queries.VarDecl{
+					"minimum": {Type: queries.NewTypeNumeric()},
+					"since":   {Type: queries.NewTypeDate(), Default: "2024-01-01T00:00:00Z"},
+					"assets":  {Type: queries.NewTypeList(queries.NewTypeString()), Optional: true},
+				},
+			},
+		},
+	}
+
+	schemas := data.JSONSchemas()
+
+	require.Len(t, schemas.Chart, 2)
+	user := schemas.Chart["users:$userID"]
+	require.NotNil(t, user)
+	address, _ := user.Properties.Get("address")
+	require.Equal(t, "^users:(?:[0-9]{3})$", address.Pattern)
+	userMetadata, _ := user.Properties.Get("metadata")
+	require.Equal(t, []string{"kyc"}, userMetadata.Required)
+	kyc, _ := userMetadata.Properties.Get("kyc")
+	require.Equal(t, []any{"true", "false"}, kyc.Enum)
+	tier, _ := userMetadata.Properties.Get("tier")
+	require.Equal(t, []any{"gold", "silver"}, tier.Enum)
+	require.Equal(t, "silver", tier.Default)
+
+	deposit := schemas.Transactions["DEPOSIT"]
+	require.NotNil(t, deposit)
+	require.Equal(t, []string{"template", "vars"}, deposit.Required)
+	vars, _ := deposit.Properties.Get("vars")
+	require.Equal(t, []string{"user"}, vars.Required)
+	userVar, _ := vars.Properties.Get("user")
+	require.Equal(t, "^users:(?:[0-9]{3})$", userVar.Pattern)
+	amountVar, _ := vars.Properties.Get("amount")
+	require.Regexp(t, amountVar.Pattern, "USD/2 100")
+	require.Equal(t, "USD/2 100", amountVar.Default)
+	depositMetadata, _ := deposit.Properties.Get("metadata")
+	require.Equal(t, []string{"reference"}, depositMetadata.Required)
+
+	balances := schemas.Queries["BALANCES"]
+	require.NotNil(t, balances)
+	require.Equal(t, []string{"minimum"}, balances.Required)
+	minimum, _ := balances.Properties.Get("minimum")
+	require.Equal(t, "integer", minimum.Type)
+	since, _ := balances.Properties.Get("since")
+	require.Equal(t, "date-time", since.Format)
+	assetsVar, _ := balances.Properties.Get("assets")
+	require.Equal(t, "array", assetsVar.Type)
+	require.Equal(t, "string", assetsVar.Items.Type)
+}
@@ -0,0 +1,55 @@
+package ledger
+
+import (
+	"math/big"
+
+	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+)
+
+// AccountStatementLine is a move of an account statement
+type AccountStatementLine struct {
+	TransactionID uint64    `json:"transactionId"`
+	InsertionDate time.Time `json:"insertionDate"`
+	EffectiveDate time.Time `json:"effectiveDate"`
+	Asset         string    `json:"asset"`
+	// Amount is negative when the account is the source of the move
+	Amount *big.Int `json:"amount"`
+	// Balance is the balance of the asset after the move
+	Balance *big.Int `json:"balance"`
+}
+
+// NewAccountStatementLine reads the balance after the move from the post commit volumes
+// of the date ordering the statement
+func NewAccountStatementLine(move Move, useInsertionDate bool) AccountStatementLine {
+	amount := new(big.Int).Set((*big.Int)(move.Amount))
+	if move.IsSource {
+		amount.Neg(amount)
+	}
+
+	volumes := move.PostCommitEffectiveVolumes
+	if useInsertionDate {
+		volumes = move.PostCommitVolumes
+	}
+
+	return AccountStatementLine{
+		TransactionID: move.TransactionID,
+		InsertionDate: move.InsertionDate,
+		EffectiveDate: move.EffectiveDate,
+		Asset:         move.Asset,
+		Amount:        amount,
+		Balance:       volumes.Balance(),
+	}
+}
+
+// AccountStatement lists the moves of an account between two dates, both included,
+// with the balances before the first date and at the end of the period
+type AccountStatement struct {
+	Account         string           `json:"account"`
+	StartTime       time.Time        `json:"startTime"`
+	EndTime         time.Time        `json:"endTime"`
+	OpeningBalances BalancesByAssets `json:"openingBalances"`
+	// Lines is a page of the moves of the period
+	Lines           *paginate.Cursor[AccountStatementLine] `json:"lines"`
+	ClosingBalances BalancesByAssets                       `json:"closingBalances"`
+}
@@ -0,0 +1,39 @@
+package ledger
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+)
+
+func TestNewAccountStatementLine(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	move := Move{
+		TransactionID:              2,
+		IsSource:                   true,
+		Account:                    "users:1",
+		Asset:                      "USD",
+		Amount:                     (*paginate.BigInt)(big.NewInt(30)),
+		InsertionDate:              now,
+		EffectiveDate:              now.Add(-time.Minute),
+		PostCommitVolumes:          &Volumes{Input: big.NewInt(150), Output: big.NewInt(30)},
+		PostCommitEffectiveVolumes: &Volumes{Input: big.NewInt(100), Output: big.NewInt(30)},
+	}
+
+	require.Equal(t, AccountStatementLine{
+		TransactionID: 2,
+		InsertionDate: now,
+		EffectiveDate: now.Add(-time.Minute),
+		Asset:         "USD",
+		Amount:        big.NewInt(-30),
+		Balance:       big.NewInt(70),
+	}, NewAccountStatementLine(move, false))
+
+	require.Equal(t, big.NewInt(120), NewAccountStatementLine(move, true).Balance)
+}
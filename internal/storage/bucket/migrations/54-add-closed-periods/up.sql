@@ -0,0 +1,13 @@
+do $$
+	begin
+		set search_path = '{{ .Schema }}';
+
+		create table closed_periods (
+			ledger varchar primary key,
+			closed_until timestamp without time zone not null
+		);
+
+		alter type log_type add value 'CLOSED_PERIOD';
+		alter type log_type add value 'REOPENED_PERIOD';
+	end
+$$;
@@ -0,0 +1,28 @@
+do $$
+	begin
+		set search_path = '{{ .Schema }}';
+
+		create table holds (
+			ledger varchar not null,
+			id varchar not null,
+			source varchar not null,
+			destination varchar not null,
+			asset varchar not null,
+			amount numeric not null,
+			metadata jsonb not null default '{}'::jsonb,
+			status varchar not null,
+			expires_at timestamp without time zone,
+			inserted_at timestamp without time zone not null,
+			ended_at timestamp without time zone,
+			primary key (ledger, id)
+		);
+
+		-- pending holds are summed by source and asset to compute the available balances
+		create index holds_pending on holds (ledger, source, asset) where status = 'PENDING';
+		create index holds_expires_at on holds (ledger, expires_at) where status = 'PENDING' and expires_at is not null;
+
+		alter type log_type add value 'CREATED_HOLD';
+		alter type log_type add value 'CAPTURED_HOLD';
+		alter type log_type add value 'VOIDED_HOLD';
+	end
+$$;
@@ -0,0 +1,11 @@
+do $$
+	begin
+		set search_path = '{{ .Schema }}';
+
+		-- amounts already reverted by index of the postings, null until a first partial revert
+		alter table transactions
+		add column reverted_amounts jsonb;
+
+		alter type log_type add value 'PARTIALLY_REVERTED_TRANSACTION';
+	end
+$$;
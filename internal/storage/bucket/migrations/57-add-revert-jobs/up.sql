@@ -0,0 +1,28 @@
+do $$
+	begin
+		set search_path = '{{ .Schema }}';
+
+		create table revert_jobs (
+			ledger varchar not null,
+			id varchar not null,
+			query jsonb,
+			force boolean not null default false,
+			at_effective_date boolean not null default false,
+			metadata jsonb not null default '{}'::jsonb,
+			status varchar not null,
+			total bigint not null default 0,
+			reverted bigint not null default 0,
+			failed bigint not null default 0,
+			last_transaction_id numeric,
+			max_transaction_id numeric,
+			last_error varchar,
+			inserted_at timestamp without time zone not null,
+			updated_at timestamp without time zone not null,
+			ended_at timestamp without time zone,
+			primary key (ledger, id)
+		);
+
+		-- the jobs to run are listed by the worker
+		create index revert_jobs_unfinished on revert_jobs (ledger, inserted_at) where status in ('PENDING', 'RUNNING');
+	end
+$$;
@@ -0,0 +1,23 @@
+do $$
+	begin
+		set search_path = '{{ .Schema }}';
+
+		create table scheduled_transactions (
+			ledger varchar not null,
+			id varchar not null,
+			scheduled_at timestamp without time zone not null,
+			transaction jsonb not null,
+			schema_version varchar,
+			status varchar not null,
+			transaction_id numeric,
+			error varchar,
+			inserted_at timestamp without time zone not null,
+			updated_at timestamp without time zone not null,
+			ended_at timestamp without time zone,
+			primary key (ledger, id)
+		);
+
+		-- the due transactions are listed by the worker
+		create index scheduled_transactions_pending on scheduled_transactions (ledger, scheduled_at) where status = 'PENDING';
+	end
+$$;
@@ -0,0 +1,30 @@
+do $$
+	begin
+		set search_path = '{{ .Schema }}';
+
+		create table recurrences (
+			ledger varchar not null,
+			id varchar not null,
+			schedule varchar not null,
+			start_at timestamp without time zone not null,
+			template varchar not null,
+			vars jsonb,
+			metadata jsonb,
+			schema_version varchar not null,
+			accounts varchar,
+			account_var varchar,
+			status varchar not null,
+			next_occurrence timestamp without time zone,
+			last_occurrence timestamp without time zone,
+			accounts_offset numeric not null default 0,
+			failed numeric not null default 0,
+			last_error varchar,
+			inserted_at timestamp without time zone not null,
+			updated_at timestamp without time zone not null,
+			primary key (ledger, id)
+		);
+
+		-- the due recurrences are listed by the worker
+		create index recurrences_active on recurrences (ledger, next_occurrence) where status = 'ACTIVE';
+	end
+$$;
@@ -0,0 +1,13 @@
+do $$
+	begin
+		set search_path = '{{ .Schema }}';
+
+		alter type log_type add value 'CREATED_REVERT_JOB';
+		alter type log_type add value 'UPDATED_REVERT_JOB';
+		alter type log_type add value 'CREATED_SCHEDULED_TRANSACTION';
+		alter type log_type add value 'UPDATED_SCHEDULED_TRANSACTION';
+		alter type log_type add value 'CREATED_RECURRENCE';
+		alter type log_type add value 'UPDATED_RECURRENCE';
+		alter type log_type add value 'DELETED_RECURRENCE';
+	end
+$$;
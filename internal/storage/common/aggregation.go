@@ -0,0 +1,245 @@
+package common
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
+	"github.com/formancehq/go-libs/v5/pkg/types/collections"
+
+	"github.com/formancehq/ledger/internal/queries"
+)
+
+// AggregationProperty is the SQL expression of a group key or of a measured property
+type AggregationProperty struct {
+	// Expression is evaluated on the filtered dataset, aliased `dataset`
+	Expression string
+	Args       []any
+	// Join is an optional join required by the expression (ex: to unnest the postings of the transactions)
+	Join string
+}
+
+// AggregationHandler is implemented by the repository handlers supporting aggregations
+type AggregationHandler[Opts any] interface {
+	ResolveAggregationProperty(query ResourceQuery[Opts], property string) (*AggregationProperty, error)
+}
+
+func (r *ResourceRepository[ResourceType, OptionsType]) Aggregate(ctx context.Context, q AggregatedQuery[OptionsType]) (*paginate.Cursor[queries.AggregationGroup], error) {
+	handler, ok := r.resourceHandler.(AggregationHandler[OptionsType])
+	if !ok {
+		return nil, NewErrInvalidQuery("aggregations are not supported on this resource")
+	}
+	if len(q.Aggregation.GroupBy) == 0 {
+		return nil, NewErrInvalidQuery("at least one group key is required")
+	}
+	if len(q.Options.Fields) > 0 {
+		return nil, NewErrInvalidQuery("projections cannot be used with aggregations")
+	}
+	if q.After != nil && q.Before != nil {
+		return nil, NewErrInvalidQuery("a cursor cannot list the groups both after and before a group")
+	}
+	for _, cursorKey := range [][]*string{q.After, q.Before} {
+		if cursorKey != nil && len(cursorKey) != len(q.Aggregation.GroupBy) {
+			return nil, NewErrInvalidQuery("the cursor does not match the group keys")
+		}
+	}
+
+	dataset, err := r.buildFilteredDataset(q.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	finalQuery := dataset.NewSelect().TableExpr("(?) dataset", dataset)
+	joins := make([]string, 0)
+	resolve := func(property string) (*AggregationProperty, error) {
+		ret, err := handler.ResolveAggregationProperty(q.Options, property)
+		if err != nil {
+			return nil, err
+		}
+		if ret.Join != "" && !slices.Contains(joins, ret.Join) {
+			joins = append(joins, ret.Join)
+		}
+		return ret, nil
+	}
+
+	groupColumns := make([]string, 0, len(q.Aggregation.GroupBy))
+	for i, key := range q.Aggregation.GroupBy {
+		property, err := resolve(key)
+		if err != nil {
+			return nil, err
+		}
+		column := fmt.Sprintf("key%d", i)
+		finalQuery = finalQuery.ColumnExpr(fmt.Sprintf("(%s)::text as %s", property.Expression, column), property.Args...)
+		groupColumns = append(groupColumns, column)
+	}
+
+	measures := make([]string, 0, len(q.Aggregation.Measures))
+	for name := range q.Aggregation.Measures {
+		measures = append(measures, name)
+	}
+	slices.Sort(measures)
+	for i, name := range measures {
+		measure := q.Aggregation.Measures[name]
+		column := fmt.Sprintf("measure%d", i)
+		switch measure.Function {
+		case queries.MeasureCount:
+			finalQuery = finalQuery.ColumnExpr(fmt.Sprintf("count(*)::text as %s", column))
+		case queries.MeasureSum, queries.MeasureMin, queries.MeasureMax:
+			property, err := resolve(measure.Property)
+			if err != nil {
+				return nil, err
+			}
+			finalQuery = finalQuery.ColumnExpr(fmt.Sprintf("%s(%s)::text as %s", measure.Function, property.Expression, column), property.Args...)
+		default:
+			return nil, NewErrInvalidQuery("invalid function `%s` for measure `%s`", measure.Function, name)
+		}
+	}
+
+	for _, join := range joins {
+		finalQuery = finalQuery.Join(join)
+	}
+	finalQuery = finalQuery.GroupExpr(strings.Join(groupColumns, ", "))
+
+	// the groups are sorted on their keys, null keys last, so the cursors can resume after (or before) a group key
+	sortColumns := make([]string, 0, 2*len(groupColumns))
+	for _, column := range groupColumns {
+		sortColumns = append(sortColumns, column+" is null", fmt.Sprintf("coalesce(%s, '')", column))
+	}
+	sortKey := "(" + strings.Join(sortColumns, ", ") + ")"
+	order := " asc"
+	if q.Before != nil {
+		order = " desc"
+	}
+	finalQuery = finalQuery.NewSelect().
+		TableExpr("(?) aggregated", finalQuery).
+		ColumnExpr(strings.Join(groupColumns, ", ")).
+		OrderExpr(strings.Join(sortColumns, order+", ") + order)
+	for i := range measures {
+		finalQuery = finalQuery.ColumnExpr(fmt.Sprintf("measure%d", i))
+	}
+	if q.After != nil {
+		finalQuery = finalQuery.Where(sortKey+" > "+sortKeyPlaceholders(len(groupColumns)), sortKeyArgs(q.After)...)
+	}
+	if q.Before != nil {
+		finalQuery = finalQuery.Where(sortKey+" < "+sortKeyPlaceholders(len(groupColumns)), sortKeyArgs(q.Before)...)
+	}
+	if q.PageSize > 0 {
+		finalQuery = finalQuery.Limit(int(q.PageSize) + 1)
+	}
+
+	rows, err := finalQuery.Rows(ctx)
+	if err != nil {
+		return nil, resolveQueryError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	groups := make([]queries.AggregationGroup, 0)
+	for rows.Next() {
+		values := make([]sql.NullString, len(groupColumns)+len(measures))
+		dest := make([]any, len(values))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		group := queries.AggregationGroup{
+			Keys:     map[string]*string{},
+			Measures: map[string]*big.Int{},
+		}
+		for i, key := range q.Aggregation.GroupBy {
+			group.Keys[key] = nil
+			if values[i].Valid {
+				group.Keys[key] = &values[i].String
+			}
+		}
+		for i, name := range measures {
+			value := values[len(groupColumns)+i]
+			group.Measures[name] = nil
+			if value.Valid {
+				measure, ok := new(big.Int).SetString(value.String, 10)
+				if !ok {
+					return nil, fmt.Errorf("invalid value for measure %s: %s", name, value.String)
+				}
+				group.Measures[name] = measure
+			}
+		}
+		groups = append(groups, group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, resolveQueryError(err)
+	}
+
+	return buildAggregationCursor(q, groups), nil
+}
+
+func sortKeyPlaceholders(size int) string {
+	return "(" + strings.Repeat("?, ", 2*size-1) + "?)"
+}
+
+// sortKeyArgs returns the values of the sort key of a group key
+func sortKeyArgs(key []*string) []any {
+	ret := make([]any, 0, 2*len(key))
+	for _, value := range key {
+		if value == nil {
+			ret = append(ret, true, "")
+		} else {
+			ret = append(ret, false, *value)
+		}
+	}
+	return ret
+}
+
+func groupKey(groupBy []string, group queries.AggregationGroup) []*string {
+	return collections.Map(groupBy, func(key string) *string {
+		return group.Keys[key]
+	})
+}
+
+func buildAggregationCursor[OptionsType any](q AggregatedQuery[OptionsType], groups []queries.AggregationGroup) *paginate.Cursor[queries.AggregationGroup] {
+	var previous, next *AggregatedQuery[OptionsType]
+
+	// the groups are fetched in reverse order when listing the groups before a group key
+	hasMore := q.PageSize != 0 && len(groups) > int(q.PageSize)
+	if hasMore {
+		groups = groups[:len(groups)-1]
+	}
+	if q.Before != nil {
+		slices.Reverse(groups)
+	}
+
+	if len(groups) > 0 && q.PageSize != 0 {
+		if (q.Before == nil && q.After != nil) || (q.Before != nil && hasMore) {
+			cp := q
+			cp.After = nil
+			cp.Before = groupKey(q.Aggregation.GroupBy, groups[0])
+			previous = &cp
+		}
+		if q.Before != nil || hasMore {
+			cp := q
+			cp.After = groupKey(q.Aggregation.GroupBy, groups[len(groups)-1])
+			cp.Before = nil
+			next = &cp
+		}
+	}
+
+	ret := &paginate.Cursor[queries.AggregationGroup]{
+		PageSize: int(q.PageSize),
+		HasMore:  next != nil,
+		Data:     groups,
+	}
+	if previous != nil {
+		ret.Previous = paginate.EncodeCursor(previous)
+	}
+	if next != nil {
+		ret.Next = paginate.EncodeCursor(next)
+	}
+	return ret
+}
@@ -70,6 +70,20 @@ func UnmarshalCursor[Options any](v string, modifiers ...func(query *InitialPagi
 	return reflect.ValueOf(q).Elem().Interface().(PaginatedQuery[Options]), nil
 }
 
+func UnmarshalAggregatedCursor[Options any](v string) (*AggregatedQuery[Options], error) {
+	res, err := base64.RawURLEncoding.DecodeString(v)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &AggregatedQuery[Options]{}
+	if err := json.Unmarshal(res, q); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	return q, nil
+}
+
 func Iterate[OF any, Options any](
 	ctx context.Context,
 	initialQuery InitialPaginatedQuery[Options],
@@ -1,7 +1,13 @@
 package common
 
 import (
+	"errors"
 	"fmt"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
 )
 
 type ErrInvalidQuery struct {
@@ -41,3 +47,14 @@ func newErrNotPaginatedField(field string) ErrNotPaginatedField {
 		field: field,
 	}
 }
+
+// resolveQueryError wraps the errors of the queries of the resources into storage errors.
+// The `$regex` operator is validated with the RE2 syntax but evaluated by the database,
+// so the expressions rejected by the database are invalid queries too.
+func resolveQueryError(err error) error {
+	var pge *pgconn.PgError
+	if errors.As(err, &pge) && pge.Code == pgerrcode.InvalidRegularExpression {
+		return NewErrInvalidQuery("invalid regular expression: %s", pge.Message)
+	}
+	return postgres.ResolveError(err)
+}
@@ -5,6 +5,8 @@ import (
 	"math/big"
 
 	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
+
+	"github.com/formancehq/ledger/internal/queries"
 )
 
 type PaginationConfig struct {
@@ -33,6 +35,16 @@ type (
 		// Marker
 		isPaginatedQuery()
 	}
+	// AggregatedQuery groups the items of a resource, the groups are paginated on their keys
+	AggregatedQuery[OptionsType any] struct {
+		Options     ResourceQuery[OptionsType] `json:"filters"`
+		Aggregation queries.Aggregation        `json:"aggregation"`
+		PageSize    uint64                     `json:"pageSize"`
+		// After lists the groups following this group key, its values are in the order of the group keys
+		After []*string `json:"after,omitempty"`
+		// Before lists the groups preceding this group key, it is set by the previous cursors
+		Before []*string `json:"before,omitempty"`
+	}
 )
 
 func (i InitialPaginatedQuery[OptionsType]) isPaginatedQuery() {}
@@ -61,6 +73,7 @@ func UnmarshalInitialPaginatedQueryOpts[TO any](from InitialPaginatedQuery[map[s
 				OOT:     from.Options.OOT,
 				Builder: from.Options.Builder,
 				Expand:  from.Options.Expand,
+				Fields:  from.Options.Fields,
 				Opts:    opts,
 			},
 		}, nil
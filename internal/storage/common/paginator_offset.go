@@ -84,3 +84,8 @@ func newOffsetPaginator[ResourceType, OptionsType any](
 ) OffsetPaginator[ResourceType, OptionsType] {
 	return OffsetPaginator[ResourceType, OptionsType]{query: query}
 }
+
+// BuildOffsetCursor builds the cursor of a page fetched outside a resource, the page holding one more item than the page size when more are available
+func BuildOffsetCursor[ResourceType, OptionsType any](query OffsetPaginatedQuery[OptionsType], ret []ResourceType) (*paginate.Cursor[ResourceType], error) {
+	return newOffsetPaginator[ResourceType, OptionsType](query).BuildCursor(ret)
+}
@@ -46,12 +46,49 @@ func ConvertOperatorToSQL(operator string) string {
 		return "<="
 	case queries.OperatorGTE:
 		return ">="
-	case queries.OperatorLike:
+	case queries.OperatorLike, queries.OperatorStartsWith:
 		return "like"
+	case queries.OperatorILike:
+		return "ilike"
+	case queries.OperatorRegex:
+		return "~"
 	}
 	panic("unreachable")
 }
 
+// ConvertValueToSQL converts the value of a filter for the SQL operator returned by ConvertOperatorToSQL,
+// the pattern operators require a string
+func ConvertValueToSQL(operator string, value any) (any, error) {
+	if !slices.Contains(queries.PatternOperators, operator) {
+		return value, nil
+	}
+	pattern, ok := value.(string)
+	if !ok {
+		return nil, NewErrInvalidQuery("expected string value for operator %s, got %T", operator, value)
+	}
+	if operator == queries.OperatorStartsWith {
+		return likeEscaper.Replace(pattern) + "%", nil
+	}
+	return pattern, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// ResolveMetadataFilter resolves a filter on a key of a jsonb column (ex: `metadata[key]`),
+// the pattern operators are applied on the value of the key, the other ones check the containment
+func ResolveMetadataFilter(column, key, operator string, value any) (string, []any, error) {
+	if slices.Contains(queries.PatternOperators, operator) {
+		value, err := ConvertValueToSQL(operator, value)
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("%s ->> ? %s ?", column, ConvertOperatorToSQL(operator)), []any{key, value}, nil
+	}
+	return column + " @> ?", []any{map[string]any{
+		key: value,
+	}}, nil
+}
+
 type JoinCondition struct {
 	Left  string
 	Right string
@@ -228,6 +265,7 @@ func (r *ResourceRepository[ResourceType, OptionsType]) expand(dataset *bun.Sele
 
 func (r *ResourceRepository[ResourceType, OptionsType]) GetOne(ctx context.Context, query ResourceQuery[OptionsType]) (*ResourceType, error) {
 
+	query.Expand = queries.ProjectExpand(query.Fields, query.Expand)
 	finalQuery, err := r.buildFilteredDataset(query)
 	if err != nil {
 		return nil, err
@@ -243,7 +281,7 @@ func (r *ResourceRepository[ResourceType, OptionsType]) GetOne(ctx context.Conte
 		Model(&ret).
 		Limit(1).
 		Scan(ctx); err != nil {
-		return nil, err
+		return nil, resolveQueryError(err)
 	}
 	if len(ret) == 0 {
 		return nil, postgres.ErrNotFound
@@ -260,7 +298,7 @@ func (r *ResourceRepository[ResourceType, OptionsType]) Count(ctx context.Contex
 	}
 
 	count, err := finalQuery.Count(ctx)
-	return count, postgres.ResolveError(err)
+	return count, resolveQueryError(err)
 }
 
 func NewResourceRepository[ResourceType, OptionsType any](
@@ -343,6 +381,7 @@ func (r *PaginatedResourceRepository[ResourceType, OptionsType]) Paginate(
 	default:
 		panic("should not happen")
 	}
+	resourceQuery.Expand = queries.ProjectExpand(resourceQuery.Fields, resourceQuery.Expand)
 
 	finalQuery, err := r.buildFilteredDataset(resourceQuery)
 	if err != nil {
@@ -368,7 +407,7 @@ func (r *PaginatedResourceRepository[ResourceType, OptionsType]) Paginate(
 	ret := make([]ResourceType, 0)
 	err = finalQuery.Model(&ret).Scan(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("scanning results: %w", err)
+		return nil, fmt.Errorf("scanning results: %w", resolveQueryError(err))
 	}
 
 	return paginator.BuildCursor(ret)
@@ -433,7 +472,10 @@ type ResourceQuery[Opts any] struct {
 	OOT     *time.Time    `json:"oot"`
 	Builder query.Builder `json:"qb"`
 	Expand  []string      `json:"expand,omitempty"`
-	Opts    Opts          `json:"opts"`
+	// Fields restricts the fetched fields, the unselected fields are left empty,
+	// and the expansions are governed by the fields (see queries.ProjectExpand)
+	Fields []string `json:"fields,omitempty"`
+	Opts   Opts     `json:"opts"`
 }
 
 func (rq ResourceQuery[Opts]) UsePIT() bool {
@@ -457,7 +499,7 @@ func (rq *ResourceQuery[Opts]) UnmarshalJSON(data []byte) error {
 
 	var err error
 	*rq = ResourceQuery[Opts](x.rawResourceQuery)
-	rq.Builder, err = query.ParseJSON(string(x.Builder))
+	rq.Builder, err = queries.ParseJSON(string(x.Builder))
 
 	return err
 }
@@ -470,4 +512,5 @@ type Resource[ResourceType, OptionsType any] interface {
 type PaginatedResource[ResourceType, OptionsType any] interface {
 	Resource[ResourceType, OptionsType]
 	Paginate(ctx context.Context, paginationOptions PaginatedQuery[OptionsType]) (*paginate.Cursor[ResourceType], error)
+	Aggregate(ctx context.Context, query AggregatedQuery[OptionsType]) (*paginate.Cursor[queries.AggregationGroup], error)
 }
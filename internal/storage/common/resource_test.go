@@ -1,6 +1,7 @@
 package common_test
 
 import (
+	"errors"
 	"testing"
 	stdtime "time"
 
@@ -8,6 +9,7 @@ import (
 
 	libtime "github.com/formancehq/go-libs/v5/pkg/types/time"
 
+	"github.com/formancehq/ledger/internal/queries"
 	"github.com/formancehq/ledger/internal/storage/common"
 )
 
@@ -53,3 +55,65 @@ func TestNormalizeDateFilterValue(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestResolveMetadataFilter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pattern operators apply on the value of the key", func(t *testing.T) {
+		t.Parallel()
+
+		where, args, err := common.ResolveMetadataFilter("metadata", "customer", queries.OperatorILike, "%acme%")
+		require.NoError(t, err)
+		require.Equal(t, "metadata ->> ? ilike ?", where)
+		require.Equal(t, []any{"customer", "%acme%"}, args)
+
+		where, args, err = common.ResolveMetadataFilter("metadata", "customer", queries.OperatorRegex, "^acme")
+		require.NoError(t, err)
+		require.Equal(t, "metadata ->> ? ~ ?", where)
+		require.Equal(t, []any{"customer", "^acme"}, args)
+	})
+
+	t.Run("startsWith escapes the like wildcards", func(t *testing.T) {
+		t.Parallel()
+
+		where, args, err := common.ResolveMetadataFilter("metadata", "ref", queries.OperatorStartsWith, `50%_off\`)
+		require.NoError(t, err)
+		require.Equal(t, "metadata ->> ? like ?", where)
+		require.Equal(t, []any{"ref", `50\%\_off\\%`}, args)
+	})
+
+	t.Run("other operators check the containment", func(t *testing.T) {
+		t.Parallel()
+
+		where, args, err := common.ResolveMetadataFilter("metadata", "customer", queries.OperatorMatch, "acme")
+		require.NoError(t, err)
+		require.Equal(t, "metadata @> ?", where)
+		require.Equal(t, []any{map[string]any{"customer": "acme"}}, args)
+	})
+}
+
+func TestConvertValueToSQL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pattern operators require a string", func(t *testing.T) {
+		t.Parallel()
+
+		for _, operator := range queries.PatternOperators {
+			_, err := common.ConvertValueToSQL(operator, 10)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, common.ErrInvalidQuery{}))
+
+			_, _, err = common.ResolveMetadataFilter("metadata", "customer", operator, true)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, common.ErrInvalidQuery{}))
+		}
+	})
+
+	t.Run("other operators pass the value through", func(t *testing.T) {
+		t.Parallel()
+
+		value, err := common.ConvertValueToSQL(queries.OperatorMatch, 10)
+		require.NoError(t, err)
+		require.Equal(t, 10, value)
+	})
+}
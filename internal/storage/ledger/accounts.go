@@ -91,6 +91,7 @@ func (store *Store) DeleteAccountMetadata(ctx context.Context, account, key stri
 			_, err := store.db.NewUpdate().
 				ModelTableExpr(store.GetPrefixedRelationName("accounts")).
 				Set("metadata = metadata - ?", key).
+				Set("updated_at = "+store.GetPrefixedRelationName("transaction_date")+"()").
 				Where("address = ?", account).
 				Where("ledger = ?", store.ledger.Name).
 				Exec(ctx)
@@ -100,6 +101,24 @@ func (store *Store) DeleteAccountMetadata(ctx context.Context, account, key stri
 	return err
 }
 
+// LockAccount returns the account with the given address and locks it until the end of the TX
+func (store *Store) LockAccount(ctx context.Context, address string) (*ledger.Account, error) {
+	account := &ledger.Account{}
+	err := store.db.NewSelect().
+		Model(account).
+		ModelTableExpr(store.GetPrefixedRelationName("accounts")).
+		Column("address", "metadata", "first_usage", "insertion_date", "updated_at").
+		Where("address = ?", address).
+		Where("ledger = ?", store.ledger.Name).
+		For("update").
+		Scan(ctx)
+	if err != nil {
+		return nil, postgres.ResolveError(err)
+	}
+
+	return account, nil
+}
+
 func (store *Store) UpsertAccounts(ctx context.Context, accounts ...ledger.AccountWithDefaultMetadata) error {
 	return tracing.SkipResult(tracing.TraceWithMetric(
 		ctx,
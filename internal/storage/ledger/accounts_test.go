@@ -13,11 +13,13 @@ import (
 
 	logging "github.com/formancehq/go-libs/v5/pkg/observe/log"
 	"github.com/formancehq/go-libs/v5/pkg/query"
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
 	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
 	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
 	"github.com/formancehq/go-libs/v5/pkg/types/time"
 
 	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/queries"
 	"github.com/formancehq/ledger/internal/storage/common"
 	ledgerstore "github.com/formancehq/ledger/internal/storage/ledger"
 )
@@ -226,6 +228,125 @@ func TestAccountsList(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, accounts.Data, 3)
 	})
+	t.Run("list accounts incompatible with a chart", func(t *testing.T) {
+		t.Parallel()
+		accounts, err := store.Accounts().Paginate(ctx, common.InitialPaginatedQuery[any]{
+			PageSize: 2,
+			Options: common.ResourceQuery[any]{
+				Opts: ledgerstore.AccountsOptions{
+					IncompatibleWith: ledger.ChartOfAccounts{
+						"account": {
+							VariableSegment: &ledger.ChartVariableSegment{
+								Label:   "id",
+								Pattern: pointer.For("^[12]$"),
+								ChartSegment: ledger.ChartSegment{
+									Account: &ledger.ChartAccount{},
+								},
+							},
+						},
+						"bank":  {Account: &ledger.ChartAccount{}},
+						"world": {Account: &ledger.ChartAccount{}},
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.True(t, accounts.HasMore)
+		require.Len(t, accounts.Data, 2)
+		require.Equal(t, "account:3", accounts.Data[0].Address)
+		require.Equal(t, "orders:1", accounts.Data[1].Address)
+	})
+	t.Run("list accounts of a chart incompatible with another one", func(t *testing.T) {
+		t.Parallel()
+		from := ledger.ChartOfAccounts{
+			"account": {
+				VariableSegment: &ledger.ChartVariableSegment{
+					Label: "id",
+					ChartSegment: ledger.ChartSegment{
+						Account: &ledger.ChartAccount{},
+					},
+				},
+			},
+			"bank":  {Account: &ledger.ChartAccount{}},
+			"world": {Account: &ledger.ChartAccount{}},
+		}
+		accounts, err := store.Accounts().Paginate(ctx, common.InitialPaginatedQuery[any]{
+			Options: common.ResourceQuery[any]{
+				Opts: ledgerstore.AccountsOptions{
+					// the orders are not valid for the compared chart, so they are not broken by the new one
+					ChartAccounts: from.AccountAddresses(""),
+					IncompatibleWith: ledger.ChartOfAccounts{
+						"account": {
+							VariableSegment: &ledger.ChartVariableSegment{
+								Label:   "id",
+								Pattern: pointer.For("^[12]$"),
+								ChartSegment: ledger.ChartSegment{
+									Account: &ledger.ChartAccount{},
+								},
+							},
+						},
+						"bank":  {Account: &ledger.ChartAccount{}},
+						"world": {Account: &ledger.ChartAccount{}},
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, accounts.Data, 1)
+		require.Equal(t, "account:3", accounts.Data[0].Address)
+	})
+	t.Run("aggregate by account of a chart", func(t *testing.T) {
+		t.Parallel()
+		chart := ledger.ChartOfAccounts{
+			"account": {
+				VariableSegment: &ledger.ChartVariableSegment{
+					Label:   "id",
+					Pattern: pointer.For("^[12]$"),
+					ChartSegment: ledger.ChartSegment{
+						Account: &ledger.ChartAccount{},
+					},
+				},
+			},
+			"bank":  {Account: &ledger.ChartAccount{}},
+			"world": {Account: &ledger.ChartAccount{}},
+		}
+
+		// account:3 does not match the pattern of the variable segment, orders:1 and orders:2 are not in the chart
+		counts, err := store.Accounts().Aggregate(ctx, common.AggregatedQuery[any]{
+			Options: common.ResourceQuery[any]{
+				Opts: ledgerstore.AccountsOptions{
+					ChartAccounts: chart.AccountAddresses(""),
+				},
+			},
+			Aggregation: queries.Aggregation{
+				GroupBy: []string{ledgerstore.ChartAccountAggregationKey},
+				Measures: map[string]queries.Measure{
+					"count": {Function: queries.MeasureCount},
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []queries.AggregationGroup{
+			{
+				Keys:     map[string]*string{ledgerstore.ChartAccountAggregationKey: pointer.For("account:$id")},
+				Measures: map[string]*big.Int{"count": big.NewInt(2)},
+			},
+			{
+				Keys:     map[string]*string{ledgerstore.ChartAccountAggregationKey: pointer.For("bank")},
+				Measures: map[string]*big.Int{"count": big.NewInt(1)},
+			},
+			{
+				Keys:     map[string]*string{ledgerstore.ChartAccountAggregationKey: pointer.For("world")},
+				Measures: map[string]*big.Int{"count": big.NewInt(1)},
+			},
+		}, counts.Data)
+
+		volumes, err := store.ChartAccountsVolumes(chart.AccountAddresses("account")).GetOne(ctx, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{})
+		require.NoError(t, err)
+		RequireEqual(t, map[string]ledger.VolumesByAssets{
+			"account:$id": {"USD": ledger.NewVolumesInt64(300, 50)},
+		}, volumes.Volumes)
+	})
 	t.Run("list using filter on balances", func(t *testing.T) {
 		t.Parallel()
 		accounts, err := store.Accounts().Paginate(ctx, common.InitialPaginatedQuery[any]{
@@ -334,6 +455,26 @@ func TestAccountsUpdateMetadata(t *testing.T) {
 	require.Equal(t, m, account.Metadata, "account metadata should match")
 }
 
+func TestAccountsLock(t *testing.T) {
+	t.Parallel()
+	store := newLedgerStore(t)
+	ctx := logging.TestingContext()
+	now := time.Now()
+
+	_, err := store.LockAccount(ctx, "bank")
+	require.ErrorIs(t, err, postgres.ErrNotFound)
+
+	require.NoError(t, store.UpdateAccountsMetadata(ctx, map[string]metadata.Metadata{
+		"bank": {"foo": "bar"},
+	}, now))
+
+	account, err := store.LockAccount(ctx, "bank")
+	require.NoError(t, err)
+	require.Equal(t, "bank", account.Address)
+	require.Equal(t, metadata.Metadata{"foo": "bar"}, account.Metadata)
+	require.Equal(t, now, account.UpdatedAt)
+}
+
 func TestAccountsGet(t *testing.T) {
 	t.Parallel()
 
@@ -7,11 +7,14 @@ import (
 	"strings"
 
 	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
 
 	ledger "github.com/formancehq/ledger/internal"
 	"github.com/formancehq/ledger/internal/tracing"
 )
 
+// GetBalances returns the available balances of the accounts and locks the accounts until the end of the TX:
+// the pending holds not expired at the date of the sql transaction, which is the date of the log being written, are deducted
 func (store *Store) GetBalances(ctx context.Context, query BalanceQuery) (ledger.Balances, error) {
 	return tracing.TraceWithMetric(
 		ctx,
@@ -19,94 +22,126 @@ func (store *Store) GetBalances(ctx context.Context, query BalanceQuery) (ledger
 		store.tracer,
 		store.getBalancesHistogram,
 		func(ctx context.Context) (ledger.Balances, error) {
-			conditions := make([]string, 0)
-			args := make([]any, 0)
-			for account, assets := range query {
-				for _, asset := range assets {
-					conditions = append(conditions, "ledger = ? and accounts_address = ? and asset = ?")
-					args = append(args, store.ledger.Name, account, asset)
-				}
-			}
+			return store.lockBalances(ctx, query, true)
+		},
+	)
+}
 
-			type AccountsVolumesWithLedger struct {
-				ledger.AccountsVolumes `bun:",extend"`
-				Ledger                 string `bun:"ledger,type:varchar"`
-			}
+// LockBalances returns the balances of the accounts (input - output), without deducting the pending holds,
+// and locks the accounts until the end of the TX
+func (store *Store) LockBalances(ctx context.Context, query BalanceQuery) (ledger.Balances, error) {
+	return tracing.TraceWithMetric(
+		ctx,
+		"LockBalances",
+		store.tracer,
+		store.lockBalancesHistogram,
+		func(ctx context.Context) (ledger.Balances, error) {
+			return store.lockBalances(ctx, query, false)
+		},
+	)
+}
 
-			accountsVolumes := make([]AccountsVolumesWithLedger, 0)
-			for account, assets := range query {
-				for _, asset := range assets {
-					accountsVolumes = append(accountsVolumes, AccountsVolumesWithLedger{
-						Ledger: store.ledger.Name,
-						AccountsVolumes: ledger.AccountsVolumes{
-							Account: account,
-							Asset:   asset,
-							Input:   new(big.Int),
-							Output:  new(big.Int),
-						},
-					})
-				}
-			}
+// lockBalances locks the volumes of the accounts and returns their balances,
+// the pending holds are deducted if withHolds is true
+func (store *Store) lockBalances(ctx context.Context, query BalanceQuery, withHolds bool) (ledger.Balances, error) {
+	conditions := make([]string, 0)
+	args := make([]any, 0)
+	for account, assets := range query {
+		for _, asset := range assets {
+			conditions = append(conditions, "ledger = ? and accounts_address = ? and asset = ?")
+			args = append(args, store.ledger.Name, account, asset)
+		}
+	}
 
-			// prevent deadlocks by sorting the accountsVolumes slice
-			slices.SortStableFunc(accountsVolumes, func(i, j AccountsVolumesWithLedger) int {
-				if i.Account < j.Account {
-					return -1
-				} else if i.Account > j.Account {
-					return 1
-				} else if i.Asset < j.Asset {
-					return -1
-				} else if i.Asset > j.Asset {
-					return 1
-				} else {
-					return 0
-				}
+	type AccountsVolumesWithLedger struct {
+		ledger.AccountsVolumes `bun:",extend"`
+		Ledger                 string   `bun:"ledger,type:varchar"`
+		PendingHolds           *big.Int `bun:"pending_holds,type:numeric,scanonly"`
+	}
+
+	accountsVolumes := make([]AccountsVolumesWithLedger, 0)
+	for account, assets := range query {
+		for _, asset := range assets {
+			accountsVolumes = append(accountsVolumes, AccountsVolumesWithLedger{
+				Ledger: store.ledger.Name,
+				AccountsVolumes: ledger.AccountsVolumes{
+					Account: account,
+					Asset:   asset,
+					Input:   new(big.Int),
+					Output:  new(big.Int),
+				},
 			})
+		}
+	}
+
+	// prevent deadlocks by sorting the accountsVolumes slice
+	slices.SortStableFunc(accountsVolumes, func(i, j AccountsVolumesWithLedger) int {
+		if i.Account < j.Account {
+			return -1
+		} else if i.Account > j.Account {
+			return 1
+		} else if i.Asset < j.Asset {
+			return -1
+		} else if i.Asset > j.Asset {
+			return 1
+		} else {
+			return 0
+		}
+	})
 
-			err := store.db.NewSelect().
-				With(
-					"ins",
-					// Try to insert volumes with 0 values.
-					// This way, if the account has a 0 balance at this point, it will be locked as any other accounts.
-					// It the complete sql transaction fail, the account volumes will not be inserted.
-					store.db.NewInsert().
-						Model(&accountsVolumes).
-						ModelTableExpr(store.GetPrefixedRelationName("accounts_volumes")).
-						On("conflict do nothing"),
-				).
+	selectVolumes := store.db.NewSelect().
+		With(
+			"ins",
+			// Try to insert volumes with 0 values.
+			// This way, if the account has a 0 balance at this point, it will be locked as any other accounts.
+			// It the complete sql transaction fail, the account volumes will not be inserted.
+			store.db.NewInsert().
 				Model(&accountsVolumes).
 				ModelTableExpr(store.GetPrefixedRelationName("accounts_volumes")).
-				Column("accounts_address", "asset", "input", "output").
-				Where("("+strings.Join(conditions, ") OR (")+")", args...).
-				For("update").
-				// notes(gfyrag): Keep order, it ensures consistent locking order and limit deadlocks
-				Order("accounts_address", "asset").
-				Scan(ctx)
-			if err != nil {
-				return nil, postgres.ResolveError(err)
-			}
+				On("conflict do nothing"),
+		).
+		Model(&accountsVolumes).
+		ModelTableExpr(store.GetPrefixedRelationName("accounts_volumes")).
+		Column("accounts_address", "asset", "input", "output").
+		Where("("+strings.Join(conditions, ") OR (")+")", args...).
+		For("update").
+		// notes(gfyrag): Keep order, it ensures consistent locking order and limit deadlocks
+		Order("accounts_address", "asset")
+	if withHolds {
+		// The holds are read by the same query, using the partial index on the pending holds,
+		// the holds of the accounts are created under the same locks
+		selectVolumes = selectVolumes.ColumnExpr(
+			"(?) as pending_holds",
+			store.selectPendingHolds("accounts_volumes.accounts_address", "accounts_volumes.asset", nil, time.Time{}),
+		)
+	}
 
-			ret := ledger.Balances{}
-			for _, volumes := range accountsVolumes {
-				if _, ok := ret[volumes.Account]; !ok {
-					ret[volumes.Account] = map[string]*big.Int{}
-				}
-				ret[volumes.Account][volumes.Asset] = new(big.Int).Sub(volumes.Input, volumes.Output)
-			}
+	if err := selectVolumes.Scan(ctx); err != nil {
+		return nil, postgres.ResolveError(err)
+	}
+
+	ret := ledger.Balances{}
+	for _, volumes := range accountsVolumes {
+		if _, ok := ret[volumes.Account]; !ok {
+			ret[volumes.Account] = map[string]*big.Int{}
+		}
+		ret[volumes.Account][volumes.Asset] = new(big.Int).Sub(volumes.Input, volumes.Output)
+		if volumes.PendingHolds != nil {
+			ret[volumes.Account][volumes.Asset].Sub(ret[volumes.Account][volumes.Asset], volumes.PendingHolds)
+		}
+	}
 
-			// Fill empty balances with 0 value
-			for account, assets := range query {
-				if _, ok := ret[account]; !ok {
-					ret[account] = map[string]*big.Int{}
-				}
-				for _, asset := range assets {
-					if _, ok := ret[account][asset]; !ok {
-						ret[account][asset] = big.NewInt(0)
-					}
-				}
+	// Fill empty balances with 0 value
+	for account, assets := range query {
+		if _, ok := ret[account]; !ok {
+			ret[account] = map[string]*big.Int{}
+		}
+		for _, asset := range assets {
+			if _, ok := ret[account][asset]; !ok {
+				ret[account][asset] = big.NewInt(0)
 			}
+		}
+	}
 
-			return ret, nil
-		},
-	)
+	return ret, nil
 }
@@ -290,6 +290,37 @@ func TestBalancesAggregates(t *testing.T) {
 			},
 		}, *ret)
 	})
+	t.Run("history on effective date", func(t *testing.T) {
+		t.Parallel()
+		ret, err := store.AggregatedVolumesHistory([]time.Time{
+			now.Add(-2 * time.Minute),
+			now.Add(-time.Second),
+			now,
+		}).GetOne(ctx, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
+			Builder: query.Match("address", "users:"),
+		})
+		require.NoError(t, err)
+		RequireEqual(t, ledger.AggregatedVolumesHistory{
+			Aggregated: []ledger.VolumesByAssets{
+				{},
+				{
+					"USD": ledger.Volumes{
+						Input:  big.NewInt(0).Add(bigInt, smallInt),
+						Output: new(big.Int),
+					},
+				},
+				{
+					"USD": ledger.Volumes{
+						Input: big.NewInt(0).Add(
+							big.NewInt(0).Mul(bigInt, big.NewInt(2)),
+							big.NewInt(0).Mul(smallInt, big.NewInt(2)),
+						),
+						Output: new(big.Int),
+					},
+				},
+			},
+		}, *ret)
+	})
 	t.Run("using pit on insertion date", func(t *testing.T) {
 		t.Parallel()
 		ret, err := store.AggregatedVolumes().GetOne(ctx, common.ResourceQuery[ledger.GetAggregatedVolumesOptions]{
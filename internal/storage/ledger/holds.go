@@ -0,0 +1,137 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+)
+
+// InsertHold inserts the hold, its insertion date defaults to the date of the sql transaction if zero
+func (store *Store) InsertHold(ctx context.Context, hold *ledger.Hold) error {
+	query := store.db.NewInsert().
+		Model(hold).
+		Value("ledger", "?", store.ledger.Name).
+		ModelTableExpr(store.GetPrefixedRelationName("holds")).
+		Returning("inserted_at")
+	if hold.InsertedAt.IsZero() {
+		query = query.Value("inserted_at", store.GetPrefixedRelationName("transaction_date")+"()")
+	}
+	_, err := query.Exec(ctx)
+	return postgres.ResolveError(err)
+}
+
+func (store *Store) FindHold(ctx context.Context, id string) (*ledger.Hold, error) {
+	hold := &ledger.Hold{}
+	err := store.db.NewSelect().
+		Model(hold).
+		ModelTableExpr(store.GetPrefixedRelationName("holds")).
+		Where("id = ?", id).
+		Where("ledger = ?", store.ledger.Name).
+		Scan(ctx)
+	if err != nil {
+		return nil, postgres.ResolveError(err)
+	}
+
+	return hold, nil
+}
+
+// EndHold changes the status of a pending hold, at the given date or at the date of the sql transaction if zero.
+// It returns the hold and a boolean indicating if the hold has been modified, false indicates an already ended hold.
+func (store *Store) EndHold(ctx context.Context, id string, status ledger.HoldStatus, at time.Time) (*ledger.Hold, bool, error) {
+	type modifiedEntity struct {
+		ledger.Hold `bun:",extend"`
+		Modified    bool `bun:"modified"`
+	}
+	me := &modifiedEntity{}
+	date, args := store.dateOrTransactionDate(at)
+
+	err := store.db.NewSelect().
+		With("upd", store.db.NewUpdate().
+			Model(&ledger.Hold{}).
+			ModelTableExpr(store.GetPrefixedRelationName("holds")).
+			Set("status = ?", status).
+			Set("ended_at = "+date, args...).
+			Where("id = ?", id).
+			Where("ledger = ?", store.ledger.Name).
+			Where("status = ?", ledger.HoldStatusPending).
+			Returning("*"),
+		).
+		ModelTableExpr(
+			"(?) holds",
+			store.db.NewSelect().
+				ColumnExpr("upd.*, true as modified").
+				ModelTableExpr("upd").
+				UnionAll(
+					store.db.NewSelect().
+						ModelTableExpr(store.GetPrefixedRelationName("holds")).
+						ColumnExpr("*, false as modified").
+						Where("id = ? and ledger = ?", id, store.ledger.Name).
+						Limit(1),
+				),
+		).
+		Model(me).
+		ColumnExpr("*").
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		return nil, false, postgres.ResolveError(err)
+	}
+
+	return &me.Hold, me.Modified, nil
+}
+
+// ListExpiredHolds returns the pending holds expired at the given date, the oldest expirations first
+func (store *Store) ListExpiredHolds(ctx context.Context, at time.Time, limit int) ([]ledger.Hold, error) {
+	ret := make([]ledger.Hold, 0)
+	err := store.db.NewSelect().
+		Model(&ret).
+		ModelTableExpr(store.GetPrefixedRelationName("holds")).
+		Where("ledger = ?", store.ledger.Name).
+		Where("status = ?", ledger.HoldStatusPending).
+		Where("expires_at <= ?", at).
+		Order("expires_at").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, postgres.ResolveError(err)
+	}
+
+	return ret, nil
+}
+
+// selectPendingHolds sums the amounts of the holds of the account and asset columns of the outer query,
+// pending at the pit if any, otherwise still pending and not expired at the given date,
+// the date of the sql transaction (the date of the log being written) being used if zero
+func (store *Store) selectPendingHolds(accountColumn, assetColumn string, pit *time.Time, at time.Time) *bun.SelectQuery {
+	query := store.db.NewSelect().
+		TableExpr(store.GetPrefixedRelationName("holds")).
+		ColumnExpr("sum(holds.amount)").
+		Where("holds.ledger = ?", store.ledger.Name).
+		Where("holds.source = " + accountColumn).
+		Where("holds.asset = " + assetColumn)
+
+	if pit == nil {
+		date, args := store.dateOrTransactionDate(at)
+		return query.
+			Where("holds.status = ?", ledger.HoldStatusPending).
+			Where("(holds.expires_at is null or holds.expires_at > "+date+")", args...)
+	}
+
+	return query.
+		Where("holds.inserted_at <= ?", pit).
+		Where("(holds.ended_at is null or holds.ended_at > ?)", pit).
+		Where("(holds.expires_at is null or holds.expires_at > ?)", pit)
+}
+
+// dateOrTransactionDate returns the sql expression of the date, the date of the sql transaction if zero
+func (store *Store) dateOrTransactionDate(at time.Time) (string, []any) {
+	if at.IsZero() {
+		return store.GetPrefixedRelationName("transaction_date") + "()", nil
+	}
+	return "?", []any{at}
+}
@@ -0,0 +1,123 @@
+//go:build it
+
+package ledger_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	logging "github.com/formancehq/go-libs/v5/pkg/observe/log"
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+	ledgerstore "github.com/formancehq/ledger/internal/storage/ledger"
+)
+
+func TestHolds(t *testing.T) {
+	t.Parallel()
+
+	ctx := logging.TestingContext()
+
+	store := newLedgerStore(t)
+	now := time.Now()
+
+	_, err := store.FindHold(ctx, "unknown")
+	require.ErrorIs(t, err, postgres.ErrNotFound)
+
+	hold := ledger.Hold{
+		ID:          "hold1",
+		Source:      "bank",
+		Destination: "merchant",
+		Asset:       "USD",
+		Amount:      big.NewInt(100),
+		Metadata:    metadata.Metadata{},
+		Status:      ledger.HoldStatusPending,
+		InsertedAt:  now,
+	}
+	require.NoError(t, store.InsertHold(ctx, &hold))
+
+	expiredHold := ledger.Hold{
+		ID:          "hold2",
+		Source:      "bank",
+		Destination: "merchant",
+		Asset:       "USD",
+		Amount:      big.NewInt(50),
+		Metadata:    metadata.Metadata{},
+		Status:      ledger.HoldStatusPending,
+		ExpiresAt:   pointer.For(now.Add(-time.Minute)),
+		InsertedAt:  now.Add(-time.Hour),
+	}
+	require.NoError(t, store.InsertHold(ctx, &expiredHold))
+
+	found, err := store.FindHold(ctx, hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, hold, *found)
+
+	// only the hold not expired reduces the balance
+	balances, err := store.GetBalances(ctx, ledgerstore.BalanceQuery{
+		"bank": {"USD"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "-100", balances["bank"]["USD"].String())
+
+	// the holds are not deducted from the locked balances
+	balances, err = store.LockBalances(ctx, ledgerstore.BalanceQuery{
+		"bank": {"USD"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "0", balances["bank"]["USD"].String())
+
+	expired, err := store.ListExpiredHolds(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	require.Equal(t, expiredHold.ID, expired[0].ID)
+
+	ended, modified, err := store.EndHold(ctx, hold.ID, ledger.HoldStatusCaptured, now)
+	require.NoError(t, err)
+	require.True(t, modified)
+	require.Equal(t, ledger.HoldStatusCaptured, ended.Status)
+	require.Equal(t, &now, ended.EndedAt)
+
+	ended, modified, err = store.EndHold(ctx, hold.ID, ledger.HoldStatusVoided, now)
+	require.NoError(t, err)
+	require.False(t, modified)
+	require.Equal(t, ledger.HoldStatusCaptured, ended.Status)
+
+	balances, err = store.GetBalances(ctx, ledgerstore.BalanceQuery{
+		"bank": {"USD"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "0", balances["bank"]["USD"].String())
+}
+
+func TestHoldsAtTransactionDate(t *testing.T) {
+	t.Parallel()
+
+	ctx := logging.TestingContext()
+
+	store := newLedgerStore(t)
+
+	// the dates default to the date of the sql transaction, which is the date of the log
+	hold := ledger.Hold{
+		ID:          "hold1",
+		Source:      "bank",
+		Destination: "merchant",
+		Asset:       "USD",
+		Amount:      big.NewInt(100),
+		Metadata:    metadata.Metadata{},
+		Status:      ledger.HoldStatusPending,
+	}
+	require.NoError(t, store.InsertHold(ctx, &hold))
+	require.False(t, hold.InsertedAt.IsZero())
+
+	ended, modified, err := store.EndHold(ctx, hold.ID, ledger.HoldStatusVoided, time.Time{})
+	require.NoError(t, err)
+	require.True(t, modified)
+	require.NotNil(t, ended.EndedAt)
+	require.False(t, ended.EndedAt.Before(hold.InsertedAt))
+}
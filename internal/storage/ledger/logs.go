@@ -26,6 +26,10 @@ type Log struct {
 }
 
 func (log Log) ToCore() ledger.Log {
+	if log.Data == nil {
+		// the data is not fetched by a projection
+		return *log.Log
+	}
 	payload, err := ledger.HydrateLog(log.Type, log.Data)
 	if err != nil {
 		panic(fmt.Errorf("hydrating log data: %w", err))
@@ -111,6 +115,38 @@ func (store *Store) InsertLog(ctx context.Context, log *ledger.Log) error {
 	return err
 }
 
+// LockLastLogID returns the id of the last log of the ledger, or nil if the ledger has no log.
+// It takes the lock used to insert the logs, so the last log can't change until the end of the TX.
+// The lock is only taken by the insertions when the logs are hashed synchronously,
+// ErrMissingFeature is returned otherwise.
+func (store *Store) LockLastLogID(ctx context.Context) (*uint64, error) {
+	if !store.ledger.HasFeature(features.FeatureHashLogs, "SYNC") {
+		return nil, NewErrMissingFeature(features.FeatureHashLogs)
+	}
+
+	_, err := store.db.NewRaw(`select pg_advisory_xact_lock(?)`, store.ledger.ID).Exec(ctx)
+	if err != nil {
+		return nil, postgres.ResolveError(err)
+	}
+
+	var ret []uint64
+	err = store.db.NewSelect().
+		ModelTableExpr(store.GetPrefixedRelationName("logs")).
+		Column("id").
+		Where("ledger = ?", store.ledger.Name).
+		Order("id desc").
+		Limit(1).
+		Scan(ctx, &ret)
+	if err != nil {
+		return nil, postgres.ResolveError(err)
+	}
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return &ret[0], nil
+}
+
 func (store *Store) ReadLogWithIdempotencyKey(ctx context.Context, key string) (*ledger.Log, error) {
 	return tracing.TraceWithMetric(
 		ctx,
@@ -22,6 +22,7 @@ import (
 	ledger "github.com/formancehq/ledger/internal"
 	"github.com/formancehq/ledger/internal/storage/common"
 	ledgerstore "github.com/formancehq/ledger/internal/storage/ledger"
+	"github.com/formancehq/ledger/pkg/features"
 )
 
 func TestLogsInsert(t *testing.T) {
@@ -202,6 +203,41 @@ func TestLogsReadWithIdempotencyKey(t *testing.T) {
 	require.Equal(t, log, *lastLog)
 }
 
+func TestLogsLockLastLogID(t *testing.T) {
+	t.Parallel()
+
+	store := newLedgerStore(t)
+	ctx := logging.TestingContext()
+
+	lastLogID, err := store.LockLastLogID(ctx)
+	require.NoError(t, err)
+	require.Nil(t, lastLogID)
+
+	log := ledger.NewLog(ledger.CreatedTransaction{
+		Transaction: ledger.NewTransaction().WithPostings(
+			ledger.NewPosting("world", "bank", "USD", big.NewInt(100)),
+		),
+		AccountMetadata: ledger.AccountMetadata{},
+	})
+	require.NoError(t, store.InsertLog(ctx, &log))
+
+	lastLogID, err = store.LockLastLogID(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, lastLogID)
+	require.Equal(t, *log.ID, *lastLogID)
+}
+
+func TestLogsLockLastLogIDWithAsyncHashing(t *testing.T) {
+	t.Parallel()
+
+	store := newLedgerStore(t, func(cfg *ledger.Configuration) {
+		cfg.Features = features.DefaultFeatures.With(features.FeatureHashLogs, "ASYNC")
+	})
+
+	_, err := store.LockLastLogID(logging.TestingContext())
+	require.ErrorIs(t, err, ledgerstore.ErrMissingFeature{})
+}
+
 func TestLogsList(t *testing.T) {
 	t.Parallel()
 	store := newLedgerStore(t)
@@ -7,6 +7,7 @@ import (
 
 	ledger "github.com/formancehq/ledger/internal"
 	"github.com/formancehq/ledger/internal/tracing"
+	"github.com/formancehq/ledger/pkg/features"
 )
 
 func (store *Store) InsertMoves(ctx context.Context, moves ...*ledger.Move) error {
@@ -29,3 +30,49 @@ func (store *Store) InsertMoves(ctx context.Context, moves ...*ledger.Move) erro
 
 	return err
 }
+
+// ListAccountMoves returns the moves of an account, ordered by date then by insertion order,
+// with the post commit volumes of the ordering date
+func (store *Store) ListAccountMoves(ctx context.Context, query AccountMovesQuery) ([]ledger.Move, error) {
+	if !store.ledger.HasFeature(features.FeatureMovesHistory, "ON") {
+		return nil, NewErrMissingFeature(features.FeatureMovesHistory)
+	}
+	if !query.UseInsertionDate && !store.ledger.HasFeature(features.FeatureMovesHistoryPostCommitEffectiveVolumes, "SYNC") {
+		return nil, NewErrMissingFeature(features.FeatureMovesHistoryPostCommitEffectiveVolumes)
+	}
+
+	return tracing.TraceWithMetric(
+		ctx,
+		"ListAccountMoves",
+		store.tracer,
+		store.listAccountMovesHistogram,
+		func(ctx context.Context) ([]ledger.Move, error) {
+			dateColumn, volumesColumn := "effective_date", "post_commit_effective_volumes"
+			if query.UseInsertionDate {
+				dateColumn, volumesColumn = "insertion_date", "post_commit_volumes"
+			}
+
+			moves := make([]ledger.Move, 0)
+			selectMoves := store.newScopedSelect().
+				Model(&moves).
+				ModelTableExpr(store.GetPrefixedRelationName("moves")).
+				Column("transactions_id", "is_source", "accounts_address", "amount", "asset", "insertion_date", "effective_date", volumesColumn).
+				Where("accounts_address = ?", query.Account).
+				Where(dateColumn+" >= ?", query.OOT).
+				Where(dateColumn+" <= ?", query.PIT).
+				Order(dateColumn, "seq")
+			if query.Offset > 0 {
+				selectMoves = selectMoves.Offset(query.Offset)
+			}
+			if query.Limit > 0 {
+				selectMoves = selectMoves.Limit(query.Limit)
+			}
+
+			if err := selectMoves.Scan(ctx); err != nil {
+				return nil, postgres.ResolveError(err)
+			}
+
+			return moves, nil
+		},
+	)
+}
@@ -20,6 +20,7 @@ import (
 
 	ledger "github.com/formancehq/ledger/internal"
 	"github.com/formancehq/ledger/internal/storage/common"
+	ledgerstore "github.com/formancehq/ledger/internal/storage/ledger"
 )
 
 func TestMovesInsert(t *testing.T) {
@@ -188,3 +189,80 @@ func TestMovesInsert(t *testing.T) {
 		}, *aggregatedVolumes)
 	})
 }
+
+func TestListAccountMoves(t *testing.T) {
+	t.Parallel()
+
+	store := newLedgerStore(t)
+	ctx := logging.TestingContext()
+	now := time.Now()
+
+	tx1 := ledger.NewTransaction().
+		WithPostings(ledger.NewPosting("world", "account:1", "USD", big.NewInt(100))).
+		WithTimestamp(now.Add(-3 * time.Minute)).
+		WithInsertedAt(now.Add(3 * time.Minute))
+	require.NoError(t, commitTransactionAndUpsertAccounts(ctx, store, &tx1))
+
+	tx2 := ledger.NewTransaction().
+		WithPostings(ledger.NewPosting("account:1", "bank", "USD", big.NewInt(30))).
+		WithTimestamp(now.Add(-2 * time.Minute)).
+		WithInsertedAt(now.Add(2 * time.Minute))
+	require.NoError(t, commitTransactionAndUpsertAccounts(ctx, store, &tx2))
+
+	tx3 := ledger.NewTransaction().
+		WithPostings(ledger.NewPosting("world", "account:1", "USD", big.NewInt(10))).
+		WithTimestamp(now.Add(-time.Minute)).
+		WithInsertedAt(now.Add(time.Minute))
+	require.NoError(t, commitTransactionAndUpsertAccounts(ctx, store, &tx3))
+
+	t.Run("effective date", func(t *testing.T) {
+		t.Parallel()
+
+		moves, err := store.ListAccountMoves(ctx, ledgerstore.AccountMovesQuery{
+			Account: "account:1",
+			OOT:     now.Add(-2 * time.Minute),
+			PIT:     now,
+		})
+		require.NoError(t, err)
+		require.Len(t, moves, 2)
+		require.Equal(t, *tx2.ID, moves[0].TransactionID)
+		require.True(t, moves[0].IsSource)
+		require.Equal(t, big.NewInt(30), (*big.Int)(moves[0].Amount))
+		require.Equal(t, big.NewInt(70), moves[0].PostCommitEffectiveVolumes.Balance())
+		require.Equal(t, *tx3.ID, moves[1].TransactionID)
+		require.False(t, moves[1].IsSource)
+		require.Equal(t, big.NewInt(80), moves[1].PostCommitEffectiveVolumes.Balance())
+	})
+
+	t.Run("effective date with offset", func(t *testing.T) {
+		t.Parallel()
+
+		moves, err := store.ListAccountMoves(ctx, ledgerstore.AccountMovesQuery{
+			Account: "account:1",
+			OOT:     now.Add(-3 * time.Minute),
+			PIT:     now,
+			Offset:  1,
+			Limit:   1,
+		})
+		require.NoError(t, err)
+		require.Len(t, moves, 1)
+		require.Equal(t, *tx2.ID, moves[0].TransactionID)
+		require.Equal(t, big.NewInt(70), moves[0].PostCommitEffectiveVolumes.Balance())
+	})
+
+	t.Run("insertion date with limit", func(t *testing.T) {
+		t.Parallel()
+
+		moves, err := store.ListAccountMoves(ctx, ledgerstore.AccountMovesQuery{
+			Account:          "account:1",
+			OOT:              now,
+			PIT:              now.Add(3 * time.Minute),
+			UseInsertionDate: true,
+			Limit:            2,
+		})
+		require.NoError(t, err)
+		require.Len(t, moves, 2)
+		require.Equal(t, *tx3.ID, moves[0].TransactionID)
+		require.Equal(t, *tx2.ID, moves[1].TransactionID)
+	})
+}
@@ -0,0 +1,95 @@
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+)
+
+type closedPeriod struct {
+	bun.BaseModel `bun:"table:closed_periods,alias:closed_periods"`
+
+	Ledger      string    `bun:"ledger,type:varchar"`
+	ClosedUntil time.Time `bun:"closed_until,type:timestamp without time zone"`
+}
+
+// FindClosedPeriod returns the closed period of the ledger, or nil if no period is closed.
+// The lock of the closed period is taken in share mode, so a concurrent close waits for the writes checked against it.
+func (s *Store) FindClosedPeriod(ctx context.Context) (*ledger.ClosedPeriod, error) {
+	if err := s.lockClosedPeriod(ctx, true); err != nil {
+		return nil, err
+	}
+	return s.findClosedPeriod(ctx)
+}
+
+// LockClosedPeriod returns the closed period of the ledger, or nil if no period is closed,
+// and takes the lock of the closed period in exclusive mode until the end of the TX, to update it.
+func (s *Store) LockClosedPeriod(ctx context.Context) (*ledger.ClosedPeriod, error) {
+	if err := s.lockClosedPeriod(ctx, false); err != nil {
+		return nil, err
+	}
+	return s.findClosedPeriod(ctx)
+}
+
+// lockClosedPeriod takes an advisory lock rather than a lock on the row of the closed period,
+// as the row does not exist until a first period is closed
+func (s *Store) lockClosedPeriod(ctx context.Context, shared bool) error {
+	lockFunction := "pg_advisory_xact_lock"
+	if shared {
+		lockFunction = "pg_advisory_xact_lock_shared"
+	}
+	_, err := s.db.NewRaw(
+		fmt.Sprintf(`select %s(hashtext(?))`, lockFunction),
+		fmt.Sprintf("closed_periods:%d", s.ledger.ID),
+	).Exec(ctx)
+	return postgres.ResolveError(err)
+}
+
+func (s *Store) findClosedPeriod(ctx context.Context) (*ledger.ClosedPeriod, error) {
+	ret := &closedPeriod{}
+	err := s.db.NewSelect().
+		Model(ret).
+		ModelTableExpr(s.GetPrefixedRelationName("closed_periods")).
+		Where("ledger = ?", s.ledger.Name).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, postgres.ResolveError(err)
+	}
+
+	return &ledger.ClosedPeriod{
+		ClosedUntil: ret.ClosedUntil,
+	}, nil
+}
+
+func (s *Store) SaveClosedPeriod(ctx context.Context, period ledger.ClosedPeriod) error {
+	_, err := s.db.NewInsert().
+		Model(&closedPeriod{
+			Ledger:      s.ledger.Name,
+			ClosedUntil: period.ClosedUntil,
+		}).
+		ModelTableExpr(s.GetPrefixedRelationName("closed_periods")).
+		On("conflict (ledger) do update").
+		Set("closed_until = excluded.closed_until").
+		Exec(ctx)
+	return postgres.ResolveError(err)
+}
+
+func (s *Store) DeleteClosedPeriod(ctx context.Context) error {
+	_, err := s.db.NewDelete().
+		Model(&closedPeriod{}).
+		ModelTableExpr(s.GetPrefixedRelationName("closed_periods")).
+		Where("ledger = ?", s.ledger.Name).
+		Exec(ctx)
+	return postgres.ResolveError(err)
+}
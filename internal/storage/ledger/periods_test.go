@@ -0,0 +1,77 @@
+//go:build it
+
+package ledger_test
+
+import (
+	"context"
+	"testing"
+	libtime "time"
+
+	"github.com/stretchr/testify/require"
+
+	logging "github.com/formancehq/go-libs/v5/pkg/observe/log"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+)
+
+func TestClosedPeriod(t *testing.T) {
+	t.Parallel()
+
+	ctx := logging.TestingContext()
+
+	store := newLedgerStore(t)
+
+	period, err := store.FindClosedPeriod(ctx)
+	require.NoError(t, err)
+	require.Nil(t, period)
+
+	now := time.Now()
+	require.NoError(t, store.SaveClosedPeriod(ctx, ledger.ClosedPeriod{ClosedUntil: now.Add(-time.Hour)}))
+	require.NoError(t, store.SaveClosedPeriod(ctx, ledger.ClosedPeriod{ClosedUntil: now}))
+
+	period, err = store.FindClosedPeriod(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, period)
+	require.Equal(t, now, period.ClosedUntil)
+
+	require.NoError(t, store.DeleteClosedPeriod(ctx))
+
+	period, err = store.FindClosedPeriod(ctx)
+	require.NoError(t, err)
+	require.Nil(t, period)
+}
+
+func TestClosedPeriodLock(t *testing.T) {
+	t.Parallel()
+
+	ctx := logging.TestingContext()
+
+	store := newLedgerStore(t)
+
+	// no period is closed, the lock must be taken anyway
+	writer, _, err := store.BeginTX(ctx, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = writer.Rollback(ctx)
+	})
+	period, err := writer.FindClosedPeriod(ctx)
+	require.NoError(t, err)
+	require.Nil(t, period)
+
+	closer, _, err := store.BeginTX(ctx, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = closer.Rollback(ctx)
+	})
+	lockCtx, cancel := context.WithTimeout(ctx, 500*libtime.Millisecond)
+	defer cancel()
+	_, err = closer.LockClosedPeriod(lockCtx)
+	require.Error(t, err)
+
+	require.NoError(t, writer.Rollback(ctx))
+
+	period, err = store.LockClosedPeriod(ctx)
+	require.NoError(t, err)
+	require.Nil(t, period)
+}
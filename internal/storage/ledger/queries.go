@@ -1,3 +1,19 @@
 package ledger
 
+import (
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+)
+
 type BalanceQuery = map[string][]string
+
+// AccountMovesQuery selects the moves of an account between two dates, both included
+type AccountMovesQuery struct {
+	Account          string
+	OOT              time.Time
+	PIT              time.Time
+	UseInsertionDate bool
+	// Offset is the number of moves to skip
+	Offset int
+	// Limit is the maximum number of moves to return, zero means no limit
+	Limit int
+}
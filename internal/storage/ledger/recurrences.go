@@ -0,0 +1,111 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/common"
+)
+
+func (store *Store) InsertRecurrence(ctx context.Context, recurrence *ledger.Recurrence) error {
+	_, err := store.db.NewInsert().
+		Model(recurrence).
+		Value("ledger", "?", store.ledger.Name).
+		ModelTableExpr(store.GetPrefixedRelationName("recurrences")).
+		Exec(ctx)
+	return postgres.ResolveError(err)
+}
+
+func (store *Store) FindRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	ret := &ledger.Recurrence{}
+	err := store.db.NewSelect().
+		Model(ret).
+		ModelTableExpr(store.GetPrefixedRelationName("recurrences")).
+		Where("id = ?", id).
+		Where("ledger = ?", store.ledger.Name).
+		Scan(ctx)
+	if err != nil {
+		return nil, postgres.ResolveError(err)
+	}
+
+	return ret, nil
+}
+
+// LockRecurrence returns the recurrence, locked until the end of the sql transaction
+func (store *Store) LockRecurrence(ctx context.Context, id string) (*ledger.Recurrence, error) {
+	ret := &ledger.Recurrence{}
+	err := store.db.NewSelect().
+		Model(ret).
+		ModelTableExpr(store.GetPrefixedRelationName("recurrences")).
+		Where("id = ?", id).
+		Where("ledger = ?", store.ledger.Name).
+		For("update").
+		Scan(ctx)
+	if err != nil {
+		return nil, postgres.ResolveError(err)
+	}
+
+	return ret, nil
+}
+
+// UpdateRecurrence saves the status and the progress of a recurrence
+func (store *Store) UpdateRecurrence(ctx context.Context, recurrence *ledger.Recurrence) error {
+	_, err := store.db.NewUpdate().
+		Model(recurrence).
+		ModelTableExpr(store.GetPrefixedRelationName("recurrences")).
+		Column("status", "next_occurrence", "last_occurrence", "accounts_offset", "failed", "last_error", "updated_at").
+		Where("id = ?", recurrence.ID).
+		Where("ledger = ?", store.ledger.Name).
+		Exec(ctx)
+	return postgres.ResolveError(err)
+}
+
+func (store *Store) DeleteRecurrence(ctx context.Context, id string) error {
+	ret, err := store.db.NewDelete().
+		Model(&ledger.Recurrence{}).
+		ModelTableExpr(store.GetPrefixedRelationName("recurrences")).
+		Where("id = ?", id).
+		Where("ledger = ?", store.ledger.Name).
+		Exec(ctx)
+	if err != nil {
+		return postgres.ResolveError(err)
+	}
+
+	rowsAffected, err := ret.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return postgres.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListRecurrences returns a page of the recurrences of the ledger, the oldest first by default
+func (store *Store) ListRecurrences(ctx context.Context, query common.PaginatedQuery[any]) (*paginate.Cursor[ledger.Recurrence], error) {
+	return store.Recurrences().Paginate(ctx, query)
+}
+
+// ListDueRecurrences returns the active recurrences having an occurrence before the given date, the most overdue first
+func (store *Store) ListDueRecurrences(ctx context.Context, at time.Time, limit int) ([]ledger.Recurrence, error) {
+	ret := make([]ledger.Recurrence, 0)
+	err := store.db.NewSelect().
+		Model(&ret).
+		ModelTableExpr(store.GetPrefixedRelationName("recurrences")).
+		Where("ledger = ?", store.ledger.Name).
+		Where("status = ?", ledger.RecurrenceStatusActive).
+		Where("next_occurrence <= ?", at).
+		Order("next_occurrence", "id").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, postgres.ResolveError(err)
+	}
+
+	return ret, nil
+}
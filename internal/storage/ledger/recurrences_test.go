@@ -0,0 +1,124 @@
+//go:build it
+
+package ledger_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	logging "github.com/formancehq/go-libs/v5/pkg/observe/log"
+	"github.com/formancehq/go-libs/v5/pkg/query"
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/common"
+)
+
+func TestRecurrences(t *testing.T) {
+	t.Parallel()
+
+	ctx := logging.TestingContext()
+
+	store := newLedgerStore(t)
+	now := time.Now()
+
+	_, err := store.FindRecurrence(ctx, "unknown")
+	require.ErrorIs(t, err, postgres.ErrNotFound)
+
+	due := ledger.Recurrence{
+		ID:             "due",
+		Schedule:       "@monthly",
+		StartAt:        now.Add(-time.Hour),
+		Template:       "FEES",
+		Vars:           map[string]string{"amount": "USD 100"},
+		Metadata:       metadata.Metadata{"foo": "bar"},
+		SchemaVersion:  "v1",
+		Accounts:       "users:$userID",
+		AccountVar:     "user",
+		Status:         ledger.RecurrenceStatusActive,
+		NextOccurrence: pointer.For(now.Add(-time.Minute)),
+		InsertedAt:     now,
+		UpdatedAt:      now,
+	}
+	require.NoError(t, store.InsertRecurrence(ctx, &due))
+
+	later := ledger.Recurrence{
+		ID:             "later",
+		Schedule:       "FREQ=DAILY",
+		StartAt:        now.Add(time.Hour),
+		Template:       "FEES",
+		Vars:           map[string]string{},
+		Metadata:       metadata.Metadata{},
+		SchemaVersion:  "v1",
+		Status:         ledger.RecurrenceStatusActive,
+		NextOccurrence: pointer.For(now.Add(time.Hour)),
+		InsertedAt:     now.Add(time.Second),
+		UpdatedAt:      now,
+	}
+	require.NoError(t, store.InsertRecurrence(ctx, &later))
+
+	found, err := store.FindRecurrence(ctx, due.ID)
+	require.NoError(t, err)
+	require.Equal(t, due.Vars, found.Vars)
+	require.Equal(t, due.Accounts, found.Accounts)
+
+	recurrences, err := store.ListRecurrences(ctx, common.InitialPaginatedQuery[any]{
+		PageSize: 1,
+	})
+	require.NoError(t, err)
+	require.Len(t, recurrences.Data, 1)
+	require.True(t, recurrences.HasMore)
+	require.Equal(t, due.ID, recurrences.Data[0].ID)
+
+	recurrences, err = store.ListRecurrences(ctx, common.InitialPaginatedQuery[any]{
+		Options: common.ResourceQuery[any]{
+			Builder: query.And(
+				query.Match("status", string(ledger.RecurrenceStatusActive)),
+				query.Lt("next_occurrence", now),
+			),
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, recurrences.Data, 1)
+	require.Equal(t, due.ID, recurrences.Data[0].ID)
+
+	dueRecurrences, err := store.ListDueRecurrences(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, dueRecurrences, 1)
+	require.Equal(t, due.ID, dueRecurrences[0].ID)
+
+	tx, _, err := store.BeginTX(ctx, nil)
+	require.NoError(t, err)
+	locked, err := tx.LockRecurrence(ctx, due.ID)
+	require.NoError(t, err)
+
+	locked.LastOccurrence = locked.NextOccurrence
+	locked.NextOccurrence = pointer.For(now.Add(24 * time.Hour))
+	locked.Failed = 1
+	locked.LastError = "insufficient funds"
+	locked.UpdatedAt = now
+	require.NoError(t, tx.UpdateRecurrence(ctx, locked))
+	require.NoError(t, tx.Commit(ctx))
+
+	found, err = store.FindRecurrence(ctx, due.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), found.Failed)
+	require.Equal(t, "insufficient funds", found.LastError)
+	require.NotNil(t, found.LastOccurrence)
+
+	dueRecurrences, err = store.ListDueRecurrences(ctx, now.Add(2*time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, dueRecurrences, 1)
+	require.Equal(t, later.ID, dueRecurrences[0].ID)
+
+	require.NoError(t, store.DeleteRecurrence(ctx, later.ID))
+	require.ErrorIs(t, store.DeleteRecurrence(ctx, later.ID), postgres.ErrNotFound)
+
+	recurrences, err = store.ListRecurrences(ctx, common.InitialPaginatedQuery[any]{})
+	require.NoError(t, err)
+	require.Len(t, recurrences.Data, 1)
+}
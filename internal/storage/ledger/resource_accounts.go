@@ -2,15 +2,29 @@ package ledger
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/stoewer/go-strcase"
 	"github.com/uptrace/bun"
 
+	ledger "github.com/formancehq/ledger/internal"
 	"github.com/formancehq/ledger/internal/queries"
 	"github.com/formancehq/ledger/internal/storage/common"
 	"github.com/formancehq/ledger/pkg/features"
 )
 
+// ChartAccountAggregationKey groups the accounts by path of account in the chart,
+// it is only available when the accounts are restricted to accounts of the chart
+const ChartAccountAggregationKey = "chartAccount"
+
+// AccountsOptions are the options of the accounts resource
+type AccountsOptions struct {
+	// ChartAccounts restricts the accounts to the ones whose address belongs to one of the accounts of the chart
+	ChartAccounts []ledger.ChartAccountAddresses
+	// IncompatibleWith restricts the accounts to the ones whose address is not valid for the chart of accounts
+	IncompatibleWith ledger.ChartOfAccounts
+}
+
 type accountsResourceHandler struct {
 	store *Store
 }
@@ -46,14 +60,86 @@ func (h accountsResourceHandler) BuildDataset(opts common.RepositoryHandlerBuild
 		ret = ret.ColumnExpr("accounts.metadata")
 	}
 
+	if options, ok := opts.Opts.(AccountsOptions); ok {
+		if options.ChartAccounts != nil {
+			where, args := filterChartAddresses("address_array", options.ChartAccounts)
+			ret = ret.Where(where, args...)
+		}
+		if options.IncompatibleWith != nil {
+			where, args := filterChartAddresses("address_array", options.IncompatibleWith.AccountAddresses(""))
+			ret = ret.Where("not ("+where+")", args...)
+		}
+	}
+
 	return ret, nil
 }
 
+// filterChartAddresses builds a filter matching the addresses which belong to one of the accounts of the chart,
+// the column is the array of the segments of the addresses
+func filterChartAddresses(column string, accounts []ledger.ChartAccountAddresses) (string, []any) {
+	clauses := make([]string, 0, len(accounts))
+	args := make([]any, 0)
+	for _, account := range accounts {
+		where, accountArgs := filterChartAccountAddresses(column, account)
+		clauses = append(clauses, "("+where+")")
+		args = append(args, accountArgs...)
+	}
+	if len(clauses) == 0 {
+		return "false", nil
+	}
+
+	return strings.Join(clauses, " or "), args
+}
+
+// classifyChartAddresses builds an expression returning the path in the chart of the account to which the addresses belong,
+// or null if they belong to none of the accounts
+func classifyChartAddresses(column string, accounts []ledger.ChartAccountAddresses) (string, []any) {
+	if len(accounts) == 0 {
+		return "null", nil
+	}
+	clauses := make([]string, 0, len(accounts))
+	args := make([]any, 0)
+	for _, account := range accounts {
+		where, accountArgs := filterChartAccountAddresses(column, account)
+		clauses = append(clauses, "when "+where+" then ?")
+		args = append(append(args, accountArgs...), account.Path)
+	}
+
+	return "case " + strings.Join(clauses, " ") + " end", args
+}
+
+// filterChartAccountAddresses builds a filter matching the addresses of an account of the chart,
+// the patterns of the variable segments are matched like the $regex operator, and like FindAccountSchema does
+func filterChartAccountAddresses(column string, account ledger.ChartAccountAddresses) (string, []any) {
+	parts := []string{fmt.Sprintf("jsonb_array_length(%s) = %d", column, len(account.Segments))}
+	args := make([]any, 0)
+	for i, segment := range account.Segments {
+		if segment.Name != "" {
+			parts = append(parts, fmt.Sprintf("(%s ->> %d) = ?", column, i))
+			args = append(args, segment.Name)
+			continue
+		}
+		if len(segment.Excluded) > 0 {
+			parts = append(parts, fmt.Sprintf("(%s ->> %d) not in (?)", column, i))
+			args = append(args, bun.In(segment.Excluded))
+		}
+		if segment.Pattern != nil {
+			parts = append(parts, fmt.Sprintf("(%s ->> %d) ~ ?", column, i))
+			args = append(args, *segment.Pattern)
+		}
+	}
+
+	return strings.Join(parts, " and "), args
+}
+
 func (h accountsResourceHandler) ResolveFilter(opts common.ResourceQuery[any], operator, property string, value any) (string, []any, error) {
 	switch {
 	case property == "address":
 		fallthrough
 	case property == "account":
+		if err := checkAddressOperator(property, operator); err != nil {
+			return "", nil, err
+		}
 		switch operator {
 		case queries.OperatorIn:
 			addresses, err := assetAddressArray(value)
@@ -106,20 +192,27 @@ func (h accountsResourceHandler) ResolveFilter(opts common.ResourceQuery[any], o
 	case common.MetadataRegex.Match([]byte(property)):
 		match := common.MetadataRegex.FindAllStringSubmatch(property, 3)
 
-		return "metadata @> ?", []any{map[string]any{
-			match[0][1]: value,
-		}}, nil
+		return common.ResolveMetadataFilter("metadata", match[0][1], operator, value)
 	default:
 		return "", nil, common.NewErrInvalidQuery("invalid filter property %s", property)
 	}
 }
 
-func (h accountsResourceHandler) Project(_ common.ResourceQuery[any], selectQuery *bun.SelectQuery) (*bun.SelectQuery, error) {
-	return selectQuery.ColumnExpr("*"), nil
+func (h accountsResourceHandler) Project(query common.ResourceQuery[any], selectQuery *bun.SelectQuery) (*bun.SelectQuery, error) {
+	// the volumes and the chart labels are fetched by the expansions
+	return projectColumns(queries.ResourceKindAccount, query.Fields, selectQuery, []string{"address"},
+		projectedColumn{field: "metadata", columns: []string{"metadata"}},
+		projectedColumn{field: "firstUsage", columns: []string{"first_usage"}},
+		projectedColumn{field: "insertionDate", columns: []string{"insertion_date"}},
+		projectedColumn{field: "updatedAt", columns: []string{"updated_at"}},
+	)
 }
 
 func (h accountsResourceHandler) Expand(opts common.ResourceQuery[any], property string) (*bun.SelectQuery, *common.JoinCondition, error) {
 	switch property {
+	case "chart":
+		// labels are extracted from the chart of accounts by the controller
+		return nil, nil, nil
 	case "volumes":
 		if !h.store.ledger.HasFeature(features.FeatureMovesHistory, "ON") {
 			return nil, nil, common.NewErrInvalidQuery("feature %s must be 'ON' to use volumes", features.FeatureMovesHistory)
@@ -164,4 +257,19 @@ func (h accountsResourceHandler) Expand(opts common.ResourceQuery[any], property
 		}, nil
 }
 
+func (h accountsResourceHandler) ResolveAggregationProperty(query common.ResourceQuery[any], property string) (*common.AggregationProperty, error) {
+	if property == "address" {
+		return &common.AggregationProperty{Expression: "dataset.address"}, nil
+	}
+	if options, ok := query.Opts.(AccountsOptions); ok && options.ChartAccounts != nil && property == ChartAccountAggregationKey {
+		expression, args := classifyChartAddresses("dataset.address_array", options.ChartAccounts)
+		return &common.AggregationProperty{Expression: expression, Args: args}, nil
+	}
+	if ret, ok, err := resolveMetadataAggregationKey(property); err != nil || ok {
+		return ret, err
+	}
+	return nil, common.NewErrInvalidQuery("cannot aggregate accounts on property %s", property)
+}
+
 var _ common.RepositoryHandler[any] = accountsResourceHandler{}
+var _ common.AggregationHandler[any] = accountsResourceHandler{}
@@ -25,66 +25,7 @@ func (h aggregatedBalancesResourceRepositoryHandler) BuildDataset(query common.R
 	canPushLateral := canPushAddressFilterToLateral(query.Builder)
 
 	if query.UsePIT() {
-		ret := h.store.newScopedSelect().
-			ModelTableExpr(h.store.GetPrefixedRelationName("moves")).
-			DistinctOn("accounts_address, asset").
-			Column("accounts_address", "asset")
-		if query.Opts.UseInsertionDate {
-			if !h.store.ledger.HasFeature(features.FeatureMovesHistory, "ON") {
-				return nil, NewErrMissingFeature(features.FeatureMovesHistory)
-			}
-
-			ret = ret.
-				ColumnExpr("first_value(post_commit_volumes) over (partition by (accounts_address, asset) order by seq desc) as volumes").
-				Where("insertion_date <= ?", query.PIT)
-		} else {
-			if !h.store.ledger.HasFeature(features.FeatureMovesHistoryPostCommitEffectiveVolumes, "SYNC") {
-				return nil, NewErrMissingFeature(features.FeatureMovesHistoryPostCommitEffectiveVolumes)
-			}
-
-			ret = ret.
-				ColumnExpr("first_value(post_commit_effective_volumes) over (partition by (accounts_address, asset) order by effective_date desc, seq desc) as volumes").
-				Where("effective_date <= ?", query.PIT)
-		}
-
-		if needAddressSegments {
-			subQuery := h.store.newScopedSelect().
-				TableExpr(h.store.GetPrefixedRelationName("accounts")).
-				Column("address_array").
-				Where("accounts.address = accounts_address")
-
-			subQuery = applyLateralAddressFilter(subQuery, allAddresses, canPushLateral)
-
-			ret = ret.
-				ColumnExpr("accounts.address_array as accounts_address_array").
-				Join(`join lateral (?) accounts on true`, subQuery)
-		}
-
-		if query.UseFilter("metadata") {
-			if h.store.ledger.HasFeature(features.FeatureAccountMetadataHistory, "SYNC") {
-				subQuery := h.store.newScopedSelect().
-					DistinctOn("accounts_address").
-					ModelTableExpr(h.store.GetPrefixedRelationName("accounts_metadata")).
-					ColumnExpr("first_value(metadata) over (partition by accounts_address order by revision desc) as metadata").
-					Where("accounts_metadata.accounts_address = moves.accounts_address").
-					Where("date <= ?", query.PIT)
-
-				ret = ret.
-					Join(`left join lateral (?) accounts_metadata on true`, subQuery).
-					ColumnExpr(metadataOrEmpty)
-			} else {
-				subQuery := h.store.newScopedSelect().
-					TableExpr(h.store.GetPrefixedRelationName("accounts")).
-					ColumnExpr("metadata").
-					Where("accounts.address = moves.accounts_address")
-
-				ret = ret.
-					Join(`left join lateral (?) accounts_metadata on true`, subQuery).
-					ColumnExpr(metadataOrEmpty)
-			}
-		}
-
-		return ret, nil
+		return h.buildDatasetAt(query, query.PIT)
 	} else {
 		ret := h.store.newScopedSelect().
 			ModelTableExpr(h.store.GetPrefixedRelationName("accounts_volumes")).
@@ -115,9 +56,80 @@ func (h aggregatedBalancesResourceRepositoryHandler) BuildDataset(query common.R
 	}
 }
 
+// buildDatasetAt builds the volumes of the accounts at a point in time from the moves,
+// pit can be a date or an expression referencing a date of an outer query
+func (h aggregatedBalancesResourceRepositoryHandler) buildDatasetAt(query common.RepositoryHandlerBuildContext[ledger.GetAggregatedVolumesOptions], pit any) (*bun.SelectQuery, error) {
+	allAddresses, needAddressSegments := collectAddressFilters(query)
+	canPushLateral := canPushAddressFilterToLateral(query.Builder)
+
+	ret := h.store.newScopedSelect().
+		ModelTableExpr(h.store.GetPrefixedRelationName("moves")).
+		DistinctOn("accounts_address, asset").
+		Column("accounts_address", "asset")
+	if query.Opts.UseInsertionDate {
+		if !h.store.ledger.HasFeature(features.FeatureMovesHistory, "ON") {
+			return nil, NewErrMissingFeature(features.FeatureMovesHistory)
+		}
+
+		ret = ret.
+			ColumnExpr("first_value(post_commit_volumes) over (partition by (accounts_address, asset) order by seq desc) as volumes").
+			Where("insertion_date <= ?", pit)
+	} else {
+		if !h.store.ledger.HasFeature(features.FeatureMovesHistoryPostCommitEffectiveVolumes, "SYNC") {
+			return nil, NewErrMissingFeature(features.FeatureMovesHistoryPostCommitEffectiveVolumes)
+		}
+
+		ret = ret.
+			ColumnExpr("first_value(post_commit_effective_volumes) over (partition by (accounts_address, asset) order by effective_date desc, seq desc) as volumes").
+			Where("effective_date <= ?", pit)
+	}
+
+	if needAddressSegments {
+		subQuery := h.store.newScopedSelect().
+			TableExpr(h.store.GetPrefixedRelationName("accounts")).
+			Column("address_array").
+			Where("accounts.address = accounts_address")
+
+		subQuery = applyLateralAddressFilter(subQuery, allAddresses, canPushLateral)
+
+		ret = ret.
+			ColumnExpr("accounts.address_array as accounts_address_array").
+			Join(`join lateral (?) accounts on true`, subQuery)
+	}
+
+	if query.UseFilter("metadata") {
+		if h.store.ledger.HasFeature(features.FeatureAccountMetadataHistory, "SYNC") {
+			subQuery := h.store.newScopedSelect().
+				DistinctOn("accounts_address").
+				ModelTableExpr(h.store.GetPrefixedRelationName("accounts_metadata")).
+				ColumnExpr("first_value(metadata) over (partition by accounts_address order by revision desc) as metadata").
+				Where("accounts_metadata.accounts_address = moves.accounts_address").
+				Where("date <= ?", pit)
+
+			ret = ret.
+				Join(`left join lateral (?) accounts_metadata on true`, subQuery).
+				ColumnExpr(metadataOrEmpty)
+		} else {
+			subQuery := h.store.newScopedSelect().
+				TableExpr(h.store.GetPrefixedRelationName("accounts")).
+				ColumnExpr("metadata").
+				Where("accounts.address = moves.accounts_address")
+
+			ret = ret.
+				Join(`left join lateral (?) accounts_metadata on true`, subQuery).
+				ColumnExpr(metadataOrEmpty)
+		}
+	}
+
+	return ret, nil
+}
+
 func (h aggregatedBalancesResourceRepositoryHandler) ResolveFilter(_ common.ResourceQuery[ledger.GetAggregatedVolumesOptions], operator, property string, value any) (string, []any, error) {
 	switch {
 	case property == "address":
+		if err := checkAddressOperator(property, operator); err != nil {
+			return "", nil, err
+		}
 		switch operator {
 		case queries.OperatorIn:
 			addresses, err := assetAddressArray(value)
@@ -135,9 +147,7 @@ func (h aggregatedBalancesResourceRepositoryHandler) ResolveFilter(_ common.Reso
 		} else {
 			match := common.MetadataRegex.FindAllStringSubmatch(property, 3)
 
-			return "metadata @> ?", []any{map[string]any{
-				match[0][1]: value,
-			}}, nil
+			return common.ResolveMetadataFilter("metadata", match[0][1], operator, value)
 		}
 	default:
 		return "", nil, common.NewErrInvalidQuery("unknown key '%s' when building query", property)
@@ -0,0 +1,56 @@
+package ledger
+
+import (
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/common"
+)
+
+// aggregatedBalancesHistoryResourceRepositoryHandler aggregates the volumes of the accounts at several dates in a single query,
+// the volumes at each date are read from the moves using a lateral join on the dates
+type aggregatedBalancesHistoryResourceRepositoryHandler struct {
+	aggregatedBalancesResourceRepositoryHandler
+	dates []time.Time
+}
+
+func (h aggregatedBalancesHistoryResourceRepositoryHandler) BuildDataset(query common.RepositoryHandlerBuildContext[ledger.GetAggregatedVolumesOptions]) (*bun.SelectQuery, error) {
+	volumes, err := h.buildDatasetAt(query, bun.Ident("dates.date"))
+	if err != nil {
+		return nil, err
+	}
+
+	return h.store.db.NewSelect().
+		TableExpr("unnest(?::timestamp without time zone[]) as dates(date)", pgdialect.Array(h.dates)).
+		ColumnExpr("dates.date").
+		ColumnExpr("volumes.*").
+		Join("join lateral (?) volumes on true", volumes), nil
+}
+
+func (h aggregatedBalancesHistoryResourceRepositoryHandler) Project(
+	_ common.ResourceQuery[ledger.GetAggregatedVolumesOptions],
+	selectQuery *bun.SelectQuery,
+) (*bun.SelectQuery, error) {
+	sumVolumesForAsset := h.store.db.NewSelect().
+		TableExpr("(?) values", selectQuery).
+		Group("date", "asset").
+		Column("date", "asset").
+		ColumnExpr("json_build_object('input', sum(((volumes).inputs)::numeric), 'output', sum(((volumes).outputs)::numeric)) as volumes")
+
+	aggregatedByDate := h.store.db.NewSelect().
+		TableExpr("(?) values", sumVolumesForAsset).
+		Group("date").
+		Column("date").
+		ColumnExpr("public.aggregate_objects(json_build_object(asset, volumes)::jsonb) as aggregated")
+
+	// dates without any move still have a point, with empty volumes
+	return h.store.db.NewSelect().
+		TableExpr("unnest(?::timestamp without time zone[]) as dates(date)", pgdialect.Array(h.dates)).
+		Join("left join (?) aggregated on aggregated.date = dates.date", aggregatedByDate).
+		ColumnExpr("json_agg(coalesce(aggregated.aggregated, '{}'::jsonb) order by dates.date) as aggregated"), nil
+}
+
+var _ common.RepositoryHandler[ledger.GetAggregatedVolumesOptions] = aggregatedBalancesHistoryResourceRepositoryHandler{}
@@ -0,0 +1,58 @@
+package ledger
+
+import (
+	"github.com/uptrace/bun"
+
+	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/storage/common"
+)
+
+// chartAccountsVolumesResourceRepositoryHandler aggregates the volumes of the accounts by account of the chart in a single query,
+// each address being classified with the same predicate as the filters on the accounts of the chart
+type chartAccountsVolumesResourceRepositoryHandler struct {
+	aggregatedBalancesResourceRepositoryHandler
+	accounts []ledger.ChartAccountAddresses
+}
+
+func (h chartAccountsVolumesResourceRepositoryHandler) BuildDataset(query common.RepositoryHandlerBuildContext[ledger.GetAggregatedVolumesOptions]) (*bun.SelectQuery, error) {
+	volumes, err := h.aggregatedBalancesResourceRepositoryHandler.BuildDataset(query)
+	if err != nil {
+		return nil, err
+	}
+
+	chartAccount, args := classifyChartAddresses("address_array", h.accounts)
+	classification := h.store.newScopedSelect().
+		TableExpr(h.store.GetPrefixedRelationName("accounts")).
+		ColumnExpr(chartAccount+" as chart_account", args...).
+		Where("accounts.address = balances.accounts_address")
+
+	return h.store.db.NewSelect().
+		TableExpr("(?) balances", volumes).
+		ColumnExpr("balances.*").
+		ColumnExpr("chart_accounts.chart_account").
+		Join("join lateral (?) chart_accounts on true", classification).
+		Where("chart_accounts.chart_account is not null"), nil
+}
+
+func (h chartAccountsVolumesResourceRepositoryHandler) Project(
+	_ common.ResourceQuery[ledger.GetAggregatedVolumesOptions],
+	selectQuery *bun.SelectQuery,
+) (*bun.SelectQuery, error) {
+	sumVolumesForAsset := h.store.db.NewSelect().
+		TableExpr("(?) values", selectQuery).
+		Group("chart_account", "asset").
+		Column("chart_account", "asset").
+		ColumnExpr("json_build_object('input', sum(((volumes).inputs)::numeric), 'output', sum(((volumes).outputs)::numeric)) as volumes")
+
+	aggregatedByChartAccount := h.store.db.NewSelect().
+		TableExpr("(?) values", sumVolumesForAsset).
+		Group("chart_account").
+		Column("chart_account").
+		ColumnExpr("public.aggregate_objects(json_build_object(asset, volumes)::jsonb) as volumes")
+
+	return h.store.db.NewSelect().
+		TableExpr("(?) values", aggregatedByChartAccount).
+		ColumnExpr("coalesce(public.aggregate_objects(json_build_object(chart_account, volumes)::jsonb), '{}'::jsonb) as volumes"), nil
+}
+
+var _ common.RepositoryHandler[ledger.GetAggregatedVolumesOptions] = chartAccountsVolumesResourceRepositoryHandler{}
@@ -3,6 +3,7 @@ package ledger
 import (
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/uptrace/bun"
 
@@ -35,6 +36,14 @@ func (h logsResourceHandler) ResolveFilter(_ common.ResourceQuery[any], operator
 	case "id":
 		return fmt.Sprintf("id %s ?", common.ConvertOperatorToSQL(operator)), []any{value}, nil
 	case "type":
+		if slices.Contains(queries.PatternOperators, operator) {
+			// the pattern operators are not defined on the log_type enum
+			value, err := common.ConvertValueToSQL(operator, value)
+			if err != nil {
+				return "", nil, err
+			}
+			return fmt.Sprintf("type::text %s ?", common.ConvertOperatorToSQL(operator)), []any{value}, nil
+		}
 		return fmt.Sprintf("type %s ?", common.ConvertOperatorToSQL(operator)), []any{value}, nil
 	default:
 		return "", nil, fmt.Errorf("unknown key '%s' when building query", property)
@@ -45,8 +54,25 @@ func (h logsResourceHandler) Expand(_ common.ResourceQuery[any], _ string) (*bun
 	return nil, nil, errors.New("no expand supported")
 }
 
-func (h logsResourceHandler) Project(_ common.ResourceQuery[any], selectQuery *bun.SelectQuery) (*bun.SelectQuery, error) {
-	return selectQuery.ColumnExpr("*"), nil
+func (h logsResourceHandler) Project(query common.ResourceQuery[any], selectQuery *bun.SelectQuery) (*bun.SelectQuery, error) {
+	// the type is needed to hydrate the data
+	return projectColumns(queries.ResourceKindLog, query.Fields, selectQuery, []string{"id"},
+		projectedColumn{field: "type", columns: []string{"type"}},
+		projectedColumn{field: "data", columns: []string{"type", "data"}},
+		projectedColumn{field: "date", columns: []string{"date"}},
+		projectedColumn{field: "idempotencyKey", columns: []string{"idempotency_key"}},
+		projectedColumn{field: "idempotencyHash", columns: []string{"idempotency_hash"}},
+		projectedColumn{field: "hash", columns: []string{"hash"}},
+		projectedColumn{field: "schemaVersion", columns: []string{"schema_version"}},
+	)
+}
+
+func (h logsResourceHandler) ResolveAggregationProperty(_ common.ResourceQuery[any], property string) (*common.AggregationProperty, error) {
+	if property == "type" {
+		return &common.AggregationProperty{Expression: "dataset.type"}, nil
+	}
+	return nil, common.NewErrInvalidQuery("cannot aggregate logs on property %s", property)
 }
 
 var _ common.RepositoryHandler[any] = logsResourceHandler{}
+var _ common.AggregationHandler[any] = logsResourceHandler{}
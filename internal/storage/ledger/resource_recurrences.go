@@ -0,0 +1,53 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/formancehq/ledger/internal/queries"
+	"github.com/formancehq/ledger/internal/storage/common"
+)
+
+type recurrencesResourceHandler struct {
+	store *Store
+}
+
+func (h recurrencesResourceHandler) Schema() queries.EntitySchema {
+	return queries.RecurrenceSchema
+}
+
+func (h recurrencesResourceHandler) BuildDataset(_ common.RepositoryHandlerBuildContext[any]) (*bun.SelectQuery, error) {
+	return h.store.newScopedSelect().
+		ModelTableExpr(h.store.GetPrefixedRelationName("recurrences")), nil
+}
+
+func (h recurrencesResourceHandler) Project(_ common.ResourceQuery[any], selectQuery *bun.SelectQuery) (*bun.SelectQuery, error) {
+	return selectQuery.ColumnExpr("*"), nil
+}
+
+func (h recurrencesResourceHandler) ResolveFilter(_ common.ResourceQuery[any], operator, property string, value any) (string, []any, error) {
+	switch property {
+	case "inserted_at", "next_occurrence":
+		value, err := common.NormalizeDateFilterValue(value)
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("%s %s ?", property, common.ConvertOperatorToSQL(operator)), []any{value}, nil
+	case "status", "template":
+		value, err := common.ConvertValueToSQL(operator, value)
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("%s %s ?", property, common.ConvertOperatorToSQL(operator)), []any{value}, nil
+	default:
+		return "", nil, fmt.Errorf("unknown key '%s' when building query", property)
+	}
+}
+
+func (h recurrencesResourceHandler) Expand(_ common.ResourceQuery[any], _ string) (*bun.SelectQuery, *common.JoinCondition, error) {
+	return nil, nil, errors.New("no expand supported")
+}
+
+var _ common.RepositoryHandler[any] = recurrencesResourceHandler{}
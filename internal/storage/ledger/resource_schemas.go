@@ -42,6 +42,10 @@ func (h schemasResourceHandler) ResolveFilter(_ common.ResourceQuery[any], opera
 		}
 		return fmt.Sprintf("created_at %s ?", common.ConvertOperatorToSQL(operator)), []any{value}, nil
 	case "version":
+		value, err := common.ConvertValueToSQL(operator, value)
+		if err != nil {
+			return "", nil, err
+		}
 		return fmt.Sprintf("version %s ?", common.ConvertOperatorToSQL(operator)), []any{value}, nil
 	default:
 		return "", nil, fmt.Errorf("unknown key '%s' when building query", property)
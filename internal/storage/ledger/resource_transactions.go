@@ -35,6 +35,7 @@ func (h transactionsResourceHandler) BuildDataset(opts common.RepositoryHandlerB
 			"sources_arrays",
 			"destinations_arrays",
 			"template",
+			"reverted_amounts",
 		)
 
 	if slices.Contains(opts.Expand, "volumes") {
@@ -82,6 +83,10 @@ func (h transactionsResourceHandler) ResolveFilter(_ common.ResourceQuery[any],
 		case queries.OperatorIn:
 			return "reference IN (?)", []any{bun.In(value)}, nil
 		default:
+			value, err := common.ConvertValueToSQL(operator, value)
+			if err != nil {
+				return "", nil, err
+			}
 			return fmt.Sprintf("reference %s ?", common.ConvertOperatorToSQL(operator)), []any{value}, nil
 		}
 	case property == "timestamp" || property == "inserted_at" || property == "updated_at":
@@ -103,6 +108,9 @@ func (h transactionsResourceHandler) ResolveFilter(_ common.ResourceQuery[any],
 		}
 		return fmt.Sprintf("dataset.reverted_at %s ?", common.ConvertOperatorToSQL(operator)), []any{value}, nil
 	case property == "account":
+		if err := checkAddressOperator(property, operator); err != nil {
+			return "", nil, err
+		}
 		switch operator {
 		case queries.OperatorIn:
 			addresses, err := assetAddressArray(value)
@@ -118,6 +126,9 @@ func (h transactionsResourceHandler) ResolveFilter(_ common.ResourceQuery[any],
 			return filterAccountAddressOnTransactions(value.(string), true, true), nil, nil
 		}
 	case property == "source":
+		if err := checkAddressOperator(property, operator); err != nil {
+			return "", nil, err
+		}
 		switch operator {
 		case queries.OperatorIn:
 			addresses, err := assetAddressArray(value)
@@ -132,6 +143,9 @@ func (h transactionsResourceHandler) ResolveFilter(_ common.ResourceQuery[any],
 			return filterAccountAddressOnTransactions(value.(string), true, false), nil, nil
 		}
 	case property == "destination":
+		if err := checkAddressOperator(property, operator); err != nil {
+			return "", nil, err
+		}
 		switch operator {
 		case queries.OperatorIn:
 			addresses, err := assetAddressArray(value)
@@ -147,9 +161,7 @@ func (h transactionsResourceHandler) ResolveFilter(_ common.ResourceQuery[any],
 	case common.MetadataRegex.Match([]byte(property)):
 		match := common.MetadataRegex.FindAllStringSubmatch(property, 3)
 
-		return "metadata @> ?", []any{map[string]any{
-			match[0][1]: value,
-		}}, nil
+		return common.ResolveMetadataFilter("metadata", match[0][1], operator, value)
 
 	case property == "metadata":
 		return "metadata -> ? is not null", []any{value}, nil
@@ -158,8 +170,21 @@ func (h transactionsResourceHandler) ResolveFilter(_ common.ResourceQuery[any],
 	}
 }
 
-func (h transactionsResourceHandler) Project(_ common.ResourceQuery[any], selectQuery *bun.SelectQuery) (*bun.SelectQuery, error) {
-	return selectQuery.ColumnExpr("*"), nil
+func (h transactionsResourceHandler) Project(query common.ResourceQuery[any], selectQuery *bun.SelectQuery) (*bun.SelectQuery, error) {
+	// the postings are needed to compute the pre commit volumes and the revertible amounts
+	return projectColumns(queries.ResourceKindTransaction, query.Fields, selectQuery, []string{"id"},
+		projectedColumn{field: "timestamp", columns: []string{"timestamp"}},
+		projectedColumn{field: "reference", columns: []string{"reference"}},
+		projectedColumn{field: "postings", columns: []string{"postings"}},
+		projectedColumn{field: "metadata", columns: []string{"metadata"}},
+		projectedColumn{field: "insertedAt", columns: []string{"inserted_at"}},
+		projectedColumn{field: "updatedAt", columns: []string{"updated_at"}},
+		projectedColumn{field: "revertedAt", columns: []string{"reverted_at"}},
+		projectedColumn{field: "template", columns: []string{"template"}},
+		projectedColumn{field: "revertedAmounts", columns: []string{"reverted_amounts", "postings"}},
+		projectedColumn{field: "postCommitVolumes", columns: []string{"post_commit_volumes", "postings"}},
+		projectedColumn{field: "postCommitEffectiveVolumes", columns: []string{"postings"}},
+	)
 }
 
 func (h transactionsResourceHandler) Expand(_ common.ResourceQuery[any], property string) (*bun.SelectQuery, *common.JoinCondition, error) {
@@ -194,4 +219,29 @@ func (h transactionsResourceHandler) Expand(_ common.ResourceQuery[any], propert
 	}, nil
 }
 
+// postingsJoin unnests the postings of the transactions, for the aggregations by posting
+const postingsJoin = "cross join lateral jsonb_array_elements(dataset.postings::jsonb) as postings(posting)"
+
+func (h transactionsResourceHandler) ResolveAggregationProperty(_ common.ResourceQuery[any], property string) (*common.AggregationProperty, error) {
+	switch property {
+	case "reference":
+		return &common.AggregationProperty{Expression: "dataset.reference"}, nil
+	case "asset", "source", "destination":
+		return &common.AggregationProperty{
+			Expression: fmt.Sprintf("postings.posting ->> '%s'", property),
+			Join:       postingsJoin,
+		}, nil
+	case "amount":
+		return &common.AggregationProperty{
+			Expression: "(postings.posting ->> 'amount')::numeric",
+			Join:       postingsJoin,
+		}, nil
+	}
+	if ret, ok, err := resolveMetadataAggregationKey(property); err != nil || ok {
+		return ret, err
+	}
+	return nil, common.NewErrInvalidQuery("cannot aggregate transactions on property %s", property)
+}
+
 var _ common.RepositoryHandler[any] = transactionsResourceHandler{}
+var _ common.AggregationHandler[any] = transactionsResourceHandler{}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/uptrace/bun"
 
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
 	ledger "github.com/formancehq/ledger/internal"
 	"github.com/formancehq/ledger/internal/queries"
 	"github.com/formancehq/ledger/internal/storage/common"
@@ -32,6 +34,7 @@ func (h volumesResourceHandler) BuildDataset(query common.RepositoryHandlerBuild
 			Column("asset", "input", "output").
 			ColumnExpr("input - output as balance").
 			ColumnExpr("accounts_address as account").
+			ColumnExpr("(?) as pending", h.store.selectPendingHolds("accounts_volumes.accounts_address", "accounts_volumes.asset", nil, time.Now())).
 			ModelTableExpr(h.store.GetPrefixedRelationName("accounts_volumes")).
 			Order("accounts_address", "asset")
 
@@ -79,7 +82,12 @@ func (h volumesResourceHandler) BuildDataset(query common.RepositoryHandlerBuild
 		}
 
 		if query.UsePIT() {
-			selectVolumes = selectVolumes.Where(dateFilterColumn+" <= ?", query.PIT)
+			selectVolumes = selectVolumes.
+				Where(dateFilterColumn+" <= ?", query.PIT).
+				ColumnExpr("(?) as pending", h.store.selectPendingHolds("moves.accounts_address", "moves.asset", query.PIT, time.Time{}))
+		} else {
+			selectVolumes = selectVolumes.
+				ColumnExpr("(?) as pending", h.store.selectPendingHolds("moves.accounts_address", "moves.asset", nil, time.Now()))
 		}
 
 		if query.UseOOT() {
@@ -133,6 +141,9 @@ func (h volumesResourceHandler) ResolveFilter(
 
 	switch {
 	case property == "address" || property == "account":
+		if err := checkAddressOperator(property, operator); err != nil {
+			return "", nil, err
+		}
 		switch operator {
 		case queries.OperatorIn:
 			addresses, err := assetAddressArray(value)
@@ -169,9 +180,7 @@ func (h volumesResourceHandler) ResolveFilter(
 		} else {
 			match := common.MetadataRegex.FindAllStringSubmatch(property, 3)
 
-			return "metadata @> ?", []any{map[string]any{
-				match[0][1]: value,
-			}}, nil
+			return common.ResolveMetadataFilter("metadata", match[0][1], operator, value)
 		}
 	default:
 		return "", nil, fmt.Errorf("unsupported filter %s", property)
@@ -182,6 +191,10 @@ func (h volumesResourceHandler) Project(
 	query common.ResourceQuery[ledger.GetVolumesOptions],
 	selectQuery *bun.SelectQuery,
 ) (*bun.SelectQuery, error) {
+	if len(query.Fields) > 0 {
+		return nil, common.NewErrInvalidQuery("projections are not supported on volumes")
+	}
+
 	selectQuery = selectQuery.DistinctOn("account, asset")
 
 	if query.Opts.GroupLvl == 0 {
@@ -190,7 +203,7 @@ func (h volumesResourceHandler) Project(
 
 	intermediate := h.store.db.NewSelect().
 		ModelTableExpr("(?) data", selectQuery).
-		Column("asset", "input", "output", "balance").
+		Column("asset", "input", "output", "balance", "pending").
 		ColumnExpr(fmt.Sprintf(`(array_to_string((string_to_array(account, ':'))[1:LEAST(array_length(string_to_array(account, ':'),1),%d)],':')) as account`, query.Opts.GroupLvl))
 
 	return h.store.db.NewSelect().
@@ -199,6 +212,7 @@ func (h volumesResourceHandler) Project(
 		ColumnExpr("sum(input) as input").
 		ColumnExpr("sum(output) as output").
 		ColumnExpr("sum(balance) as balance").
+		ColumnExpr("sum(pending) as pending").
 		GroupExpr("account, asset"), nil
 }
 
@@ -206,4 +220,14 @@ func (h volumesResourceHandler) Expand(_ common.ResourceQuery[ledger.GetVolumesO
 	return nil, nil, errors.New("no expansion available")
 }
 
+func (h volumesResourceHandler) ResolveAggregationProperty(_ common.ResourceQuery[ledger.GetVolumesOptions], property string) (*common.AggregationProperty, error) {
+	switch property {
+	case "account", "asset", "input", "output", "balance", "pending":
+		return &common.AggregationProperty{Expression: "dataset." + property}, nil
+	default:
+		return nil, common.NewErrInvalidQuery("cannot aggregate volumes on property %s", property)
+	}
+}
+
 var _ common.RepositoryHandler[ledger.GetVolumesOptions] = volumesResourceHandler{}
+var _ common.AggregationHandler[ledger.GetVolumesOptions] = volumesResourceHandler{}
@@ -0,0 +1,80 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+
+	ledger "github.com/formancehq/ledger/internal"
+)
+
+func (store *Store) InsertRevertJob(ctx context.Context, job *ledger.RevertJob) error {
+	_, err := store.db.NewInsert().
+		Model(job).
+		Value("ledger", "?", store.ledger.Name).
+		ModelTableExpr(store.GetPrefixedRelationName("revert_jobs")).
+		Exec(ctx)
+	return postgres.ResolveError(err)
+}
+
+func (store *Store) FindRevertJob(ctx context.Context, id string) (*ledger.RevertJob, error) {
+	job := &ledger.RevertJob{}
+	err := store.db.NewSelect().
+		Model(job).
+		ModelTableExpr(store.GetPrefixedRelationName("revert_jobs")).
+		Where("id = ?", id).
+		Where("ledger = ?", store.ledger.Name).
+		Scan(ctx)
+	if err != nil {
+		return nil, postgres.ResolveError(err)
+	}
+
+	return job, nil
+}
+
+// LockRevertJob returns the job, locked until the end of the sql transaction.
+// A job already locked by another sql transaction is skipped, and reported as not found.
+func (store *Store) LockRevertJob(ctx context.Context, id string) (*ledger.RevertJob, error) {
+	job := &ledger.RevertJob{}
+	err := store.db.NewSelect().
+		Model(job).
+		ModelTableExpr(store.GetPrefixedRelationName("revert_jobs")).
+		Where("id = ?", id).
+		Where("ledger = ?", store.ledger.Name).
+		For("update skip locked").
+		Scan(ctx)
+	if err != nil {
+		return nil, postgres.ResolveError(err)
+	}
+
+	return job, nil
+}
+
+// UpdateRevertJob saves the progress of a job
+func (store *Store) UpdateRevertJob(ctx context.Context, job *ledger.RevertJob) error {
+	_, err := store.db.NewUpdate().
+		Model(job).
+		ModelTableExpr(store.GetPrefixedRelationName("revert_jobs")).
+		Column("status", "reverted", "failed", "last_error", "last_transaction_id", "updated_at", "ended_at").
+		Where("id = ?", job.ID).
+		Where("ledger = ?", store.ledger.Name).
+		Exec(ctx)
+	return postgres.ResolveError(err)
+}
+
+// ListUnfinishedRevertJobs returns the jobs still having transactions to process, the oldest first
+func (store *Store) ListUnfinishedRevertJobs(ctx context.Context) ([]ledger.RevertJob, error) {
+	ret := make([]ledger.RevertJob, 0)
+	err := store.db.NewSelect().
+		Model(&ret).
+		ModelTableExpr(store.GetPrefixedRelationName("revert_jobs")).
+		Where("ledger = ?", store.ledger.Name).
+		Where("status in (?, ?)", ledger.RevertJobStatusPending, ledger.RevertJobStatusRunning).
+		Order("inserted_at").
+		Scan(ctx)
+	if err != nil {
+		return nil, postgres.ResolveError(err)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,88 @@
+//go:build it
+
+package ledger_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	logging "github.com/formancehq/go-libs/v5/pkg/observe/log"
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+)
+
+func TestRevertJobs(t *testing.T) {
+	t.Parallel()
+
+	ctx := logging.TestingContext()
+
+	store := newLedgerStore(t)
+	now := time.Now()
+
+	_, err := store.FindRevertJob(ctx, "unknown")
+	require.ErrorIs(t, err, postgres.ErrNotFound)
+
+	job := ledger.RevertJob{
+		ID:         "job1",
+		Query:      json.RawMessage(`{"$match": {"account": "users:001"}}`),
+		Metadata:   metadata.Metadata{"reason": "bug"},
+		Status:     ledger.RevertJobStatusPending,
+		Total:      2,
+		InsertedAt: now,
+		UpdatedAt:  now,
+
+		MaxTransactionID: pointer.For(uint64(20)),
+	}
+	require.NoError(t, store.InsertRevertJob(ctx, &job))
+
+	found, err := store.FindRevertJob(ctx, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, job.Status, found.Status)
+	require.Equal(t, uint64(20), *found.MaxTransactionID)
+	require.JSONEq(t, string(job.Query), string(found.Query))
+
+	jobs, err := store.ListUnfinishedRevertJobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+
+	tx, _, err := store.BeginTX(ctx, nil)
+	require.NoError(t, err)
+	locked, err := tx.LockRevertJob(ctx, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, job.ID, locked.ID)
+
+	// The job is skipped while another sql transaction holds its lock
+	concurrentTx, _, err := store.BeginTX(ctx, nil)
+	require.NoError(t, err)
+	_, err = concurrentTx.LockRevertJob(ctx, job.ID)
+	require.ErrorIs(t, err, postgres.ErrNotFound)
+	require.NoError(t, concurrentTx.Rollback(ctx))
+	require.NoError(t, tx.Rollback(ctx))
+
+	job.Status = ledger.RevertJobStatusSucceeded
+	job.Reverted = 1
+	job.Failed = 1
+	job.LastError = "insufficient funds"
+	job.LastTransactionID = pointer.For(uint64(10))
+	job.EndedAt = pointer.For(now.Add(time.Minute))
+	job.UpdatedAt = now.Add(time.Minute)
+	require.NoError(t, store.UpdateRevertJob(ctx, &job))
+
+	found, err = store.FindRevertJob(ctx, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, ledger.RevertJobStatusSucceeded, found.Status)
+	require.Equal(t, uint64(1), found.Reverted)
+	require.Equal(t, uint64(1), found.Failed)
+	require.Equal(t, job.LastError, found.LastError)
+	require.Equal(t, uint64(10), *found.LastTransactionID)
+
+	jobs, err = store.ListUnfinishedRevertJobs(ctx)
+	require.NoError(t, err)
+	require.Empty(t, jobs)
+}
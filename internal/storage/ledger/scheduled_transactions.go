@@ -0,0 +1,82 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+)
+
+func (store *Store) InsertScheduledTransaction(ctx context.Context, scheduledTransaction *ledger.ScheduledTransaction) error {
+	_, err := store.db.NewInsert().
+		Model(scheduledTransaction).
+		Value("ledger", "?", store.ledger.Name).
+		ModelTableExpr(store.GetPrefixedRelationName("scheduled_transactions")).
+		Exec(ctx)
+	return postgres.ResolveError(err)
+}
+
+func (store *Store) FindScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	ret := &ledger.ScheduledTransaction{}
+	err := store.db.NewSelect().
+		Model(ret).
+		ModelTableExpr(store.GetPrefixedRelationName("scheduled_transactions")).
+		Where("id = ?", id).
+		Where("ledger = ?", store.ledger.Name).
+		Scan(ctx)
+	if err != nil {
+		return nil, postgres.ResolveError(err)
+	}
+
+	return ret, nil
+}
+
+// LockScheduledTransaction returns the scheduled transaction, locked until the end of the sql transaction
+func (store *Store) LockScheduledTransaction(ctx context.Context, id string) (*ledger.ScheduledTransaction, error) {
+	ret := &ledger.ScheduledTransaction{}
+	err := store.db.NewSelect().
+		Model(ret).
+		ModelTableExpr(store.GetPrefixedRelationName("scheduled_transactions")).
+		Where("id = ?", id).
+		Where("ledger = ?", store.ledger.Name).
+		For("update").
+		Scan(ctx)
+	if err != nil {
+		return nil, postgres.ResolveError(err)
+	}
+
+	return ret, nil
+}
+
+// UpdateScheduledTransaction saves the outcome of a scheduled transaction
+func (store *Store) UpdateScheduledTransaction(ctx context.Context, scheduledTransaction *ledger.ScheduledTransaction) error {
+	_, err := store.db.NewUpdate().
+		Model(scheduledTransaction).
+		ModelTableExpr(store.GetPrefixedRelationName("scheduled_transactions")).
+		Column("status", "transaction_id", "error", "updated_at", "ended_at").
+		Where("id = ?", scheduledTransaction.ID).
+		Where("ledger = ?", store.ledger.Name).
+		Exec(ctx)
+	return postgres.ResolveError(err)
+}
+
+// ListDueScheduledTransactions returns the pending transactions scheduled before the given date, the oldest first
+func (store *Store) ListDueScheduledTransactions(ctx context.Context, at time.Time, limit int) ([]ledger.ScheduledTransaction, error) {
+	ret := make([]ledger.ScheduledTransaction, 0)
+	err := store.db.NewSelect().
+		Model(&ret).
+		ModelTableExpr(store.GetPrefixedRelationName("scheduled_transactions")).
+		Where("ledger = ?", store.ledger.Name).
+		Where("status = ?", ledger.ScheduledTransactionStatusPending).
+		Where("scheduled_at <= ?", at).
+		Order("scheduled_at", "id").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, postgres.ResolveError(err)
+	}
+
+	return ret, nil
+}
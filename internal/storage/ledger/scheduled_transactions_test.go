@@ -0,0 +1,87 @@
+//go:build it
+
+package ledger_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	logging "github.com/formancehq/go-libs/v5/pkg/observe/log"
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+)
+
+func TestScheduledTransactions(t *testing.T) {
+	t.Parallel()
+
+	ctx := logging.TestingContext()
+
+	store := newLedgerStore(t)
+	now := time.Now()
+
+	_, err := store.FindScheduledTransaction(ctx, "unknown")
+	require.ErrorIs(t, err, postgres.ErrNotFound)
+
+	due := ledger.ScheduledTransaction{
+		ID:          "due",
+		ScheduledAt: now.Add(-time.Minute),
+		Transaction: ledger.ScheduledTransactionData{
+			Postings: ledger.Postings{ledger.NewPosting("world", "bank", "USD", big.NewInt(100))},
+			Metadata: metadata.Metadata{"foo": "bar"},
+		},
+		Status:     ledger.ScheduledTransactionStatusPending,
+		InsertedAt: now,
+		UpdatedAt:  now,
+	}
+	require.NoError(t, store.InsertScheduledTransaction(ctx, &due))
+
+	later := ledger.ScheduledTransaction{
+		ID:          "later",
+		ScheduledAt: now.Add(time.Hour),
+		Transaction: ledger.ScheduledTransactionData{
+			Script: "send [USD 100] (source = @world destination = @bank)",
+		},
+		Status:     ledger.ScheduledTransactionStatusPending,
+		InsertedAt: now,
+		UpdatedAt:  now,
+	}
+	require.NoError(t, store.InsertScheduledTransaction(ctx, &later))
+
+	found, err := store.FindScheduledTransaction(ctx, due.ID)
+	require.NoError(t, err)
+	require.Len(t, found.Transaction.Postings, 1)
+	require.Equal(t, "100", found.Transaction.Postings[0].Amount.String())
+
+	dueTransactions, err := store.ListDueScheduledTransactions(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, dueTransactions, 1)
+	require.Equal(t, due.ID, dueTransactions[0].ID)
+
+	tx, _, err := store.BeginTX(ctx, nil)
+	require.NoError(t, err)
+	locked, err := tx.LockScheduledTransaction(ctx, due.ID)
+	require.NoError(t, err)
+
+	locked.Status = ledger.ScheduledTransactionStatusExecuted
+	locked.TransactionID = pointer.For(uint64(1))
+	locked.EndedAt = pointer.For(now)
+	locked.UpdatedAt = now
+	require.NoError(t, tx.UpdateScheduledTransaction(ctx, locked))
+	require.NoError(t, tx.Commit(ctx))
+
+	found, err = store.FindScheduledTransaction(ctx, due.ID)
+	require.NoError(t, err)
+	require.Equal(t, ledger.ScheduledTransactionStatusExecuted, found.Status)
+	require.Equal(t, uint64(1), *found.TransactionID)
+
+	dueTransactions, err = store.ListDueScheduledTransactions(ctx, now.Add(2*time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, dueTransactions, 1)
+	require.Equal(t, later.ID, dueTransactions[0].ID)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
 	"github.com/formancehq/go-libs/v5/pkg/storage/migrations"
 	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	"github.com/formancehq/go-libs/v5/pkg/types/time"
 
 	ledger "github.com/formancehq/ledger/internal"
 	"github.com/formancehq/ledger/internal/storage/bucket"
@@ -47,9 +48,11 @@ type Store struct {
 	deleteAccountMetadataHistogram     metric.Int64Histogram
 	upsertAccountsHistogram            metric.Int64Histogram
 	getBalancesHistogram               metric.Int64Histogram
+	lockBalancesHistogram              metric.Int64Histogram
 	insertLogHistogram                 metric.Int64Histogram
 	readLogWithIdempotencyKeyHistogram metric.Int64Histogram
 	insertMovesHistogram               metric.Int64Histogram
+	listAccountMovesHistogram          metric.Int64Histogram
 	insertTransactionHistogram         metric.Int64Histogram
 	revertTransactionHistogram         metric.Int64Histogram
 	updateTransactionMetadataHistogram metric.Int64Histogram
@@ -76,6 +79,27 @@ func (store *Store) AggregatedVolumes() common.Resource[ledger.AggregatedVolumes
 	})
 }
 
+// AggregatedVolumesHistory aggregates the volumes at each of the dates, the point in time of the queries is ignored
+func (store *Store) AggregatedVolumesHistory(dates []time.Time) common.Resource[ledger.AggregatedVolumesHistory, ledger.GetAggregatedVolumesOptions] {
+	return common.NewResourceRepository[ledger.AggregatedVolumesHistory, ledger.GetAggregatedVolumesOptions](&aggregatedBalancesHistoryResourceRepositoryHandler{
+		aggregatedBalancesResourceRepositoryHandler: aggregatedBalancesResourceRepositoryHandler{
+			store: store,
+		},
+		dates: dates,
+	})
+}
+
+// ChartAccountsVolumes aggregates the volumes of the accounts by account of the chart,
+// the addresses belonging to none of the accounts are ignored
+func (store *Store) ChartAccountsVolumes(accounts []ledger.ChartAccountAddresses) common.Resource[ledger.ChartAccountsVolumes, ledger.GetAggregatedVolumesOptions] {
+	return common.NewResourceRepository[ledger.ChartAccountsVolumes, ledger.GetAggregatedVolumesOptions](&chartAccountsVolumesResourceRepositoryHandler{
+		aggregatedBalancesResourceRepositoryHandler: aggregatedBalancesResourceRepositoryHandler{
+			store: store,
+		},
+		accounts: accounts,
+	})
+}
+
 func (store *Store) Transactions() common.PaginatedResource[
 	ledger.Transaction,
 	any] {
@@ -106,6 +130,14 @@ func (store *Store) Schemas() common.PaginatedResource[
 	}, "created_at", paginate.OrderDesc)
 }
 
+func (store *Store) Recurrences() common.PaginatedResource[
+	ledger.Recurrence,
+	any] {
+	return common.NewPaginatedResourceRepository[ledger.Recurrence, any](&recurrencesResourceHandler{
+		store: store,
+	}, "inserted_at", paginate.OrderAsc)
+}
+
 func (store *Store) BeginTX(ctx context.Context, options *sql.TxOptions) (*Store, *bun.Tx, error) {
 
 	tx, err := tracing.TraceWithMetric(ctx, "BeginTX", store.tracer, store.beginTXHistogram, func(ctx context.Context) (bun.Tx, error) {
@@ -277,6 +309,11 @@ func New(db bun.IDB, bucket bucket.Bucket, l ledger.Ledger, opts ...Option) *Sto
 		panic(err)
 	}
 
+	ret.lockBalancesHistogram, err = ret.meter.Int64Histogram("store.lock_balances", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+
 	ret.insertLogHistogram, err = ret.meter.Int64Histogram("store.insert_log", metric.WithUnit("ms"))
 	if err != nil {
 		panic(err)
@@ -292,6 +329,11 @@ func New(db bun.IDB, bucket bucket.Bucket, l ledger.Ledger, opts ...Option) *Sto
 		panic(err)
 	}
 
+	ret.listAccountMovesHistogram, err = ret.meter.Int64Histogram("store.list_account_moves", metric.WithUnit("ms"))
+	if err != nil {
+		panic(err)
+	}
+
 	ret.insertTransactionHistogram, err = ret.meter.Int64Histogram("store.insert_transaction", metric.WithUnit("ms"))
 	if err != nil {
 		panic(err)
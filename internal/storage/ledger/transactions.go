@@ -211,6 +211,60 @@ func (store *Store) RevertTransaction(ctx context.Context, id uint64, at time.Ti
 	return tx, modified, err
 }
 
+// LockTransaction returns a transaction, locking it until the end of the sql transaction
+func (store *Store) LockTransaction(ctx context.Context, id uint64) (*ledger.Transaction, error) {
+	tx := &ledger.Transaction{}
+	err := store.db.NewSelect().
+		Model(tx).
+		ModelTableExpr(store.GetPrefixedRelationName("transactions")).
+		ColumnExpr("*").
+		Where("id = ?", id).
+		Where("ledger = ?", store.ledger.Name).
+		For("update").
+		Scan(ctx)
+	if err != nil {
+		return nil, postgres.ResolveError(err)
+	}
+
+	return tx, nil
+}
+
+// UpdateTransactionRevertedAmounts saves the amounts reverted by partial reverts,
+// if reverted is true, the transaction is also marked as reverted.
+func (store *Store) UpdateTransactionRevertedAmounts(ctx context.Context, id uint64, amounts []*big.Int, at time.Time, reverted bool) (*ledger.Transaction, error) {
+	tx := &ledger.Transaction{}
+	query := store.db.NewUpdate().
+		Model(tx).
+		ModelTableExpr(store.GetPrefixedRelationName("transactions")).
+		Set("reverted_amounts = ?", amounts).
+		Where("id = ?", id).
+		Where("ledger = ?", store.ledger.Name).
+		Where("reverted_at is null").
+		Returning("*")
+	date := "?"
+	args := []any{at}
+	if at.IsZero() {
+		date = store.GetPrefixedRelationName("transaction_date") + "()"
+		args = nil
+	}
+	query = query.Set("updated_at = "+date, args...)
+	if reverted {
+		query = query.Set("reverted_at = "+date, args...)
+	}
+
+	result, err := query.Exec(ctx)
+	if err != nil {
+		return nil, postgres.ResolveError(err)
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if rowsAffected == 0 {
+		return nil, postgres.ErrNotFound
+	}
+
+	return tx, nil
+}
+
 func (store *Store) UpdateTransactionMetadata(ctx context.Context, id uint64, m metadata.Metadata, at time.Time) (tx *ledger.Transaction, modified bool, err error) {
 	_, err = tracing.TraceWithMetric(
 		ctx,
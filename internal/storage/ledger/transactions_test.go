@@ -23,6 +23,7 @@ import (
 	"github.com/formancehq/go-libs/v5/pkg/types/time"
 
 	ledger "github.com/formancehq/ledger/internal"
+	"github.com/formancehq/ledger/internal/queries"
 	"github.com/formancehq/ledger/internal/storage/common"
 	ledgerstore "github.com/formancehq/ledger/internal/storage/ledger"
 )
@@ -574,6 +575,51 @@ func TestTransactionsRevert(t *testing.T) {
 	require.False(t, reverted)
 }
 
+func TestTransactionsUpdateRevertedAmounts(t *testing.T) {
+	t.Parallel()
+
+	store := newLedgerStore(t)
+	now := time.Now()
+	ctx := logging.TestingContext()
+
+	tx1 := ledger.NewTransaction().
+		WithPostings(
+			ledger.NewPosting("world", "alice", "USD", big.NewInt(100)),
+			ledger.NewPosting("alice", "bob", "USD", big.NewInt(50)),
+		).
+		WithTimestamp(now.Add(-3 * time.Hour))
+	err := commitTransactionAndUpsertAccounts(ctx, store, &tx1)
+	require.NoError(t, err)
+
+	lockedTx, err := store.LockTransaction(ctx, *tx1.ID)
+	require.NoError(t, err)
+	require.Nil(t, lockedTx.RevertedAmounts)
+
+	// Partially revert the tx
+	updatedTx, err := store.UpdateTransactionRevertedAmounts(ctx, *tx1.ID, []*big.Int{big.NewInt(0), big.NewInt(20)}, time.Time{}, false)
+	require.NoError(t, err)
+	require.False(t, updatedTx.IsReverted())
+	require.Len(t, updatedTx.RevertedAmounts, 2)
+	require.Equal(t, "20", updatedTx.RevertedAmounts[1].String())
+
+	lockedTx, err = store.LockTransaction(ctx, *tx1.ID)
+	require.NoError(t, err)
+	require.Equal(t, "20", lockedTx.RevertedAmounts[1].String())
+
+	// Revert the remaining amounts
+	updatedTx, err = store.UpdateTransactionRevertedAmounts(ctx, *tx1.ID, []*big.Int{big.NewInt(100), big.NewInt(50)}, now, true)
+	require.NoError(t, err)
+	require.True(t, updatedTx.IsReverted())
+
+	// A reverted transaction cannot be updated anymore
+	_, err = store.UpdateTransactionRevertedAmounts(ctx, *tx1.ID, []*big.Int{big.NewInt(100), big.NewInt(50)}, now, true)
+	require.True(t, errors.Is(err, postgres.ErrNotFound))
+
+	// Lock a not existing transaction
+	_, err = store.LockTransaction(ctx, 2)
+	require.True(t, errors.Is(err, postgres.ErrNotFound))
+}
+
 func TestTransactionsInsert(t *testing.T) {
 	t.Parallel()
 
@@ -947,3 +993,231 @@ func TestTransactionsList(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionsAggregate(t *testing.T) {
+	t.Parallel()
+
+	store := newLedgerStore(t)
+	ctx := logging.TestingContext()
+
+	for _, tx := range []ledger.Transaction{
+		ledger.NewTransaction().
+			WithPostings(
+				ledger.NewPosting("world", "alice", "USD", big.NewInt(100)),
+				ledger.NewPosting("world", "alice", "EUR", big.NewInt(10)),
+			).
+			WithMetadata(metadata.Metadata{"merchant_id": "1"}),
+		ledger.NewTransaction().
+			WithPostings(
+				ledger.NewPosting("world", "bob", "USD", big.NewInt(200)),
+			).
+			WithMetadata(metadata.Metadata{"merchant_id": "1"}),
+		ledger.NewTransaction().
+			WithPostings(
+				ledger.NewPosting("world", "bob", "USD", big.NewInt(50)),
+			).
+			WithMetadata(metadata.Metadata{"merchant_id": "2"}),
+	} {
+		err := commitTransactionAndUpsertAccounts(ctx, store, &tx)
+		require.NoError(t, err)
+	}
+
+	aggregation := queries.Aggregation{
+		GroupBy: []string{"metadata[merchant_id]", "asset"},
+		Measures: map[string]queries.Measure{
+			"total": {Function: queries.MeasureSum, Property: "amount"},
+			"count": {Function: queries.MeasureCount},
+		},
+	}
+	cursor, err := store.Transactions().Aggregate(ctx, common.AggregatedQuery[any]{
+		Aggregation: aggregation,
+		PageSize:    2,
+	})
+	require.NoError(t, err)
+	require.True(t, cursor.HasMore)
+	require.Equal(t, []queries.AggregationGroup{
+		{
+			Keys:     map[string]*string{"metadata[merchant_id]": pointer.For("1"), "asset": pointer.For("EUR")},
+			Measures: map[string]*big.Int{"total": big.NewInt(10), "count": big.NewInt(1)},
+		},
+		{
+			Keys:     map[string]*string{"metadata[merchant_id]": pointer.For("1"), "asset": pointer.For("USD")},
+			Measures: map[string]*big.Int{"total": big.NewInt(300), "count": big.NewInt(2)},
+		},
+	}, cursor.Data)
+
+	firstPage := cursor.Data
+	require.Empty(t, cursor.Previous)
+
+	// the cursors resume from the keys of the groups
+	next, err := common.UnmarshalAggregatedCursor[any](cursor.Next)
+	require.NoError(t, err)
+	require.Equal(t, []*string{pointer.For("1"), pointer.For("USD")}, next.After)
+	cursor, err = store.Transactions().Aggregate(ctx, *next)
+	require.NoError(t, err)
+	require.False(t, cursor.HasMore)
+	require.Equal(t, []queries.AggregationGroup{{
+		Keys:     map[string]*string{"metadata[merchant_id]": pointer.For("2"), "asset": pointer.For("USD")},
+		Measures: map[string]*big.Int{"total": big.NewInt(50), "count": big.NewInt(1)},
+	}}, cursor.Data)
+
+	previous, err := common.UnmarshalAggregatedCursor[any](cursor.Previous)
+	require.NoError(t, err)
+	cursor, err = store.Transactions().Aggregate(ctx, *previous)
+	require.NoError(t, err)
+	require.True(t, cursor.HasMore)
+	require.Empty(t, cursor.Previous)
+	require.Equal(t, firstPage, cursor.Data)
+
+	// a new group inserted before the cursor does not shift the next page
+	require.NoError(t, commitTransactionAndUpsertAccounts(ctx, store, pointer.For(ledger.NewTransaction().
+		WithPostings(ledger.NewPosting("world", "alice", "BTC", big.NewInt(1))).
+		WithMetadata(metadata.Metadata{"merchant_id": "0"}))))
+	cursor, err = store.Transactions().Aggregate(ctx, *next)
+	require.NoError(t, err)
+	require.Len(t, cursor.Data, 1)
+	require.Equal(t, pointer.For("2"), cursor.Data[0].Keys["metadata[merchant_id]"])
+
+	// filters apply before the aggregation
+	cursor, err = store.Transactions().Aggregate(ctx, common.AggregatedQuery[any]{
+		Options: common.ResourceQuery[any]{
+			Builder: query.Match("metadata[merchant_id]", "2"),
+		},
+		Aggregation: queries.Aggregation{
+			GroupBy: []string{"destination"},
+			Measures: map[string]queries.Measure{
+				"max": {Function: queries.MeasureMax, Property: "amount"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []queries.AggregationGroup{{
+		Keys:     map[string]*string{"destination": pointer.For("bob")},
+		Measures: map[string]*big.Int{"max": big.NewInt(50)},
+	}}, cursor.Data)
+}
+
+func TestTransactionsListWithFields(t *testing.T) {
+	t.Parallel()
+
+	store := newLedgerStore(t)
+	ctx := logging.TestingContext()
+
+	tx := ledger.NewTransaction().
+		WithPostings(ledger.NewPosting("world", "alice", "USD", big.NewInt(100))).
+		WithMetadata(metadata.Metadata{"merchant_id": "1"}).
+		WithReference("ref")
+	require.NoError(t, commitTransactionAndUpsertAccounts(ctx, store, &tx))
+
+	cursor, err := store.Transactions().Paginate(ctx, common.InitialPaginatedQuery[any]{
+		Options: common.ResourceQuery[any]{
+			Builder: query.Match("metadata[merchant_id]", "1"),
+			Fields:  []string{"id", "timestamp"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, cursor.Data, 1)
+	require.Equal(t, tx.ID, cursor.Data[0].ID)
+	require.Equal(t, tx.Timestamp, cursor.Data[0].Timestamp)
+	require.Empty(t, cursor.Data[0].Reference)
+	require.Nil(t, cursor.Data[0].Postings)
+	require.Nil(t, cursor.Data[0].Metadata)
+
+	cursor, err = store.Transactions().Paginate(ctx, common.InitialPaginatedQuery[any]{
+		Options: common.ResourceQuery[any]{
+			Fields: []string{"id", "postings"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, cursor.Data, 1)
+	require.Equal(t, tx.Postings, cursor.Data[0].Postings)
+	require.Nil(t, cursor.Data[0].Metadata)
+
+	// the volumes are selected by the fields, not by the expansions
+	cursor, err = store.Transactions().Paginate(ctx, common.InitialPaginatedQuery[any]{
+		Options: common.ResourceQuery[any]{
+			Fields: []string{"id", "postCommitVolumes"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, cursor.Data, 1)
+	RequireEqual(t, tx.PostCommitVolumes, cursor.Data[0].PostCommitVolumes)
+	require.Equal(t, tx.Postings, cursor.Data[0].Postings)
+
+	cursor, err = store.Transactions().Paginate(ctx, common.InitialPaginatedQuery[any]{
+		Options: common.ResourceQuery[any]{
+			Expand: []string{"volumes", "effectiveVolumes"},
+			Fields: []string{"id"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, cursor.Data, 1)
+	require.Nil(t, cursor.Data[0].PostCommitVolumes)
+	require.Nil(t, cursor.Data[0].PostCommitEffectiveVolumes)
+
+	_, err = store.Transactions().Paginate(ctx, common.InitialPaginatedQuery[any]{
+		Options: common.ResourceQuery[any]{
+			Fields: []string{"address"},
+		},
+	})
+	require.True(t, errors.Is(err, common.ErrInvalidQuery{}))
+}
+
+func TestTransactionsListWithPatternOperators(t *testing.T) {
+	t.Parallel()
+
+	store := newLedgerStore(t)
+	ctx := logging.TestingContext()
+
+	for _, tx := range []ledger.Transaction{
+		ledger.NewTransaction().
+			WithPostings(ledger.NewPosting("world", "alice", "USD", big.NewInt(100))).
+			WithMetadata(metadata.Metadata{"customer": "ACME Corp"}).
+			WithReference("INV-001"),
+		ledger.NewTransaction().
+			WithPostings(ledger.NewPosting("world", "bob", "USD", big.NewInt(100))).
+			WithMetadata(metadata.Metadata{"customer": "Globex"}).
+			WithReference("INV_002"),
+		ledger.NewTransaction().
+			WithPostings(ledger.NewPosting("world", "bob", "USD", big.NewInt(100))).
+			WithReference("REFUND-001"),
+	} {
+		require.NoError(t, commitTransactionAndUpsertAccounts(ctx, store, &tx))
+	}
+
+	list := func(builder query.Builder) []string {
+		cursor, err := store.Transactions().Paginate(ctx, common.InitialPaginatedQuery[any]{
+			Options: common.ResourceQuery[any]{
+				Builder: builder,
+			},
+		})
+		require.NoError(t, err)
+		references := make([]string, 0, len(cursor.Data))
+		for _, tx := range cursor.Data {
+			references = append(references, tx.Reference)
+		}
+		return references
+	}
+
+	require.Equal(t, []string{"INV_002", "INV-001"}, list(queries.Regex("reference", "^INV.[0-9]+$")))
+	// the wildcards of the prefix are escaped
+	require.Equal(t, []string{"INV_002"}, list(queries.StartsWith("reference", "INV_")))
+	require.Equal(t, []string{"INV-001"}, list(queries.ILike("metadata[customer]", "%acme%")))
+	require.Equal(t, []string{"INV_002"}, list(queries.Regex("metadata[customer]", "^Glo")))
+	require.Equal(t, []string{"REFUND-001"}, list(query.Not(queries.StartsWith("reference", "INV"))))
+
+	_, err := store.Transactions().Paginate(ctx, common.InitialPaginatedQuery[any]{
+		Options: common.ResourceQuery[any]{
+			Builder: queries.Regex("source", "^wor"),
+		},
+	})
+	require.True(t, errors.Is(err, common.ErrInvalidQuery{}))
+
+	// the named groups are valid for the RE2 syntax but rejected by the database
+	_, err = store.Transactions().Paginate(ctx, common.InitialPaginatedQuery[any]{
+		Options: common.ResourceQuery[any]{
+			Builder: queries.Regex("reference", "^(?P<prefix>INV)"),
+		},
+	})
+	require.True(t, errors.Is(err, common.ErrInvalidQuery{}))
+}
@@ -3,13 +3,81 @@ package ledger
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/uptrace/bun"
 
 	"github.com/formancehq/go-libs/v5/pkg/query"
+
+	"github.com/formancehq/ledger/internal/queries"
+	"github.com/formancehq/ledger/internal/storage/common"
 )
 
+// resolveMetadataAggregationKey resolves the `metadata[key]` group keys of the aggregations
+func resolveMetadataAggregationKey(property string) (*common.AggregationProperty, bool, error) {
+	name, key, err := queries.ParseAggregationKey(property)
+	if err != nil {
+		return nil, false, common.NewErrInvalidQuery("invalid group key %s: %s", property, err)
+	}
+	if name != "metadata" || key == "" {
+		return nil, false, nil
+	}
+	return &common.AggregationProperty{
+		Expression: "dataset.metadata ->> ?",
+		Args:       []any{key},
+	}, true, nil
+}
+
+// checkAddressOperator rejects the pattern operators on the addresses, which have their own segment syntax
+func checkAddressOperator(property, operator string) error {
+	if slices.Contains(queries.PatternOperators, operator) {
+		return common.NewErrInvalidQuery("operator '%s' is not allowed for property '%s'", operator, property)
+	}
+	return nil
+}
+
+// projectedColumn lists the columns fetched when a field is selected by a projection
+type projectedColumn struct {
+	field   string
+	columns []string
+}
+
+// projectColumns applies the fields of a projection, only the columns of the selected fields are fetched,
+// along with the keys and the paginated columns which are used by the pagination and the expansions
+func projectColumns(kind queries.ResourceKind, fields []string, selectQuery *bun.SelectQuery, keys []string, columns ...projectedColumn) (*bun.SelectQuery, error) {
+	if len(fields) == 0 {
+		return selectQuery.ColumnExpr("*"), nil
+	}
+	if err := queries.ValidateFields(kind, fields); err != nil {
+		return nil, common.NewErrInvalidQuery("invalid fields: %s", err)
+	}
+	schema, err := queries.GetResourceSchema(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := slices.Clone(keys)
+	for _, name := range slices.Sorted(maps.Keys(schema.Fields)) {
+		if schema.Fields[name].IsPaginated && !slices.Contains(selected, name) {
+			selected = append(selected, name)
+		}
+	}
+	for _, column := range columns {
+		if !slices.Contains(fields, column.field) {
+			continue
+		}
+		for _, name := range column.columns {
+			if !slices.Contains(selected, name) {
+				selected = append(selected, name)
+			}
+		}
+	}
+
+	return selectQuery.Column(selected...), nil
+}
+
 func isPartialAddress(address string) bool {
 	src := strings.Split(address, ":")
 
@@ -140,10 +208,10 @@ func isAddressKey(key string) bool {
 }
 
 // isLeafOperator returns true for query leaf operators ($match, $gt, etc).
-// Must match leaf operators in go-libs/v3/query/expression.go (mapMapToExpression).
+// Must match leaf operators in queries.ParseJSON.
 func isLeafOperator(op string) bool {
 	switch op {
-	case "$match", "$gt", "$gte", "$lt", "$lte", "$like", "$exists", "$in":
+	case "$match", "$gt", "$gte", "$lt", "$lte", "$like", "$exists", "$in", "$regex", "$ilike", "$startsWith":
 		return true
 	}
 	return false
@@ -294,6 +294,19 @@ func GetMigrator(db bun.IDB, options ...migrations.Option) *migrations.Migrator
 				})
 			},
 		},
+		migrations.Migration{
+			Name: "Add schema settings columns to ledgers",
+			Up: func(ctx context.Context, db bun.IDB) error {
+				return db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+					_, err := tx.ExecContext(ctx, `
+						alter table _system.ledgers
+						add column if not exists schema_enforcement_mode varchar(255),
+						add column if not exists default_schema_version varchar(255);
+					`)
+					return err
+				})
+			},
+		},
 	)
 
 	return migrator
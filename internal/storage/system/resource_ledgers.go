@@ -3,6 +3,7 @@ package system
 import (
 	"errors"
 	"regexp"
+	"slices"
 
 	"github.com/uptrace/bun"
 
@@ -47,23 +48,30 @@ func (h ledgersResourceHandler) BuildDataset(ctx common.RepositoryHandlerBuildCo
 func (h ledgersResourceHandler) ResolveFilter(_ common.ResourceQuery[ListLedgersQueryPayload], operator, property string, value any) (string, []any, error) {
 	switch {
 	case property == "bucket":
+		if slices.Contains(queries.PatternOperators, operator) {
+			value, err := common.ConvertValueToSQL(operator, value)
+			if err != nil {
+				return "", nil, err
+			}
+			return "bucket " + common.ConvertOperatorToSQL(operator) + " ?", []any{value}, nil
+		}
 		return "bucket = ?", []any{value}, nil
 	case featuresRegex.Match([]byte(property)):
 		match := featuresRegex.FindAllStringSubmatch(property, 3)
 
-		return "features @> ?", []any{map[string]any{
-			match[0][1]: value,
-		}}, nil
+		return common.ResolveMetadataFilter("features", match[0][1], operator, value)
 	case common.MetadataRegex.Match([]byte(property)):
 		match := common.MetadataRegex.FindAllStringSubmatch(property, 3)
 
-		return "metadata @> ?", []any{map[string]any{
-			match[0][1]: value,
-		}}, nil
+		return common.ResolveMetadataFilter("metadata", match[0][1], operator, value)
 
 	case property == "metadata":
 		return "metadata -> ? is not null", []any{value}, nil
 	case property == "name":
+		value, err := common.ConvertValueToSQL(operator, value)
+		if err != nil {
+			return "", nil, err
+		}
 		return "name " + common.ConvertOperatorToSQL(operator) + " ?", []any{value}, nil
 	default:
 		return "", nil, common.NewErrInvalidQuery("invalid filter property %s", property)
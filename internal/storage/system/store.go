@@ -25,6 +25,7 @@ type Store interface {
 	CreateLedger(ctx context.Context, l *ledger.Ledger) error
 	DeleteLedgerMetadata(ctx context.Context, name string, key string) error
 	UpdateLedgerMetadata(ctx context.Context, name string, m metadata.Metadata) error
+	UpdateLedgerSchemaSettings(ctx context.Context, name string, settings ledger.SchemaSettings) error
 	Ledgers() common.PaginatedResource[ledger.Ledger, ListLedgersQueryPayload]
 	GetLedger(ctx context.Context, name string) (*ledger.Ledger, error)
 	GetDistinctBuckets(ctx context.Context) ([]string, error)
@@ -45,6 +46,7 @@ const (
 
 var (
 	ErrLedgerAlreadyExists = errors.New("ledger already exists")
+	ErrLedgerNotFound      = errors.New("ledger not found")
 )
 
 type DefaultStore struct {
@@ -110,6 +112,29 @@ func (d *DefaultStore) UpdateLedgerMetadata(ctx context.Context, name string, m
 	return err
 }
 
+func (d *DefaultStore) UpdateLedgerSchemaSettings(ctx context.Context, name string, settings ledger.SchemaSettings) error {
+	ret, err := d.db.NewUpdate().
+		Model(&ledger.Ledger{
+			Configuration: ledger.Configuration{
+				SchemaSettings: settings,
+			},
+		}).
+		Column("schema_enforcement_mode", "default_schema_version").
+		Where("name = ?", name).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := ret.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrLedgerNotFound
+	}
+	return nil
+}
+
 func (d *DefaultStore) DeleteLedgerMetadata(ctx context.Context, name string, key string) error {
 	_, err := d.db.NewUpdate().
 		Model(&ledger.Ledger{}).
@@ -133,6 +133,38 @@ func TestLedgerUpdateMetadata(t *testing.T) {
 	require.Equal(t, addedMetadata, ledgerFromDB.Metadata)
 }
 
+func TestLedgerUpdateSchemaSettings(t *testing.T) {
+	t.Parallel()
+
+	ctx := logging.TestingContext()
+	store := newStore(t)
+
+	l := ledger.MustNewWithDefault(uuid.NewString())
+	err := store.CreateLedger(ctx, &l)
+	require.NoError(t, err)
+
+	settings := ledger.SchemaSettings{
+		SchemaEnforcementMode: ledger.SchemaEnforcementStrict,
+		DefaultSchemaVersion:  "v1",
+	}
+	err = store.UpdateLedgerSchemaSettings(ctx, l.Name, settings)
+	require.NoError(t, err)
+
+	ledgerFromDB, err := store.GetLedger(ctx, l.Name)
+	require.NoError(t, err)
+	require.Equal(t, settings, ledgerFromDB.SchemaSettings)
+
+	err = store.UpdateLedgerSchemaSettings(ctx, l.Name, ledger.SchemaSettings{})
+	require.NoError(t, err)
+
+	ledgerFromDB, err = store.GetLedger(ctx, l.Name)
+	require.NoError(t, err)
+	require.Equal(t, ledger.SchemaSettings{}, ledgerFromDB.SchemaSettings)
+
+	err = store.UpdateLedgerSchemaSettings(ctx, uuid.NewString(), settings)
+	require.ErrorIs(t, err, ErrLedgerNotFound)
+}
+
 func TestLedgerDeleteMetadata(t *testing.T) {
 	t.Parallel()
 
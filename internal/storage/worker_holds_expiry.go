@@ -0,0 +1,196 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/fx"
+
+	logging "github.com/formancehq/go-libs/v5/pkg/observe/log"
+	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
+	libtime "github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+	ledgercontroller "github.com/formancehq/ledger/internal/controller/ledger"
+	systemcontroller "github.com/formancehq/ledger/internal/controller/system"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+	"github.com/formancehq/ledger/internal/storage/driver"
+	systemstore "github.com/formancehq/ledger/internal/storage/system"
+)
+
+type HoldsExpiryRunnerConfig struct {
+	// BatchSize is the maximum number of holds voided per ledger on each run
+	BatchSize int
+	Schedule  cron.Schedule
+}
+
+// HoldsExpiryRunner voids the pending holds which have expired.
+// Expired holds already stop reserving funds, voiding them records the end of the hold in the logs.
+type HoldsExpiryRunner struct {
+	stopChannel chan chan struct{}
+	logger      logging.Logger
+	db          *bun.DB
+	driver      *driver.Driver
+	cfg         HoldsExpiryRunnerConfig
+	tracer      trace.Tracer
+
+	// systemController provides the ledger controllers, so the holds are voided
+	// with the same guarantees as from the api (retries, traces)
+	systemController systemcontroller.Controller
+}
+
+func (r *HoldsExpiryRunner) Name() string {
+	return "Holds expiry runner"
+}
+
+func (r *HoldsExpiryRunner) Run(ctx context.Context) error {
+	now := time.Now()
+	next := r.cfg.Schedule.Next(now).Sub(now)
+
+	for {
+		select {
+		case <-time.After(next):
+			if err := r.run(ctx); err != nil {
+				r.logger.Errorf("error running holds expiry: %v", err)
+			}
+
+			now = time.Now()
+			next = r.cfg.Schedule.Next(now).Sub(now)
+		case ch := <-r.stopChannel:
+			close(ch)
+			return nil
+		}
+	}
+}
+
+func (r *HoldsExpiryRunner) Stop(ctx context.Context) error {
+	ch := make(chan struct{})
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case r.stopChannel <- ch:
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ch:
+		}
+	}
+	return nil
+}
+
+func (r *HoldsExpiryRunner) run(ctx context.Context) error {
+	ctx, span := r.tracer.Start(ctx, "Run")
+	defer span.End()
+
+	systemStore := systemstore.New(r.db)
+	return storagecommon.Iterate(
+		ctx,
+		storagecommon.InitialPaginatedQuery[systemstore.ListLedgersQueryPayload]{},
+		systemStore.Ledgers().Paginate,
+		func(cursor *paginate.Cursor[ledger.Ledger]) error {
+			for _, l := range cursor.Data {
+				if err := r.processLedger(ctx, l); err != nil {
+					// Continue with other ledgers even if one fails
+					r.logger.Errorf("error voiding expired holds of ledger %s: %v", l.Name, err)
+				}
+			}
+			return nil
+		},
+	)
+}
+
+func (r *HoldsExpiryRunner) processLedger(ctx context.Context, l ledger.Ledger) error {
+	ctx, span := r.tracer.Start(ctx, "RunForLedger")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("ledger", l.Name))
+
+	store, _, err := r.driver.OpenLedger(ctx, l.Name)
+	if err != nil {
+		return fmt.Errorf("opening ledger: %w", err)
+	}
+
+	holds, err := store.ListExpiredHolds(ctx, libtime.Now(), r.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("listing expired holds: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("holds", len(holds)))
+
+	ctrl, err := r.systemController.GetLedgerController(ctx, l.Name)
+	if err != nil {
+		return fmt.Errorf("getting ledger controller: %w", err)
+	}
+	for _, hold := range holds {
+		_, _, _, err := ctrl.VoidHold(ctx, ledgercontroller.Parameters[ledgercontroller.VoidHold]{
+			Input: ledgercontroller.VoidHold{
+				ID: hold.ID,
+			},
+		})
+		// The hold may have been captured or voided since it has been listed
+		if err != nil && !errors.Is(err, ledgercontroller.ErrHoldNotPending{}) {
+			return fmt.Errorf("voiding hold %s: %w", hold.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// NewHoldsExpiryRunner creates a HoldsExpiryRunner voiding the expired holds of all the ledgers of the driver.
+func NewHoldsExpiryRunner(logger logging.Logger, db *bun.DB, driver *driver.Driver, systemController systemcontroller.Controller, cfg HoldsExpiryRunnerConfig, opts ...HoldsExpiryRunnerOption) *HoldsExpiryRunner {
+	ret := &HoldsExpiryRunner{
+		stopChannel:      make(chan chan struct{}),
+		logger:           logger,
+		db:               db,
+		driver:           driver,
+		cfg:              cfg,
+		systemController: systemController,
+	}
+
+	for _, opt := range append(defaultHoldsExpiryRunnerOptions, opts...) {
+		opt(ret)
+	}
+
+	return ret
+}
+
+type HoldsExpiryRunnerOption func(*HoldsExpiryRunner)
+
+func WithHoldsExpiryRunnerTracer(tracer trace.Tracer) HoldsExpiryRunnerOption {
+	return func(r *HoldsExpiryRunner) {
+		r.tracer = tracer
+	}
+}
+
+var defaultHoldsExpiryRunnerOptions = []HoldsExpiryRunnerOption{
+	WithHoldsExpiryRunnerTracer(noop.Tracer{}),
+}
+
+func NewHoldsExpiryRunnerModule(cfg HoldsExpiryRunnerConfig) fx.Option {
+	return fx.Options(
+		fx.Provide(func(logger logging.Logger, db *bun.DB, driver *driver.Driver, systemController systemcontroller.Controller) (*HoldsExpiryRunner, error) {
+			return NewHoldsExpiryRunner(logger, db, driver, systemController, cfg), nil
+		}),
+		fx.Invoke(func(lc fx.Lifecycle, holdsExpiryRunner *HoldsExpiryRunner) {
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					go func() {
+						if err := holdsExpiryRunner.Run(context.WithoutCancel(ctx)); err != nil {
+							panic(err)
+						}
+					}()
+
+					return nil
+				},
+				OnStop: holdsExpiryRunner.Stop,
+			})
+		}),
+	)
+}
@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/fx"
+
+	logging "github.com/formancehq/go-libs/v5/pkg/observe/log"
+	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+	libtime "github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+	systemcontroller "github.com/formancehq/ledger/internal/controller/system"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+	"github.com/formancehq/ledger/internal/storage/driver"
+	systemstore "github.com/formancehq/ledger/internal/storage/system"
+)
+
+type RecurrencesRunnerConfig struct {
+	// BatchSize is the maximum number of recurrences processed per ledger on each run
+	BatchSize int
+	// AccountsBatchSize is the maximum number of accounts of an occurrence processed in a single sql transaction
+	AccountsBatchSize int
+	Schedule          cron.Schedule
+}
+
+// RecurrencesRunner creates the transactions of the due occurrences of the recurrences.
+// A recurrence late by several occurrences, after a downtime of the worker, catches up one occurrence after the other.
+type RecurrencesRunner struct {
+	stopChannel chan chan struct{}
+	logger      logging.Logger
+	db          *bun.DB
+	driver      *driver.Driver
+	cfg         RecurrencesRunnerConfig
+	tracer      trace.Tracer
+
+	// systemController provides the ledger controllers, so the transactions are created
+	// with the same guarantees as from the api (events, schema enforcement, retries, traces)
+	systemController systemcontroller.Controller
+}
+
+func (r *RecurrencesRunner) Name() string {
+	return "Recurrences runner"
+}
+
+func (r *RecurrencesRunner) Run(ctx context.Context) error {
+	now := time.Now()
+	next := r.cfg.Schedule.Next(now).Sub(now)
+
+	for {
+		select {
+		case <-time.After(next):
+			if err := r.run(ctx); err != nil {
+				r.logger.Errorf("error running recurrences: %v", err)
+			}
+
+			now = time.Now()
+			next = r.cfg.Schedule.Next(now).Sub(now)
+		case ch := <-r.stopChannel:
+			close(ch)
+			return nil
+		}
+	}
+}
+
+func (r *RecurrencesRunner) Stop(ctx context.Context) error {
+	ch := make(chan struct{})
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case r.stopChannel <- ch:
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ch:
+		}
+	}
+	return nil
+}
+
+func (r *RecurrencesRunner) run(ctx context.Context) error {
+	ctx, span := r.tracer.Start(ctx, "Run")
+	defer span.End()
+
+	systemStore := systemstore.New(r.db)
+	return storagecommon.Iterate(
+		ctx,
+		storagecommon.InitialPaginatedQuery[systemstore.ListLedgersQueryPayload]{},
+		systemStore.Ledgers().Paginate,
+		func(cursor *paginate.Cursor[ledger.Ledger]) error {
+			for _, l := range cursor.Data {
+				if err := r.processLedger(ctx, l); err != nil {
+					// Continue with other ledgers even if one fails
+					r.logger.Errorf("error running recurrences of ledger %s: %v", l.Name, err)
+				}
+			}
+			return nil
+		},
+	)
+}
+
+func (r *RecurrencesRunner) processLedger(ctx context.Context, l ledger.Ledger) error {
+	ctx, span := r.tracer.Start(ctx, "RunForLedger")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("ledger", l.Name))
+
+	store, _, err := r.driver.OpenLedger(ctx, l.Name)
+	if err != nil {
+		return fmt.Errorf("opening ledger: %w", err)
+	}
+
+	now := libtime.Now()
+	recurrences, err := store.ListDueRecurrences(ctx, now, r.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("listing due recurrences: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("recurrences", len(recurrences)))
+
+	ctrl, err := r.systemController.GetLedgerController(ctx, l.Name)
+	if err != nil {
+		return fmt.Errorf("getting ledger controller: %w", err)
+	}
+	for _, recurrence := range recurrences {
+		// Each call processes a batch of accounts, the occurrence being completed with the last batch
+		for {
+			ret, _, err := ctrl.RunRecurrence(ctx, recurrence.ID, r.cfg.AccountsBatchSize)
+			if err != nil {
+				// The recurrence has been deleted since it has been listed
+				if errors.Is(err, postgres.ErrNotFound) {
+					break
+				}
+				// Continue with the other recurrences, the failing one is retried on the next run
+				r.logger.Errorf("error running recurrence %s of ledger %s: %v", recurrence.ID, l.Name, err)
+				break
+			}
+			if !ret.IsDue(now) {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// NewRecurrencesRunner creates a RecurrencesRunner processing the due recurrences of all the ledgers of the driver.
+func NewRecurrencesRunner(logger logging.Logger, db *bun.DB, driver *driver.Driver, systemController systemcontroller.Controller, cfg RecurrencesRunnerConfig, opts ...RecurrencesRunnerOption) *RecurrencesRunner {
+	ret := &RecurrencesRunner{
+		stopChannel: make(chan chan struct{}),
+		logger:      logger,
+		db:          db,
+		driver:      driver,
+		cfg:         cfg,
+
+		systemController: systemController,
+	}
+
+	for _, opt := range append(defaultRecurrencesRunnerOptions, opts...) {
+		opt(ret)
+	}
+
+	return ret
+}
+
+type RecurrencesRunnerOption func(*RecurrencesRunner)
+
+func WithRecurrencesRunnerTracer(tracer trace.Tracer) RecurrencesRunnerOption {
+	return func(r *RecurrencesRunner) {
+		r.tracer = tracer
+	}
+}
+
+var defaultRecurrencesRunnerOptions = []RecurrencesRunnerOption{
+	WithRecurrencesRunnerTracer(noop.Tracer{}),
+}
+
+func NewRecurrencesRunnerModule(cfg RecurrencesRunnerConfig) fx.Option {
+	return fx.Options(
+		fx.Provide(func(logger logging.Logger, db *bun.DB, driver *driver.Driver, systemController systemcontroller.Controller) (*RecurrencesRunner, error) {
+			return NewRecurrencesRunner(logger, db, driver, systemController, cfg), nil
+		}),
+		fx.Invoke(func(lc fx.Lifecycle, recurrencesRunner *RecurrencesRunner) {
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					go func() {
+						if err := recurrencesRunner.Run(context.WithoutCancel(ctx)); err != nil {
+							panic(err)
+						}
+					}()
+
+					return nil
+				},
+				OnStop: recurrencesRunner.Stop,
+			})
+		}),
+	)
+}
@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/fx"
+
+	logging "github.com/formancehq/go-libs/v5/pkg/observe/log"
+	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
+	"github.com/formancehq/go-libs/v5/pkg/storage/postgres"
+
+	ledger "github.com/formancehq/ledger/internal"
+	systemcontroller "github.com/formancehq/ledger/internal/controller/system"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+	"github.com/formancehq/ledger/internal/storage/driver"
+	systemstore "github.com/formancehq/ledger/internal/storage/system"
+)
+
+type RevertJobsRunnerConfig struct {
+	// BatchSize is the maximum number of transactions processed per job on each run
+	BatchSize int
+	Schedule  cron.Schedule
+}
+
+// RevertJobsRunner processes the revert jobs created on the ledgers.
+// Each run reverts the next batch of transactions of the unfinished jobs.
+type RevertJobsRunner struct {
+	stopChannel chan chan struct{}
+	logger      logging.Logger
+	db          *bun.DB
+	driver      *driver.Driver
+	cfg         RevertJobsRunnerConfig
+	tracer      trace.Tracer
+
+	// systemController provides the ledger controllers, so the transactions are reverted
+	// with the same guarantees as from the api (events, schema enforcement, retries, traces)
+	systemController systemcontroller.Controller
+}
+
+func (r *RevertJobsRunner) Name() string {
+	return "Revert jobs runner"
+}
+
+func (r *RevertJobsRunner) Run(ctx context.Context) error {
+	now := time.Now()
+	next := r.cfg.Schedule.Next(now).Sub(now)
+
+	for {
+		select {
+		case <-time.After(next):
+			if err := r.run(ctx); err != nil {
+				r.logger.Errorf("error running revert jobs: %v", err)
+			}
+
+			now = time.Now()
+			next = r.cfg.Schedule.Next(now).Sub(now)
+		case ch := <-r.stopChannel:
+			close(ch)
+			return nil
+		}
+	}
+}
+
+func (r *RevertJobsRunner) Stop(ctx context.Context) error {
+	ch := make(chan struct{})
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case r.stopChannel <- ch:
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ch:
+		}
+	}
+	return nil
+}
+
+func (r *RevertJobsRunner) run(ctx context.Context) error {
+	ctx, span := r.tracer.Start(ctx, "Run")
+	defer span.End()
+
+	systemStore := systemstore.New(r.db)
+	return storagecommon.Iterate(
+		ctx,
+		storagecommon.InitialPaginatedQuery[systemstore.ListLedgersQueryPayload]{},
+		systemStore.Ledgers().Paginate,
+		func(cursor *paginate.Cursor[ledger.Ledger]) error {
+			for _, l := range cursor.Data {
+				if err := r.processLedger(ctx, l); err != nil {
+					// Continue with other ledgers even if one fails
+					r.logger.Errorf("error running revert jobs of ledger %s: %v", l.Name, err)
+				}
+			}
+			return nil
+		},
+	)
+}
+
+func (r *RevertJobsRunner) processLedger(ctx context.Context, l ledger.Ledger) error {
+	ctx, span := r.tracer.Start(ctx, "RunForLedger")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("ledger", l.Name))
+
+	store, _, err := r.driver.OpenLedger(ctx, l.Name)
+	if err != nil {
+		return fmt.Errorf("opening ledger: %w", err)
+	}
+
+	jobs, err := store.ListUnfinishedRevertJobs(ctx)
+	if err != nil {
+		return fmt.Errorf("listing unfinished revert jobs: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("jobs", len(jobs)))
+
+	ctrl, err := r.systemController.GetLedgerController(ctx, l.Name)
+	if err != nil {
+		return fmt.Errorf("getting ledger controller: %w", err)
+	}
+	for _, job := range jobs {
+		if _, _, err := ctrl.RunRevertJob(ctx, job.ID, r.cfg.BatchSize); err != nil {
+			// The job is being run by another worker
+			if errors.Is(err, postgres.ErrNotFound) {
+				continue
+			}
+			// Continue with the other jobs, the failing one is retried on the next run
+			r.logger.Errorf("error running revert job %s of ledger %s: %v", job.ID, l.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// NewRevertJobsRunner creates a RevertJobsRunner processing the revert jobs of all the ledgers of the driver.
+func NewRevertJobsRunner(logger logging.Logger, db *bun.DB, driver *driver.Driver, systemController systemcontroller.Controller, cfg RevertJobsRunnerConfig, opts ...RevertJobsRunnerOption) *RevertJobsRunner {
+	ret := &RevertJobsRunner{
+		stopChannel:      make(chan chan struct{}),
+		logger:           logger,
+		db:               db,
+		driver:           driver,
+		cfg:              cfg,
+		systemController: systemController,
+	}
+
+	for _, opt := range append(defaultRevertJobsRunnerOptions, opts...) {
+		opt(ret)
+	}
+
+	return ret
+}
+
+type RevertJobsRunnerOption func(*RevertJobsRunner)
+
+func WithRevertJobsRunnerTracer(tracer trace.Tracer) RevertJobsRunnerOption {
+	return func(r *RevertJobsRunner) {
+		r.tracer = tracer
+	}
+}
+
+var defaultRevertJobsRunnerOptions = []RevertJobsRunnerOption{
+	WithRevertJobsRunnerTracer(noop.Tracer{}),
+}
+
+func NewRevertJobsRunnerModule(cfg RevertJobsRunnerConfig) fx.Option {
+	return fx.Options(
+		fx.Provide(func(logger logging.Logger, db *bun.DB, driver *driver.Driver, systemController systemcontroller.Controller) (*RevertJobsRunner, error) {
+			return NewRevertJobsRunner(logger, db, driver, systemController, cfg), nil
+		}),
+		fx.Invoke(func(lc fx.Lifecycle, revertJobsRunner *RevertJobsRunner) {
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					go func() {
+						if err := revertJobsRunner.Run(context.WithoutCancel(ctx)); err != nil {
+							panic(err)
+						}
+					}()
+
+					return nil
+				},
+				OnStop: revertJobsRunner.Stop,
+			})
+		}),
+	)
+}
@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/fx"
+
+	logging "github.com/formancehq/go-libs/v5/pkg/observe/log"
+	"github.com/formancehq/go-libs/v5/pkg/storage/bun/paginate"
+	libtime "github.com/formancehq/go-libs/v5/pkg/types/time"
+
+	ledger "github.com/formancehq/ledger/internal"
+	systemcontroller "github.com/formancehq/ledger/internal/controller/system"
+	storagecommon "github.com/formancehq/ledger/internal/storage/common"
+	"github.com/formancehq/ledger/internal/storage/driver"
+	systemstore "github.com/formancehq/ledger/internal/storage/system"
+)
+
+type ScheduledTransactionsRunnerConfig struct {
+	// BatchSize is the maximum number of scheduled transactions executed per ledger on each run
+	BatchSize int
+	Schedule  cron.Schedule
+}
+
+// ScheduledTransactionsRunner creates the scheduled transactions which are due.
+// The outcome of each execution, the created transaction or the reason of the failure, is recorded on the scheduled transaction.
+type ScheduledTransactionsRunner struct {
+	stopChannel chan chan struct{}
+	logger      logging.Logger
+	db          *bun.DB
+	driver      *driver.Driver
+	cfg         ScheduledTransactionsRunnerConfig
+	tracer      trace.Tracer
+
+	// systemController provides the ledger controllers, so the transactions are created
+	// with the same guarantees as from the api (events, schema enforcement, retries, traces)
+	systemController systemcontroller.Controller
+}
+
+func (r *ScheduledTransactionsRunner) Name() string {
+	return "Scheduled transactions runner"
+}
+
+func (r *ScheduledTransactionsRunner) Run(ctx context.Context) error {
+	now := time.Now()
+	next := r.cfg.Schedule.Next(now).Sub(now)
+
+	for {
+		select {
+		case <-time.After(next):
+			if err := r.run(ctx); err != nil {
+				r.logger.Errorf("error running scheduled transactions: %v", err)
+			}
+
+			now = time.Now()
+			next = r.cfg.Schedule.Next(now).Sub(now)
+		case ch := <-r.stopChannel:
+			close(ch)
+			return nil
+		}
+	}
+}
+
+func (r *ScheduledTransactionsRunner) Stop(ctx context.Context) error {
+	ch := make(chan struct{})
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case r.stopChannel <- ch:
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ch:
+		}
+	}
+	return nil
+}
+
+func (r *ScheduledTransactionsRunner) run(ctx context.Context) error {
+	ctx, span := r.tracer.Start(ctx, "Run")
+	defer span.End()
+
+	systemStore := systemstore.New(r.db)
+	return storagecommon.Iterate(
+		ctx,
+		storagecommon.InitialPaginatedQuery[systemstore.ListLedgersQueryPayload]{},
+		systemStore.Ledgers().Paginate,
+		func(cursor *paginate.Cursor[ledger.Ledger]) error {
+			for _, l := range cursor.Data {
+				if err := r.processLedger(ctx, l); err != nil {
+					// Continue with other ledgers even if one fails
+					r.logger.Errorf("error executing scheduled transactions of ledger %s: %v", l.Name, err)
+				}
+			}
+			return nil
+		},
+	)
+}
+
+func (r *ScheduledTransactionsRunner) processLedger(ctx context.Context, l ledger.Ledger) error {
+	ctx, span := r.tracer.Start(ctx, "RunForLedger")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("ledger", l.Name))
+
+	store, _, err := r.driver.OpenLedger(ctx, l.Name)
+	if err != nil {
+		return fmt.Errorf("opening ledger: %w", err)
+	}
+
+	scheduledTransactions, err := store.ListDueScheduledTransactions(ctx, libtime.Now(), r.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("listing due scheduled transactions: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("scheduled_transactions", len(scheduledTransactions)))
+
+	ctrl, err := r.systemController.GetLedgerController(ctx, l.Name)
+	if err != nil {
+		return fmt.Errorf("getting ledger controller: %w", err)
+	}
+	for _, scheduledTransaction := range scheduledTransactions {
+		if _, _, err := ctrl.ExecuteScheduledTransaction(ctx, scheduledTransaction.ID); err != nil {
+			// Continue with the other transactions, the failing one is retried on the next run
+			r.logger.Errorf("error executing scheduled transaction %s of ledger %s: %v", scheduledTransaction.ID, l.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// NewScheduledTransactionsRunner creates a ScheduledTransactionsRunner executing the due scheduled transactions of all the ledgers of the driver.
+func NewScheduledTransactionsRunner(logger logging.Logger, db *bun.DB, driver *driver.Driver, systemController systemcontroller.Controller, cfg ScheduledTransactionsRunnerConfig, opts ...ScheduledTransactionsRunnerOption) *ScheduledTransactionsRunner {
+	ret := &ScheduledTransactionsRunner{
+		stopChannel:      make(chan chan struct{}),
+		logger:           logger,
+		db:               db,
+		driver:           driver,
+		cfg:              cfg,
+		systemController: systemController,
+	}
+
+	for _, opt := range append(defaultScheduledTransactionsRunnerOptions, opts...) {
+		opt(ret)
+	}
+
+	return ret
+}
+
+type ScheduledTransactionsRunnerOption func(*ScheduledTransactionsRunner)
+
+func WithScheduledTransactionsRunnerTracer(tracer trace.Tracer) ScheduledTransactionsRunnerOption {
+	return func(r *ScheduledTransactionsRunner) {
+		r.tracer = tracer
+	}
+}
+
+var defaultScheduledTransactionsRunnerOptions = []ScheduledTransactionsRunnerOption{
+	WithScheduledTransactionsRunnerTracer(noop.Tracer{}),
+}
+
+func NewScheduledTransactionsRunnerModule(cfg ScheduledTransactionsRunnerConfig) fx.Option {
+	return fx.Options(
+		fx.Provide(func(logger logging.Logger, db *bun.DB, driver *driver.Driver, systemController systemcontroller.Controller) (*ScheduledTransactionsRunner, error) {
+			return NewScheduledTransactionsRunner(logger, db, driver, systemController, cfg), nil
+		}),
+		fx.Invoke(func(lc fx.Lifecycle, scheduledTransactionsRunner *ScheduledTransactionsRunner) {
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					go func() {
+						if err := scheduledTransactionsRunner.Run(context.WithoutCancel(ctx)); err != nil {
+							panic(err)
+						}
+					}()
+
+					return nil
+				},
+				OnStop: scheduledTransactionsRunner.Stop,
+			})
+		}),
+	)
+}
@@ -2,6 +2,8 @@ package ledger
 
 import (
 	"encoding/json"
+	"fmt"
+	"math/big"
 	"slices"
 	"sort"
 
@@ -43,6 +45,9 @@ type Transaction struct {
 	InsertedAt time.Time  `json:"insertedAt,omitempty" bun:"inserted_at,type:timestamp without time zone,nullzero"`
 	UpdatedAt  time.Time  `json:"updatedAt,omitempty" bun:"updated_at,type:timestamp without time zone,nullzero"`
 	RevertedAt *time.Time `json:"revertedAt,omitempty" bun:"reverted_at,type:timestamp without time zone"`
+	// RevertedAmounts are the amounts already reverted by partial reverts, by index of the postings.
+	// It is nil until the transaction is partially reverted.
+	RevertedAmounts []*big.Int `json:"revertedAmounts,omitempty" bun:"reverted_amounts,type:jsonb,nullzero"`
 	// PostCommitVolumes are the volumes of each account/asset after a transaction has been committed.
 	// Those volumes will never change as those are computed in flight.
 	PostCommitVolumes PostCommitVolumes `json:"postCommitVolumes,omitempty" bun:"post_commit_volumes,type:jsonb"`
@@ -59,10 +64,111 @@ func (Transaction) JSONSchemaExtend(schema *jsonschema.Schema) {
 	postCommitVolumesSchema, _ := schema.Properties.Get("postCommitVolumes")
 	schema.Properties.Set("preCommitVolumes", postCommitVolumesSchema)
 	schema.Properties.Set("preCommitEffectiveVolumes", postCommitVolumesSchema)
+	revertedAmountsSchema, _ := schema.Properties.Get("revertedAmounts")
+	schema.Properties.Set("revertibleAmounts", revertedAmountsSchema)
 }
 
+// Reverse returns the transaction reverting the amounts of the postings not already reverted by partial reverts
 func (tx Transaction) Reverse() Transaction {
-	ret := NewTransaction().WithPostings(tx.Postings.Reverse()...)
+	if tx.RevertedAmounts == nil {
+		return NewTransaction().WithPostings(tx.Postings.Reverse()...)
+	}
+
+	postings := make(Postings, 0, len(tx.Postings))
+	for i, posting := range tx.Postings {
+		remaining := tx.remainingAmount(i)
+		if remaining.Sign() == 0 {
+			continue
+		}
+		posting.Amount = remaining
+		postings = append(postings, posting)
+	}
+
+	return NewTransaction().WithPostings(postings.Reverse()...)
+}
+
+// PartiallyReverse returns the transaction reverting the given amounts, by index of the postings,
+// and the amounts reverted once it is committed.
+// The reverted amounts of a posting cannot exceed its amount.
+func (tx Transaction) PartiallyReverse(amounts map[int]*big.Int) (Transaction, []*big.Int, error) {
+	for i := range amounts {
+		if i < 0 || i >= len(tx.Postings) {
+			return Transaction{}, nil, fmt.Errorf("posting %d does not exist, the transaction has %d postings", i, len(tx.Postings))
+		}
+	}
+
+	revertedAmounts := make([]*big.Int, len(tx.Postings))
+	postings := make(Postings, 0, len(amounts))
+	for i, posting := range tx.Postings {
+		revertedAmounts[i] = new(big.Int).Sub(posting.Amount, tx.remainingAmount(i))
+
+		amount, ok := amounts[i]
+		if !ok || amount.Sign() == 0 {
+			continue
+		}
+		if amount.Sign() < 0 {
+			return Transaction{}, nil, fmt.Errorf("posting %d: the reverted amount must be positive", i)
+		}
+		if remaining := tx.remainingAmount(i); amount.Cmp(remaining) > 0 {
+			return Transaction{}, nil, fmt.Errorf("posting %d: cannot revert %s, only %s remains", i, amount, remaining)
+		}
+
+		revertedAmounts[i].Add(revertedAmounts[i], amount)
+		posting.Amount = new(big.Int).Set(amount)
+		postings = append(postings, posting)
+	}
+	if len(postings) == 0 {
+		return Transaction{}, nil, fmt.Errorf("nothing to revert")
+	}
+
+	return NewTransaction().WithPostings(postings.Reverse()...), revertedAmounts, nil
+}
+
+// ScaledRevertibleAmounts returns the remaining amounts of the postings multiplied by the ratio, rounded down.
+// The ratio must be in (0, 1].
+func (tx Transaction) ScaledRevertibleAmounts(ratio *big.Rat) (map[int]*big.Int, error) {
+	if ratio.Sign() <= 0 || ratio.Cmp(big.NewRat(1, 1)) > 0 {
+		return nil, fmt.Errorf("the ratio must be greater than 0 and lower than or equal to 1, got %s", ratio.RatString())
+	}
+
+	ret := make(map[int]*big.Int)
+	for i := range tx.Postings {
+		amount := new(big.Int).Mul(tx.remainingAmount(i), ratio.Num())
+		ret[i] = amount.Quo(amount, ratio.Denom())
+	}
+
+	return ret, nil
+}
+
+// RevertibleAmounts returns the amounts which can still be reverted, by index of the postings
+func (tx Transaction) RevertibleAmounts() []*big.Int {
+	ret := make([]*big.Int, len(tx.Postings))
+	for i := range tx.Postings {
+		if tx.IsReverted() {
+			ret[i] = new(big.Int)
+			continue
+		}
+		ret[i] = tx.remainingAmount(i)
+	}
+	return ret
+}
+
+// IsFullyReverted indicates if all the amounts of the postings have been reverted by partial reverts
+func (tx Transaction) IsFullyReverted() bool {
+	for i := range tx.Postings {
+		if tx.remainingAmount(i).Sign() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// remainingAmount returns the amount of a posting not reverted by partial reverts
+func (tx Transaction) remainingAmount(index int) *big.Int {
+	ret := new(big.Int).Set(tx.Postings[index].Amount)
+	if index < len(tx.RevertedAmounts) && tx.RevertedAmounts[index] != nil {
+		ret.Sub(ret, tx.RevertedAmounts[index])
+	}
 	return ret
 }
 
@@ -101,6 +207,20 @@ func (tx Transaction) WithTemplate(template string) Transaction {
 	return tx
 }
 
+func (tx Transaction) InvolvedSources() map[string][]string {
+	ret := make(map[string][]string)
+	for _, posting := range tx.Postings {
+		ret[posting.Source] = append(ret[posting.Source], posting.Asset)
+	}
+
+	for account, assets := range ret {
+		sort.Strings(assets)
+		ret[account] = slices.Compact(assets)
+	}
+
+	return ret
+}
+
 func (tx Transaction) InvolvedDestinations() map[string][]string {
 	ret := make(map[string][]string)
 	for _, posting := range tx.Postings {
@@ -190,17 +310,25 @@ func (tx Transaction) VolumeUpdates() []AccountsVolumes {
 func (tx Transaction) MarshalJSON() ([]byte, error) {
 	type Aux Transaction
 
+	// the revertible amounts are only exposed once the transaction has been partially reverted
+	var revertibleAmounts []*big.Int
+	if tx.RevertedAmounts != nil {
+		revertibleAmounts = tx.RevertibleAmounts()
+	}
+
 	return json.Marshal(struct {
 		Aux
 
 		Reverted                  bool              `json:"reverted"`
 		PreCommitVolumes          PostCommitVolumes `json:"preCommitVolumes,omitempty"`
 		PreCommitEffectiveVolumes PostCommitVolumes `json:"preCommitEffectiveVolumes,omitempty"`
+		RevertibleAmounts         []*big.Int        `json:"revertibleAmounts,omitempty"`
 	}{
 		Aux:                       Aux(tx),
 		Reverted:                  tx.RevertedAt != nil && !tx.RevertedAt.IsZero(),
 		PreCommitVolumes:          tx.PostCommitVolumes.SubtractPostings(tx.Postings),
 		PreCommitEffectiveVolumes: tx.PostCommitEffectiveVolumes.SubtractPostings(tx.Postings),
+		RevertibleAmounts:         revertibleAmounts,
 	})
 }
 
@@ -1,8 +1,13 @@
 package ledger
 
 import (
+	"encoding/json"
 	"fmt"
 	"slices"
+
+	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+
+	"github.com/formancehq/ledger/internal/machine"
 )
 
 type RuntimeType string
@@ -12,19 +17,251 @@ const (
 	RuntimeExperimentalInterpreter RuntimeType = "experimental-interpreter"
 )
 
+// TransactionTemplateMetadata declares a metadata of the transactions created with a template.
+// It supports the same constraints as the metadata of the chart of accounts.
+type TransactionTemplateMetadata = ChartAccountMetadata
+
+// TransactionTemplateVarTypes are the types of variables a transaction template can declare
+var TransactionTemplateVarTypes = map[string]machine.Type{
+	machine.TypeMonetary.String(): machine.TypeMonetary,
+	machine.TypeAccount.String():  machine.TypeAccount,
+	machine.TypePortion.String():  machine.TypePortion,
+	machine.TypeNumber.String():   machine.TypeNumber,
+	machine.TypeString.String():   machine.TypeString,
+}
+
+// TransactionTemplateVar declares a variable of a transaction template.
+// Values use the same format as the numscript variables (ex: `USD/2 100` for a monetary).
+type TransactionTemplateVar struct {
+	Type    string  `json:"type"`
+	Default *string `json:"default,omitempty"`
+	// Chart restricts an account variable to the addresses of a path of the chart (ex: `users:$userID:main`)
+	Chart string `json:"chart,omitempty"`
+}
+
+func (v *TransactionTemplateVar) UnmarshalJSON(b []byte) error {
+	// handle plain string as type
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		v.Type = s
+		return nil
+	}
+	type transactionTemplateVar TransactionTemplateVar
+	return json.Unmarshal(b, (*transactionTemplateVar)(v))
+}
+
+func (v TransactionTemplateVar) Validate(chart ChartOfAccounts) error {
+	if _, ok := TransactionTemplateVarTypes[v.Type]; !ok {
+		return fmt.Errorf("invalid type `%s`, expected one of `monetary`, `account`, `portion`, `number`, `string`", v.Type)
+	}
+	if v.Chart != "" {
+		if v.Type != machine.TypeAccount.String() {
+			return fmt.Errorf("chart constraint can only be used on account variables")
+		}
+		segment, err := chart.FindSegment(v.Chart)
+		if err != nil {
+			return fmt.Errorf("invalid chart constraint: %w", err)
+		}
+		if segment.Account == nil {
+			return fmt.Errorf("invalid chart constraint: `%s` is not an account", v.Chart)
+		}
+	}
+	if v.Default != nil {
+		if err := v.ValidateValue(chart, *v.Default); err != nil {
+			return fmt.Errorf("invalid default: %w", err)
+		}
+	}
+	return nil
+}
+
+// ValidateValue checks a value passed for the variable
+func (v TransactionTemplateVar) ValidateValue(chart ChartOfAccounts, value string) error {
+	if _, err := machine.NewValueFromString(TransactionTemplateVarTypes[v.Type], value); err != nil {
+		return fmt.Errorf("invalid %s: %w", v.Type, err)
+	}
+	if v.Chart != "" && !chart.MatchPath(v.Chart, value) {
+		return fmt.Errorf("account `%s` does not match `%s`", value, v.Chart)
+	}
+	return nil
+}
+
 type TransactionTemplate struct {
 	Description string      `json:"description"`
 	Script      string      `json:"script"`
 	Runtime     RuntimeType `json:"runtime,omitempty"`
+	// Vars declares the variables of the script.
+	// When empty, variables are only checked by the runtime.
+	Vars map[string]TransactionTemplateVar `json:"vars,omitempty"`
+	// Metadata declares the metadata allowed on the transactions created with the template.
+	// When empty, any metadata is allowed.
+	Metadata map[string]TransactionTemplateMetadata `json:"metadata,omitempty"`
+}
+
+// ResolveVars validates the variables passed to the template and fills the missing ones with their default value.
+// The variables are resolved even when one of them is invalid, along with the first error,
+// so the caller can still run the script when the schema is not enforced.
+func (t TransactionTemplate) ResolveVars(template string, chart ChartOfAccounts, vars map[string]string) (map[string]string, error) {
+	if len(t.Vars) == 0 {
+		return vars, nil
+	}
+	var err error
+	resolved := make(map[string]string, len(t.Vars))
+	for _, name := range sortedKeys(vars) {
+		resolved[name] = vars[name]
+		if err != nil {
+			continue
+		}
+		decl, ok := t.Vars[name]
+		if !ok {
+			err = ErrInvalidTransactionVariable{
+				template: template,
+				name:     name,
+				err:      fmt.Errorf("variable is not declared by the template"),
+			}
+		} else if validationErr := decl.ValidateValue(chart, vars[name]); validationErr != nil {
+			err = ErrInvalidTransactionVariable{
+				template: template,
+				name:     name,
+				err:      validationErr,
+			}
+		}
+	}
+	for _, name := range sortedKeys(t.Vars) {
+		if _, ok := resolved[name]; ok {
+			continue
+		}
+		if t.Vars[name].Default == nil {
+			if err == nil {
+				err = ErrInvalidTransactionVariable{
+					template: template,
+					name:     name,
+					err:      fmt.Errorf("variable is required"),
+				}
+			}
+			continue
+		}
+		resolved[name] = *t.Vars[name].Default
+	}
+	return resolved, err
+}
+
+// DefaultMetadata returns the default values of the metadata declared by the template
+func (t TransactionTemplate) DefaultMetadata() metadata.Metadata {
+	defaultMetadata := metadata.Metadata{}
+	for key, value := range t.Metadata {
+		if value.Default != nil {
+			defaultMetadata[key] = *value.Default
+		}
+	}
+	return defaultMetadata
+}
+
+// ValidateMetadata checks the metadata of a transaction created with the template
+func (t TransactionTemplate) ValidateMetadata(template string, m metadata.Metadata) error {
+	if len(t.Metadata) == 0 {
+		return nil
+	}
+	for _, key := range sortedKeys(m) {
+		schema, ok := t.Metadata[key]
+		if !ok {
+			return ErrInvalidTransactionMetadata{
+				template: template,
+				key:      key,
+				err:      fmt.Errorf("metadata is not declared by the template"),
+			}
+		}
+		if err := schema.ValidateValue(m[key]); err != nil {
+			return ErrInvalidTransactionMetadata{
+				template: template,
+				key:      key,
+				err:      err,
+			}
+		}
+	}
+	for _, key := range sortedKeys(t.Metadata) {
+		if _, ok := m[key]; !ok && t.Metadata[key].Required {
+			return ErrInvalidTransactionMetadata{
+				template: template,
+				key:      key,
+				err:      fmt.Errorf("metadata is required"),
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateMetadataUpdate checks metadata added on an existing transaction created with the template
+func (t TransactionTemplate) ValidateMetadataUpdate(template string, existing, m metadata.Metadata) error {
+	if len(t.Metadata) == 0 {
+		return nil
+	}
+	merged := metadata.Metadata{}
+	for key, value := range existing {
+		merged[key] = value
+	}
+	for key, value := range m {
+		if previous, ok := existing[key]; ok && previous != value && t.Metadata[key].Immutable {
+			return ErrInvalidTransactionMetadata{
+				template: template,
+				key:      key,
+				err:      fmt.Errorf("metadata is immutable"),
+			}
+		}
+		merged[key] = value
+	}
+	return t.ValidateMetadata(template, merged)
+}
+
+// ValidateMetadataDeletion checks a metadata can be deleted from a transaction created with the template
+func (t TransactionTemplate) ValidateMetadataDeletion(template, key string) error {
+	schema, ok := t.Metadata[key]
+	if !ok {
+		return nil
+	}
+	if schema.Required {
+		return ErrInvalidTransactionMetadata{
+			template: template,
+			key:      key,
+			err:      fmt.Errorf("metadata is required"),
+		}
+	}
+	if schema.Immutable {
+		return ErrInvalidTransactionMetadata{
+			template: template,
+			key:      key,
+			err:      fmt.Errorf("metadata is immutable"),
+		}
+	}
+	return nil
 }
 
 type TransactionTemplates map[string]TransactionTemplate
 
-func (t TransactionTemplates) Validate() error {
-	for _, t := range t {
+// HasMetadata indicates if any template declares transaction metadata
+func (t TransactionTemplates) HasMetadata() bool {
+	for _, template := range t {
+		if len(template.Metadata) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (t TransactionTemplates) Validate(chart ChartOfAccounts) error {
+	for id, t := range t {
 		if !slices.Contains([]RuntimeType{"", RuntimeMachine, RuntimeExperimentalInterpreter}, t.Runtime) {
 			return fmt.Errorf("unexpected runtime `%s`: should be `%s` or `%s`", t.Runtime, RuntimeMachine, RuntimeExperimentalInterpreter)
 		}
+		for name, decl := range t.Vars {
+			if err := decl.Validate(chart); err != nil {
+				return fmt.Errorf("invalid variable `%s` of template `%s`: %v", name, id, err)
+			}
+		}
+		for key, schema := range t.Metadata {
+			if err := schema.Validate(); err != nil {
+				return fmt.Errorf("invalid metadata `%s` of template `%s`: %v", key, id, err)
+			}
+		}
 	}
 	return nil
 }
@@ -0,0 +1,181 @@
+package ledger
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/formancehq/go-libs/v5/pkg/types/metadata"
+	"github.com/formancehq/go-libs/v5/pkg/types/pointer"
+)
+
+func TestTransactionTemplatesValidation(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		source        string
+		expectedError string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name: "valid template",
+			source: `{
+				"PAYMENT": {
+					"script": "...",
+					"metadata": {
+						"order_id": { "required": true },
+						"channel": { "enum": ["web", "mobile"], "default": "web" }
+					}
+				}
+			}`,
+		},
+		{
+			name:          "invalid runtime",
+			source:        `{ "PAYMENT": { "script": "...", "runtime": "other" } }`,
+			expectedError: "unexpected runtime `other`",
+		},
+		{
+			name: "invalid metadata default",
+			source: `{
+				"PAYMENT": {
+					"script": "...",
+					"metadata": {
+						"channel": { "enum": ["web", "mobile"], "default": "store" }
+					}
+				}
+			}`,
+			expectedError: "invalid metadata `channel` of template `PAYMENT`: invalid default",
+		},
+		{
+			name: "valid variables",
+			source: `{
+				"PAYMENT": {
+					"script": "...",
+					"vars": {
+						"amount": { "type": "monetary", "default": "USD/2 100" },
+						"source": { "type": "account", "chart": "users:$userID:main" },
+						"fee": "portion",
+						"reference": "string"
+					}
+				}
+			}`,
+		},
+		{
+			name:          "invalid variable type",
+			source:        `{ "PAYMENT": { "script": "...", "vars": { "amount": "int" } } }`,
+			expectedError: "invalid variable `amount` of template `PAYMENT`: invalid type `int`",
+		},
+		{
+			name:          "invalid variable default",
+			source:        `{ "PAYMENT": { "script": "...", "vars": { "fee": { "type": "portion", "default": "150%" } } } }`,
+			expectedError: "invalid variable `fee` of template `PAYMENT`: invalid default",
+		},
+		{
+			name:          "chart constraint on non account variable",
+			source:        `{ "PAYMENT": { "script": "...", "vars": { "reference": { "type": "string", "chart": "bank" } } } }`,
+			expectedError: "chart constraint can only be used on account variables",
+		},
+		{
+			name:          "chart constraint on unknown path",
+			source:        `{ "PAYMENT": { "script": "...", "vars": { "source": { "type": "account", "chart": "users:$id:main" } } } }`,
+			expectedError: "no variable segment `$id` under `users`",
+		},
+		{
+			name:          "chart constraint on non account path",
+			source:        `{ "PAYMENT": { "script": "...", "vars": { "source": { "type": "account", "chart": "users" } } } }`,
+			expectedError: "`users` is not an account",
+		},
+	} {
+		var templates TransactionTemplates
+		require.NoError(t, json.Unmarshal([]byte(tc.source), &templates), tc.name)
+
+		err := templates.Validate(testChart())
+		if tc.expectedError == "" {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.ErrorContains(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
+
+func TestTransactionTemplateMetadataValidation(t *testing.T) {
+	t.Parallel()
+
+	template := TransactionTemplate{
+		Metadata: map[string]TransactionTemplateMetadata{
+			"order_id": {
+				Required:  true,
+				Immutable: true,
+			},
+			"amount_cents": {
+				Type: "int",
+			},
+			"channel": {
+				Enum:    []string{"web", "mobile"},
+				Default: pointer.For("web"),
+			},
+		},
+	}
+
+	require.Equal(t, metadata.Metadata{"channel": "web"}, template.DefaultMetadata())
+
+	type testCase struct {
+		name          string
+		existing      metadata.Metadata
+		metadata      metadata.Metadata
+		expectedError string
+	}
+
+	for _, tc := range []testCase{
+		{
+			name:     "valid metadata",
+			metadata: metadata.Metadata{"order_id": "1", "amount_cents": "100"},
+		},
+		{
+			name:          "missing required metadata",
+			metadata:      metadata.Metadata{"amount_cents": "100"},
+			expectedError: "invalid metadata `order_id` for transaction template `PAYMENT`: metadata is required",
+		},
+		{
+			name:          "undeclared metadata",
+			metadata:      metadata.Metadata{"order_id": "1", "foo": "bar"},
+			expectedError: "invalid metadata `foo` for transaction template `PAYMENT`: metadata is not declared by the template",
+		},
+		{
+			name:          "invalid type",
+			metadata:      metadata.Metadata{"order_id": "1", "amount_cents": "1.5"},
+			expectedError: "invalid metadata `amount_cents` for transaction template `PAYMENT`: value `1.5` is not an integer",
+		},
+		{
+			name:     "update without required metadata",
+			existing: metadata.Metadata{"order_id": "1"},
+			metadata: metadata.Metadata{"channel": "mobile"},
+		},
+		{
+			name:          "update of immutable metadata",
+			existing:      metadata.Metadata{"order_id": "1"},
+			metadata:      metadata.Metadata{"order_id": "2"},
+			expectedError: "invalid metadata `order_id` for transaction template `PAYMENT`: metadata is immutable",
+		},
+	} {
+		var err error
+		if tc.existing != nil {
+			err = template.ValidateMetadataUpdate("PAYMENT", tc.existing, tc.metadata)
+		} else {
+			err = template.ValidateMetadata("PAYMENT", tc.metadata)
+		}
+		if tc.expectedError == "" {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.EqualError(t, err, tc.expectedError, tc.name)
+			require.ErrorIs(t, err, ErrInvalidTransactionMetadata{}, tc.name)
+		}
+	}
+
+	require.ErrorContains(t, template.ValidateMetadataDeletion("PAYMENT", "order_id"), "metadata is required")
+	require.NoError(t, template.ValidateMetadataDeletion("PAYMENT", "channel"))
+	require.NoError(t, TransactionTemplate{}.ValidateMetadata("PAYMENT", metadata.Metadata{"foo": "bar"}))
+}
@@ -2,6 +2,7 @@ package ledger
 
 import (
 	"encoding/base64"
+	"fmt"
 	"math/big"
 	"testing"
 
@@ -32,6 +33,94 @@ func TestTransactionsReverse(t *testing.T) {
 	require.Equal(t, expected, reversed)
 }
 
+func TestTransactionsPartiallyReverse(t *testing.T) {
+	tx := NewTransaction().
+		WithPostings(
+			NewPosting("world", "users:001", "COIN", big.NewInt(100)),
+			NewPosting("users:001", "payments:001", "COIN", big.NewInt(50)),
+		)
+
+	reversed, revertedAmounts, err := tx.PartiallyReverse(map[int]*big.Int{
+		1: big.NewInt(20),
+	})
+	require.NoError(t, err)
+	require.Equal(t, Postings{
+		NewPosting("payments:001", "users:001", "COIN", big.NewInt(20)),
+	}, reversed.Postings)
+	require.Equal(t, "[0 20]", fmt.Sprint(revertedAmounts))
+
+	tx.RevertedAmounts = revertedAmounts
+	require.Equal(t, "[100 30]", fmt.Sprint(tx.RevertibleAmounts()))
+	require.False(t, tx.IsFullyReverted())
+
+	_, _, err = tx.PartiallyReverse(map[int]*big.Int{
+		1: big.NewInt(31),
+	})
+	require.Error(t, err)
+
+	_, _, err = tx.PartiallyReverse(map[int]*big.Int{
+		2: big.NewInt(1),
+	})
+	require.Error(t, err)
+
+	_, _, err = tx.PartiallyReverse(map[int]*big.Int{
+		0: big.NewInt(0),
+	})
+	require.Error(t, err)
+
+	amounts, err := tx.ScaledRevertibleAmounts(big.NewRat(1, 3))
+	require.NoError(t, err)
+	require.Equal(t, "map[0:33 1:10]", fmt.Sprint(amounts))
+
+	_, err = tx.ScaledRevertibleAmounts(big.NewRat(3, 2))
+	require.Error(t, err)
+
+	// a full revert only reverses the remaining amounts
+	require.Equal(t, Postings{
+		NewPosting("payments:001", "users:001", "COIN", big.NewInt(30)),
+		NewPosting("users:001", "world", "COIN", big.NewInt(100)),
+	}, tx.Reverse().Postings)
+
+	reversed, revertedAmounts, err = tx.PartiallyReverse(map[int]*big.Int{
+		0: big.NewInt(100),
+		1: big.NewInt(30),
+	})
+	require.NoError(t, err)
+	require.Len(t, reversed.Postings, 2)
+
+	tx.RevertedAmounts = revertedAmounts
+	require.True(t, tx.IsFullyReverted())
+	require.Empty(t, tx.Reverse().Postings)
+}
+
+func TestPartiallyRevertedTransactionValidateWithSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := Schema{
+		SchemaData: SchemaData{
+			Chart: testChart(),
+		},
+	}
+
+	tx := NewTransaction().
+		WithPostings(NewPosting("bank:001", "users:001:main", "USD/2", big.NewInt(100)))
+	reversed, _, err := tx.PartiallyReverse(map[int]*big.Int{0: big.NewInt(20)})
+	require.NoError(t, err)
+	require.NoError(t, PartiallyRevertedTransaction{
+		RevertedTransaction: tx,
+		RevertTransaction:   reversed,
+	}.ValidateWithSchema(schema))
+
+	tx = NewTransaction().
+		WithPostings(NewPosting("bank:001", "users:001:main", "BTC", big.NewInt(100)))
+	reversed, _, err = tx.PartiallyReverse(map[int]*big.Int{0: big.NewInt(20)})
+	require.NoError(t, err)
+	require.ErrorIs(t, PartiallyRevertedTransaction{
+		RevertedTransaction: tx,
+		RevertTransaction:   reversed,
+	}.ValidateWithSchema(schema), ErrAssetNotAllowed{})
+}
+
 func TestTransactionsVolumesUpdate(t *testing.T) {
 	tx := NewTransaction().
 		WithPostings(
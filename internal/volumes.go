@@ -65,6 +65,8 @@ type VolumesWithBalanceByAssetByAccount struct {
 	Account string `json:"account" bun:"account"`
 	Asset   string `json:"asset" bun:"asset"`
 	VolumesWithBalance
+	// Pending is the amount of the pending holds of the account, nil if none
+	Pending *big.Int `json:"pending,omitempty" bun:"pending"`
 }
 
 type VolumesWithBalance struct {
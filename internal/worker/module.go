@@ -23,13 +23,18 @@ type GRPCServerModuleConfig struct {
 }
 
 type ModuleConfig struct {
-	AsyncBlockRunnerConfig    storage.AsyncBlockRunnerConfig
-	ReplicationConfig         replication.WorkerModuleConfig
-	BucketCleanupRunnerConfig storage.BucketCleanupRunnerConfig
+	AsyncBlockRunnerConfig            storage.AsyncBlockRunnerConfig
+	ReplicationConfig                 replication.WorkerModuleConfig
+	BucketCleanupRunnerConfig         storage.BucketCleanupRunnerConfig
+	HoldsExpiryRunnerConfig           storage.HoldsExpiryRunnerConfig
+	RevertJobsRunnerConfig            storage.RevertJobsRunnerConfig
+	ScheduledTransactionsRunnerConfig storage.ScheduledTransactionsRunnerConfig
+	RecurrencesRunnerConfig           storage.RecurrencesRunnerConfig
 }
 
 // NewFXModule constructs an fx.Option that installs the storage async block runner,
-// the replication worker, and the bucket cleanup runner modules into an Fx application.
+// the replication worker, the bucket cleanup runner, the holds expiry runner, the revert jobs runner,
+// the scheduled transactions runner and the recurrences runner modules into an Fx application.
 // The provided cfg supplies each submodule's configuration.
 func NewFXModule(cfg ModuleConfig) fx.Option {
 	return fx.Options(
@@ -37,6 +42,10 @@ func NewFXModule(cfg ModuleConfig) fx.Option {
 		storage.NewAsyncBlockRunnerModule(cfg.AsyncBlockRunnerConfig),
 		replication.NewWorkerFXModule(cfg.ReplicationConfig),
 		storage.NewBucketCleanupRunnerModule(cfg.BucketCleanupRunnerConfig),
+		storage.NewHoldsExpiryRunnerModule(cfg.HoldsExpiryRunnerConfig),
+		storage.NewRevertJobsRunnerModule(cfg.RevertJobsRunnerConfig),
+		storage.NewScheduledTransactionsRunnerModule(cfg.ScheduledTransactionsRunnerConfig),
+		storage.NewRecurrencesRunnerModule(cfg.RecurrencesRunnerConfig),
 	)
 }
 
@@ -4,9 +4,10 @@ const (
 	EventVersion = "v2"
 	EventApp     = "ledger"
 
-	EventTypeCommittedTransactions = "COMMITTED_TRANSACTIONS"
-	EventTypeSavedMetadata         = "SAVED_METADATA"
-	EventTypeRevertedTransaction   = "REVERTED_TRANSACTION"
-	EventTypeDeletedMetadata       = "DELETED_METADATA"
-	EventTypeInsertedSchema        = "INSERTED_SCHEMA"
+	EventTypeCommittedTransactions        = "COMMITTED_TRANSACTIONS"
+	EventTypeSavedMetadata                = "SAVED_METADATA"
+	EventTypeRevertedTransaction          = "REVERTED_TRANSACTION"
+	EventTypePartiallyRevertedTransaction = "PARTIALLY_REVERTED_TRANSACTION"
+	EventTypeDeletedMetadata              = "DELETED_METADATA"
+	EventTypeInsertedSchema               = "INSERTED_SCHEMA"
 )
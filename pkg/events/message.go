@@ -57,6 +57,22 @@ func NewEventRevertedTransaction(revertedTransaction RevertedTransaction) publis
 	}
 }
 
+type PartiallyRevertedTransaction struct {
+	Ledger              string             `json:"ledger"`
+	RevertedTransaction ledger.Transaction `json:"revertedTransaction"`
+	RevertTransaction   ledger.Transaction `json:"revertTransaction"`
+}
+
+func NewEventPartiallyRevertedTransaction(partiallyRevertedTransaction PartiallyRevertedTransaction) publish.EventMessage {
+	return publish.EventMessage{
+		Date:    time.Now().Time,
+		App:     EventApp,
+		Version: EventVersion,
+		Type:    EventTypePartiallyRevertedTransaction,
+		Payload: partiallyRevertedTransaction,
+	}
+}
+
 type DeletedMetadata struct {
 	Ledger     string `json:"ledger"`
 	TargetType string `json:"targetType"`